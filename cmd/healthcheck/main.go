@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+// healthcheck requests the probe url and exits with non zero code unless it responds with 200,
+// the app image is built from scratch, so docker healthcheck has no curl or wget
+func main() {
+	log.SetFlags(0)
+
+	timeout := flag.Duration("timeout", 3*time.Second, "request timeout")
+	flag.Parse()
+
+	url := flag.Arg(0)
+	if url == "" {
+		url = "http://localhost:8080/readyz"
+	}
+
+	client := &http.Client{Timeout: *timeout}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("%s responded with %s", url, resp.Status)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"github.com/romandnk/todo/pkg/oidc/oidctest"
+	"log"
+	"net/http"
+	"time"
+)
+
+// mockidp runs OpenID Connect identity provider which signs in any user without a password,
+// so OIDC login of TODO App can be tried locally
+func main() {
+	log.SetFlags(0)
+
+	addr := flag.String("addr", "localhost:9000", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9000", "issuer url, must match OIDC_ISSUER of the app")
+	clientID := flag.String("client-id", "todo", "client id")
+	clientSecret := flag.String("client-secret", "secret", "client secret")
+	flag.Parse()
+
+	idp, err := oidctest.New(*issuer, *clientID, *clientSecret)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           idp,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	log.Printf("mock identity provider %s is listening on %s", *issuer, *addr)
+	log.Fatal(srv.ListenAndServe())
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const usage = `todotxt imports and exports tasks in todo.txt format through TODO App HTTP API.
+
+Usage:
+	todotxt import [-addr URL] [-token TOKEN] [-workspace ID] [-tz ZONE] [-file todo.txt] [-dry-run]
+	todotxt export [-addr URL] [-token TOKEN] [-workspace ID] [-tz ZONE] [-file todo.txt] [-status-name NAME] [-date RFC3339]
+
+Token is API key or OIDC token, TODO_TOKEN and TODO_WORKSPACE_ID environment variables are used by default.
+`
+
+const defaultAddr = "http://localhost:8080"
+
+// api sends requests to TODO App on behalf of the token owner in the workspace
+type api struct {
+	client    *http.Client
+	addr      *string
+	token     *string
+	workspace *string
+	tz        *string
+}
+
+// newAPI registers flags of the API connection shared by the commands
+func newAPI(client *http.Client, fs *flag.FlagSet) *api {
+	return &api{
+		client:    client,
+		addr:      fs.String("addr", defaultAddr, "TODO App address"),
+		token:     fs.String("token", os.Getenv("TODO_TOKEN"), "API key or OIDC token sent in Authorization header"),
+		workspace: fs.String("workspace", os.Getenv("TODO_WORKSPACE_ID"), "workspace id, the default workspace if empty"),
+		tz:        fs.String("tz", "", "IANA time zone of the dates"),
+	}
+}
+
+func (a *api) do(method, path string, query url.Values, contentType string, body io.Reader) (*http.Response, error) {
+	if *a.tz != "" {
+		query.Set("tz", *a.tz)
+	}
+
+	req, err := http.NewRequest(method, *a.addr+path+"?"+query.Encode(), body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if *a.token != "" {
+		req.Header.Set("Authorization", "Bearer "+*a.token)
+	}
+	if *a.workspace != "" {
+		req.Header.Set("Workspace-ID", *a.workspace)
+	}
+
+	return a.client.Do(req)
+}
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	client := &http.Client{Timeout: time.Minute}
+
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = runImport(client, os.Args[2:])
+	case "export":
+		err = runExport(client, os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func runImport(client *http.Client, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	api := newAPI(client, fs)
+	file := fs.String("file", "-", "todo.txt file to import, - for stdin")
+	dryRun := fs.Bool("dry-run", false, "validate tasks without saving")
+	_ = fs.Parse(args)
+
+	in := io.Reader(os.Stdin)
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	query := url.Values{}
+	query.Set("format", "todotxt")
+	query.Set("dry-run", fmt.Sprint(*dryRun))
+
+	resp, err := api.do(http.MethodPost, "/api/v1/tasks/import", query, "text/plain; charset=utf-8", in)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(os.Stdout, resp.Body)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("import failed with status %s", resp.Status)
+	}
+
+	return nil
+}
+
+func runExport(client *http.Client, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	api := newAPI(client, fs)
+	file := fs.String("file", "-", "todo.txt file to write, - for stdout")
+	statusName := fs.String("status-name", "", "task status name for filtering")
+	date := fs.String("date", "", "date for filtering tasks in RFC3339 or YYYY-MM-DD format")
+	_ = fs.Parse(args)
+
+	query := url.Values{}
+	query.Set("format", "todotxt")
+	if *statusName != "" {
+		query.Set("status-name", *statusName)
+	}
+	if *date != "" {
+		query.Set("date", *date)
+	}
+
+	resp, err := api.do(http.MethodGet, "/api/v1/tasks/export", query, "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("export failed with status %s: %s", resp.Status, body)
+	}
+
+	out := io.Writer(os.Stdout)
+	if *file != "-" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
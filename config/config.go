@@ -10,9 +10,24 @@ import (
 const configPath string = "./config/config.yml"
 
 type Config struct {
-	ZapLogger  ZapLogger  `yaml:"zap_logger"`
-	Postgres   Postgres   `yaml:"postgres"`
-	HTTPServer HTTPServer `json:"http_server"`
+	Logger      Logger      `yaml:"logger"`
+	ZapLogger   ZapLogger   `yaml:"zap_logger"`
+	SlogLogger  SlogLogger  `yaml:"slog_logger"`
+	Postgres    Postgres    `yaml:"postgres"`
+	HTTPServer  HTTPServer  `json:"http_server"`
+	Idempotency Idempotency `yaml:"idempotency"`
+	Attachments Attachments `yaml:"attachments"`
+	Identity    Identity    `yaml:"identity"`
+	Workspaces  Workspaces  `yaml:"workspaces"`
+	OIDC        OIDC        `yaml:"oidc"`
+	RateLimit   RateLimit   `yaml:"rate_limit"`
+	Tracing     Tracing     `yaml:"tracing"`
+	Cache       Cache       `yaml:"cache"`
+}
+
+type Logger struct {
+	// Backend is "zap", "slog" or "nop"
+	Backend string `yaml:"backend" env:"LOGGER_BACKEND" env-default:"zap"`
 }
 
 type ZapLogger struct {
@@ -22,6 +37,13 @@ type ZapLogger struct {
 	ErrorOutputPaths []string `yaml:"error_output_paths"`
 }
 
+type SlogLogger struct {
+	// Level is "debug", "info", "warn" or "error"
+	Level string `yaml:"level" env-default:"info"`
+	// Format is "json" or "text"
+	Format string `yaml:"format" env-default:"json"`
+}
+
 type Postgres struct {
 	Host     string `env:"POSTGRES_HOST" env-required:"true"`
 	Port     int    `env:"POSTGRES_PORT" env-required:"true"`
@@ -31,6 +53,9 @@ type Postgres struct {
 	SSLMode  string `yaml:"ssl_mode" env:"POSTGRES_SSLMODE" env-required:"true"`
 	MaxConns int32  `yaml:"max_conns"`
 	MinConns int32  `yaml:"min_conns"`
+	// ConnectTimeout is the time of waiting for postgres on startup, ping is retried every ConnectRetryInterval
+	ConnectTimeout       time.Duration `yaml:"connect_timeout" env:"POSTGRES_CONNECT_TIMEOUT" env-default:"30s"`
+	ConnectRetryInterval time.Duration `yaml:"connect_retry_interval" env-default:"1s"`
 }
 
 type HTTPServer struct {
@@ -39,6 +64,116 @@ type HTTPServer struct {
 	ReadTimeout     time.Duration `yaml:"read_timeout" env-default:"3s"`
 	WriteTimeout    time.Duration `yaml:"write_timeout" env-default:"5s"`
 	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env-default:"5s"`
+	// DrainDelay is the time between failing readiness checks and stopping the server on shutdown,
+	// so load balancers stop sending new requests to the app
+	DrainDelay time.Duration `yaml:"drain_delay" env:"HTTP_SERVER_DRAIN_DELAY" env-default:"0s"`
+}
+
+type Idempotency struct {
+	TTL             time.Duration `yaml:"ttl" env-default:"24h"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env-default:"1h"`
+}
+
+type Attachments struct {
+	MaxSize int64 `yaml:"max_size" env-default:"10485760"`
+	// ContentTypes are allowed media types like "image/png" or "image/*", empty list allows any type
+	ContentTypes []string  `yaml:"content_types"`
+	BlobStore    BlobStore `yaml:"blob_store"`
+}
+
+type BlobStore struct {
+	// Driver is "local" or "s3"
+	Driver   string `yaml:"driver" env:"BLOB_STORE_DRIVER" env-default:"local"`
+	LocalDir string `yaml:"local_dir" env:"BLOB_STORE_LOCAL_DIR" env-default:"./data/attachments"`
+	S3       S3     `yaml:"s3"`
+}
+
+type S3 struct {
+	Endpoint  string `yaml:"endpoint" env:"S3_ENDPOINT"`
+	Region    string `yaml:"region" env:"S3_REGION" env-default:"us-east-1"`
+	Bucket    string `yaml:"bucket" env:"S3_BUCKET"`
+	AccessKey string `env:"S3_ACCESS_KEY"`
+	SecretKey string `env:"S3_SECRET_KEY"`
+}
+
+type Identity struct {
+	// UsernameHeader takes the request author from Username header without authentication,
+	// it is for local development only, deployments authenticate users with API keys or OIDC
+	UsernameHeader bool `yaml:"username_header" env:"IDENTITY_USERNAME_HEADER"`
+}
+
+type Workspaces struct {
+	// AnonymousRole is the role in the default workspace of users who are not its members, empty role denies access
+	AnonymousRole string        `yaml:"anonymous_role" env:"WORKSPACE_ANONYMOUS_ROLE"`
+	InvitationTTL time.Duration `yaml:"invitation_ttl" env-default:"168h"`
+	// BootstrapOwner becomes the owner of the default workspace at startup if it has no owner
+	BootstrapOwner string `yaml:"bootstrap_owner" env:"WORKSPACE_BOOTSTRAP_OWNER"`
+}
+
+type OIDC struct {
+	// Issuer is the identity provider url like "https://accounts.example.com", empty issuer disables OIDC
+	Issuer       string `yaml:"issuer" env:"OIDC_ISSUER"`
+	ClientID     string `yaml:"client_id" env:"OIDC_CLIENT_ID"`
+	ClientSecret string `env:"OIDC_CLIENT_SECRET"`
+	// RedirectURL is the callback url of the app registered in the identity provider
+	RedirectURL string `yaml:"redirect_url" env:"OIDC_REDIRECT_URL"`
+	// Audience is the expected aud claim of bearer tokens, client id by default
+	Audience string   `yaml:"audience" env:"OIDC_AUDIENCE"`
+	Scopes   []string `yaml:"scopes" env-default:"openid,profile,email"`
+	// UsernameClaim is the claim mapped to the local username. It must be stable and unique like "sub",
+	// claims editable by users like "preferred_username" let them take names of others
+	UsernameClaim string        `yaml:"username_claim" env:"OIDC_USERNAME_CLAIM" env-default:"sub"`
+	JWKSCacheTTL  time.Duration `yaml:"jwks_cache_ttl" env-default:"1h"`
+}
+
+type RateLimit struct {
+	// Store is "memory" or "postgres", postgres store shares quotas between app instances
+	Store           string        `yaml:"store" env:"RATE_LIMIT_STORE" env-default:"memory"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env-default:"1m"`
+	// Default is the quota of route groups without their own quota
+	Default RateLimitQuota `yaml:"default"`
+	// Groups are quotas of route groups like "tasks" or "auth"
+	Groups map[string]RateLimitQuota `yaml:"groups"`
+}
+
+type RateLimitQuota struct {
+	// Rate is the number of requests per second, zero rate disables the limit
+	Rate float64 `yaml:"rate"`
+	// Burst is the number of requests allowed at once
+	Burst int `yaml:"burst"`
+}
+
+type Tracing struct {
+	// Exporter is "none" or "otlp", none exporter does not record spans but still propagates trace context
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"`
+	// Endpoint is the host and port of OTLP/HTTP collector like "localhost:4318"
+	Endpoint string `yaml:"endpoint" env:"TRACING_ENDPOINT" env-default:"localhost:4318"`
+	Insecure bool   `yaml:"insecure" env:"TRACING_INSECURE"`
+	// ServiceName is the service.name resource attribute of spans
+	ServiceName string `yaml:"service_name" env:"TRACING_SERVICE_NAME" env-default:"todo"`
+	// SampleRatio is the fraction of traces started by the app which are sampled
+	SampleRatio float64 `yaml:"sample_ratio" env-default:"1"`
+}
+
+type Cache struct {
+	// Backend is "none", "memory" or "redis", none backend disables caching of statuses and tasks
+	Backend string        `yaml:"backend" env:"CACHE_BACKEND" env-default:"none"`
+	TTL     time.Duration `yaml:"ttl" env:"CACHE_TTL" env-default:"1m"`
+	// Size is the max number of entries of the memory cache
+	Size int `yaml:"size" env-default:"10000"`
+	// Notify sends invalidated keys to other app instances with postgres notifications,
+	// it is needed for memory cache of multiple instances
+	Notify bool  `yaml:"notify" env:"CACHE_NOTIFY"`
+	Redis  Redis `yaml:"redis"`
+}
+
+type Redis struct {
+	// Addr is the host and port of redis compatible server like "localhost:6379"
+	Addr     string        `yaml:"addr" env:"REDIS_ADDR" env-default:"localhost:6379"`
+	Password string        `env:"REDIS_PASSWORD"`
+	DB       int           `yaml:"db" env:"REDIS_DB"`
+	PoolSize int           `yaml:"pool_size" env-default:"10"`
+	Timeout  time.Duration `yaml:"timeout" env-default:"1s"`
 }
 
 func NewConfig() (*Config, error) {
@@ -9,316 +9,2985 @@ const docTemplate = `{
     "info": {
         "description": "{{escape .Description}}",
         "title": "{{.Title}}",
-        "termsOfService": "http://swagger.io/terms/",
-        "contact": {
-            "name": "API [Roman] Support"
-        },
-        "license": {
-            "name": "romandnk",
-            "url": "https://github.com/romandnk/todo"
-        },
+        "contact": {},
         "version": "{{.Version}}"
     },
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/statuses/": {
+        "/api-keys/": {
+            "get": {
+                "description": "Get API keys of the workspace including revoked ones, keys themselves are not returned.",
+                "tags": [
+                    "APIKey"
+                ],
+                "summary": "Get API keys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Request author",
+                        "name": "Username",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Workspace id",
+                        "name": "Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API keys were received successfully",
+                        "schema": {
+                            "$ref": "#/definitions/apikeyservice.GetAPIKeysResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role does not allow the action",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Create new task status.",
+                "description": "Create API key of the workspace with tasks:read, tasks:write or statuses:admin scopes. The key is shown only once.",
                 "tags": [
-                    "Status"
+                    "APIKey"
                 ],
-                "summary": "CreateStatus",
+                "summary": "Create API key",
                 "parameters": [
                     {
-                        "description": "Required JSON body with status name",
+                        "type": "string",
+                        "description": "Request author",
+                        "name": "Username",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Workspace id",
+                        "name": "Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "JSON body with key name and scopes",
                         "name": "params",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/statusservice.CreateStatusParams"
+                            "$ref": "#/definitions/apikeyservice.CreateAPIKeyParams"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Status was created successfully",
+                    "201": {
+                        "description": "API key was created successfully",
                         "schema": {
-                            "$ref": "#/definitions/statusservice.CreateStatusResponse"
+                            "$ref": "#/definitions/apikeyservice.CreateAPIKeyResponse"
                         }
                     },
                     "400": {
                         "description": "Invalid input data",
                         "schema": {
-                            "$ref": "#/definitions/v1.response"
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Username header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role does not allow the action",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
                         }
                     },
                     "500": {
                         "description": "Internal error",
                         "schema": {
-                            "$ref": "#/definitions/v1.response"
+                            "$ref": "#/definitions/v1.problem"
                         }
                     }
                 }
             }
         },
-        "/tasks/": {
-            "get": {
-                "description": "Get tasks with filtration by status name or date and pagination with limit.",
+        "/api-keys/:key_id": {
+            "delete": {
+                "description": "Revoke API key of the workspace, requests with revoked key are rejected.",
                 "tags": [
-                    "Task"
+                    "APIKey"
                 ],
-                "summary": "Get tasks",
+                "summary": "Revoke API key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "tasks limit on the page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "API key id",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request author",
+                        "name": "Username",
+                        "in": "header"
                     },
                     {
                         "type": "integer",
-                        "description": "last task id for getting next page",
-                        "name": "last-id",
-                        "in": "query"
+                        "description": "Workspace id",
+                        "name": "Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "API key was revoked successfully"
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role does not allow the action",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "API key is not found or already revoked",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
                     },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/callback": {
+            "get": {
+                "description": "Exchange authorization code for tokens. ID token is used in Authorization header as Bearer token.",
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Finish login with identity provider",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "task status name for filtering",
-                        "name": "status-name",
-                        "in": "query"
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "date for getting task by date in RFC3339 format",
-                        "name": "date",
-                        "in": "query"
+                        "description": "Login state",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Tasks were gotten successfully",
+                        "description": "User was signed in successfully",
                         "schema": {
-                            "$ref": "#/definitions/taskservice.GetAllTasksResponse"
+                            "$ref": "#/definitions/authservice.CallbackResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code or state",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Authorization code is invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "OIDC login is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
                         }
                     },
                     "500": {
                         "description": "Internal error",
                         "schema": {
-                            "$ref": "#/definitions/v1.response"
+                            "$ref": "#/definitions/v1.problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/oidc/login": {
+            "get": {
+                "description": "Redirect to the login page of OpenID Connect identity provider, which redirects back to the callback.",
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login with identity provider",
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the identity provider"
+                    },
+                    "404": {
+                        "description": "OIDC login is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/": {
             "post": {
-                "description": "Create new task.",
+                "description": "Create iCalendar feed of tasks with stable tokenized URL.",
                 "tags": [
-                    "Task"
+                    "Feed"
                 ],
-                "summary": "Create task",
+                "summary": "Create calendar feed",
                 "parameters": [
                     {
-                        "description": "Required JSON body with all required task field",
+                        "description": "JSON body with optional status name filter and VTODO or VEVENT component",
                         "name": "params",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/taskservice.CreateTaskParams"
+                            "$ref": "#/definitions/feedservice.CreateFeedParams"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Task was created successfully",
+                        "description": "Feed was created successfully",
                         "schema": {
-                            "$ref": "#/definitions/taskservice.CreateTaskResponse"
+                            "$ref": "#/definitions/feedservice.CreateFeedResponse"
                         }
                     },
                     "400": {
                         "description": "Invalid input data",
                         "schema": {
-                            "$ref": "#/definitions/v1.response"
+                            "$ref": "#/definitions/v1.problem"
                         }
                     },
                     "500": {
                         "description": "Internal error",
                         "schema": {
-                            "$ref": "#/definitions/v1.response"
+                            "$ref": "#/definitions/v1.problem"
                         }
                     }
                 }
             }
         },
-        "/tasks/:id": {
-            "get": {
-                "description": "Get task by its id.",
+        "/feeds/:token": {
+            "delete": {
+                "description": "Revoke feed token.",
                 "tags": [
-                    "Task"
+                    "Feed"
                 ],
-                "summary": "Get task by ID",
+                "summary": "Delete calendar feed",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Required task id for getting",
-                        "name": "params",
+                        "type": "string",
+                        "description": "Feed token",
+                        "name": "token",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Task was received successfully",
-                        "schema": {
-                            "$ref": "#/definitions/taskservice.GetTaskWithStatusNameModel"
-                        }
+                        "description": "Feed was deleted successfully"
                     },
                     "400": {
                         "description": "Invalid input data",
                         "schema": {
-                            "$ref": "#/definitions/v1.response"
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Feed is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
                         }
                     },
                     "500": {
                         "description": "Internal error",
                         "schema": {
-                            "$ref": "#/definitions/v1.response"
+                            "$ref": "#/definitions/v1.problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete task by its id.",
+            }
+        },
+        "/feeds/:token/calendar.ics": {
+            "get": {
+                "description": "Get iCalendar document with feed tasks.",
+                "produces": [
+                    "text/calendar"
+                ],
                 "tags": [
-                    "Task"
+                    "Feed"
                 ],
-                "summary": "Delete task by ID",
+                "summary": "Get calendar feed",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Required task id for deleting",
-                        "name": "params",
+                        "type": "string",
+                        "description": "Feed token",
+                        "name": "token",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Task was deleted successfully"
+                        "description": "Calendar was generated successfully",
+                        "schema": {
+                            "type": "file"
+                        }
                     },
                     "400": {
                         "description": "Invalid input data",
                         "schema": {
-                            "$ref": "#/definitions/v1.response"
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Feed is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
                         }
                     },
                     "500": {
                         "description": "Internal error",
                         "schema": {
-                            "$ref": "#/definitions/v1.response"
+                            "$ref": "#/definitions/v1.problem"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update task selected fields by its id.",
+            }
+        },
+        "/statuses/": {
+            "post": {
+                "description": "Create new task status.",
                 "tags": [
-                    "Task"
+                    "Status"
                 ],
-                "summary": "Update task by ID",
+                "summary": "CreateStatus",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Required task id for updating",
-                        "name": "params",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Required JSON body with necessary fields to update",
+                        "description": "Required JSON body with status name",
                         "name": "params",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/taskservice.UpdateTaskByIDParams"
+                            "$ref": "#/definitions/statusservice.CreateStatusParams"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Task was updated successfully"
+                        "description": "Status was created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/statusservice.CreateStatusResponse"
+                        }
                     },
                     "400": {
                         "description": "Invalid input data",
                         "schema": {
-                            "$ref": "#/definitions/v1.response"
+                            "$ref": "#/definitions/v1.problem"
                         }
                     },
                     "500": {
                         "description": "Internal error",
                         "schema": {
-                            "$ref": "#/definitions/v1.response"
+                            "$ref": "#/definitions/v1.problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "statusservice.CreateStatusParams": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "statusservice.CreateStatusResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "taskservice.CreateTaskParams": {
-            "type": "object",
-            "required": [
-                "date",
-                "description",
-                "status_name",
-                "title"
-            ],
-            "properties": {
-                "date": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "status_name": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                }
-            }
-        },
-        "taskservice.CreateTaskResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer"
-                }
-            }
         },
-        "taskservice.GetAllTasksResponse": {
-            "type": "object",
-            "properties": {
-                "tasks": {
-                    "type": "array",
-                    "items": {
+        "/tasks/": {
+            "get": {
+                "description": "Get tasks with filtration by status name, date, checklist completion or members and pagination with limit. Day of the date is evaluated in the Time-Zone header or tz parameter time zone. Response ETag header is the hash of the list.",
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Get tasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "tasks limit on the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "last task id for getting next page",
+                        "name": "last-id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "task status name for filtering",
+                        "name": "status-name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "date for getting task by date in RFC3339 or YYYY-MM-DD format",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "min checklist completion percentage, tasks without checklist are skipped",
+                        "name": "min-completion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max checklist completion percentage, tasks without checklist are skipped",
+                        "name": "max-completion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only tasks assigned to the Username header user",
+                        "name": "assigned-to-me",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only tasks watched by the Username header user",
+                        "name": "watching",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, Time-Zone header is used first",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username of the request author",
+                        "name": "Username",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List ETag to check whether tasks were changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tasks were gotten successfully",
+                        "schema": {
+                            "$ref": "#/definitions/taskservice.GetAllTasksResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Tasks were not changed"
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Username header is required by member filters",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create new task. Date is RFC3339, YYYY-MM-DD or natural language like \"tomorrow 9am\" or \"завтра в 9\" in Time-Zone time zone. Response of the request with Idempotency-Key header is replayed on retry.",
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Create task",
+                "parameters": [
+                    {
+                        "description": "Required JSON body with all required task field",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/taskservice.CreateTaskParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Task was created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/taskservice.CreateTaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Request with the same idempotency key is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency key was used with a different request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id": {
+            "get": {
+                "description": "Get task by its id. Response ETag header contains task version, Vary header lists user, workspace and Time-Zone headers.",
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Get task by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Required task id for getting",
+                        "name": "params",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Task ETag to check whether task was changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task was received successfully",
+                        "schema": {
+                            "$ref": "#/definitions/taskservice.GetTaskWithStatusNameModel"
+                        }
+                    },
+                    "304": {
+                        "description": "Task was not changed"
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete task by its id together with its comments. Task version from ETag can be checked with If-Match header.",
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Delete task by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Required task id for deleting",
+                        "name": "params",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Task ETag for optimistic concurrency control",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task was deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Task version does not match",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update task selected fields by its id. Date is parsed as on task creation. Task version from ETag can be checked with If-Match header.",
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Update task by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Required task id for updating",
+                        "name": "params",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Task ETag for optimistic concurrency control",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Required JSON body with necessary fields to update",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/taskservice.UpdateTaskByIDParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task was updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/taskservice.UpdateTaskByIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Task version does not match",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id/attachments/": {
+            "get": {
+                "description": "Get metadata of task attachments in upload order.",
+                "tags": [
+                    "Attachment"
+                ],
+                "summary": "Get task attachments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachments were gotten successfully",
+                        "schema": {
+                            "$ref": "#/definitions/attachmentservice.GetAttachmentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Attach file to the task with multipart/form-data request. File size and content type are limited by config.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "tags": [
+                    "Attachment"
+                ],
+                "summary": "Upload attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Attached file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Attachment was uploaded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/attachmentservice.AttachmentModel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "413": {
+                        "description": "File is too large",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "415": {
+                        "description": "File content type is not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id/attachments/:attachment_id": {
+            "get": {
+                "description": "Download attached file with its content type and file name.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Attachment"
+                ],
+                "summary": "Download attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment id",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment was downloaded successfully",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete task attachment with its file.",
+                "tags": [
+                    "Attachment"
+                ],
+                "summary": "Delete attachment by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment id",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment was deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id/checklist/": {
+            "get": {
+                "description": "Get task checklist items in their order with completion percentage.",
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "Get task checklist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checklist was gotten successfully",
+                        "schema": {
+                            "$ref": "#/definitions/checklistservice.GetChecklistResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add not done item to the end of the task checklist.",
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "Add checklist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "JSON body with item text",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/checklistservice.AddChecklistItemParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Checklist item was added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/checklistservice.ChecklistItemModel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id/checklist/:item_id": {
+            "delete": {
+                "description": "Delete item from the task checklist.",
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "Delete checklist item by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Checklist item id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checklist item was deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Checklist item is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id/checklist/:item_id/toggle": {
+            "post": {
+                "description": "Mark not done item as done and done item as not done.",
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "Toggle checklist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Checklist item id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checklist item was toggled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/checklistservice.ChecklistItemModel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Checklist item is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id/checklist/order": {
+            "put": {
+                "description": "Place checklist items in order of ids. Ids must contain every item of the task once.",
+                "tags": [
+                    "Checklist"
+                ],
+                "summary": "Reorder checklist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "JSON body with item ids in the new order",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/checklistservice.ReorderChecklistParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checklist was reordered successfully",
+                        "schema": {
+                            "$ref": "#/definitions/checklistservice.GetChecklistResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id/comments/": {
+            "get": {
+                "description": "Get task comments in creation order with pagination by limit and last comment id, total is the number of all task comments.",
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Get task comments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "comments limit on the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "last comment id for getting next page",
+                        "name": "last-id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comments were gotten successfully",
+                        "schema": {
+                            "$ref": "#/definitions/commentservice.GetCommentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add comment of the request author to the task. Timestamps are in Time-Zone time zone.",
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Create comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "JSON body with comment body",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/commentservice.CreateCommentParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Comment was created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/commentservice.CommentModel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id/comments/:comment_id": {
+            "delete": {
+                "description": "Delete task comment by its id, only author or workspace admin can do it.",
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Delete comment by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment id",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comment was deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not author of the comment",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Edit comment body, only author or workspace admin can do it.",
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Update comment by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment id",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "JSON body with new comment body",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/commentservice.UpdateCommentParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comment was updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/commentservice.CommentModel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not author of the comment",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id/members/": {
+            "get": {
+                "description": "Get task assignees and watchers in order of their addition.",
+                "tags": [
+                    "Member"
+                ],
+                "summary": "Get task members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Members were gotten successfully",
+                        "schema": {
+                            "$ref": "#/definitions/memberservice.GetMembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id/members/assignees": {
+            "post": {
+                "description": "Assign the task to the user. Assignment event is emitted if the user was not assigned before.",
+                "tags": [
+                    "Member"
+                ],
+                "summary": "Assign task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "JSON body with assignee username",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/memberservice.AddAssigneeParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username of the request author",
+                        "name": "Username",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Task was assigned successfully",
+                        "schema": {
+                            "$ref": "#/definitions/memberservice.MemberModel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id/members/assignees/:username": {
+            "delete": {
+                "description": "Remove the user from task assignees and emit unassignment event.",
+                "tags": [
+                    "Member"
+                ],
+                "summary": "Unassign task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Assignee username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username of the request author",
+                        "name": "Username",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task was unassigned successfully"
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Assignee is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/:id/members/watchers": {
+            "post": {
+                "description": "Make the Username header user a watcher of the task.",
+                "tags": [
+                    "Member"
+                ],
+                "summary": "Watch task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username of the request author",
+                        "name": "Username",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Task is watched successfully",
+                        "schema": {
+                            "$ref": "#/definitions/memberservice.MemberModel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Username header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Task is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop the Username header user watching the task.",
+                "tags": [
+                    "Member"
+                ],
+                "summary": "Unwatch task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username of the request author",
+                        "name": "Username",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task is not watched anymore"
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Username header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Watcher is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/bulk": {
+            "post": {
+                "description": "Create many tasks, update status, delete and restore many tasks in one transaction.",
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Bulk task operations",
+                "parameters": [
+                    {
+                        "description": "Required JSON body with bulk operations",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/taskservice.BulkTasksParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bulk operations were committed",
+                        "schema": {
+                            "$ref": "#/definitions/taskservice.BulkTasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Bulk operations were not committed",
+                        "schema": {
+                            "$ref": "#/definitions/taskservice.BulkTasksResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/export": {
+            "get": {
+                "description": "Stream all tasks matching the task list filters in csv, json, ndjson, iCalendar or todo.txt format.",
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Export tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "export format: csv, json (default), ndjson, ics, ics-vevent or todotxt",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "task status name for filtering",
+                        "name": "status-name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "date for getting task by date in RFC3339 or YYYY-MM-DD format",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "min checklist completion percentage, tasks without checklist are skipped",
+                        "name": "min-completion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max checklist completion percentage, tasks without checklist are skipped",
+                        "name": "max-completion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only tasks assigned to the request author",
+                        "name": "assigned-to-me",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only tasks watched by the request author",
+                        "name": "watching",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tasks were exported successfully",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Request author is required by member filters",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/import": {
+            "post": {
+                "description": "Import tasks from csv, json, ndjson, iCalendar or todo.txt request body validated with create task rules. Valid tasks are saved in one transaction, all or none of them.",
+                "consumes": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Import tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "import format: csv, json (default), ndjson, ics or todotxt",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "validate tasks without saving",
+                        "name": "dry-run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Tasks document",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tasks were imported or validated",
+                        "schema": {
+                            "$ref": "#/definitions/taskservice.ImportTasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Document is too large",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/quick": {
+            "post": {
+                "description": "Create task from one line like \"Pay rent #finance +home !high tomorrow @done\". Words with # are tags, with + are projects, with ! is priority (high, medium, low, letter or number) and with @ is status (done, todo or status name with _ instead of spaces). The longest group of the other words which is a date becomes due date, today by default, the rest is the title.",
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Quick-add task",
+                "parameters": [
+                    {
+                        "description": "Required JSON body with task text",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/taskservice.QuickAddTaskParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of dates, UTC by default",
+                        "name": "Time-Zone",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Task was created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/taskservice.QuickAddTaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/": {
+            "get": {
+                "description": "Get workspaces of the request author with the author roles.",
+                "tags": [
+                    "Workspace"
+                ],
+                "summary": "Get workspaces",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Request author",
+                        "name": "Username",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Workspaces were received successfully",
+                        "schema": {
+                            "$ref": "#/definitions/workspaceservice.GetWorkspacesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Username header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create workspace owned by the request author with default statuses.",
+                "tags": [
+                    "Workspace"
+                ],
+                "summary": "Create workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Request author",
+                        "name": "Username",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "JSON body with workspace name",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/workspaceservice.CreateWorkspaceParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Workspace was created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/workspaceservice.WorkspaceModel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Username header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/:workspace_id/invitations": {
+            "post": {
+                "description": "Create invitation link to join the workspace with admin, member or viewer role.",
+                "tags": [
+                    "Workspace"
+                ],
+                "summary": "Create invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace id",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request author",
+                        "name": "Username",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "JSON body with role of invited users",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/workspaceservice.CreateInvitationParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Invitation was created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/workspaceservice.CreateInvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Username header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role does not allow the action",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/:workspace_id/members": {
+            "get": {
+                "description": "Get members of the workspace with their roles.",
+                "tags": [
+                    "Workspace"
+                ],
+                "summary": "Get workspace members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace id",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request author",
+                        "name": "Username",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Members were received successfully",
+                        "schema": {
+                            "$ref": "#/definitions/workspaceservice.GetWorkspaceMembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/:workspace_id/members/:username": {
+            "delete": {
+                "description": "Remove user from the workspace. Any member can leave, other members are removed by admins and owners.",
+                "tags": [
+                    "Workspace"
+                ],
+                "summary": "Remove workspace member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace id",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request author",
+                        "name": "Username",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Member was removed successfully"
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role does not allow the action",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace or member is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Last owner cannot be removed",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change role of the workspace member. Only owners can grant or revoke owner role, the last owner keeps it.",
+                "tags": [
+                    "Workspace"
+                ],
+                "summary": "Change member role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace id",
+                        "name": "workspace_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request author",
+                        "name": "Username",
+                        "in": "header"
+                    },
+                    {
+                        "description": "JSON body with owner, admin, member or viewer role",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/workspaceservice.UpdateMemberRoleParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role was changed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/workspaceservice.WorkspaceMemberModel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Role does not allow the action",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace or member is not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Last owner cannot lose owner role",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/invitations/:token/accept": {
+            "post": {
+                "description": "Join the workspace of the invitation, invitation is accepted once, existing member keeps the role.",
+                "tags": [
+                    "Workspace"
+                ],
+                "summary": "Accept invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request author",
+                        "name": "Username",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation was accepted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/workspaceservice.AcceptInvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Username header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Invitation is not found, expired or already accepted",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apikeyservice.APIKeyModel": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apikeyservice.CreateAPIKeyParams": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apikeyservice.CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apikeyservice.GetAPIKeysResponse": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apikeyservice.APIKeyModel"
+                    }
+                }
+            }
+        },
+        "attachmentservice.AttachmentModel": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "attachmentservice.GetAttachmentsResponse": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/attachmentservice.AttachmentModel"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "authservice.CallbackResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "id_token": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "checklistservice.AddChecklistItemParams": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "checklistservice.ChecklistItemModel": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "checklistservice.GetChecklistResponse": {
+            "type": "object",
+            "properties": {
+                "completion": {
+                    "description": "Completion is the percentage of done items rounded down",
+                    "type": "integer"
+                },
+                "done": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/checklistservice.ChecklistItemModel"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "checklistservice.ReorderChecklistParams": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "commentservice.CommentModel": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "commentservice.CreateCommentParams": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string"
+                }
+            }
+        },
+        "commentservice.GetCommentsResponse": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/commentservice.CommentModel"
+                    }
+                },
+                "total": {
+                    "description": "Total is the number of all task comments, not of the page",
+                    "type": "integer"
+                }
+            }
+        },
+        "commentservice.UpdateCommentParams": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string"
+                }
+            }
+        },
+        "feedservice.CreateFeedParams": {
+            "type": "object",
+            "properties": {
+                "component": {
+                    "type": "string"
+                },
+                "status_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "feedservice.CreateFeedResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "memberservice.AddAssigneeParams": {
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "memberservice.GetMembersResponse": {
+            "type": "object",
+            "properties": {
+                "assignees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/memberservice.MemberModel"
+                    }
+                },
+                "watchers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/memberservice.MemberModel"
+                    }
+                }
+            }
+        },
+        "memberservice.MemberModel": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "statusservice.CreateStatusParams": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "statusservice.CreateStatusResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "taskservice.BulkTaskResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskservice.FieldViolation"
+                    }
+                }
+            }
+        },
+        "taskservice.BulkTasksParams": {
+            "type": "object",
+            "properties": {
+                "all_or_nothing": {
+                    "type": "boolean"
+                },
+                "create": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskservice.CreateTaskParams"
+                    }
+                },
+                "delete": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "restore": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "update_status": {
+                    "$ref": "#/definitions/taskservice.BulkUpdateStatusParams"
+                }
+            }
+        },
+        "taskservice.BulkTasksResponse": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskservice.BulkTaskResult"
+                    }
+                }
+            }
+        },
+        "taskservice.BulkUpdateStatusParams": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskservice.CreateTaskParams": {
+            "type": "object",
+            "required": [
+                "date",
+                "description",
+                "status_name",
+                "title"
+            ],
+            "properties": {
+                "all_day": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status_name": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskservice.CreateTaskResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "taskservice.FieldViolation": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskservice.GetAllTasksResponse": {
+            "type": "object",
+            "properties": {
+                "tasks": {
+                    "type": "array",
+                    "items": {
                         "$ref": "#/definitions/taskservice.GetTaskWithStatusNameModel"
                     }
                 },
@@ -330,6 +2999,19 @@ const docTemplate = `{
         "taskservice.GetTaskWithStatusNameModel": {
             "type": "object",
             "properties": {
+                "all_day": {
+                    "type": "boolean"
+                },
+                "checklist_completion": {
+                    "description": "ChecklistCompletion is the percentage of done checklist items",
+                    "type": "integer"
+                },
+                "checklist_total": {
+                    "type": "integer"
+                },
+                "comments_count": {
+                    "type": "integer"
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -342,9 +3024,122 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "priority": {
+                    "type": "integer"
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status_name": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "taskservice.ImportTaskError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskservice.FieldViolation"
+                    }
+                }
+            }
+        },
+        "taskservice.ImportTasksResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/taskservice.ImportTaskError"
+                    }
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "taskservice.QuickAddTaskParams": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "taskservice.QuickAddTaskResponse": {
+            "type": "object",
+            "properties": {
+                "all_day": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "date_text": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "status_name": {
                     "type": "string"
                 },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "title": {
                     "type": "string"
                 }
@@ -353,27 +3148,202 @@ const docTemplate = `{
         "taskservice.UpdateTaskByIDParams": {
             "type": "object",
             "properties": {
+                "all_day": {
+                    "type": "boolean"
+                },
                 "date": {
                     "type": "string"
                 },
                 "description": {
                     "type": "string"
                 },
+                "priority": {
+                    "type": "integer"
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "status_name": {
                     "type": "string"
                 },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "title": {
                     "type": "string"
                 }
             }
         },
-        "v1.response": {
+        "taskservice.UpdateTaskByIDResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.problem": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "string"
                 },
-                "message": {
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.problemField"
+                    }
+                },
+                "field": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.problemField": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                }
+            }
+        },
+        "workspaceservice.AcceptInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "workspaceservice.CreateInvitationParams": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "workspaceservice.CreateInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "workspaceservice.CreateWorkspaceParams": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "workspaceservice.GetWorkspaceMembersResponse": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workspaceservice.WorkspaceMemberModel"
+                    }
+                }
+            }
+        },
+        "workspaceservice.GetWorkspacesResponse": {
+            "type": "object",
+            "properties": {
+                "workspaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workspaceservice.WorkspaceModel"
+                    }
+                }
+            }
+        },
+        "workspaceservice.UpdateMemberRoleParams": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "workspaceservice.WorkspaceMemberModel": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "workspaceservice.WorkspaceModel": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
                     "type": "string"
                 }
             }
@@ -383,12 +3353,12 @@ const docTemplate = `{
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
-	Version:          "1.0",
+	Version:          "",
 	Host:             "",
-	BasePath:         "/api/v1/",
+	BasePath:         "",
 	Schemes:          []string{},
-	Title:            "TODO App Swagger",
-	Description:      "Swagger API for Golang Project TODO.",
+	Title:            "",
+	Description:      "",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
@@ -2,19 +2,44 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"github.com/romandnk/todo/config"
 	storage "github.com/romandnk/todo/internal/repo"
 	httpserver "github.com/romandnk/todo/internal/server/http"
 	v1 "github.com/romandnk/todo/internal/server/http/v1"
 	"github.com/romandnk/todo/internal/service"
+	attachmentservice "github.com/romandnk/todo/internal/service/attachment"
+	authservice "github.com/romandnk/todo/internal/service/auth"
+	healthservice "github.com/romandnk/todo/internal/service/health"
+	memberservice "github.com/romandnk/todo/internal/service/member"
+	ratelimitservice "github.com/romandnk/todo/internal/service/ratelimit"
+	taskservice "github.com/romandnk/todo/internal/service/task"
+	workspaceservice "github.com/romandnk/todo/internal/service/workspace"
+	"github.com/romandnk/todo/migrations"
+	"github.com/romandnk/todo/pkg/blobstore"
+	localblobstore "github.com/romandnk/todo/pkg/blobstore/local"
+	s3blobstore "github.com/romandnk/todo/pkg/blobstore/s3"
+	"github.com/romandnk/todo/pkg/cache"
+	memorycache "github.com/romandnk/todo/pkg/cache/memory"
+	postgrescache "github.com/romandnk/todo/pkg/cache/postgres"
+	rediscache "github.com/romandnk/todo/pkg/cache/redis"
+	"github.com/romandnk/todo/pkg/logger"
+	noplogger "github.com/romandnk/todo/pkg/logger/nop"
+	sloglogger "github.com/romandnk/todo/pkg/logger/slog"
 	zaplogger "github.com/romandnk/todo/pkg/logger/zap"
+	"github.com/romandnk/todo/pkg/metrics"
+	"github.com/romandnk/todo/pkg/oidc"
+	"github.com/romandnk/todo/pkg/ratelimit"
+	memoryratelimit "github.com/romandnk/todo/pkg/ratelimit/memory"
+	postgresratelimit "github.com/romandnk/todo/pkg/ratelimit/postgres"
 	postgres "github.com/romandnk/todo/pkg/storage"
-	"go.uber.org/zap"
+	"github.com/romandnk/todo/pkg/tracing"
 	"log"
 	"net"
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 )
 
 //	@title			TODO App Swagger
@@ -42,64 +67,307 @@ func Run() {
 		log.Fatalf("error reading config file: %s", err.Error())
 	}
 
-	// initializing zap logger
-	logger, err := zaplogger.NewLogger(cfg.ZapLogger)
+	// initializing logger of the configured backend
+	l, err := newLogger(cfg)
 	if err != nil {
-		log.Fatalf("error initializing zap logger: %s", err.Error())
+		log.Fatalf("error initializing logger: %s", err.Error())
 	}
 
-	logger.Info("using zap logger")
+	l.Info("using logger", logger.String("backend", cfg.Logger.Backend))
 
-	// initializing connection to postgres db
-	db, err := postgres.NewStorage(ctx, cfg.Postgres)
+	// initializing prometheus metrics
+	m := metrics.New()
+
+	// initializing opentelemetry tracer provider
+	tracerProvider, err := tracing.NewProvider(ctx, cfg.Tracing)
+	if err != nil {
+		l.Fatal("error initializing tracer provider", logger.Error(err))
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTPServer.ShutdownTimeout)
+		defer cancel()
+
+		err := tracerProvider.Shutdown(shutdownCtx)
+		if err != nil {
+			l.Error("error shutting down tracer provider", logger.Error(err))
+		}
+	}()
+
+	l.Info("using tracing exporter", logger.String("exporter", cfg.Tracing.Exporter))
+
+	// initializing connection to postgres db, queries are measured and traced by repository methods
+	db, err := postgres.NewStorage(ctx, cfg.Postgres,
+		m.NewQueryTracer(),
+		tracing.NewQueryTracer(tracerProvider),
+	)
 	if err != nil {
-		db.Close()
-		logger.Fatal("error initializing postgres db", zap.Error(err))
+		l.Fatal("error initializing postgres db", logger.Error(err))
 	}
 	defer db.Close()
 
-	logger.Info("using postgres repo",
-		zap.String("host", cfg.Postgres.Host),
-		zap.Int("port", cfg.Postgres.Port),
+	err = m.RegisterPool(db)
+	if err != nil {
+		l.Fatal("error registering postgres pool metrics", logger.Error(err))
+	}
+
+	l.Info("using postgres repo",
+		logger.String("host", cfg.Postgres.Host),
+		logger.Int("port", cfg.Postgres.Port),
 	)
 
 	// initializing repository
 	repo := storage.NewRepository(db)
 
+	// initializing cache of statuses and tasks, memory caches of app instances are invalidated by notifications
+	c, notifier, err := newCache(cfg.Cache, db)
+	if err != nil {
+		l.Fatal("error initializing cache", logger.Error(err))
+	}
+	if c != nil {
+		repo = repo.WithCache(c, notifier, cfg.Cache.TTL, l)
+	}
+	if notifier != nil {
+		go listenCacheInvalidation(ctx, notifier, c, l)
+	}
+
+	l.Info("using cache", logger.String("backend", cfg.Cache.Backend), logger.Bool("notify", notifier != nil))
+
+	// schema version the app is built for is checked by readiness probe
+	schemaVersion, err := migrations.LatestVersion()
+	if err != nil {
+		l.Fatal("error reading migrations version", logger.Error(err))
+	}
+
+	// initializing blob store of task attachments
+	blob, err := newBlobStore(cfg.Attachments.BlobStore)
+	if err != nil {
+		l.Fatal("error initializing blob store", logger.Error(err))
+	}
+
+	l.Info("using blob store", logger.String("driver", cfg.Attachments.BlobStore.Driver))
+
+	// initializing identity provider of oidc login
+	provider, err := newOIDCProvider(cfg.OIDC)
+	if err != nil {
+		l.Fatal("error initializing oidc provider", logger.Error(err))
+	}
+
+	// users of identity provider could be impersonated with Username header
+	if provider != nil && cfg.Identity.UsernameHeader {
+		l.Fatal("username header identity cannot be enabled with oidc provider")
+	}
+
+	if provider != nil {
+		l.Info("using oidc provider", logger.String("issuer", cfg.OIDC.Issuer))
+	} else {
+		l.Info("oidc login is disabled")
+	}
+
+	// initializing store of rate limit buckets
+	rateLimitStore, err := newRateLimitStore(cfg.RateLimit.Store, db)
+	if err != nil {
+		l.Fatal("error initializing rate limit store", logger.Error(err))
+	}
+
+	l.Info("using rate limit store", logger.String("store", cfg.RateLimit.Store))
+
 	// initializing service dependencies
 	dep := service.Dependencies{
-		Repo:   repo,
-		Logger: logger,
+		Repo:           repo,
+		Logger:         l,
+		IdempotencyTTL: cfg.Idempotency.TTL,
+		BlobStore:      blob,
+		Attachments: attachmentservice.Limits{
+			MaxSize:      cfg.Attachments.MaxSize,
+			ContentTypes: cfg.Attachments.ContentTypes,
+		},
+		TaskHooks: []taskservice.TaskHook{
+			taskservice.CountTaskHook(
+				m.Counter("tasks_created_total", "Number of created tasks."),
+				m.Counter("tasks_deleted_total", "Number of deleted tasks."),
+			),
+		},
+		AssignmentHooks: []memberservice.AssignmentHook{
+			memberservice.LogAssignmentHook(l),
+		},
+		Workspaces: workspaceservice.Settings{
+			AnonymousRole:  cfg.Workspaces.AnonymousRole,
+			InvitationTTL:  cfg.Workspaces.InvitationTTL,
+			BootstrapOwner: cfg.Workspaces.BootstrapOwner,
+		},
+		OIDC: provider,
+		Auth: authservice.Settings{
+			UsernameClaim: cfg.OIDC.UsernameClaim,
+		},
+		RateLimitStore: rateLimitStore,
+		RateLimit:      newRateLimitSettings(cfg.RateLimit),
+		Health: healthservice.Settings{
+			SchemaVersion: schemaVersion,
+			CheckTimeout:  readinessCheckTimeout,
+		},
+		TracerProvider: tracerProvider,
 	}
 
 	// initializing services
 	services := service.NewServices(dep)
 
+	// data created before workspaces is in the default workspace, which has no members after upgrade
+	added, err := services.Workspace.BootstrapOwner(ctx)
+	if err != nil {
+		l.Fatal("error adding default workspace owner", logger.Error(err))
+	}
+	if added {
+		l.Info("default workspace owner is added", logger.String("username", cfg.Workspaces.BootstrapOwner))
+	}
+
+	// deleting expired idempotency keys in background
+	go services.Idempotency.RunCleanup(ctx, cfg.Idempotency.CleanupInterval)
+
+	// deleting full rate limit buckets in background
+	go services.RateLimit.RunCleanup(ctx, cfg.RateLimit.CleanupInterval)
+
 	// initializing middlewares
-	mw := v1.NewMiddlewares(logger)
+	mw := v1.NewMiddlewares(l, services.Idempotency, services.Workspace, services.APIKey, services.Auth, services.RateLimit, m, tracerProvider)
 
 	// initializing http handler
-	handler := v1.NewHandler(services, logger, mw)
+	handler := v1.NewHandler(services, l, mw, m, v1.Settings{
+		UsernameHeader:    cfg.Identity.UsernameHeader,
+		AttachmentMaxSize: cfg.Attachments.MaxSize,
+	})
 
 	// initializing http server
 	srv := httpserver.NewServer(cfg.HTTPServer, handler.InitRoutes())
 
-	logger.Info("starting http server...",
+	l.Info("starting http server...",
 		"address", net.JoinHostPort(cfg.HTTPServer.Host, strconv.Itoa(cfg.HTTPServer.Port)))
 	srv.Start()
 
 	select {
 	case <-ctx.Done():
-		logger.Info("stopping http server...")
+		l.Info("stopping http server...")
 
-		err = srv.Stop(ctx)
+		// failing readiness probe before stopping the server, so load balancers stop sending new requests
+		services.Health.Drain()
+		time.Sleep(cfg.HTTPServer.DrainDelay)
+
+		err = srv.Stop(context.Background())
 		if err != nil {
-			logger.Error("error stopping http server", zap.Error(err))
+			l.Error("error stopping http server", logger.Error(err))
 		}
 
-		logger.Info("http server is stopped")
+		l.Info("http server is stopped")
 	case err = <-srv.Notify():
-		logger.Error("error starting http server", zap.Error(err))
+		l.Error("error starting http server", logger.Error(err))
 		cancel()
 	}
 }
+
+// readinessCheckTimeout limits every check of readiness probe
+const readinessCheckTimeout = 2 * time.Second
+
+// newLogger creates logger of the configured backend
+func newLogger(cfg *config.Config) (logger.Logger, error) {
+	switch cfg.Logger.Backend {
+	case logger.BackendZap:
+		return zaplogger.NewLogger(cfg.ZapLogger)
+	case logger.BackendSlog:
+		return sloglogger.NewLogger(cfg.SlogLogger)
+	case logger.BackendNop:
+		return noplogger.NewLogger(), nil
+	default:
+		return nil, fmt.Errorf("unknown logger backend '%s'", cfg.Logger.Backend)
+	}
+}
+
+// cacheListenRetryInterval is the delay of listening cache notifications again after connection loss
+const cacheListenRetryInterval = 5 * time.Second
+
+// newCache creates cache of the configured backend, none backend returns nil cache
+func newCache(cfg config.Cache, db postgres.PgxPool) (cache.Cache, cache.Notifier, error) {
+	var c cache.Cache
+	switch cfg.Backend {
+	case cache.BackendNone:
+		return nil, nil, nil
+	case cache.BackendMemory:
+		c = memorycache.NewCache(cfg.Size)
+	case cache.BackendRedis:
+		// requests would wait forever for a connection of empty pool
+		if cfg.Redis.PoolSize < 1 {
+			return nil, nil, fmt.Errorf("redis pool size must be at least 1, got %d", cfg.Redis.PoolSize)
+		}
+		c = rediscache.NewCache(cfg.Redis)
+	default:
+		return nil, nil, fmt.Errorf("unknown cache backend '%s'", cfg.Backend)
+	}
+
+	if !cfg.Notify {
+		return c, nil, nil
+	}
+	return c, postgrescache.NewNotifier(db), nil
+}
+
+// listenCacheInvalidation drops keys invalidated by app instances until ctx is done.
+// Keys invalidated while the connection is lost stay cached until their ttl expires
+func listenCacheInvalidation(ctx context.Context, notifier cache.Notifier, c cache.Cache, l logger.Logger) {
+	for {
+		err := notifier.Listen(ctx, func(keys []string) {
+			err := c.Delete(ctx, keys...)
+			if err != nil {
+				l.Error("error deleting invalidated cached values", logger.Error(err))
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		l.Error("error listening cache notifications", logger.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(cacheListenRetryInterval):
+		}
+	}
+}
+
+// newBlobStore creates blob store of the configured driver
+func newBlobStore(cfg config.BlobStore) (blobstore.BlobStore, error) {
+	switch cfg.Driver {
+	case blobstore.DriverLocal:
+		return localblobstore.NewStore(cfg.LocalDir)
+	case blobstore.DriverS3:
+		return s3blobstore.NewStore(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown blob store driver '%s'", cfg.Driver)
+	}
+}
+
+// newOIDCProvider creates identity provider client, empty issuer disables oidc
+func newOIDCProvider(cfg config.OIDC) (oidc.Provider, error) {
+	if cfg.Issuer == "" {
+		return nil, nil
+	}
+	return oidc.NewClient(cfg)
+}
+
+// newRateLimitStore creates store of rate limit buckets of the configured kind
+func newRateLimitStore(store string, db postgres.PgxPool) (ratelimit.Store, error) {
+	switch store {
+	case ratelimit.StoreMemory:
+		return memoryratelimit.NewStore(), nil
+	case ratelimit.StorePostgres:
+		return postgresratelimit.NewStore(db), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store '%s'", store)
+	}
+}
+
+func newRateLimitSettings(cfg config.RateLimit) ratelimitservice.Settings {
+	settings := ratelimitservice.Settings{
+		Default: ratelimit.Limit{Rate: cfg.Default.Rate, Burst: cfg.Default.Burst},
+		Groups:  make(map[string]ratelimit.Limit, len(cfg.Groups)),
+	}
+	for group, quota := range cfg.Groups {
+		settings.Groups[group] = ratelimit.Limit{Rate: quota.Rate, Burst: quota.Burst}
+	}
+	return settings
+}
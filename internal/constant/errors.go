@@ -0,0 +1,80 @@
+package constant
+
+import "strings"
+
+// ErrorKind classifies domain errors, transport layer maps kinds to its status codes
+type ErrorKind string
+
+const (
+	KindValidation         ErrorKind = "validation"
+	KindNotFound           ErrorKind = "not_found"
+	KindConflict           ErrorKind = "conflict"
+	KindPreconditionFailed ErrorKind = "precondition_failed"
+	KindUnprocessable      ErrorKind = "unprocessable"
+	KindTooLarge           ErrorKind = "too_large"
+	KindUnsupportedMedia   ErrorKind = "unsupported_media"
+	KindUnauthorized       ErrorKind = "unauthorized"
+	KindForbidden          ErrorKind = "forbidden"
+	KindTooManyRequests    ErrorKind = "too_many_requests"
+	KindInternal           ErrorKind = "internal"
+)
+
+// Error is a domain error with machine-readable code and name of the invalid field
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Field   string
+	Message string
+}
+
+func newError(kind ErrorKind, code, field, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Code:    code,
+		Field:   field,
+		Message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is a domain error with the same code
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithMessage returns copy of the error with another message
+func (e *Error) WithMessage(message string) *Error {
+	err := *e
+	err.Message = message
+	return &err
+}
+
+// WithField returns copy of the error with another field name
+func (e *Error) WithField(field string) *Error {
+	err := *e
+	err.Field = field
+	return &err
+}
+
+// ValidationErrors is a list of all field violations found in the request
+type ValidationErrors []*Error
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
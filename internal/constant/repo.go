@@ -2,8 +2,20 @@ package constant
 
 // tables in DB
 const (
-	TasksTable    string = "tasks"
-	StatusesTable string = "statuses"
+	TasksTable                string = "tasks"
+	StatusesTable             string = "statuses"
+	TaskFeedsTable            string = "task_feeds"
+	IdempotencyKeysTable      string = "idempotency_keys"
+	TaskCommentsTable         string = "task_comments"
+	TaskAttachmentsTable      string = "task_attachments"
+	TaskChecklistTable        string = "task_checklist_items"
+	TaskMembersTable          string = "task_members"
+	WorkspacesTable           string = "workspaces"
+	WorkspaceMembersTable     string = "workspace_members"
+	WorkspaceInvitationsTable string = "workspace_invitations"
+	APIKeysTable              string = "api_keys"
+	// SchemaMigrationsTable is the version of db schema kept by golang-migrate
+	SchemaMigrationsTable string = "schema_migrations"
 )
 
 // placeholder in sql query
@@ -4,7 +4,54 @@ import "errors"
 
 // task repo errors
 var (
-	ErrTaskIDNotExists = errors.New("no task with id")
+	ErrTaskIDNotExists     = errors.New("no task with id")
+	ErrTaskVersionMismatch = errors.New("task version mismatch")
+	ErrUnknownBatchAction  = errors.New("unknown batch action")
+	ErrTaskBatchRolledBack = errors.New("task batch was rolled back")
+)
+
+// feed repo errors
+var (
+	ErrFeedNotExists = errors.New("no feed with token")
+)
+
+// comment repo errors
+var (
+	ErrCommentIDNotExists = errors.New("no comment with id")
+)
+
+// attachment repo errors
+var (
+	ErrAttachmentIDNotExists = errors.New("no attachment with id")
+)
+
+// checklist repo errors
+var (
+	ErrChecklistItemIDNotExists = errors.New("no checklist item with id")
+	ErrChecklistOrderMismatch   = errors.New("checklist order does not match task items")
+)
+
+// member repo errors
+var (
+	ErrTaskMemberNotExists = errors.New("no task member")
+)
+
+// workspace repo errors
+var (
+	ErrWorkspaceMemberNotExists = errors.New("no workspace member")
+	ErrWorkspaceWithoutOwner    = errors.New("workspace cannot be left without owner")
+	ErrInvitationNotExists      = errors.New("no invitation with token")
+)
+
+// api key repo errors
+var (
+	ErrAPIKeyIDNotExists = errors.New("no api key with id")
+	ErrAPIKeyNotExists   = errors.New("no api key with hash")
+)
+
+// idempotency repo errors
+var (
+	ErrIdempotencyKeyNotExists = errors.New("no idempotency key")
 )
 
 // utils repo errors
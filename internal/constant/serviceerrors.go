@@ -1,32 +1,172 @@
 package constant
 
-import "errors"
-
 // mutual errors
 var (
-	ErrInternalError = errors.New("internal error")
+	ErrInternalError       = newError(KindInternal, "internal_error", "", "internal error")
+	ErrInvalidRequestBody  = newError(KindValidation, "invalid_request_body", "", "request body is invalid")
+	ErrTooLargeRequestBody = newError(KindTooLarge, "too_large_request_body", "", "request body is too large")
+	ErrValidationFailed    = newError(KindValidation, "validation_failed", "", "request has invalid fields")
 )
 
 // status service errors
 var (
-	ErrEmptyStatusName   = errors.New("status name cannot be empty")
-	ErrTooLongStatusName = errors.New("max status name length is 16")
-	ErrStatusNameExists  = errors.New("status name already exists")
+	ErrEmptyStatusName   = newError(KindValidation, "empty_status_name", "name", "status name cannot be empty")
+	ErrTooLongStatusName = newError(KindValidation, "too_long_status_name", "name", "max status name length is 16")
+	ErrStatusNameExists  = newError(KindConflict, "status_name_exists", "name", "status name already exists")
+	ErrUnknownStatusName = newError(KindValidation, "unknown_status_name", "status_name", "status name is not found")
+	ErrStatusNotFound    = newError(KindNotFound, "status_not_found", "", "status is not found")
 )
 
 // task service errors
 var (
-	ErrEmptyTitle         = errors.New("title cannot be empty")
-	ErrEmptyDescription   = errors.New("description cannot be empty")
-	ErrTooLongTitle       = errors.New("max task title length is 64")
-	ErrEmptyDate          = errors.New("date cannot be empty")
-	ErrInvalidDateFormat  = errors.New("date must be in RFC3339 format")
-	ErrOutdatedDate       = errors.New("you cannot set task date on the past")
-	ErrEmptyTaskID        = errors.New("task id cannot be empty")
-	ErrInvalidTaskID      = errors.New("task id must be int")
-	ErrNonPositiveTaskID  = errors.New("task id must be positive")
-	ErrInvalidLimit       = errors.New("limit must be int")
-	ErrInvalidLastTaskID  = errors.New("last task id must be int")
-	ErrNegativeLimit      = errors.New("limit cannot be negative")
-	ErrNegativeLastTaskID = errors.New("last task id cannot be negative")
+	ErrEmptyTitle             = newError(KindValidation, "empty_title", "title", "title cannot be empty")
+	ErrEmptyDescription       = newError(KindValidation, "empty_description", "description", "description cannot be empty")
+	ErrTooLongTitle           = newError(KindValidation, "too_long_title", "title", "max task title length is 64")
+	ErrEmptyDate              = newError(KindValidation, "empty_date", "date", "date cannot be empty")
+	ErrInvalidDateFormat      = newError(KindValidation, "invalid_date_format", "date", "date must be in RFC3339, YYYY-MM-DD format or natural language like 'tomorrow 9am'")
+	ErrOutdatedDate           = newError(KindValidation, "outdated_date", "date", "you cannot set task date on the past")
+	ErrEmptyTaskID            = newError(KindValidation, "empty_task_id", "id", "task id cannot be empty")
+	ErrInvalidTaskID          = newError(KindValidation, "invalid_task_id", "id", "task id must be int")
+	ErrNonPositiveTaskID      = newError(KindValidation, "non_positive_task_id", "id", "task id must be positive")
+	ErrInvalidLimit           = newError(KindValidation, "invalid_limit", "limit", "limit must be int")
+	ErrInvalidLastTaskID      = newError(KindValidation, "invalid_last_task_id", "last-id", "last task id must be int")
+	ErrNegativeLimit          = newError(KindValidation, "negative_limit", "limit", "limit cannot be negative")
+	ErrNegativeLastTaskID     = newError(KindValidation, "negative_last_task_id", "last-id", "last task id cannot be negative")
+	ErrInvalidPriority        = newError(KindValidation, "invalid_priority", "priority", "priority must be from 0 to 26")
+	ErrNegativeTaskVersion    = newError(KindValidation, "negative_task_version", "If-Match", "task version cannot be negative")
+	ErrInvalidIfMatch         = newError(KindValidation, "invalid_if_match", "If-Match", "If-Match header must contain one strong entity tag with task version")
+	ErrTaskModified           = newError(KindPreconditionFailed, "task_modified", "If-Match", "task was modified, its version does not match")
+	ErrInvalidLabel           = newError(KindValidation, "invalid_label", "tags", "tags and projects cannot contain spaces")
+	ErrTooLongLabel           = newError(KindValidation, "too_long_label", "tags", "max tag and project length is 32")
+	ErrTaskNotFound           = newError(KindNotFound, "task_not_found", "", "task is not found")
+	ErrAllDayWithoutDate      = newError(KindValidation, "all_day_without_date", "all_day", "all_day can be changed only together with date")
+	ErrInvalidTimezone        = newError(KindValidation, "invalid_timezone", "Time-Zone", "time zone must be IANA time zone name")
+	ErrEmptyQuickAddText      = newError(KindValidation, "empty_quick_add_text", "text", "quick-add text cannot be empty")
+	ErrInvalidMinCompletion   = newError(KindValidation, "invalid_min_completion", "min-completion", "min completion must be int from 0 to 100")
+	ErrInvalidMaxCompletion   = newError(KindValidation, "invalid_max_completion", "max-completion", "max completion must be int from 0 to 100")
+	ErrInvalidCompletionRange = newError(KindValidation, "invalid_completion_range", "min-completion", "min completion cannot be greater than max completion")
+)
+
+// bulk task service errors
+var (
+	ErrEmptyBulkOperations = newError(KindValidation, "empty_bulk_operations", "", "bulk request must contain at least one operation")
+	ErrTooManyBulkItems    = newError(KindValidation, "too_many_bulk_items", "", "max bulk items quantity is 1000")
+)
+
+// import/export task service errors
+var (
+	ErrUnknownTaskFormat       = newError(KindValidation, "unknown_task_format", "format", "format must be one of csv, json, ndjson, ics, ics-vevent, todotxt")
+	ErrUnsupportedImportFormat = newError(KindValidation, "unsupported_import_format", "format", "format is not supported by import")
+	ErrInvalidDryRun           = newError(KindValidation, "invalid_dry_run", "dry-run", "dry-run must be bool")
+	ErrInvalidCSVImportHeader  = newError(KindValidation, "invalid_csv_import_header", "", "csv header must contain title, description, status_name and date columns")
+	ErrEmptyImport             = newError(KindValidation, "empty_import", "", "import must contain at least one task")
+	ErrInvalidImport           = newError(KindValidation, "invalid_import", "", "import document is invalid")
+	ErrTooLargeImport          = newError(KindTooLarge, "too_large_import", "", "import document is too large")
+)
+
+// feed service errors
+var (
+	ErrEmptyFeedToken       = newError(KindValidation, "empty_feed_token", "token", "feed token cannot be empty")
+	ErrFeedNotFound         = newError(KindNotFound, "feed_not_found", "", "feed is not found")
+	ErrInvalidFeedComponent = newError(KindValidation, "invalid_feed_component", "component", "feed component must be VTODO or VEVENT")
+)
+
+// comment service errors
+var (
+	ErrEmptyCommentAuthor    = newError(KindValidation, "empty_comment_author", "author", "comment author cannot be empty")
+	ErrTooLongCommentAuthor  = newError(KindValidation, "too_long_comment_author", "author", "max comment author length is 64")
+	ErrEmptyCommentBody      = newError(KindValidation, "empty_comment_body", "body", "comment body cannot be empty")
+	ErrTooLongCommentBody    = newError(KindValidation, "too_long_comment_body", "body", "max comment body length is 4096")
+	ErrEmptyCommentID        = newError(KindValidation, "empty_comment_id", "comment_id", "comment id cannot be empty")
+	ErrInvalidCommentID      = newError(KindValidation, "invalid_comment_id", "comment_id", "comment id must be int")
+	ErrNonPositiveCommentID  = newError(KindValidation, "non_positive_comment_id", "comment_id", "comment id must be positive")
+	ErrInvalidLastCommentID  = newError(KindValidation, "invalid_last_comment_id", "last-id", "last comment id must be int")
+	ErrNegativeLastCommentID = newError(KindValidation, "negative_last_comment_id", "last-id", "last comment id cannot be negative")
+	ErrCommentNotFound       = newError(KindNotFound, "comment_not_found", "", "comment is not found")
+)
+
+// attachment service errors
+var (
+	ErrEmptyAttachmentFile       = newError(KindValidation, "empty_attachment_file", "file", "attachment file cannot be empty")
+	ErrInvalidAttachmentFileName = newError(KindValidation, "invalid_attachment_file_name", "file", "attachment file name must be from 1 to 255 characters")
+	ErrTooLargeAttachment        = newError(KindTooLarge, "too_large_attachment", "file", "attachment file is too large")
+	ErrUnsupportedAttachmentType = newError(KindUnsupportedMedia, "unsupported_attachment_type", "file", "attachment content type is not allowed")
+	ErrEmptyAttachmentID         = newError(KindValidation, "empty_attachment_id", "attachment_id", "attachment id cannot be empty")
+	ErrInvalidAttachmentID       = newError(KindValidation, "invalid_attachment_id", "attachment_id", "attachment id must be int")
+	ErrNonPositiveAttachmentID   = newError(KindValidation, "non_positive_attachment_id", "attachment_id", "attachment id must be positive")
+	ErrAttachmentNotFound        = newError(KindNotFound, "attachment_not_found", "", "attachment is not found")
+)
+
+// checklist service errors
+var (
+	ErrEmptyChecklistItemText     = newError(KindValidation, "empty_checklist_item_text", "text", "checklist item text cannot be empty")
+	ErrTooLongChecklistItemText   = newError(KindValidation, "too_long_checklist_item_text", "text", "max checklist item text length is 255")
+	ErrEmptyChecklistItemID       = newError(KindValidation, "empty_checklist_item_id", "item_id", "checklist item id cannot be empty")
+	ErrInvalidChecklistItemID     = newError(KindValidation, "invalid_checklist_item_id", "item_id", "checklist item id must be int")
+	ErrNonPositiveChecklistItemID = newError(KindValidation, "non_positive_checklist_item_id", "item_id", "checklist item id must be positive")
+	ErrInvalidChecklistOrder      = newError(KindValidation, "invalid_checklist_order", "ids", "ids must contain every checklist item id of the task once")
+	ErrChecklistItemNotFound      = newError(KindNotFound, "checklist_item_not_found", "", "checklist item is not found")
+)
+
+// member service errors
+var (
+	ErrEmptyUsername       = newError(KindValidation, "empty_username", "username", "username cannot be empty")
+	ErrTooLongUsername     = newError(KindValidation, "too_long_username", "username", "max username length is 64")
+	ErrInvalidUsername     = newError(KindValidation, "invalid_username", "username", "username cannot contain spaces or slashes")
+	ErrInvalidAssignedToMe = newError(KindValidation, "invalid_assigned_to_me", "assigned-to-me", "assigned-to-me must be bool")
+	ErrInvalidWatching     = newError(KindValidation, "invalid_watching", "watching", "watching must be bool")
+	ErrUsernameRequired    = newError(KindUnauthorized, "username_required", "Username", "request must have Username header")
+	ErrTaskMemberNotFound  = newError(KindNotFound, "task_member_not_found", "", "task member is not found")
+)
+
+// workspace service errors
+var (
+	ErrEmptyWorkspaceName      = newError(KindValidation, "empty_workspace_name", "name", "workspace name cannot be empty")
+	ErrTooLongWorkspaceName    = newError(KindValidation, "too_long_workspace_name", "name", "max workspace name length is 64")
+	ErrInvalidWorkspaceID      = newError(KindValidation, "invalid_workspace_id", "workspace_id", "workspace id must be int")
+	ErrNonPositiveWorkspaceID  = newError(KindValidation, "non_positive_workspace_id", "workspace_id", "workspace id must be positive")
+	ErrInvalidRole             = newError(KindValidation, "invalid_role", "role", "role must be owner, admin, member or viewer")
+	ErrEmptyInvitationToken    = newError(KindValidation, "empty_invitation_token", "token", "invitation token cannot be empty")
+	ErrWorkspaceNotFound       = newError(KindNotFound, "workspace_not_found", "", "workspace is not found")
+	ErrWorkspaceMemberNotFound = newError(KindNotFound, "workspace_member_not_found", "", "workspace member is not found")
+	ErrInvitationNotFound      = newError(KindNotFound, "invitation_not_found", "", "invitation is not found, expired or already accepted")
+	ErrLastWorkspaceOwner      = newError(KindConflict, "last_workspace_owner", "role", "workspace must have at least one owner")
+	ErrPermissionDenied        = newError(KindForbidden, "permission_denied", "", "your role in the workspace does not allow this action")
+)
+
+// api key service errors
+var (
+	ErrEmptyAPIKeyName       = newError(KindValidation, "empty_api_key_name", "name", "api key name cannot be empty")
+	ErrTooLongAPIKeyName     = newError(KindValidation, "too_long_api_key_name", "name", "max api key name length is 64")
+	ErrEmptyAPIKeyScopes     = newError(KindValidation, "empty_api_key_scopes", "scopes", "api key must have at least one scope")
+	ErrInvalidAPIKeyScope    = newError(KindValidation, "invalid_api_key_scope", "scopes", "api key scope must be tasks:read, tasks:write or statuses:admin")
+	ErrInvalidAPIKeyID       = newError(KindValidation, "invalid_api_key_id", "key_id", "api key id must be int")
+	ErrNonPositiveAPIKeyID   = newError(KindValidation, "non_positive_api_key_id", "key_id", "api key id must be positive")
+	ErrAPIKeyNotFound        = newError(KindNotFound, "api_key_not_found", "", "api key is not found")
+	ErrInvalidAuthorization  = newError(KindUnauthorized, "invalid_authorization", "Authorization", "Authorization header must contain Bearer token")
+	ErrInvalidAPIKey         = newError(KindUnauthorized, "invalid_api_key", "Authorization", "api key is invalid or revoked")
+	ErrAPIKeyWorkspaceDenied = newError(KindForbidden, "api_key_workspace_denied", "Workspace-ID", "api key does not give access to the workspace")
+	ErrUsernameHeaderDenied  = newError(KindValidation, "username_header_denied", "Username", "Username header cannot be used with Authorization header")
+)
+
+// auth service errors
+var (
+	ErrOIDCDisabled         = newError(KindNotFound, "oidc_disabled", "", "oidc login is not configured")
+	ErrEmptyAuthCode        = newError(KindValidation, "empty_authorization_code", "code", "authorization code cannot be empty")
+	ErrInvalidOIDCState     = newError(KindValidation, "invalid_oidc_state", "state", "login state does not match, start login again")
+	ErrInvalidAuthCode      = newError(KindUnauthorized, "invalid_authorization_code", "code", "authorization code is invalid or expired")
+	ErrInvalidToken         = newError(KindUnauthorized, "invalid_token", "Authorization", "bearer token is invalid or expired")
+	ErrInvalidUsernameClaim = newError(KindUnauthorized, "invalid_username_claim", "", "token does not contain valid username claim")
+)
+
+// rate limit service errors
+var (
+	ErrRateLimitExceeded = newError(KindTooManyRequests, "rate_limit_exceeded", "", "too many requests, retry later")
+)
+
+// idempotency service errors
+var (
+	ErrTooLongIdempotencyKey    = newError(KindValidation, "too_long_idempotency_key", "Idempotency-Key", "max idempotency key length is 255")
+	ErrIdempotencyKeyReused     = newError(KindUnprocessable, "idempotency_key_reused", "Idempotency-Key", "idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = newError(KindConflict, "idempotency_key_in_progress", "Idempotency-Key", "request with the idempotency key is still in progress")
 )
@@ -0,0 +1,7 @@
+package constant
+
+// statuses names created by migrations
+const (
+	StatusNameDone    string = "выполнено"
+	StatusNameNotDone string = "не выполнено"
+)
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// APIKeyPrefix marks API keys, so leaked keys are easy to find in code and logs
+// and bearer API keys are told apart from tokens of identity provider
+const APIKeyPrefix = "todo_"
+
+// APIKey gives machine client access to the workspace limited by scopes.
+// Only hash of the key is stored, Prefix lets users recognize their keys
+type APIKey struct {
+	ID          int
+	WorkspaceID int
+	Name        string
+	Prefix      string
+	KeyHash     string
+	Scopes      []string
+	CreatedBy   string
+	CreatedAt   time.Time
+	LastUsedAt  sql.NullTime
+	RevokedAt   sql.NullTime
+}
+
+// apiKeyScopes are permissions that can be granted to API keys
+var apiKeyScopes = []Permission{PermTasksRead, PermTasksWrite, PermStatusesAdmin}
+
+// ValidAPIKeyScope reports whether scope can be granted to API key
+func ValidAPIKeyScope(scope string) bool {
+	for _, s := range apiKeyScopes {
+		if string(s) == scope {
+			return true
+		}
+	}
+	return false
+}
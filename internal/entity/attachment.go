@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type Attachment struct {
+	ID          int
+	TaskID      int
+	FileName    string
+	ContentType string
+	Size        int64
+	// StorageKey is the key of attachment content in the blob store
+	StorageKey string
+	CreatedAt  time.Time
+}
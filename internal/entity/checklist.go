@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+type ChecklistItem struct {
+	ID     int
+	TaskID int
+	Text   string
+	Done   bool
+	// Position orders items of the task, new item is placed last
+	Position  int
+	CreatedAt time.Time
+}
+
+// ChecklistCompletion returns percentage of done items rounded down, empty checklist is 0 percent complete
+func ChecklistCompletion(done, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return done * 100 / total
+}
+
+// CompletionFilter selects tasks having checklist with completion percentage from Min to Max.
+// Not valid filter selects all tasks
+type CompletionFilter struct {
+	Min   int
+	Max   int
+	Valid bool
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type Comment struct {
+	ID        int
+	TaskID    int
+	Author    string
+	Body      string
+	Deleted   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt time.Time
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+type Feed struct {
+	ID          int
+	WorkspaceID int
+	TokenHash   string
+	StatusID    int
+	Component   string
+	CreatedAt   time.Time
+}
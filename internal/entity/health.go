@@ -0,0 +1,7 @@
+package entity
+
+// SchemaVersion is the version of the last applied migration, dirty migration failed in the middle
+type SchemaVersion struct {
+	Version int
+	Dirty   bool
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// IdempotencyKey is a stored response of the request with Idempotency-Key header.
+// Keys are unique in the workspace for the principal, zero StatusCode means that the request is still in progress
+type IdempotencyKey struct {
+	WorkspaceID int
+	Principal   string
+	Key         string
+	RequestHash string
+	StatusCode  int
+	Headers     map[string][]string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
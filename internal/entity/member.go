@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// roles of task members
+const (
+	MemberRoleAssignee = "assignee"
+	MemberRoleWatcher  = "watcher"
+)
+
+// TaskMember relates user to the task as assignee or watcher
+type TaskMember struct {
+	TaskID    int
+	Username  string
+	Role      string
+	CreatedAt time.Time
+}
+
+// MemberFilter selects tasks assigned to Assignee and watched by Watcher, empty username doesn't filter
+type MemberFilter struct {
+	Assignee string
+	Watcher  string
+}
+
+// types of assignment events
+const (
+	AssignmentEventAssigned   = "assigned"
+	AssignmentEventUnassigned = "unassigned"
+)
+
+// AssignmentEvent describes change of the task assignees made by Actor, empty Actor is anonymous
+type AssignmentEvent struct {
+	Type     string
+	TaskID   int
+	Username string
+	Actor    string
+	At       time.Time
+}
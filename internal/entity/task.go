@@ -8,7 +8,34 @@ type Task struct {
 	Description string
 	StatusID    int
 	Date        time.Time
-	Deleted     bool
-	CreatedAt   time.Time
-	DeletedAt   time.Time
+	// AllDay tasks are due on the calendar day of Date stored as midnight in UTC
+	AllDay    bool
+	Priority  int
+	Tags      []string
+	Projects  []string
+	Version   int
+	Deleted   bool
+	CreatedAt time.Time
+	DeletedAt time.Time
+	// CommentsCount is the number of not deleted task comments
+	CommentsCount int
+	// ChecklistTotal and ChecklistDone are numbers of all and done checklist items
+	ChecklistTotal int
+	ChecklistDone  int
+	// ClearPriority resets priority to zero on update, zero Priority alone keeps the current one
+	ClearPriority bool
+}
+
+// types of task events
+const (
+	TaskEventCreated = "created"
+	TaskEventDeleted = "deleted"
+)
+
+// TaskEvent describes creation or deletion of the task made by Actor, empty Actor is anonymous
+type TaskEvent struct {
+	Type   string
+	TaskID int
+	Actor  string
+	At     time.Time
 }
@@ -0,0 +1,20 @@
+package entity
+
+type TaskBatchAction string
+
+const (
+	TaskBatchCreate       TaskBatchAction = "create"
+	TaskBatchUpdateStatus TaskBatchAction = "update_status"
+	TaskBatchDelete       TaskBatchAction = "delete"
+	TaskBatchRestore      TaskBatchAction = "restore"
+)
+
+type TaskBatchItem struct {
+	Action TaskBatchAction
+	Task   Task
+}
+
+type TaskBatchResult struct {
+	ID  int
+	Err error
+}
@@ -0,0 +1,81 @@
+package entity
+
+import "time"
+
+type Workspace struct {
+	ID        int
+	Name      string
+	CreatedAt time.Time
+}
+
+// UserWorkspace is the workspace with the role of the user in it
+type UserWorkspace struct {
+	ID        int
+	Name      string
+	Role      string
+	CreatedAt time.Time
+}
+
+type WorkspaceMember struct {
+	WorkspaceID int
+	Username    string
+	Role        string
+	CreatedAt   time.Time
+}
+
+// Invitation lets one user who knows the token join the workspace with the role until ExpiresAt.
+// Only SHA-256 hash of the token is stored
+type Invitation struct {
+	ID          int
+	WorkspaceID int
+	TokenHash   string
+	Role        string
+	CreatedBy   string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	AcceptedBy  *string
+	AcceptedAt  *time.Time
+}
+
+// roles of workspace members from the most to the least privileged
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+)
+
+// Permission allows group of actions in the workspace
+type Permission string
+
+const (
+	PermTasksRead      Permission = "tasks:read"
+	PermTasksWrite     Permission = "tasks:write"
+	PermStatusesAdmin  Permission = "statuses:admin"
+	PermMembersAdmin   Permission = "members:admin"
+	PermWorkspaceAdmin Permission = "workspace:admin"
+)
+
+// rolePermissions is the permission matrix of workspace roles
+var rolePermissions = map[string][]Permission{
+	RoleOwner:  {PermTasksRead, PermTasksWrite, PermStatusesAdmin, PermMembersAdmin, PermWorkspaceAdmin},
+	RoleAdmin:  {PermTasksRead, PermTasksWrite, PermStatusesAdmin, PermMembersAdmin},
+	RoleMember: {PermTasksRead, PermTasksWrite},
+	RoleViewer: {PermTasksRead},
+}
+
+// ValidRole reports whether role is one of workspace roles
+func ValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// HasPermission reports whether role has permission, unknown role has no permissions
+func HasPermission(role string, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,282 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/pkg/cache"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/tenant"
+	"time"
+)
+
+// keyPrefix separates keys of the app in shared cache
+const keyPrefix = "todo:"
+
+// repoCache keeps statuses and tasks by workspace. Errors of the cache are logged and
+// the repo is queried, so unavailable cache only slows down requests
+type repoCache struct {
+	cache    cache.Cache
+	notifier cache.Notifier
+	ttl      time.Duration
+	logger   logger.Logger
+}
+
+// WithCache returns repository with cached status and task reads. Writes of tasks,
+// their comments and checklist items drop cached task, as it has counts of them.
+// Nil notifier means the cache is not shared with other app instances or is shared itself like redis.
+// Value read before concurrent write may be cached after invalidation, it lives until ttl expires
+func (r *Repository) WithCache(c cache.Cache, notifier cache.Notifier, ttl time.Duration, logger logger.Logger) *Repository {
+	rc := &repoCache{
+		cache:    c,
+		notifier: notifier,
+		ttl:      ttl,
+		logger:   logger,
+	}
+
+	cached := *r
+	cached.Status = &cachedStatus{next: r.Status, cache: rc}
+	cached.Task = &cachedTask{next: r.Task, cache: rc}
+	cached.Comment = &cachedComment{next: r.Comment, cache: rc}
+	cached.Checklist = &cachedChecklist{next: r.Checklist, cache: rc}
+
+	return &cached
+}
+
+func allStatusesKey(ctx context.Context) string {
+	return fmt.Sprintf("%sws:%d:statuses", keyPrefix, tenant.WorkspaceID(ctx))
+}
+
+func statusByNameKey(ctx context.Context, name string) string {
+	return fmt.Sprintf("%sws:%d:status:name:%s", keyPrefix, tenant.WorkspaceID(ctx), name)
+}
+
+func statusByIDKey(ctx context.Context, id int) string {
+	return fmt.Sprintf("%sws:%d:status:id:%d", keyPrefix, tenant.WorkspaceID(ctx), id)
+}
+
+func taskKey(ctx context.Context, id int) string {
+	return fmt.Sprintf("%sws:%d:task:%d", keyPrefix, tenant.WorkspaceID(ctx), id)
+}
+
+// load returns cached value of the key or loads and caches it, errors of load are not cached
+func load[T any](ctx context.Context, c *repoCache, key string, loadFn func() (T, error)) (T, error) {
+	data, ok, err := c.cache.Get(ctx, key)
+	if err != nil {
+		c.logger.WarnContext(ctx, "error getting cached value", logger.String("key", key), logger.Error(err))
+	}
+	if ok {
+		var value T
+		err = json.Unmarshal(data, &value)
+		if err == nil {
+			return value, nil
+		}
+		c.logger.WarnContext(ctx, "error decoding cached value", logger.String("key", key), logger.Error(err))
+	}
+
+	value, err := loadFn()
+	if err != nil {
+		return value, err
+	}
+
+	data, err = json.Marshal(value)
+	if err != nil {
+		c.logger.WarnContext(ctx, "error encoding cached value", logger.String("key", key), logger.Error(err))
+		return value, nil
+	}
+	err = c.cache.Set(ctx, key, data, c.ttl)
+	if err != nil {
+		c.logger.WarnContext(ctx, "error caching value", logger.String("key", key), logger.Error(err))
+	}
+
+	return value, nil
+}
+
+// invalidate drops keys of this instance cache and notifies other instances
+func (c *repoCache) invalidate(ctx context.Context, keys ...string) {
+	err := c.cache.Delete(ctx, keys...)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "error deleting cached values", logger.Any("keys", keys), logger.Error(err))
+	}
+
+	if c.notifier == nil {
+		return
+	}
+	err = c.notifier.Notify(ctx, keys...)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "error notifying about deleted cached values", logger.Any("keys", keys), logger.Error(err))
+	}
+}
+
+type cachedStatus struct {
+	next  Status
+	cache *repoCache
+}
+
+func (s *cachedStatus) CreateStatus(ctx context.Context, status entity.Status) (int, error) {
+	id, err := s.next.CreateStatus(ctx, status)
+	if err != nil {
+		return id, err
+	}
+
+	s.cache.invalidate(ctx, allStatusesKey(ctx))
+	return id, nil
+}
+
+func (s *cachedStatus) GetAllStatuses(ctx context.Context) ([]*entity.Status, error) {
+	return load(ctx, s.cache, allStatusesKey(ctx), func() ([]*entity.Status, error) {
+		return s.next.GetAllStatuses(ctx)
+	})
+}
+
+func (s *cachedStatus) GetStatusByName(ctx context.Context, name string) (entity.Status, error) {
+	return load(ctx, s.cache, statusByNameKey(ctx, name), func() (entity.Status, error) {
+		return s.next.GetStatusByName(ctx, name)
+	})
+}
+
+func (s *cachedStatus) GetStatusByID(ctx context.Context, id int) (entity.Status, error) {
+	return load(ctx, s.cache, statusByIDKey(ctx, id), func() (entity.Status, error) {
+		return s.next.GetStatusByID(ctx, id)
+	})
+}
+
+// cachedTask caches tasks by id, lists have too many filters to be cached
+type cachedTask struct {
+	next  Task
+	cache *repoCache
+}
+
+func (t *cachedTask) CreateTask(ctx context.Context, task entity.Task) (int, error) {
+	return t.next.CreateTask(ctx, task)
+}
+
+func (t *cachedTask) GetAllTasks(ctx context.Context, statusID, limit, lastID int, date time.Time, completion entity.CompletionFilter, members entity.MemberFilter) ([]*entity.Task, error) {
+	return t.next.GetAllTasks(ctx, statusID, limit, lastID, date, completion, members)
+}
+
+func (t *cachedTask) GetTaskByID(ctx context.Context, id int) (entity.Task, error) {
+	return load(ctx, t.cache, taskKey(ctx, id), func() (entity.Task, error) {
+		return t.next.GetTaskByID(ctx, id)
+	})
+}
+
+func (t *cachedTask) UpdateTaskByID(ctx context.Context, id int, task entity.Task) (int, error) {
+	version, err := t.next.UpdateTaskByID(ctx, id, task)
+	if err != nil {
+		return version, err
+	}
+
+	t.cache.invalidate(ctx, taskKey(ctx, id))
+	return version, nil
+}
+
+func (t *cachedTask) DeleteTaskByID(ctx context.Context, id int, version int) error {
+	err := t.next.DeleteTaskByID(ctx, id, version)
+	if err != nil {
+		return err
+	}
+
+	t.cache.invalidate(ctx, taskKey(ctx, id))
+	return nil
+}
+
+func (t *cachedTask) ExecTaskBatch(ctx context.Context, items []entity.TaskBatchItem, atomic bool) ([]entity.TaskBatchResult, error) {
+	results, err := t.next.ExecTaskBatch(ctx, items, atomic)
+
+	// items of not atomic batch may be committed even if the batch has failed
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.Action != entity.TaskBatchCreate {
+			keys = append(keys, taskKey(ctx, item.Task.ID))
+		}
+	}
+	if len(keys) > 0 {
+		t.cache.invalidate(ctx, keys...)
+	}
+
+	return results, err
+}
+
+// cachedComment drops cached task which comments count is changed
+type cachedComment struct {
+	next  Comment
+	cache *repoCache
+}
+
+func (c *cachedComment) CreateComment(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	created, err := c.next.CreateComment(ctx, comment)
+	if err != nil {
+		return created, err
+	}
+
+	c.cache.invalidate(ctx, taskKey(ctx, comment.TaskID))
+	return created, nil
+}
+
+func (c *cachedComment) GetCommentsByTaskID(ctx context.Context, taskID, limit, lastID int) ([]*entity.Comment, error) {
+	return c.next.GetCommentsByTaskID(ctx, taskID, limit, lastID)
+}
+
+func (c *cachedComment) GetCommentByID(ctx context.Context, taskID, id int) (entity.Comment, error) {
+	return c.next.GetCommentByID(ctx, taskID, id)
+}
+
+func (c *cachedComment) UpdateComment(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	return c.next.UpdateComment(ctx, comment)
+}
+
+func (c *cachedComment) DeleteComment(ctx context.Context, taskID, id int) error {
+	err := c.next.DeleteComment(ctx, taskID, id)
+	if err != nil {
+		return err
+	}
+
+	c.cache.invalidate(ctx, taskKey(ctx, taskID))
+	return nil
+}
+
+// cachedChecklist drops cached task which checklist counts are changed
+type cachedChecklist struct {
+	next  Checklist
+	cache *repoCache
+}
+
+func (c *cachedChecklist) AddChecklistItem(ctx context.Context, item entity.ChecklistItem) (entity.ChecklistItem, error) {
+	added, err := c.next.AddChecklistItem(ctx, item)
+	if err != nil {
+		return added, err
+	}
+
+	c.cache.invalidate(ctx, taskKey(ctx, item.TaskID))
+	return added, nil
+}
+
+func (c *cachedChecklist) GetChecklistItemsByTaskID(ctx context.Context, taskID int) ([]*entity.ChecklistItem, error) {
+	return c.next.GetChecklistItemsByTaskID(ctx, taskID)
+}
+
+func (c *cachedChecklist) ToggleChecklistItem(ctx context.Context, taskID, id int) (entity.ChecklistItem, error) {
+	item, err := c.next.ToggleChecklistItem(ctx, taskID, id)
+	if err != nil {
+		return item, err
+	}
+
+	c.cache.invalidate(ctx, taskKey(ctx, taskID))
+	return item, nil
+}
+
+func (c *cachedChecklist) ReorderChecklistItems(ctx context.Context, taskID int, ids []int) error {
+	return c.next.ReorderChecklistItems(ctx, taskID, ids)
+}
+
+func (c *cachedChecklist) DeleteChecklistItem(ctx context.Context, taskID, id int) error {
+	err := c.next.DeleteChecklistItem(ctx, taskID, id)
+	if err != nil {
+		return err
+	}
+
+	c.cache.invalidate(ctx, taskKey(ctx, taskID))
+	return nil
+}
@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/entity"
+	mock_storage "github.com/romandnk/todo/internal/repo/mock"
+	memorycache "github.com/romandnk/todo/pkg/cache/memory"
+	mock_cache "github.com/romandnk/todo/pkg/cache/mock"
+	"github.com/romandnk/todo/pkg/logger"
+	recorderlogger "github.com/romandnk/todo/pkg/logger/recorder"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestRepository_WithCache_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := tenant.WithWorkspace(context.Background(), 2, entity.RoleMember)
+	status := mock_storage.NewMockStatus(ctrl)
+	c := memorycache.NewCache(10)
+
+	repo := (&Repository{Status: status}).WithCache(c, nil, time.Minute, recorderlogger.NewLogger())
+
+	// statuses are queried once, errors are not cached
+	status.EXPECT().GetAllStatuses(gomock.Any()).Return([]*entity.Status{{ID: 1, Name: "todo"}}, nil)
+	status.EXPECT().GetStatusByName(gomock.Any(), "done").Return(entity.Status{}, pgx.ErrNoRows).Times(2)
+	status.EXPECT().GetStatusByID(gomock.Any(), 1).Return(entity.Status{ID: 1, Name: "todo"}, nil)
+
+	for i := 0; i < 2; i++ {
+		statuses, err := repo.Status.GetAllStatuses(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []*entity.Status{{ID: 1, Name: "todo"}}, statuses)
+
+		_, err = repo.Status.GetStatusByName(ctx, "done")
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+
+		s, err := repo.Status.GetStatusByID(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, entity.Status{ID: 1, Name: "todo"}, s)
+	}
+
+	// statuses of other workspace are not shared
+	otherCtx := tenant.WithWorkspace(context.Background(), 3, entity.RoleMember)
+	status.EXPECT().GetAllStatuses(gomock.Any()).Return(nil, nil)
+	_, err := repo.Status.GetAllStatuses(otherCtx)
+	require.NoError(t, err)
+
+	// created status drops the list
+	status.EXPECT().CreateStatus(gomock.Any(), entity.Status{Name: "done"}).Return(2, nil)
+	_, err = repo.Status.CreateStatus(ctx, entity.Status{Name: "done"})
+	require.NoError(t, err)
+
+	status.EXPECT().GetAllStatuses(gomock.Any()).Return([]*entity.Status{{ID: 1, Name: "todo"}, {ID: 2, Name: "done"}}, nil)
+	statuses, err := repo.Status.GetAllStatuses(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+}
+
+func TestRepository_WithCache_Task(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := tenant.WithWorkspace(context.Background(), 2, entity.RoleMember)
+	task := mock_storage.NewMockTask(ctrl)
+	comment := mock_storage.NewMockComment(ctrl)
+	checklist := mock_storage.NewMockChecklist(ctrl)
+	notifier := mock_cache.NewMockNotifier(ctrl)
+	c := memorycache.NewCache(10)
+
+	repo := (&Repository{Task: task, Comment: comment, Checklist: checklist}).
+		WithCache(c, notifier, time.Minute, recorderlogger.NewLogger())
+
+	cachedTask := entity.Task{ID: 1, Title: "task", Version: 1, CreatedAt: time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)}
+	key := "todo:ws:2:task:1"
+
+	getTask := func(expected entity.Task) {
+		got, err := repo.Task.GetTaskByID(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, expected, got)
+	}
+
+	task.EXPECT().GetTaskByID(gomock.Any(), 1).Return(cachedTask, nil)
+	getTask(cachedTask)
+	getTask(cachedTask)
+
+	testCases := []struct {
+		name  string
+		write func()
+	}{
+		{
+			name: "update",
+			write: func() {
+				task.EXPECT().UpdateTaskByID(gomock.Any(), 1, gomock.Any()).Return(2, nil)
+				_, err := repo.Task.UpdateTaskByID(ctx, 1, entity.Task{})
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "batch",
+			write: func() {
+				items := []entity.TaskBatchItem{
+					{Action: entity.TaskBatchCreate, Task: entity.Task{Title: "new"}},
+					{Action: entity.TaskBatchUpdateStatus, Task: entity.Task{ID: 1, StatusID: 2}},
+				}
+				task.EXPECT().ExecTaskBatch(gomock.Any(), items, false).Return(nil, errors.New("batch error"))
+				_, err := repo.Task.ExecTaskBatch(ctx, items, false)
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "comment",
+			write: func() {
+				comment.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(entity.Comment{}, nil)
+				_, err := repo.Comment.CreateComment(ctx, entity.Comment{TaskID: 1, Body: "text"})
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "checklist",
+			write: func() {
+				checklist.EXPECT().ToggleChecklistItem(gomock.Any(), 1, 5).Return(entity.ChecklistItem{}, nil)
+				_, err := repo.Checklist.ToggleChecklistItem(ctx, 1, 5)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "delete",
+			write: func() {
+				task.EXPECT().DeleteTaskByID(gomock.Any(), 1, 0).Return(nil)
+				require.NoError(t, repo.Task.DeleteTaskByID(ctx, 1, 0))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			notifier.EXPECT().Notify(gomock.Any(), key).Return(nil)
+			tc.write()
+
+			updated := cachedTask
+			updated.Title = tc.name
+			task.EXPECT().GetTaskByID(gomock.Any(), 1).Return(updated, nil)
+			getTask(updated)
+			getTask(updated)
+		})
+	}
+}
+
+func TestRepository_WithCache_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := tenant.WithWorkspace(context.Background(), 2, entity.RoleMember)
+	task := mock_storage.NewMockTask(ctrl)
+	c := mock_cache.NewMockCache(ctrl)
+	log := recorderlogger.NewLogger()
+
+	repo := (&Repository{Task: task}).WithCache(c, nil, time.Minute, log)
+
+	// unavailable cache does not fail reads
+	cacheErr := errors.New("connection refused")
+	c.EXPECT().Get(gomock.Any(), "todo:ws:2:task:1").Return(nil, false, cacheErr)
+	task.EXPECT().GetTaskByID(gomock.Any(), 1).Return(entity.Task{ID: 1}, nil)
+	c.EXPECT().Set(gomock.Any(), "todo:ws:2:task:1", gomock.Any(), time.Minute).Return(cacheErr)
+
+	got, err := repo.Task.GetTaskByID(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, entity.Task{ID: 1}, got)
+
+	entries := log.Entries()
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		require.Equal(t, logger.WarnLevel, entry.Level)
+		value, _ := entry.Field("error")
+		require.Equal(t, cacheErr, value)
+	}
+}
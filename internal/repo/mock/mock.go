@@ -56,32 +56,47 @@ func (mr *MockTaskMockRecorder) CreateTask(ctx, task any) *gomock.Call {
 }
 
 // DeleteTaskByID mocks base method.
-func (m *MockTask) DeleteTaskByID(ctx context.Context, id int) error {
+func (m *MockTask) DeleteTaskByID(ctx context.Context, id, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTaskByID", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteTaskByID", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTaskByID indicates an expected call of DeleteTaskByID.
-func (mr *MockTaskMockRecorder) DeleteTaskByID(ctx, id any) *gomock.Call {
+func (mr *MockTaskMockRecorder) DeleteTaskByID(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskByID", reflect.TypeOf((*MockTask)(nil).DeleteTaskByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskByID", reflect.TypeOf((*MockTask)(nil).DeleteTaskByID), ctx, id, version)
+}
+
+// ExecTaskBatch mocks base method.
+func (m *MockTask) ExecTaskBatch(ctx context.Context, items []entity.TaskBatchItem, atomic bool) ([]entity.TaskBatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecTaskBatch", ctx, items, atomic)
+	ret0, _ := ret[0].([]entity.TaskBatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecTaskBatch indicates an expected call of ExecTaskBatch.
+func (mr *MockTaskMockRecorder) ExecTaskBatch(ctx, items, atomic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTaskBatch", reflect.TypeOf((*MockTask)(nil).ExecTaskBatch), ctx, items, atomic)
 }
 
 // GetAllTasks mocks base method.
-func (m *MockTask) GetAllTasks(ctx context.Context, statusID, limit, lastID int, date time.Time) ([]*entity.Task, error) {
+func (m *MockTask) GetAllTasks(ctx context.Context, statusID, limit, lastID int, date time.Time, completion entity.CompletionFilter, members entity.MemberFilter) ([]*entity.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTasks", ctx, statusID, limit, lastID, date)
+	ret := m.ctrl.Call(m, "GetAllTasks", ctx, statusID, limit, lastID, date, completion, members)
 	ret0, _ := ret[0].([]*entity.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllTasks indicates an expected call of GetAllTasks.
-func (mr *MockTaskMockRecorder) GetAllTasks(ctx, statusID, limit, lastID, date any) *gomock.Call {
+func (mr *MockTaskMockRecorder) GetAllTasks(ctx, statusID, limit, lastID, date, completion, members any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTask)(nil).GetAllTasks), ctx, statusID, limit, lastID, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTask)(nil).GetAllTasks), ctx, statusID, limit, lastID, date, completion, members)
 }
 
 // GetTaskByID mocks base method.
@@ -100,11 +115,12 @@ func (mr *MockTaskMockRecorder) GetTaskByID(ctx, id any) *gomock.Call {
 }
 
 // UpdateTaskByID mocks base method.
-func (m *MockTask) UpdateTaskByID(ctx context.Context, id int, task entity.Task) error {
+func (m *MockTask) UpdateTaskByID(ctx context.Context, id int, task entity.Task) (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateTaskByID", ctx, id, task)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateTaskByID indicates an expected call of UpdateTaskByID.
@@ -195,3 +211,801 @@ func (mr *MockStatusMockRecorder) GetStatusByName(ctx, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusByName", reflect.TypeOf((*MockStatus)(nil).GetStatusByName), ctx, name)
 }
+
+// MockFeed is a mock of Feed interface.
+type MockFeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedMockRecorder
+}
+
+// MockFeedMockRecorder is the mock recorder for MockFeed.
+type MockFeedMockRecorder struct {
+	mock *MockFeed
+}
+
+// NewMockFeed creates a new mock instance.
+func NewMockFeed(ctrl *gomock.Controller) *MockFeed {
+	mock := &MockFeed{ctrl: ctrl}
+	mock.recorder = &MockFeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeed) EXPECT() *MockFeedMockRecorder {
+	return m.recorder
+}
+
+// CreateFeed mocks base method.
+func (m *MockFeed) CreateFeed(ctx context.Context, feed entity.Feed) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeed", ctx, feed)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFeed indicates an expected call of CreateFeed.
+func (mr *MockFeedMockRecorder) CreateFeed(ctx, feed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeed", reflect.TypeOf((*MockFeed)(nil).CreateFeed), ctx, feed)
+}
+
+// DeleteFeedByTokenHash mocks base method.
+func (m *MockFeed) DeleteFeedByTokenHash(ctx context.Context, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFeedByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFeedByTokenHash indicates an expected call of DeleteFeedByTokenHash.
+func (mr *MockFeedMockRecorder) DeleteFeedByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFeedByTokenHash", reflect.TypeOf((*MockFeed)(nil).DeleteFeedByTokenHash), ctx, tokenHash)
+}
+
+// GetFeedByTokenHash mocks base method.
+func (m *MockFeed) GetFeedByTokenHash(ctx context.Context, tokenHash string) (entity.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(entity.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedByTokenHash indicates an expected call of GetFeedByTokenHash.
+func (mr *MockFeedMockRecorder) GetFeedByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedByTokenHash", reflect.TypeOf((*MockFeed)(nil).GetFeedByTokenHash), ctx, tokenHash)
+}
+
+// MockComment is a mock of Comment interface.
+type MockComment struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentMockRecorder
+}
+
+// MockCommentMockRecorder is the mock recorder for MockComment.
+type MockCommentMockRecorder struct {
+	mock *MockComment
+}
+
+// NewMockComment creates a new mock instance.
+func NewMockComment(ctrl *gomock.Controller) *MockComment {
+	mock := &MockComment{ctrl: ctrl}
+	mock.recorder = &MockCommentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComment) EXPECT() *MockCommentMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockComment) CreateComment(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ctx, comment)
+	ret0, _ := ret[0].(entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentMockRecorder) CreateComment(ctx, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockComment)(nil).CreateComment), ctx, comment)
+}
+
+// DeleteComment mocks base method.
+func (m *MockComment) DeleteComment(ctx context.Context, taskID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, taskID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentMockRecorder) DeleteComment(ctx, taskID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockComment)(nil).DeleteComment), ctx, taskID, id)
+}
+
+// GetCommentByID mocks base method.
+func (m *MockComment) GetCommentByID(ctx context.Context, taskID, id int) (entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentByID", ctx, taskID, id)
+	ret0, _ := ret[0].(entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentByID indicates an expected call of GetCommentByID.
+func (mr *MockCommentMockRecorder) GetCommentByID(ctx, taskID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentByID", reflect.TypeOf((*MockComment)(nil).GetCommentByID), ctx, taskID, id)
+}
+
+// GetCommentsByTaskID mocks base method.
+func (m *MockComment) GetCommentsByTaskID(ctx context.Context, taskID, limit, lastID int) ([]*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentsByTaskID", ctx, taskID, limit, lastID)
+	ret0, _ := ret[0].([]*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentsByTaskID indicates an expected call of GetCommentsByTaskID.
+func (mr *MockCommentMockRecorder) GetCommentsByTaskID(ctx, taskID, limit, lastID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentsByTaskID", reflect.TypeOf((*MockComment)(nil).GetCommentsByTaskID), ctx, taskID, limit, lastID)
+}
+
+// UpdateComment mocks base method.
+func (m *MockComment) UpdateComment(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, comment)
+	ret0, _ := ret[0].(entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockCommentMockRecorder) UpdateComment(ctx, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockComment)(nil).UpdateComment), ctx, comment)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// CreateAttachment mocks base method.
+func (m *MockAttachment) CreateAttachment(ctx context.Context, attachment entity.Attachment) (entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, attachment)
+	ret0, _ := ret[0].(entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockAttachmentMockRecorder) CreateAttachment(ctx, attachment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockAttachment)(nil).CreateAttachment), ctx, attachment)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachment) DeleteAttachment(ctx context.Context, taskID, id int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, taskID, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentMockRecorder) DeleteAttachment(ctx, taskID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachment)(nil).DeleteAttachment), ctx, taskID, id)
+}
+
+// GetAttachmentByID mocks base method.
+func (m *MockAttachment) GetAttachmentByID(ctx context.Context, taskID, id int) (entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentByID", ctx, taskID, id)
+	ret0, _ := ret[0].(entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentByID indicates an expected call of GetAttachmentByID.
+func (mr *MockAttachmentMockRecorder) GetAttachmentByID(ctx, taskID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentByID", reflect.TypeOf((*MockAttachment)(nil).GetAttachmentByID), ctx, taskID, id)
+}
+
+// GetAttachmentsByTaskID mocks base method.
+func (m *MockAttachment) GetAttachmentsByTaskID(ctx context.Context, taskID int) ([]*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentsByTaskID", ctx, taskID)
+	ret0, _ := ret[0].([]*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentsByTaskID indicates an expected call of GetAttachmentsByTaskID.
+func (mr *MockAttachmentMockRecorder) GetAttachmentsByTaskID(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentsByTaskID", reflect.TypeOf((*MockAttachment)(nil).GetAttachmentsByTaskID), ctx, taskID)
+}
+
+// MockChecklist is a mock of Checklist interface.
+type MockChecklist struct {
+	ctrl     *gomock.Controller
+	recorder *MockChecklistMockRecorder
+}
+
+// MockChecklistMockRecorder is the mock recorder for MockChecklist.
+type MockChecklistMockRecorder struct {
+	mock *MockChecklist
+}
+
+// NewMockChecklist creates a new mock instance.
+func NewMockChecklist(ctrl *gomock.Controller) *MockChecklist {
+	mock := &MockChecklist{ctrl: ctrl}
+	mock.recorder = &MockChecklistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecklist) EXPECT() *MockChecklistMockRecorder {
+	return m.recorder
+}
+
+// AddChecklistItem mocks base method.
+func (m *MockChecklist) AddChecklistItem(ctx context.Context, item entity.ChecklistItem) (entity.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", ctx, item)
+	ret0, _ := ret[0].(entity.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *MockChecklistMockRecorder) AddChecklistItem(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*MockChecklist)(nil).AddChecklistItem), ctx, item)
+}
+
+// DeleteChecklistItem mocks base method.
+func (m *MockChecklist) DeleteChecklistItem(ctx context.Context, taskID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", ctx, taskID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *MockChecklistMockRecorder) DeleteChecklistItem(ctx, taskID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*MockChecklist)(nil).DeleteChecklistItem), ctx, taskID, id)
+}
+
+// GetChecklistItemsByTaskID mocks base method.
+func (m *MockChecklist) GetChecklistItemsByTaskID(ctx context.Context, taskID int) ([]*entity.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecklistItemsByTaskID", ctx, taskID)
+	ret0, _ := ret[0].([]*entity.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChecklistItemsByTaskID indicates an expected call of GetChecklistItemsByTaskID.
+func (mr *MockChecklistMockRecorder) GetChecklistItemsByTaskID(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecklistItemsByTaskID", reflect.TypeOf((*MockChecklist)(nil).GetChecklistItemsByTaskID), ctx, taskID)
+}
+
+// ReorderChecklistItems mocks base method.
+func (m *MockChecklist) ReorderChecklistItems(ctx context.Context, taskID int, ids []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderChecklistItems", ctx, taskID, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderChecklistItems indicates an expected call of ReorderChecklistItems.
+func (mr *MockChecklistMockRecorder) ReorderChecklistItems(ctx, taskID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderChecklistItems", reflect.TypeOf((*MockChecklist)(nil).ReorderChecklistItems), ctx, taskID, ids)
+}
+
+// ToggleChecklistItem mocks base method.
+func (m *MockChecklist) ToggleChecklistItem(ctx context.Context, taskID, id int) (entity.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToggleChecklistItem", ctx, taskID, id)
+	ret0, _ := ret[0].(entity.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToggleChecklistItem indicates an expected call of ToggleChecklistItem.
+func (mr *MockChecklistMockRecorder) ToggleChecklistItem(ctx, taskID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleChecklistItem", reflect.TypeOf((*MockChecklist)(nil).ToggleChecklistItem), ctx, taskID, id)
+}
+
+// MockMember is a mock of Member interface.
+type MockMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberMockRecorder
+}
+
+// MockMemberMockRecorder is the mock recorder for MockMember.
+type MockMemberMockRecorder struct {
+	mock *MockMember
+}
+
+// NewMockMember creates a new mock instance.
+func NewMockMember(ctrl *gomock.Controller) *MockMember {
+	mock := &MockMember{ctrl: ctrl}
+	mock.recorder = &MockMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMember) EXPECT() *MockMemberMockRecorder {
+	return m.recorder
+}
+
+// AddTaskMember mocks base method.
+func (m *MockMember) AddTaskMember(ctx context.Context, member entity.TaskMember) (entity.TaskMember, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskMember", ctx, member)
+	ret0, _ := ret[0].(entity.TaskMember)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddTaskMember indicates an expected call of AddTaskMember.
+func (mr *MockMemberMockRecorder) AddTaskMember(ctx, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskMember", reflect.TypeOf((*MockMember)(nil).AddTaskMember), ctx, member)
+}
+
+// DeleteTaskMember mocks base method.
+func (m *MockMember) DeleteTaskMember(ctx context.Context, member entity.TaskMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskMember", ctx, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskMember indicates an expected call of DeleteTaskMember.
+func (mr *MockMemberMockRecorder) DeleteTaskMember(ctx, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskMember", reflect.TypeOf((*MockMember)(nil).DeleteTaskMember), ctx, member)
+}
+
+// GetTaskMembers mocks base method.
+func (m *MockMember) GetTaskMembers(ctx context.Context, taskID int) ([]*entity.TaskMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskMembers", ctx, taskID)
+	ret0, _ := ret[0].([]*entity.TaskMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskMembers indicates an expected call of GetTaskMembers.
+func (mr *MockMemberMockRecorder) GetTaskMembers(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskMembers", reflect.TypeOf((*MockMember)(nil).GetTaskMembers), ctx, taskID)
+}
+
+// MockWorkspace is a mock of Workspace interface.
+type MockWorkspace struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceMockRecorder
+}
+
+// MockWorkspaceMockRecorder is the mock recorder for MockWorkspace.
+type MockWorkspaceMockRecorder struct {
+	mock *MockWorkspace
+}
+
+// NewMockWorkspace creates a new mock instance.
+func NewMockWorkspace(ctrl *gomock.Controller) *MockWorkspace {
+	mock := &MockWorkspace{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspace) EXPECT() *MockWorkspaceMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockWorkspace) AcceptInvitation(ctx context.Context, tokenHash, username string, now time.Time) (entity.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, tokenHash, username, now)
+	ret0, _ := ret[0].(entity.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockWorkspaceMockRecorder) AcceptInvitation(ctx, tokenHash, username, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockWorkspace)(nil).AcceptInvitation), ctx, tokenHash, username, now)
+}
+
+// AddOwnerIfNone mocks base method.
+func (m *MockWorkspace) AddOwnerIfNone(ctx context.Context, workspaceID int, username string, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOwnerIfNone", ctx, workspaceID, username, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOwnerIfNone indicates an expected call of AddOwnerIfNone.
+func (mr *MockWorkspaceMockRecorder) AddOwnerIfNone(ctx, workspaceID, username, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOwnerIfNone", reflect.TypeOf((*MockWorkspace)(nil).AddOwnerIfNone), ctx, workspaceID, username, now)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockWorkspace) CreateInvitation(ctx context.Context, invitation entity.Invitation) (entity.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, invitation)
+	ret0, _ := ret[0].(entity.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockWorkspaceMockRecorder) CreateInvitation(ctx, invitation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockWorkspace)(nil).CreateInvitation), ctx, invitation)
+}
+
+// CreateWorkspace mocks base method.
+func (m *MockWorkspace) CreateWorkspace(ctx context.Context, workspace entity.Workspace, owner string) (entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", ctx, workspace, owner)
+	ret0, _ := ret[0].(entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockWorkspaceMockRecorder) CreateWorkspace(ctx, workspace, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockWorkspace)(nil).CreateWorkspace), ctx, workspace, owner)
+}
+
+// DeleteMember mocks base method.
+func (m *MockWorkspace) DeleteMember(ctx context.Context, workspaceID int, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", ctx, workspaceID, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockWorkspaceMockRecorder) DeleteMember(ctx, workspaceID, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockWorkspace)(nil).DeleteMember), ctx, workspaceID, username)
+}
+
+// GetMemberRole mocks base method.
+func (m *MockWorkspace) GetMemberRole(ctx context.Context, workspaceID int, username string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberRole", ctx, workspaceID, username)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberRole indicates an expected call of GetMemberRole.
+func (mr *MockWorkspaceMockRecorder) GetMemberRole(ctx, workspaceID, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberRole", reflect.TypeOf((*MockWorkspace)(nil).GetMemberRole), ctx, workspaceID, username)
+}
+
+// GetWorkspaceMembers mocks base method.
+func (m *MockWorkspace) GetWorkspaceMembers(ctx context.Context, workspaceID int) ([]*entity.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceMembers", ctx, workspaceID)
+	ret0, _ := ret[0].([]*entity.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceMembers indicates an expected call of GetWorkspaceMembers.
+func (mr *MockWorkspaceMockRecorder) GetWorkspaceMembers(ctx, workspaceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceMembers", reflect.TypeOf((*MockWorkspace)(nil).GetWorkspaceMembers), ctx, workspaceID)
+}
+
+// GetWorkspacesByUsername mocks base method.
+func (m *MockWorkspace) GetWorkspacesByUsername(ctx context.Context, username string) ([]*entity.UserWorkspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspacesByUsername", ctx, username)
+	ret0, _ := ret[0].([]*entity.UserWorkspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspacesByUsername indicates an expected call of GetWorkspacesByUsername.
+func (mr *MockWorkspaceMockRecorder) GetWorkspacesByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspacesByUsername", reflect.TypeOf((*MockWorkspace)(nil).GetWorkspacesByUsername), ctx, username)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockWorkspace) UpdateMemberRole(ctx context.Context, member entity.WorkspaceMember) (entity.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", ctx, member)
+	ret0, _ := ret[0].(entity.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockWorkspaceMockRecorder) UpdateMemberRole(ctx, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockWorkspace)(nil).UpdateMemberRole), ctx, member)
+}
+
+// MockAPIKey is a mock of APIKey interface.
+type MockAPIKey struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyMockRecorder
+}
+
+// MockAPIKeyMockRecorder is the mock recorder for MockAPIKey.
+type MockAPIKeyMockRecorder struct {
+	mock *MockAPIKey
+}
+
+// NewMockAPIKey creates a new mock instance.
+func NewMockAPIKey(ctrl *gomock.Controller) *MockAPIKey {
+	mock := &MockAPIKey{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKey) EXPECT() *MockAPIKeyMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKey) CreateAPIKey(ctx context.Context, key entity.APIKey) (entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, key)
+	ret0, _ := ret[0].(entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyMockRecorder) CreateAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKey)(nil).CreateAPIKey), ctx, key)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockAPIKey) GetAPIKeys(ctx context.Context) ([]*entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", ctx)
+	ret0, _ := ret[0].([]*entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockAPIKeyMockRecorder) GetAPIKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockAPIKey)(nil).GetAPIKeys), ctx)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKey) RevokeAPIKey(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyMockRecorder) RevokeAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKey)(nil).RevokeAPIKey), ctx, id)
+}
+
+// UseAPIKey mocks base method.
+func (m *MockAPIKey) UseAPIKey(ctx context.Context, keyHash string, now time.Time) (entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseAPIKey", ctx, keyHash, now)
+	ret0, _ := ret[0].(entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseAPIKey indicates an expected call of UseAPIKey.
+func (mr *MockAPIKeyMockRecorder) UseAPIKey(ctx, keyHash, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseAPIKey", reflect.TypeOf((*MockAPIKey)(nil).UseAPIKey), ctx, keyHash, now)
+}
+
+// MockIdempotency is a mock of Idempotency interface.
+type MockIdempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyMockRecorder
+}
+
+// MockIdempotencyMockRecorder is the mock recorder for MockIdempotency.
+type MockIdempotencyMockRecorder struct {
+	mock *MockIdempotency
+}
+
+// NewMockIdempotency creates a new mock instance.
+func NewMockIdempotency(ctrl *gomock.Controller) *MockIdempotency {
+	mock := &MockIdempotency{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotency) EXPECT() *MockIdempotencyMockRecorder {
+	return m.recorder
+}
+
+// CreateIdempotencyKey mocks base method.
+func (m *MockIdempotency) CreateIdempotencyKey(ctx context.Context, key entity.IdempotencyKey) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdempotencyKey", ctx, key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIdempotencyKey indicates an expected call of CreateIdempotencyKey.
+func (mr *MockIdempotencyMockRecorder) CreateIdempotencyKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdempotencyKey", reflect.TypeOf((*MockIdempotency)(nil).CreateIdempotencyKey), ctx, key)
+}
+
+// DeleteExpiredIdempotencyKeys mocks base method.
+func (m *MockIdempotency) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredIdempotencyKeys", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredIdempotencyKeys indicates an expected call of DeleteExpiredIdempotencyKeys.
+func (mr *MockIdempotencyMockRecorder) DeleteExpiredIdempotencyKeys(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredIdempotencyKeys", reflect.TypeOf((*MockIdempotency)(nil).DeleteExpiredIdempotencyKeys), ctx, now)
+}
+
+// DeleteIdempotencyKey mocks base method.
+func (m *MockIdempotency) DeleteIdempotencyKey(ctx context.Context, workspaceID int, principal, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdempotencyKey", ctx, workspaceID, principal, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdempotencyKey indicates an expected call of DeleteIdempotencyKey.
+func (mr *MockIdempotencyMockRecorder) DeleteIdempotencyKey(ctx, workspaceID, principal, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdempotencyKey", reflect.TypeOf((*MockIdempotency)(nil).DeleteIdempotencyKey), ctx, workspaceID, principal, key)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockIdempotency) GetIdempotencyKey(ctx context.Context, workspaceID int, principal, key string) (entity.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, workspaceID, principal, key)
+	ret0, _ := ret[0].(entity.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockIdempotencyMockRecorder) GetIdempotencyKey(ctx, workspaceID, principal, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockIdempotency)(nil).GetIdempotencyKey), ctx, workspaceID, principal, key)
+}
+
+// SaveIdempotencyResponse mocks base method.
+func (m *MockIdempotency) SaveIdempotencyResponse(ctx context.Context, key entity.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIdempotencyResponse", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveIdempotencyResponse indicates an expected call of SaveIdempotencyResponse.
+func (mr *MockIdempotencyMockRecorder) SaveIdempotencyResponse(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdempotencyResponse", reflect.TypeOf((*MockIdempotency)(nil).SaveIdempotencyResponse), ctx, key)
+}
+
+// MockHealth is a mock of Health interface.
+type MockHealth struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthMockRecorder
+}
+
+// MockHealthMockRecorder is the mock recorder for MockHealth.
+type MockHealthMockRecorder struct {
+	mock *MockHealth
+}
+
+// NewMockHealth creates a new mock instance.
+func NewMockHealth(ctrl *gomock.Controller) *MockHealth {
+	mock := &MockHealth{ctrl: ctrl}
+	mock.recorder = &MockHealthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealth) EXPECT() *MockHealthMockRecorder {
+	return m.recorder
+}
+
+// GetSchemaVersion mocks base method.
+func (m *MockHealth) GetSchemaVersion(ctx context.Context) (entity.SchemaVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemaVersion", ctx)
+	ret0, _ := ret[0].(entity.SchemaVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemaVersion indicates an expected call of GetSchemaVersion.
+func (mr *MockHealthMockRecorder) GetSchemaVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaVersion", reflect.TypeOf((*MockHealth)(nil).GetSchemaVersion), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockHealth) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockHealthMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockHealth)(nil).Ping), ctx)
+}
@@ -0,0 +1,127 @@
+package postgresrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	postgres "github.com/romandnk/todo/pkg/storage"
+	"github.com/romandnk/todo/pkg/tenant"
+	"time"
+)
+
+type APIKeyRepo struct {
+	db postgres.PgxPool
+}
+
+func NewAPIKeyRepo(db postgres.PgxPool) *APIKeyRepo {
+	return &APIKeyRepo{db: db}
+}
+
+// CreateAPIKey stores API key in the context workspace
+func (r *APIKeyRepo) CreateAPIKey(ctx context.Context, key entity.APIKey) (entity.APIKey, error) {
+	ctx = postgres.WithMethod(ctx, "APIKeyRepo.CreateAPIKey")
+
+	key.WorkspaceID = tenant.WorkspaceID(ctx)
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(workspace_id, name, prefix, key_hash, scopes, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, constant.APIKeysTable)
+
+	err := r.db.QueryRow(ctx, query,
+		key.WorkspaceID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.Scopes,
+		key.CreatedBy,
+		key.CreatedAt,
+	).Scan(&key.ID)
+	if err != nil {
+		return key, err
+	}
+
+	return key, nil
+}
+
+// GetAPIKeys returns API keys of the context workspace including revoked ones in creation order
+func (r *APIKeyRepo) GetAPIKeys(ctx context.Context) ([]*entity.APIKey, error) {
+	ctx = postgres.WithMethod(ctx, "APIKeyRepo.GetAPIKeys")
+
+	var keys []*entity.APIKey
+
+	query := fmt.Sprintf(`
+		SELECT
+		    id,
+		    workspace_id,
+		    name,
+		    prefix,
+		    scopes,
+		    created_by,
+		    created_at,
+		    last_used_at,
+		    revoked_at
+		FROM %[1]s
+		WHERE workspace_id=$1
+		ORDER BY id
+	`, constant.APIKeysTable)
+
+	err := pgxscan.Select(ctx, r.db, &keys, query, tenant.WorkspaceID(ctx))
+	if err != nil {
+		return keys, err
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey marks not revoked API key of the context workspace as revoked
+func (r *APIKeyRepo) RevokeAPIKey(ctx context.Context, id int) error {
+	ctx = postgres.WithMethod(ctx, "APIKeyRepo.RevokeAPIKey")
+
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET revoked_at=$1
+		WHERE id=$2 AND workspace_id=$3 AND revoked_at IS NULL
+	`, constant.APIKeysTable)
+
+	res, err := r.db.Exec(ctx, query, time.Now().UTC(), id, tenant.WorkspaceID(ctx))
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return constant.ErrAPIKeyIDNotExists
+	}
+
+	return nil
+}
+
+// UseAPIKey finds not revoked API key by its hash in any workspace and sets its last usage time
+func (r *APIKeyRepo) UseAPIKey(ctx context.Context, keyHash string, now time.Time) (entity.APIKey, error) {
+	ctx = postgres.WithMethod(ctx, "APIKeyRepo.UseAPIKey")
+
+	var key entity.APIKey
+
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET last_used_at=$2
+		WHERE key_hash=$1 AND revoked_at IS NULL
+		RETURNING id, workspace_id, name, prefix, scopes, created_by, created_at, last_used_at
+	`, constant.APIKeysTable)
+
+	err := pgxscan.Get(ctx, r.db, &key, query, keyHash, now)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return key, constant.ErrAPIKeyNotExists
+		}
+		return key, err
+	}
+
+	return key, nil
+}
@@ -0,0 +1,160 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyRepo_CreateAPIKey(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	ctx := tenant.WithWorkspace(context.Background(), 2, entity.RoleAdmin)
+	now := time.Now().UTC()
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(workspace_id, name, prefix, key_hash, scopes, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, constant.APIKeysTable)
+
+	inputKey := entity.APIKey{
+		Name:      "ci",
+		Prefix:    "todo_1a2b3c4d",
+		KeyHash:   "hash",
+		Scopes:    []string{"tasks:read"},
+		CreatedBy: "ivan",
+		CreatedAt: now,
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(2, inputKey.Name, inputKey.Prefix, inputKey.KeyHash, inputKey.Scopes, inputKey.CreatedBy, now).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(1))
+
+	storage := NewAPIKeyRepo(mock)
+
+	key, err := storage.CreateAPIKey(ctx, inputKey)
+	require.NoError(t, err)
+	require.Equal(t, 1, key.ID)
+	require.Equal(t, 2, key.WorkspaceID)
+
+	require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+}
+
+func TestAPIKeyRepo_RevokeAPIKey(t *testing.T) {
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET revoked_at=$1
+		WHERE id=$2 AND workspace_id=$3 AND revoked_at IS NULL
+	`, constant.APIKeysTable)
+
+	testCases := []struct {
+		name          string
+		rowsAffected  int64
+		expectedError error
+	}{
+		{
+			name:         "OK",
+			rowsAffected: 1,
+		},
+		{
+			name:          "key not exists or revoked",
+			rowsAffected:  0,
+			expectedError: constant.ErrAPIKeyIDNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(pgxmock.AnyArg(), 1, tenant.DefaultWorkspaceID).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tc.rowsAffected))
+
+			storage := NewAPIKeyRepo(mock)
+
+			err = storage.RevokeAPIKey(context.Background(), 1)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestAPIKeyRepo_UseAPIKey(t *testing.T) {
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET last_used_at=$2
+		WHERE key_hash=$1 AND revoked_at IS NULL
+		RETURNING id, workspace_id, name, prefix, scopes, created_by, created_at, last_used_at
+	`, constant.APIKeysTable)
+
+	testCases := []struct {
+		name          string
+		exists        bool
+		expectedError error
+	}{
+		{
+			name:   "OK",
+			exists: true,
+		},
+		{
+			name:          "unknown or revoked key",
+			exists:        false,
+			expectedError: constant.ErrAPIKeyNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			now := time.Now().UTC()
+
+			expectation := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("hash", now)
+			if tc.exists {
+				expectation.WillReturnRows(pgxmock.NewRows([]string{"id", "workspace_id", "name", "prefix", "scopes", "created_by", "created_at", "last_used_at"}).
+					AddRow(1, 2, "ci", "todo_1a2b3c4d", []string{"tasks:read"}, "ivan", now, sql.NullTime{Time: now, Valid: true}))
+			} else {
+				expectation.WillReturnError(pgx.ErrNoRows)
+			}
+
+			storage := NewAPIKeyRepo(mock)
+
+			key, err := storage.UseAPIKey(context.Background(), "hash", now)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, entity.APIKey{
+					ID:          1,
+					WorkspaceID: 2,
+					Name:        "ci",
+					Prefix:      "todo_1a2b3c4d",
+					Scopes:      []string{"tasks:read"},
+					CreatedBy:   "ivan",
+					CreatedAt:   now,
+					LastUsedAt:  sql.NullTime{Time: now, Valid: true},
+				}, key)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package postgresrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	postgres "github.com/romandnk/todo/pkg/storage"
+	"github.com/romandnk/todo/pkg/tenant"
+	"time"
+)
+
+type AttachmentRepo struct {
+	db postgres.PgxPool
+}
+
+func NewAttachmentRepo(db postgres.PgxPool) *AttachmentRepo {
+	return &AttachmentRepo{db: db}
+}
+
+// CreateAttachment adds attachment metadata to not deleted task
+func (r *AttachmentRepo) CreateAttachment(ctx context.Context, attachment entity.Attachment) (entity.Attachment, error) {
+	ctx = postgres.WithMethod(ctx, "AttachmentRepo.CreateAttachment")
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(task_id, file_name, content_type, size, storage_key, created_at)
+		SELECT $1, $2, $3, $4, $5, $6
+		WHERE EXISTS (
+			SELECT 1
+			FROM %[2]s
+			WHERE id=$1 AND workspace_id=$7 AND deleted=false
+		)
+		RETURNING id, created_at
+	`, constant.TaskAttachmentsTable, constant.TasksTable)
+
+	err := r.db.QueryRow(ctx, query,
+		attachment.TaskID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.StorageKey,
+		time.Now().UTC(),
+		tenant.WorkspaceID(ctx),
+	).Scan(&attachment.ID, &attachment.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return attachment, constant.ErrTaskIDNotExists
+		}
+		return attachment, err
+	}
+
+	return attachment, nil
+}
+
+// GetAttachmentsByTaskID returns task attachments in upload order
+func (r *AttachmentRepo) GetAttachmentsByTaskID(ctx context.Context, taskID int) ([]*entity.Attachment, error) {
+	ctx = postgres.WithMethod(ctx, "AttachmentRepo.GetAttachmentsByTaskID")
+
+	var attachments []*entity.Attachment
+
+	query := fmt.Sprintf(`
+		SELECT
+		    id,
+		    task_id,
+		    file_name,
+		    content_type,
+		    size,
+		    storage_key,
+		    created_at
+		FROM %[1]s
+		WHERE task_id=$1 AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$2)
+		ORDER BY id
+	`, constant.TaskAttachmentsTable, constant.TasksTable)
+
+	err := pgxscan.Select(ctx, r.db, &attachments, query, taskID, tenant.WorkspaceID(ctx))
+	if err != nil {
+		return attachments, err
+	}
+
+	return attachments, nil
+}
+
+// GetAttachmentByID returns attachment of not deleted task
+func (r *AttachmentRepo) GetAttachmentByID(ctx context.Context, taskID, id int) (entity.Attachment, error) {
+	ctx = postgres.WithMethod(ctx, "AttachmentRepo.GetAttachmentByID")
+
+	var attachment entity.Attachment
+
+	query := fmt.Sprintf(`
+		SELECT
+		    a.id,
+		    a.task_id,
+		    a.file_name,
+		    a.content_type,
+		    a.size,
+		    a.storage_key,
+		    a.created_at
+		FROM %[1]s a
+		JOIN %[2]s t ON t.id=a.task_id
+		WHERE a.id=$1 AND a.task_id=$2 AND t.workspace_id=$3 AND t.deleted=false
+	`, constant.TaskAttachmentsTable, constant.TasksTable)
+
+	err := pgxscan.Get(ctx, r.db, &attachment, query, id, taskID, tenant.WorkspaceID(ctx))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return attachment, constant.ErrAttachmentIDNotExists
+		}
+		return attachment, err
+	}
+
+	return attachment, nil
+}
+
+// DeleteAttachment deletes attachment metadata and returns blob store key of its content
+func (r *AttachmentRepo) DeleteAttachment(ctx context.Context, taskID, id int) (string, error) {
+	ctx = postgres.WithMethod(ctx, "AttachmentRepo.DeleteAttachment")
+
+	var storageKey string
+
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE id=$1 AND task_id=$2 AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$3)
+		RETURNING storage_key
+	`, constant.TaskAttachmentsTable, constant.TasksTable)
+
+	err := r.db.QueryRow(ctx, query, id, taskID, tenant.WorkspaceID(ctx)).Scan(&storageKey)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storageKey, constant.ErrAttachmentIDNotExists
+		}
+		return storageKey, err
+	}
+
+	return storageKey, nil
+}
@@ -0,0 +1,221 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAttachmentRepo_CreateAttachment(t *testing.T) {
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(task_id, file_name, content_type, size, storage_key, created_at)
+		SELECT $1, $2, $3, $4, $5, $6
+		WHERE EXISTS (
+			SELECT 1
+			FROM %[2]s
+			WHERE id=$1 AND workspace_id=$7 AND deleted=false
+		)
+		RETURNING id, created_at
+	`, constant.TaskAttachmentsTable, constant.TasksTable)
+
+	testCases := []struct {
+		name          string
+		taskExists    bool
+		expectedError error
+	}{
+		{
+			name:       "OK",
+			taskExists: true,
+		},
+		{
+			name:          "task not exists",
+			taskExists:    false,
+			expectedError: constant.ErrTaskIDNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+
+			now := time.Now().UTC()
+			inputAttachment := entity.Attachment{
+				TaskID:      1,
+				FileName:    "screenshot.png",
+				ContentType: "image/png",
+				Size:        10,
+				StorageKey:  "tasks/1/token",
+			}
+
+			expectation := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(
+				inputAttachment.TaskID,
+				inputAttachment.FileName,
+				inputAttachment.ContentType,
+				inputAttachment.Size,
+				inputAttachment.StorageKey,
+				pgxmock.AnyArg(),
+				tenant.DefaultWorkspaceID,
+			)
+			if tc.taskExists {
+				expectation.WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(2, now))
+			} else {
+				expectation.WillReturnError(pgx.ErrNoRows)
+			}
+
+			storage := NewAttachmentRepo(mock)
+
+			attachment, err := storage.CreateAttachment(ctx, inputAttachment)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, 2, attachment.ID)
+				require.Equal(t, now, attachment.CreatedAt)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestAttachmentRepo_GetAttachmentByID(t *testing.T) {
+	query := fmt.Sprintf(`
+		SELECT
+		    a.id,
+		    a.task_id,
+		    a.file_name,
+		    a.content_type,
+		    a.size,
+		    a.storage_key,
+		    a.created_at
+		FROM %[1]s a
+		JOIN %[2]s t ON t.id=a.task_id
+		WHERE a.id=$1 AND a.task_id=$2 AND t.workspace_id=$3 AND t.deleted=false
+	`, constant.TaskAttachmentsTable, constant.TasksTable)
+
+	now := time.Now().UTC()
+	expectedAttachment := entity.Attachment{
+		ID:          2,
+		TaskID:      1,
+		FileName:    "screenshot.png",
+		ContentType: "image/png",
+		Size:        10,
+		StorageKey:  "tasks/1/token",
+		CreatedAt:   now,
+	}
+
+	testCases := []struct {
+		name          string
+		exists        bool
+		expectedError error
+	}{
+		{
+			name:   "OK",
+			exists: true,
+		},
+		{
+			name:          "attachment not exists",
+			exists:        false,
+			expectedError: constant.ErrAttachmentIDNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+
+			rows := pgxmock.NewRows([]string{"id", "task_id", "file_name", "content_type", "size", "storage_key", "created_at"})
+			if tc.exists {
+				rows.AddRow(
+					expectedAttachment.ID,
+					expectedAttachment.TaskID,
+					expectedAttachment.FileName,
+					expectedAttachment.ContentType,
+					expectedAttachment.Size,
+					expectedAttachment.StorageKey,
+					expectedAttachment.CreatedAt,
+				)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(2, 1, tenant.DefaultWorkspaceID).WillReturnRows(rows)
+
+			storage := NewAttachmentRepo(mock)
+
+			attachment, err := storage.GetAttachmentByID(ctx, 1, 2)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, expectedAttachment, attachment)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestAttachmentRepo_DeleteAttachment(t *testing.T) {
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE id=$1 AND task_id=$2 AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$3)
+		RETURNING storage_key
+	`, constant.TaskAttachmentsTable, constant.TasksTable)
+
+	testCases := []struct {
+		name          string
+		exists        bool
+		expectedKey   string
+		expectedError error
+	}{
+		{
+			name:        "OK",
+			exists:      true,
+			expectedKey: "tasks/1/token",
+		},
+		{
+			name:          "attachment not exists",
+			exists:        false,
+			expectedError: constant.ErrAttachmentIDNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+
+			expectation := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(2, 1, tenant.DefaultWorkspaceID)
+			if tc.exists {
+				expectation.WillReturnRows(pgxmock.NewRows([]string{"storage_key"}).AddRow(tc.expectedKey))
+			} else {
+				expectation.WillReturnError(pgx.ErrNoRows)
+			}
+
+			storage := NewAttachmentRepo(mock)
+
+			key, err := storage.DeleteAttachment(ctx, 1, 2)
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expectedKey, key)
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
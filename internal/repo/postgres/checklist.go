@@ -0,0 +1,176 @@
+package postgresrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	postgres "github.com/romandnk/todo/pkg/storage"
+	"github.com/romandnk/todo/pkg/tenant"
+	"time"
+)
+
+type ChecklistRepo struct {
+	db postgres.PgxPool
+}
+
+func NewChecklistRepo(db postgres.PgxPool) *ChecklistRepo {
+	return &ChecklistRepo{db: db}
+}
+
+// AddChecklistItem adds not done item to the end of not deleted task checklist
+func (r *ChecklistRepo) AddChecklistItem(ctx context.Context, item entity.ChecklistItem) (entity.ChecklistItem, error) {
+	ctx = postgres.WithMethod(ctx, "ChecklistRepo.AddChecklistItem")
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(task_id, text, done, position, created_at)
+		SELECT $1, $2, false, COALESCE(MAX(position), 0)+1, $3
+		FROM %[1]s
+		WHERE task_id=$1
+		HAVING EXISTS (
+			SELECT 1
+			FROM %[2]s
+			WHERE id=$1 AND workspace_id=$4 AND deleted=false
+		)
+		RETURNING id, done, position, created_at
+	`, constant.TaskChecklistTable, constant.TasksTable)
+
+	err := r.db.QueryRow(ctx, query,
+		item.TaskID,
+		item.Text,
+		time.Now().UTC(),
+		tenant.WorkspaceID(ctx),
+	).Scan(&item.ID, &item.Done, &item.Position, &item.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return item, constant.ErrTaskIDNotExists
+		}
+		return item, err
+	}
+
+	return item, nil
+}
+
+// GetChecklistItemsByTaskID returns task checklist items in their order
+func (r *ChecklistRepo) GetChecklistItemsByTaskID(ctx context.Context, taskID int) ([]*entity.ChecklistItem, error) {
+	ctx = postgres.WithMethod(ctx, "ChecklistRepo.GetChecklistItemsByTaskID")
+
+	var items []*entity.ChecklistItem
+
+	query := fmt.Sprintf(`
+		SELECT
+		    id,
+		    task_id,
+		    text,
+		    done,
+		    position,
+		    created_at
+		FROM %[1]s
+		WHERE task_id=$1 AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$2)
+		ORDER BY position, id
+	`, constant.TaskChecklistTable, constant.TasksTable)
+
+	err := pgxscan.Select(ctx, r.db, &items, query, taskID, tenant.WorkspaceID(ctx))
+	if err != nil {
+		return items, err
+	}
+
+	return items, nil
+}
+
+// ToggleChecklistItem flips done flag of the item of not deleted task
+func (r *ChecklistRepo) ToggleChecklistItem(ctx context.Context, taskID, id int) (entity.ChecklistItem, error) {
+	ctx = postgres.WithMethod(ctx, "ChecklistRepo.ToggleChecklistItem")
+
+	var item entity.ChecklistItem
+
+	query := fmt.Sprintf(`
+		UPDATE %[1]s c
+		SET done=NOT c.done
+		FROM %[2]s t
+		WHERE c.id=$1 AND c.task_id=$2 AND t.id=c.task_id AND t.workspace_id=$3 AND t.deleted=false
+		RETURNING c.id, c.task_id, c.text, c.done, c.position, c.created_at
+	`, constant.TaskChecklistTable, constant.TasksTable)
+
+	err := pgxscan.Get(ctx, r.db, &item, query, id, taskID, tenant.WorkspaceID(ctx))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return item, constant.ErrChecklistItemIDNotExists
+		}
+		return item, err
+	}
+
+	return item, nil
+}
+
+// ReorderChecklistItems sets positions of task items in order of ids,
+// ids must contain every item of the task once
+func (r *ChecklistRepo) ReorderChecklistItems(ctx context.Context, taskID int, ids []int) error {
+	ctx = postgres.WithMethod(ctx, "ChecklistRepo.ReorderChecklistItems")
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var current []int
+	err = pgxscan.Select(ctx, tx, &current, fmt.Sprintf(`
+		SELECT id
+		FROM %[1]s
+		WHERE task_id=$1 AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$2)
+		FOR UPDATE
+	`, constant.TaskChecklistTable, constant.TasksTable), taskID, tenant.WorkspaceID(ctx))
+	if err != nil {
+		return err
+	}
+
+	if len(current) != len(ids) {
+		return constant.ErrChecklistOrderMismatch
+	}
+	ordered := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		ordered[id] = struct{}{}
+	}
+	for _, id := range current {
+		if _, ok := ordered[id]; !ok {
+			return constant.ErrChecklistOrderMismatch
+		}
+	}
+
+	_, err = tx.Exec(ctx, fmt.Sprintf(`
+		UPDATE %[1]s c
+		SET position=o.position
+		FROM unnest($2::bigint[]) WITH ORDINALITY AS o(id, position)
+		WHERE c.id=o.id AND c.task_id=$1
+	`, constant.TaskChecklistTable), taskID, ids)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *ChecklistRepo) DeleteChecklistItem(ctx context.Context, taskID, id int) error {
+	ctx = postgres.WithMethod(ctx, "ChecklistRepo.DeleteChecklistItem")
+
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE id=$1 AND task_id=$2 AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$3)
+	`, constant.TaskChecklistTable, constant.TasksTable)
+
+	res, err := r.db.Exec(ctx, query, id, taskID, tenant.WorkspaceID(ctx))
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return constant.ErrChecklistItemIDNotExists
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestChecklistRepo_AddChecklistItem(t *testing.T) {
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(task_id, text, done, position, created_at)
+		SELECT $1, $2, false, COALESCE(MAX(position), 0)+1, $3
+		FROM %[1]s
+		WHERE task_id=$1
+		HAVING EXISTS (
+			SELECT 1
+			FROM %[2]s
+			WHERE id=$1 AND workspace_id=$4 AND deleted=false
+		)
+		RETURNING id, done, position, created_at
+	`, constant.TaskChecklistTable, constant.TasksTable)
+
+	testCases := []struct {
+		name          string
+		taskExists    bool
+		expectedError error
+	}{
+		{
+			name:       "OK",
+			taskExists: true,
+		},
+		{
+			name:          "task not exists",
+			taskExists:    false,
+			expectedError: constant.ErrTaskIDNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+
+			now := time.Now().UTC()
+			inputItem := entity.ChecklistItem{
+				TaskID: 1,
+				Text:   "buy milk",
+			}
+
+			expectation := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(
+				inputItem.TaskID,
+				inputItem.Text,
+				pgxmock.AnyArg(),
+				tenant.DefaultWorkspaceID,
+			)
+			if tc.taskExists {
+				expectation.WillReturnRows(pgxmock.NewRows([]string{"id", "done", "position", "created_at"}).AddRow(2, false, 3, now))
+			} else {
+				expectation.WillReturnError(pgx.ErrNoRows)
+			}
+
+			storage := NewChecklistRepo(mock)
+
+			item, err := storage.AddChecklistItem(ctx, inputItem)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, 2, item.ID)
+				require.Equal(t, 3, item.Position)
+				require.Equal(t, now, item.CreatedAt)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestChecklistRepo_ReorderChecklistItems(t *testing.T) {
+	selectQuery := fmt.Sprintf(`
+		SELECT id
+		FROM %[1]s
+		WHERE task_id=$1 AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$2)
+		FOR UPDATE
+	`, constant.TaskChecklistTable, constant.TasksTable)
+	updateQuery := fmt.Sprintf(`
+		UPDATE %[1]s c
+		SET position=o.position
+		FROM unnest($2::bigint[]) WITH ORDINALITY AS o(id, position)
+		WHERE c.id=o.id AND c.task_id=$1
+	`, constant.TaskChecklistTable)
+
+	testCases := []struct {
+		name          string
+		ids           []int
+		expectedError error
+	}{
+		{
+			name: "OK",
+			ids:  []int{3, 1, 2},
+		},
+		{
+			name:          "missing item",
+			ids:           []int{3, 1},
+			expectedError: constant.ErrChecklistOrderMismatch,
+		},
+		{
+			name:          "item of another task",
+			ids:           []int{3, 1, 4},
+			expectedError: constant.ErrChecklistOrderMismatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WithArgs(1, tenant.DefaultWorkspaceID).
+				WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3))
+			if tc.expectedError == nil {
+				mock.ExpectExec(regexp.QuoteMeta(updateQuery)).WithArgs(1, tc.ids).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+				mock.ExpectCommit()
+			}
+			mock.ExpectRollback()
+
+			storage := NewChecklistRepo(mock)
+
+			err = storage.ReorderChecklistItems(ctx, 1, tc.ids)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestChecklistRepo_DeleteChecklistItem(t *testing.T) {
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE id=$1 AND task_id=$2 AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$3)
+	`, constant.TaskChecklistTable, constant.TasksTable)
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(2, 1, tenant.DefaultWorkspaceID).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	storage := NewChecklistRepo(mock)
+
+	err = storage.DeleteChecklistItem(context.Background(), 1, 2)
+	require.ErrorIs(t, err, constant.ErrChecklistItemIDNotExists)
+
+	require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+}
@@ -0,0 +1,167 @@
+package postgresrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	postgres "github.com/romandnk/todo/pkg/storage"
+	"github.com/romandnk/todo/pkg/tenant"
+	"time"
+)
+
+type CommentRepo struct {
+	db postgres.PgxPool
+}
+
+func NewCommentRepo(db postgres.PgxPool) *CommentRepo {
+	return &CommentRepo{db: db}
+}
+
+// CreateComment adds comment to not deleted task
+func (r *CommentRepo) CreateComment(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	ctx = postgres.WithMethod(ctx, "CommentRepo.CreateComment")
+
+	now := time.Now().UTC()
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(task_id, author, body, deleted, created_at, updated_at)
+		SELECT $1, $2, $3, false, $4, $4
+		WHERE EXISTS (
+			SELECT 1
+			FROM %[2]s
+			WHERE id=$1 AND workspace_id=$5 AND deleted=false
+		)
+		RETURNING id, created_at, updated_at
+	`, constant.TaskCommentsTable, constant.TasksTable)
+
+	err := r.db.QueryRow(ctx, query, comment.TaskID, comment.Author, comment.Body, now, tenant.WorkspaceID(ctx)).
+		Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return comment, constant.ErrTaskIDNotExists
+		}
+		return comment, err
+	}
+
+	return comment, nil
+}
+
+// GetCommentsByTaskID returns not deleted task comments in creation order after lastID, zero limit means all comments
+func (r *CommentRepo) GetCommentsByTaskID(ctx context.Context, taskID, limit, lastID int) ([]*entity.Comment, error) {
+	ctx = postgres.WithMethod(ctx, "CommentRepo.GetCommentsByTaskID")
+
+	var comments []*entity.Comment
+
+	values := []any{taskID, lastID, tenant.WorkspaceID(ctx)}
+	query := fmt.Sprintf(`
+		SELECT
+		    id,
+		    task_id,
+		    author,
+		    body,
+		    created_at,
+		    updated_at
+		FROM %[1]s
+		WHERE task_id=$1 AND deleted=false AND id>$2
+			AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$3)
+		ORDER BY id
+	`, constant.TaskCommentsTable, constant.TasksTable)
+
+	if limit > 0 {
+		query += " LIMIT $4"
+		values = append(values, limit)
+	}
+
+	err := pgxscan.Select(ctx, r.db, &comments, query, values...)
+	if err != nil {
+		return comments, err
+	}
+
+	return comments, nil
+}
+
+// GetCommentByID returns not deleted task comment
+func (r *CommentRepo) GetCommentByID(ctx context.Context, taskID, id int) (entity.Comment, error) {
+	ctx = postgres.WithMethod(ctx, "CommentRepo.GetCommentByID")
+
+	var comment entity.Comment
+
+	query := fmt.Sprintf(`
+		SELECT
+		    id,
+		    task_id,
+		    author,
+		    body,
+		    created_at,
+		    updated_at
+		FROM %[1]s
+		WHERE id=$1 AND task_id=$2 AND deleted=false
+			AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$3)
+	`, constant.TaskCommentsTable, constant.TasksTable)
+
+	err := pgxscan.Get(ctx, r.db, &comment, query, id, taskID, tenant.WorkspaceID(ctx))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return comment, constant.ErrCommentIDNotExists
+		}
+		return comment, err
+	}
+
+	return comment, nil
+}
+
+// UpdateComment changes body of not deleted task comment
+func (r *CommentRepo) UpdateComment(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	ctx = postgres.WithMethod(ctx, "CommentRepo.UpdateComment")
+
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET
+		    body=$1,
+		    updated_at=$2
+		WHERE id=$3 AND task_id=$4 AND deleted=false
+			AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$5)
+		RETURNING author, created_at, updated_at
+	`, constant.TaskCommentsTable, constant.TasksTable)
+
+	err := r.db.QueryRow(ctx, query, comment.Body, time.Now().UTC(), comment.ID, comment.TaskID, tenant.WorkspaceID(ctx)).
+		Scan(&comment.Author, &comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return comment, constant.ErrCommentIDNotExists
+		}
+		return comment, err
+	}
+
+	return comment, nil
+}
+
+// DeleteComment marks task comment as deleted
+func (r *CommentRepo) DeleteComment(ctx context.Context, taskID, id int) error {
+	ctx = postgres.WithMethod(ctx, "CommentRepo.DeleteComment")
+
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET
+		    deleted=true,
+		    deleted_at=$1
+		WHERE id=$2 AND task_id=$3 AND deleted=false
+			AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$4)
+	`, constant.TaskCommentsTable, constant.TasksTable)
+
+	res, err := r.db.Exec(ctx, query, time.Now().UTC(), id, taskID, tenant.WorkspaceID(ctx))
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return constant.ErrCommentIDNotExists
+	}
+
+	return nil
+}
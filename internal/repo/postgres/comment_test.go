@@ -0,0 +1,338 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestCommentRepo_CreateComment(t *testing.T) {
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(task_id, author, body, deleted, created_at, updated_at)
+		SELECT $1, $2, $3, false, $4, $4
+		WHERE EXISTS (
+			SELECT 1
+			FROM %[2]s
+			WHERE id=$1 AND workspace_id=$5 AND deleted=false
+		)
+		RETURNING id, created_at, updated_at
+	`, constant.TaskCommentsTable, constant.TasksTable)
+
+	testCases := []struct {
+		name          string
+		taskExists    bool
+		expectedError error
+	}{
+		{
+			name:       "OK",
+			taskExists: true,
+		},
+		{
+			name:          "task not exists",
+			taskExists:    false,
+			expectedError: constant.ErrTaskIDNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+
+			now := time.Now().UTC()
+			inputComment := entity.Comment{
+				TaskID: 1,
+				Author: "ivan",
+				Body:   "comment",
+			}
+
+			expectation := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(
+				inputComment.TaskID,
+				inputComment.Author,
+				inputComment.Body,
+				pgxmock.AnyArg(),
+				tenant.DefaultWorkspaceID,
+			)
+			if tc.taskExists {
+				expectation.WillReturnRows(pgxmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(2, now, now))
+			} else {
+				expectation.WillReturnError(pgx.ErrNoRows)
+			}
+
+			storage := NewCommentRepo(mock)
+
+			comment, err := storage.CreateComment(ctx, inputComment)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, 2, comment.ID)
+				require.Equal(t, now, comment.CreatedAt)
+				require.Equal(t, now, comment.UpdatedAt)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestCommentRepo_GetCommentsByTaskID(t *testing.T) {
+	now := time.Now().UTC()
+
+	testCases := []struct {
+		name     string
+		limit    int
+		lastID   int
+		args     []any
+		queryEnd string
+	}{
+		{
+			name:     "OK with limit",
+			limit:    5,
+			lastID:   1,
+			args:     []any{1, 1, 3, 5},
+			queryEnd: " LIMIT $4",
+		},
+		{
+			name:   "OK without limit",
+			limit:  0,
+			lastID: 0,
+			args:   []any{1, 0, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := tenant.WithWorkspace(context.Background(), 3, entity.RoleMember)
+
+			query := fmt.Sprintf(`
+				SELECT
+				    id,
+				    task_id,
+				    author,
+				    body,
+				    created_at,
+				    updated_at
+				FROM %[1]s
+				WHERE task_id=$1 AND deleted=false AND id>$2
+					AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$3)
+				ORDER BY id
+			`, constant.TaskCommentsTable, constant.TasksTable) + tc.queryEnd
+
+			expectedComments := []*entity.Comment{
+				{ID: 2, TaskID: 1, Author: "ivan", Body: "first", CreatedAt: now, UpdatedAt: now},
+				{ID: 3, TaskID: 1, Author: "petr", Body: "second", CreatedAt: now, UpdatedAt: now},
+			}
+
+			rows := pgxmock.NewRows([]string{"id", "task_id", "author", "body", "created_at", "updated_at"})
+			for _, comment := range expectedComments {
+				rows.AddRow(comment.ID, comment.TaskID, comment.Author, comment.Body, comment.CreatedAt, comment.UpdatedAt)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(tc.args...).WillReturnRows(rows)
+
+			storage := NewCommentRepo(mock)
+
+			comments, err := storage.GetCommentsByTaskID(ctx, 1, tc.limit, tc.lastID)
+			require.NoError(t, err)
+			require.Equal(t, expectedComments, comments)
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestCommentRepo_GetCommentByID(t *testing.T) {
+	now := time.Now().UTC()
+
+	query := fmt.Sprintf(`
+		SELECT
+		    id,
+		    task_id,
+		    author,
+		    body,
+		    created_at,
+		    updated_at
+		FROM %[1]s
+		WHERE id=$1 AND task_id=$2 AND deleted=false
+			AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$3)
+	`, constant.TaskCommentsTable, constant.TasksTable)
+
+	testCases := []struct {
+		name          string
+		found         bool
+		expectedError error
+	}{
+		{
+			name:  "OK",
+			found: true,
+		},
+		{
+			name:          "comment not found",
+			expectedError: constant.ErrCommentIDNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := tenant.WithWorkspace(context.Background(), 3, entity.RoleMember)
+
+			expectation := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(2, 1, 3)
+			if tc.found {
+				expectation.WillReturnRows(pgxmock.NewRows([]string{"id", "task_id", "author", "body", "created_at", "updated_at"}).
+					AddRow(2, 1, "ivan", "first", now, now))
+			} else {
+				expectation.WillReturnError(pgx.ErrNoRows)
+			}
+
+			storage := NewCommentRepo(mock)
+
+			comment, err := storage.GetCommentByID(ctx, 1, 2)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, entity.Comment{ID: 2, TaskID: 1, Author: "ivan", Body: "first", CreatedAt: now, UpdatedAt: now}, comment)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestCommentRepo_UpdateComment(t *testing.T) {
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET
+		    body=$1,
+		    updated_at=$2
+		WHERE id=$3 AND task_id=$4 AND deleted=false
+			AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$5)
+		RETURNING author, created_at, updated_at
+	`, constant.TaskCommentsTable, constant.TasksTable)
+
+	testCases := []struct {
+		name          string
+		exists        bool
+		expectedError error
+	}{
+		{
+			name:   "OK",
+			exists: true,
+		},
+		{
+			name:          "comment not exists",
+			exists:        false,
+			expectedError: constant.ErrCommentIDNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+
+			now := time.Now().UTC()
+			inputComment := entity.Comment{
+				ID:     2,
+				TaskID: 1,
+				Body:   "edited",
+			}
+
+			expectation := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(
+				inputComment.Body,
+				pgxmock.AnyArg(),
+				inputComment.ID,
+				inputComment.TaskID,
+				tenant.DefaultWorkspaceID,
+			)
+			if tc.exists {
+				expectation.WillReturnRows(pgxmock.NewRows([]string{"author", "created_at", "updated_at"}).AddRow("ivan", now, now))
+			} else {
+				expectation.WillReturnError(pgx.ErrNoRows)
+			}
+
+			storage := NewCommentRepo(mock)
+
+			comment, err := storage.UpdateComment(ctx, inputComment)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, "ivan", comment.Author)
+				require.Equal(t, "edited", comment.Body)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestCommentRepo_DeleteComment(t *testing.T) {
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET
+		    deleted=true,
+		    deleted_at=$1
+		WHERE id=$2 AND task_id=$3 AND deleted=false
+			AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$4)
+	`, constant.TaskCommentsTable, constant.TasksTable)
+
+	testCases := []struct {
+		name          string
+		rowsAffected  int64
+		expectedError error
+	}{
+		{
+			name:         "OK",
+			rowsAffected: 1,
+		},
+		{
+			name:          "comment not exists",
+			rowsAffected:  0,
+			expectedError: constant.ErrCommentIDNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(pgxmock.AnyArg(), 2, 1, tenant.DefaultWorkspaceID).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tc.rowsAffected))
+
+			storage := NewCommentRepo(mock)
+
+			err = storage.DeleteComment(ctx, 1, 2)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
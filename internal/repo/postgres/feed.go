@@ -0,0 +1,87 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	postgres "github.com/romandnk/todo/pkg/storage"
+	"github.com/romandnk/todo/pkg/tenant"
+	"time"
+)
+
+type FeedRepo struct {
+	db postgres.PgxPool
+}
+
+func NewFeedRepo(db postgres.PgxPool) *FeedRepo {
+	return &FeedRepo{db: db}
+}
+
+func (r *FeedRepo) CreateFeed(ctx context.Context, feed entity.Feed) (int, error) {
+	ctx = postgres.WithMethod(ctx, "FeedRepo.CreateFeed")
+
+	var id int
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(token_hash, status_id, component, created_at, workspace_id)
+		VALUES ($1, NULLIF($2, 0), $3, $4, $5)
+		RETURNING id
+	`, constant.TaskFeedsTable)
+
+	err := pgxscan.Get(ctx, r.db, &id, query, feed.TokenHash, feed.StatusID, feed.Component, time.Now().UTC(), tenant.WorkspaceID(ctx))
+	if err != nil {
+		return id, err
+	}
+
+	return id, nil
+}
+
+// GetFeedByTokenHash is not scoped to the context workspace: the token alone
+// identifies the feed, and the caller switches to the feed's workspace.
+func (r *FeedRepo) GetFeedByTokenHash(ctx context.Context, tokenHash string) (entity.Feed, error) {
+	ctx = postgres.WithMethod(ctx, "FeedRepo.GetFeedByTokenHash")
+
+	var feed entity.Feed
+
+	query := fmt.Sprintf(`
+		SELECT 
+		    id, 
+		    workspace_id,
+		    token_hash, 
+		    COALESCE(status_id, 0) AS status_id, 
+		    component, 
+		    created_at
+		FROM %[1]s
+		WHERE token_hash=$1
+	`, constant.TaskFeedsTable)
+
+	err := pgxscan.Get(ctx, r.db, &feed, query, tokenHash)
+	if err != nil {
+		return feed, err
+	}
+
+	return feed, nil
+}
+
+func (r *FeedRepo) DeleteFeedByTokenHash(ctx context.Context, tokenHash string) error {
+	ctx = postgres.WithMethod(ctx, "FeedRepo.DeleteFeedByTokenHash")
+
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE token_hash=$1 AND workspace_id=$2
+	`, constant.TaskFeedsTable)
+
+	res, err := r.db.Exec(ctx, query, tokenHash, tenant.WorkspaceID(ctx))
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return constant.ErrFeedNotExists
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+)
+
+func TestFeedRepo_CreateFeed(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	ctx := context.Background()
+
+	inputFeed := entity.Feed{
+		TokenHash: "hash",
+		StatusID:  0,
+		Component: "VTODO",
+	}
+	expectedID := 1
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(token_hash, status_id, component, created_at, workspace_id)
+		VALUES ($1, NULLIF($2, 0), $3, $4, $5)
+		RETURNING id
+	`, constant.TaskFeedsTable)
+
+	rows := pgxmock.NewRows([]string{"id"}).AddRow(expectedID)
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(
+		inputFeed.TokenHash,
+		inputFeed.StatusID,
+		inputFeed.Component,
+		pgxmock.AnyArg(),
+		tenant.DefaultWorkspaceID,
+	).WillReturnRows(rows)
+
+	storage := NewFeedRepo(mock)
+
+	id, err := storage.CreateFeed(ctx, inputFeed)
+	require.NoError(t, err)
+	require.Equal(t, expectedID, id)
+
+	require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+}
+
+func TestFeedRepo_DeleteFeedByTokenHash(t *testing.T) {
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE token_hash=$1 AND workspace_id=$2
+	`, constant.TaskFeedsTable)
+
+	testCases := []struct {
+		name          string
+		rowsAffected  int64
+		expectedError error
+	}{
+		{
+			name:         "OK",
+			rowsAffected: 1,
+		},
+		{
+			name:          "feed not exists",
+			rowsAffected:  0,
+			expectedError: constant.ErrFeedNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs("hash", tenant.DefaultWorkspaceID).
+				WillReturnResult(pgxmock.NewResult("DELETE", tc.rowsAffected))
+
+			storage := NewFeedRepo(mock)
+
+			err = storage.DeleteFeedByTokenHash(ctx, "hash")
+			require.ErrorIs(t, err, tc.expectedError)
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
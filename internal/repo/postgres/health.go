@@ -0,0 +1,44 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	postgres "github.com/romandnk/todo/pkg/storage"
+)
+
+type HealthRepo struct {
+	db postgres.PgxPool
+}
+
+func NewHealthRepo(db postgres.PgxPool) *HealthRepo {
+	return &HealthRepo{db: db}
+}
+
+func (r *HealthRepo) Ping(ctx context.Context) error {
+	ctx = postgres.WithMethod(ctx, "HealthRepo.Ping")
+
+	return r.db.Ping(ctx)
+}
+
+// GetSchemaVersion returns the version of applied migrations, no rows means no migrations were applied
+func (r *HealthRepo) GetSchemaVersion(ctx context.Context) (entity.SchemaVersion, error) {
+	ctx = postgres.WithMethod(ctx, "HealthRepo.GetSchemaVersion")
+
+	var version entity.SchemaVersion
+
+	query := fmt.Sprintf(`
+		SELECT version, dirty
+		FROM %s
+		LIMIT 1
+	`, constant.SchemaMigrationsTable)
+
+	err := pgxscan.Get(ctx, r.db, &version, query)
+	if err != nil {
+		return version, err
+	}
+
+	return version, nil
+}
@@ -0,0 +1,35 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+)
+
+func TestHealthRepo_GetSchemaVersion(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	query := fmt.Sprintf(`
+		SELECT version, dirty
+		FROM %s
+		LIMIT 1
+	`, constant.SchemaMigrationsTable)
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WillReturnRows(pgxmock.NewRows([]string{"version", "dirty"}).AddRow(13, false))
+
+	storage := NewHealthRepo(mock)
+
+	version, err := storage.GetSchemaVersion(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, entity.SchemaVersion{Version: 13, Dirty: false}, version)
+
+	require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+}
@@ -0,0 +1,132 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	postgres "github.com/romandnk/todo/pkg/storage"
+	"time"
+)
+
+type IdempotencyRepo struct {
+	db postgres.PgxPool
+}
+
+func NewIdempotencyRepo(db postgres.PgxPool) *IdempotencyRepo {
+	return &IdempotencyRepo{db: db}
+}
+
+// CreateIdempotencyKey reserves the key for the request. Expired key is replaced.
+// It returns false if the key is already reserved.
+func (r *IdempotencyRepo) CreateIdempotencyKey(ctx context.Context, key entity.IdempotencyKey) (bool, error) {
+	ctx = postgres.WithMethod(ctx, "IdempotencyRepo.CreateIdempotencyKey")
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(workspace_id, principal, key, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (workspace_id, principal, key) DO UPDATE
+		SET 
+		    request_hash=EXCLUDED.request_hash,
+		    status_code=0,
+		    headers='{}',
+		    body=NULL,
+		    created_at=EXCLUDED.created_at,
+		    expires_at=EXCLUDED.expires_at
+		WHERE %[1]s.expires_at<=EXCLUDED.created_at
+	`, constant.IdempotencyKeysTable)
+
+	res, err := r.db.Exec(ctx, query, key.WorkspaceID, key.Principal, key.Key, key.RequestHash, key.CreatedAt, key.ExpiresAt)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() == 1, nil
+}
+
+func (r *IdempotencyRepo) GetIdempotencyKey(ctx context.Context, workspaceID int, principal, key string) (entity.IdempotencyKey, error) {
+	ctx = postgres.WithMethod(ctx, "IdempotencyRepo.GetIdempotencyKey")
+
+	var idempotencyKey entity.IdempotencyKey
+
+	query := fmt.Sprintf(`
+		SELECT 
+		    workspace_id, 
+		    principal, 
+		    key, 
+		    request_hash, 
+		    status_code, 
+		    headers, 
+		    body, 
+		    created_at, 
+		    expires_at
+		FROM %[1]s
+		WHERE workspace_id=$1 AND principal=$2 AND key=$3
+	`, constant.IdempotencyKeysTable)
+
+	err := pgxscan.Get(ctx, r.db, &idempotencyKey, query, workspaceID, principal, key)
+	if err != nil {
+		return idempotencyKey, err
+	}
+
+	return idempotencyKey, nil
+}
+
+func (r *IdempotencyRepo) SaveIdempotencyResponse(ctx context.Context, key entity.IdempotencyKey) error {
+	ctx = postgres.WithMethod(ctx, "IdempotencyRepo.SaveIdempotencyResponse")
+
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET 
+		    status_code=$1,
+		    headers=$2,
+		    body=$3
+		WHERE workspace_id=$4 AND principal=$5 AND key=$6
+	`, constant.IdempotencyKeysTable)
+
+	res, err := r.db.Exec(ctx, query, key.StatusCode, key.Headers, key.Body, key.WorkspaceID, key.Principal, key.Key)
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return constant.ErrIdempotencyKeyNotExists
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepo) DeleteIdempotencyKey(ctx context.Context, workspaceID int, principal, key string) error {
+	ctx = postgres.WithMethod(ctx, "IdempotencyRepo.DeleteIdempotencyKey")
+
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE workspace_id=$1 AND principal=$2 AND key=$3
+	`, constant.IdempotencyKeysTable)
+
+	_, err := r.db.Exec(ctx, query, workspaceID, principal, key)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys deletes keys expired before now and returns their number
+func (r *IdempotencyRepo) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	ctx = postgres.WithMethod(ctx, "IdempotencyRepo.DeleteExpiredIdempotencyKeys")
+
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE expires_at<=$1
+	`, constant.IdempotencyKeysTable)
+
+	res, err := r.db.Exec(ctx, query, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
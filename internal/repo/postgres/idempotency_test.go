@@ -0,0 +1,131 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyRepo_CreateIdempotencyKey(t *testing.T) {
+	now := time.Now().UTC()
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(workspace_id, principal, key, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (workspace_id, principal, key) DO UPDATE
+	`, constant.IdempotencyKeysTable)
+
+	testCases := []struct {
+		name            string
+		rowsAffected    int64
+		expectedCreated bool
+	}{
+		{
+			name:            "key is reserved",
+			rowsAffected:    1,
+			expectedCreated: true,
+		},
+		{
+			name:            "key already exists",
+			rowsAffected:    0,
+			expectedCreated: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+
+			key := entity.IdempotencyKey{
+				WorkspaceID: 2,
+				Principal:   "user:ivan",
+				Key:         "key",
+				RequestHash: "hash",
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(time.Hour),
+			}
+
+			mock.ExpectExec(regexp.QuoteMeta(query)).
+				WithArgs(key.WorkspaceID, key.Principal, key.Key, key.RequestHash, key.CreatedAt, key.ExpiresAt).
+				WillReturnResult(pgxmock.NewResult("insert", tc.rowsAffected))
+
+			storage := NewIdempotencyRepo(mock)
+
+			created, err := storage.CreateIdempotencyKey(ctx, key)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCreated, created)
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestIdempotencyRepo_SaveIdempotencyResponse(t *testing.T) {
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET 
+		    status_code=$1,
+		    headers=$2,
+		    body=$3
+		WHERE workspace_id=$4 AND principal=$5 AND key=$6
+	`, constant.IdempotencyKeysTable)
+
+	testCases := []struct {
+		name          string
+		rowsAffected  int64
+		expectedError error
+	}{
+		{
+			name:         "OK",
+			rowsAffected: 1,
+		},
+		{
+			name:          "key isn't found",
+			rowsAffected:  0,
+			expectedError: constant.ErrIdempotencyKeyNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+
+			key := entity.IdempotencyKey{
+				WorkspaceID: 2,
+				Principal:   "user:ivan",
+				Key:         "key",
+				StatusCode:  201,
+				Headers:     map[string][]string{"Content-Type": {"application/json"}},
+				Body:        []byte(`{"id":1}`),
+			}
+
+			mock.ExpectExec(regexp.QuoteMeta(query)).
+				WithArgs(key.StatusCode, key.Headers, key.Body, key.WorkspaceID, key.Principal, key.Key).
+				WillReturnResult(pgxmock.NewResult("update", tc.rowsAffected))
+
+			storage := NewIdempotencyRepo(mock)
+
+			err = storage.SaveIdempotencyResponse(ctx, key)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package postgresrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	postgres "github.com/romandnk/todo/pkg/storage"
+	"github.com/romandnk/todo/pkg/tenant"
+	"time"
+)
+
+type MemberRepo struct {
+	db postgres.PgxPool
+}
+
+func NewMemberRepo(db postgres.PgxPool) *MemberRepo {
+	return &MemberRepo{db: db}
+}
+
+// AddTaskMember relates user to not deleted task, added is false if the user already has the role
+func (r *MemberRepo) AddTaskMember(ctx context.Context, member entity.TaskMember) (entity.TaskMember, bool, error) {
+	ctx = postgres.WithMethod(ctx, "MemberRepo.AddTaskMember")
+
+	query := fmt.Sprintf(`
+		WITH inserted AS (
+			INSERT INTO %[1]s
+			(task_id, username, role, created_at)
+			SELECT $1, $2, $3, $4
+			WHERE EXISTS (
+				SELECT 1
+				FROM %[2]s
+				WHERE id=$1 AND workspace_id=$5 AND deleted=false
+			)
+			ON CONFLICT (task_id, role, username) DO NOTHING
+			RETURNING created_at, true AS added
+		)
+		SELECT created_at, added FROM inserted
+		UNION ALL
+		SELECT created_at, false AS added
+		FROM %[1]s
+		WHERE task_id=$1 AND username=$2 AND role=$3
+			AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$5)
+	`, constant.TaskMembersTable, constant.TasksTable)
+
+	var added bool
+	err := r.db.QueryRow(ctx, query,
+		member.TaskID,
+		member.Username,
+		member.Role,
+		time.Now().UTC(),
+		tenant.WorkspaceID(ctx),
+	).Scan(&member.CreatedAt, &added)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return member, false, constant.ErrTaskIDNotExists
+		}
+		return member, false, err
+	}
+
+	return member, added, nil
+}
+
+// GetTaskMembers returns task members of every role in order of their addition
+func (r *MemberRepo) GetTaskMembers(ctx context.Context, taskID int) ([]*entity.TaskMember, error) {
+	ctx = postgres.WithMethod(ctx, "MemberRepo.GetTaskMembers")
+
+	var members []*entity.TaskMember
+
+	query := fmt.Sprintf(`
+		SELECT
+		    task_id,
+		    username,
+		    role,
+		    created_at
+		FROM %[1]s
+		WHERE task_id=$1 AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$2)
+		ORDER BY created_at, username
+	`, constant.TaskMembersTable, constant.TasksTable)
+
+	err := pgxscan.Select(ctx, r.db, &members, query, taskID, tenant.WorkspaceID(ctx))
+	if err != nil {
+		return members, err
+	}
+
+	return members, nil
+}
+
+func (r *MemberRepo) DeleteTaskMember(ctx context.Context, member entity.TaskMember) error {
+	ctx = postgres.WithMethod(ctx, "MemberRepo.DeleteTaskMember")
+
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE task_id=$1 AND username=$2 AND role=$3
+			AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$4)
+	`, constant.TaskMembersTable, constant.TasksTable)
+
+	res, err := r.db.Exec(ctx, query, member.TaskID, member.Username, member.Role, tenant.WorkspaceID(ctx))
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return constant.ErrTaskMemberNotExists
+	}
+
+	return nil
+}
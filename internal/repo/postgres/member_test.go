@@ -0,0 +1,123 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestMemberRepo_AddTaskMember(t *testing.T) {
+	query := fmt.Sprintf(`
+		WITH inserted AS (
+			INSERT INTO %[1]s
+			(task_id, username, role, created_at)
+			SELECT $1, $2, $3, $4
+			WHERE EXISTS (
+				SELECT 1
+				FROM %[2]s
+				WHERE id=$1 AND workspace_id=$5 AND deleted=false
+			)
+			ON CONFLICT (task_id, role, username) DO NOTHING
+			RETURNING created_at, true AS added
+		)
+		SELECT created_at, added FROM inserted
+		UNION ALL
+		SELECT created_at, false AS added
+		FROM %[1]s
+		WHERE task_id=$1 AND username=$2 AND role=$3
+			AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$5)
+	`, constant.TaskMembersTable, constant.TasksTable)
+
+	now := time.Now().UTC()
+
+	testCases := []struct {
+		name          string
+		rows          *pgxmock.Rows
+		expectedAdded bool
+		expectedError error
+	}{
+		{
+			name:          "new member",
+			rows:          pgxmock.NewRows([]string{"created_at", "added"}).AddRow(now, true),
+			expectedAdded: true,
+		},
+		{
+			name:          "existing member",
+			rows:          pgxmock.NewRows([]string{"created_at", "added"}).AddRow(now, false),
+			expectedAdded: false,
+		},
+		{
+			name:          "task not exists",
+			expectedError: constant.ErrTaskIDNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			inputMember := entity.TaskMember{
+				TaskID:   1,
+				Username: "ivan",
+				Role:     entity.MemberRoleAssignee,
+			}
+
+			expectation := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(
+				inputMember.TaskID,
+				inputMember.Username,
+				inputMember.Role,
+				pgxmock.AnyArg(),
+				tenant.DefaultWorkspaceID,
+			)
+			if tc.rows != nil {
+				expectation.WillReturnRows(tc.rows)
+			} else {
+				expectation.WillReturnError(pgx.ErrNoRows)
+			}
+
+			storage := NewMemberRepo(mock)
+
+			member, added, err := storage.AddTaskMember(context.Background(), inputMember)
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expectedAdded, added)
+			if tc.expectedError == nil {
+				require.Equal(t, now, member.CreatedAt)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestMemberRepo_DeleteTaskMember(t *testing.T) {
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE task_id=$1 AND username=$2 AND role=$3
+			AND task_id IN (SELECT id FROM %[2]s WHERE workspace_id=$4)
+	`, constant.TaskMembersTable, constant.TasksTable)
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1, "ivan", entity.MemberRoleWatcher, tenant.DefaultWorkspaceID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	storage := NewMemberRepo(mock)
+
+	err = storage.DeleteTaskMember(context.Background(), entity.TaskMember{TaskID: 1, Username: "ivan", Role: entity.MemberRoleWatcher})
+	require.ErrorIs(t, err, constant.ErrTaskMemberNotExists)
+
+	require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+}
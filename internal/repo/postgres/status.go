@@ -7,6 +7,7 @@ import (
 	"github.com/romandnk/todo/internal/constant"
 	"github.com/romandnk/todo/internal/entity"
 	postgres "github.com/romandnk/todo/pkg/storage"
+	"github.com/romandnk/todo/pkg/tenant"
 	"github.com/romandnk/todo/pkg/utils"
 )
 
@@ -19,16 +20,18 @@ func NewStatusRepo(db postgres.PgxPool) *StatusRepo {
 }
 
 func (r *StatusRepo) CreateStatus(ctx context.Context, status entity.Status) (int, error) {
+	ctx = postgres.WithMethod(ctx, "StatusRepo.CreateStatus")
+
 	var id int
 
-	values := []any{status.Name}
+	values := []any{status.Name, tenant.WorkspaceID(ctx)}
 	placeholderString, err := utils.SetPlaceholders(constant.PlaceholderDollar, len(values))
 	if err != nil {
 		return id, err
 	}
 	query := fmt.Sprintf(`
 		INSERT INTO %[1]s
-		(name)
+		(name, workspace_id)
 		VALUES %[2]s
 		RETURNING id
 	`, constant.StatusesTable, placeholderString)
@@ -42,14 +45,17 @@ func (r *StatusRepo) CreateStatus(ctx context.Context, status entity.Status) (in
 }
 
 func (r *StatusRepo) GetAllStatuses(ctx context.Context) ([]*entity.Status, error) {
+	ctx = postgres.WithMethod(ctx, "StatusRepo.GetAllStatuses")
+
 	var statuses []*entity.Status
 
 	query := fmt.Sprintf(`
 		SELECT id, name
 		FROM %[1]s
+		WHERE workspace_id=$1
 	`, constant.StatusesTable)
 
-	err := pgxscan.Select(ctx, r.db, &statuses, query)
+	err := pgxscan.Select(ctx, r.db, &statuses, query, tenant.WorkspaceID(ctx))
 	if err != nil {
 		return statuses, err
 	}
@@ -58,15 +64,17 @@ func (r *StatusRepo) GetAllStatuses(ctx context.Context) ([]*entity.Status, erro
 }
 
 func (r *StatusRepo) GetStatusByName(ctx context.Context, name string) (entity.Status, error) {
+	ctx = postgres.WithMethod(ctx, "StatusRepo.GetStatusByName")
+
 	var status entity.Status
 
 	query := fmt.Sprintf(`
 		SELECT id, name
 		FROM %[1]s
-		WHERE name=$1
+		WHERE name=$1 AND workspace_id=$2
 	`, constant.StatusesTable)
 
-	err := pgxscan.Get(ctx, r.db, &status, query, name)
+	err := pgxscan.Get(ctx, r.db, &status, query, name, tenant.WorkspaceID(ctx))
 	if err != nil {
 		return status, err
 	}
@@ -75,15 +83,17 @@ func (r *StatusRepo) GetStatusByName(ctx context.Context, name string) (entity.S
 }
 
 func (r *StatusRepo) GetStatusByID(ctx context.Context, id int) (entity.Status, error) {
+	ctx = postgres.WithMethod(ctx, "StatusRepo.GetStatusByID")
+
 	var status entity.Status
 
 	query := fmt.Sprintf(`
 		SELECT id, name
 		FROM %[1]s
-		WHERE id=$1
+		WHERE id=$1 AND workspace_id=$2
 	`, constant.StatusesTable)
 
-	err := pgxscan.Get(ctx, r.db, &status, query, id)
+	err := pgxscan.Get(ctx, r.db, &status, query, id, tenant.WorkspaceID(ctx))
 	if err != nil {
 		return status, err
 	}
@@ -2,11 +2,14 @@ package postgresrepo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
 	"github.com/romandnk/todo/internal/constant"
 	"github.com/romandnk/todo/internal/entity"
 	postgres "github.com/romandnk/todo/pkg/storage"
+	"github.com/romandnk/todo/pkg/tenant"
 	"github.com/romandnk/todo/pkg/utils"
 	"time"
 )
@@ -20,16 +23,31 @@ func NewTaskRepo(db postgres.PgxPool) *TaskRepo {
 }
 
 func (r *TaskRepo) CreateTask(ctx context.Context, task entity.Task) (int, error) {
+	ctx = postgres.WithMethod(ctx, "TaskRepo.CreateTask")
+
 	var id int
 
-	values := []any{task.Title, task.Description, task.StatusID, task.Date, task.Deleted, time.Now().UTC(), task.DeletedAt}
+	values := []any{
+		task.Title,
+		task.Description,
+		task.StatusID,
+		task.Date,
+		task.AllDay,
+		task.Priority,
+		task.Tags,
+		task.Projects,
+		task.Deleted,
+		time.Now().UTC(),
+		task.DeletedAt,
+		tenant.WorkspaceID(ctx),
+	}
 	placeholderString, err := utils.SetPlaceholders(constant.PlaceholderDollar, len(values))
 	if err != nil {
 		return id, err
 	}
 	query := fmt.Sprintf(`
 		INSERT INTO %[1]s
-		(title, description, status_id, date, deleted, created_at, deleted_at)
+		(title, description, status_id, date, all_day, priority, tags, projects, deleted, created_at, deleted_at, workspace_id)
 		VALUES %[2]s
 		RETURNING id
 	`, constant.TasksTable, placeholderString)
@@ -42,11 +60,13 @@ func (r *TaskRepo) CreateTask(ctx context.Context, task entity.Task) (int, error
 	return id, nil
 }
 
-func (r *TaskRepo) GetAllTasks(ctx context.Context, statusID, limit, lastID int, date time.Time) ([]*entity.Task, error) {
+func (r *TaskRepo) GetAllTasks(ctx context.Context, statusID, limit, lastID int, date time.Time, completion entity.CompletionFilter, members entity.MemberFilter) ([]*entity.Task, error) {
+	ctx = postgres.WithMethod(ctx, "TaskRepo.GetAllTasks")
+
 	var tasks []*entity.Task
 
-	values := make([]any, 0)
-	counter := 1
+	values := []any{tenant.WorkspaceID(ctx)}
+	counter := 2
 	query := fmt.Sprintf(`
 		SELECT 
 		    id, 
@@ -54,10 +74,29 @@ func (r *TaskRepo) GetAllTasks(ctx context.Context, statusID, limit, lastID int,
 		    description, 
 		    status_id, 
 		    date,  
-		    created_at
-		FROM %[1]s 
-		WHERE deleted=false
-	`, constant.TasksTable)
+		    all_day, 
+		    priority, 
+		    tags, 
+		    projects, 
+		    version, 
+		    created_at,
+		    (
+		        SELECT COUNT(*) 
+		        FROM %[2]s 
+		        WHERE %[2]s.task_id=%[1]s.id AND %[2]s.deleted=false
+		    ) AS comments_count,
+		    checklist.total AS checklist_total,
+		    checklist.done AS checklist_done
+		FROM %[1]s
+		LEFT JOIN LATERAL (
+		    SELECT 
+		        COUNT(*) AS total, 
+		        COUNT(*) FILTER (WHERE done) AS done
+		    FROM %[3]s 
+		    WHERE %[3]s.task_id=%[1]s.id
+		) AS checklist ON true 
+		WHERE workspace_id=$1 AND deleted=false
+	`, constant.TasksTable, constant.TaskCommentsTable, constant.TaskChecklistTable)
 
 	if statusID != 0 {
 		query += fmt.Sprintf(" AND status_id=$%d", counter)
@@ -65,12 +104,35 @@ func (r *TaskRepo) GetAllTasks(ctx context.Context, statusID, limit, lastID int,
 		values = append(values, statusID)
 	}
 
+	// day boundaries of timed tasks are evaluated in the date location
 	if !date.IsZero() {
-		query += fmt.Sprintf(" AND date BETWEEN $%d AND $%d", counter, counter+1)
-		counter += 2
-		dateFrom := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		query += fmt.Sprintf(" AND ((all_day AND date=$%d) OR (NOT all_day AND date BETWEEN $%d AND $%d))",
+			counter, counter+1, counter+2)
+		counter += 3
+		day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		dateFrom := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 		dateTo := dateFrom.AddDate(0, 0, 1).Add(-time.Nanosecond)
-		values = append(values, dateFrom, dateTo)
+		values = append(values, day, dateFrom, dateTo)
+	}
+
+	if completion.Valid {
+		query += fmt.Sprintf(" AND checklist.total>0 AND checklist.done*100/checklist.total BETWEEN $%d AND $%d",
+			counter, counter+1)
+		counter += 2
+		values = append(values, completion.Min, completion.Max)
+	}
+
+	for _, member := range []struct{ role, username string }{
+		{role: entity.MemberRoleAssignee, username: members.Assignee},
+		{role: entity.MemberRoleWatcher, username: members.Watcher},
+	} {
+		if member.username == "" {
+			continue
+		}
+		query += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM %[1]s WHERE %[1]s.task_id=%[2]s.id AND %[1]s.role=$%[3]d AND %[1]s.username=$%[4]d)",
+			constant.TaskMembersTable, constant.TasksTable, counter, counter+1)
+		counter += 2
+		values = append(values, member.role, member.username)
 	}
 
 	query += fmt.Sprintf(" AND id>$%d", counter)
@@ -93,6 +155,8 @@ func (r *TaskRepo) GetAllTasks(ctx context.Context, statusID, limit, lastID int,
 }
 
 func (r *TaskRepo) GetTaskByID(ctx context.Context, id int) (entity.Task, error) {
+	ctx = postgres.WithMethod(ctx, "TaskRepo.GetTaskByID")
+
 	var task entity.Task
 
 	query := fmt.Sprintf(`
@@ -102,12 +166,31 @@ func (r *TaskRepo) GetTaskByID(ctx context.Context, id int) (entity.Task, error)
 		    description, 
 		    status_id, 
 		    date, 
-		    created_at
+		    all_day, 
+		    priority, 
+		    tags, 
+		    projects, 
+		    version, 
+		    created_at,
+		    (
+		        SELECT COUNT(*) 
+		        FROM %[2]s 
+		        WHERE %[2]s.task_id=%[1]s.id AND %[2]s.deleted=false
+		    ) AS comments_count,
+		    checklist.total AS checklist_total,
+		    checklist.done AS checklist_done
 		FROM %[1]s
-		WHERE id=$1 AND deleted=false
-	`, constant.TasksTable)
+		LEFT JOIN LATERAL (
+		    SELECT 
+		        COUNT(*) AS total, 
+		        COUNT(*) FILTER (WHERE done) AS done
+		    FROM %[3]s 
+		    WHERE %[3]s.task_id=%[1]s.id
+		) AS checklist ON true
+		WHERE id=$1 AND workspace_id=$2 AND deleted=false
+	`, constant.TasksTable, constant.TaskCommentsTable, constant.TaskChecklistTable)
 
-	err := pgxscan.Get(ctx, r.db, &task, query, id)
+	err := pgxscan.Get(ctx, r.db, &task, query, id, tenant.WorkspaceID(ctx))
 	if err != nil {
 		return task, err
 	}
@@ -140,51 +223,256 @@ func (r *TaskRepo) GetTaskByID(ctx context.Context, id int) (entity.Task, error)
 //	return task, nil
 //}
 
-func (r *TaskRepo) UpdateTaskByID(ctx context.Context, id int, task entity.Task) error {
+// UpdateTaskByID updates not empty task fields and returns new task version.
+// Task version is checked if it is not zero.
+func (r *TaskRepo) UpdateTaskByID(ctx context.Context, id int, task entity.Task) (int, error) {
+	ctx = postgres.WithMethod(ctx, "TaskRepo.UpdateTaskByID")
+
+	var version int
+
 	newTask := utils.CheckEmptyTaskFields(task)
 
-	values := []any{newTask.Title, newTask.Description, newTask.StatusID, newTask.Date, id}
+	values := []any{
+		newTask.Title,
+		newTask.Description,
+		newTask.StatusID,
+		newTask.Date,
+		newTask.Priority,
+		newTask.Tags,
+		newTask.Projects,
+		newTask.AllDay,
+		id,
+		tenant.WorkspaceID(ctx),
+	}
 	query := fmt.Sprintf(`
 		UPDATE %[1]s
 		SET 
 			title=COALESCE($1, title),
 			description=COALESCE($2, description),
 			status_id=COALESCE($3, status_id),
-			date=COALESCE($4, date)
-		WHERE id=$5 AND deleted=false
+			date=COALESCE($4, date),
+			priority=COALESCE($5, priority),
+			tags=COALESCE($6, tags),
+			projects=COALESCE($7, projects),
+			all_day=COALESCE($8, all_day),
+			version=version+1
+		WHERE id=$9 AND workspace_id=$10 AND deleted=false
 	`, constant.TasksTable)
 
+	if task.Version != 0 {
+		query += " AND version=$11"
+		values = append(values, task.Version)
+	}
+
+	query += " RETURNING version"
+
+	err := pgxscan.Get(ctx, r.db, &version, query, values...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return version, r.notChangedTaskError(ctx, id, task.Version)
+		}
+		return version, err
+	}
+
+	return version, nil
+}
+
+// DeleteTaskByID marks task and its comments as deleted. Task version is checked if it is not zero.
+func (r *TaskRepo) DeleteTaskByID(ctx context.Context, id int, version int) error {
+	ctx = postgres.WithMethod(ctx, "TaskRepo.DeleteTaskByID")
+
+	now := time.Now().UTC()
+
+	values := []any{now, id, tenant.WorkspaceID(ctx)}
+	versionCondition := ""
+	if version != 0 {
+		versionCondition = " AND version=$4"
+		values = append(values, version)
+	}
+
+	query := fmt.Sprintf(deleteTaskQuery, versionCondition)
+
 	res, err := r.db.Exec(ctx, query, values...)
 	if err != nil {
 		return err
 	}
 
 	if res.RowsAffected() == 0 {
-		return constant.ErrTaskIDNotExists
+		return r.notChangedTaskError(ctx, id, version)
 	}
 
 	return nil
 }
 
-func (r *TaskRepo) DeleteTaskByID(ctx context.Context, id int) error {
-	now := time.Now().UTC()
-
-	query := fmt.Sprintf(`
+// deleteTaskQuery marks task with id $2 of workspace $3 as deleted at $1 together with its comments,
+// comments deleted with the task have the same deletion time. %s is replaced with extra task condition
+var deleteTaskQuery = fmt.Sprintf(`
+	WITH deleted_task AS (
 		UPDATE %[1]s
 		SET 
+		    deleted=true,
+		    deleted_at=$1,
+		    version=version+1
+		WHERE id=$2 AND workspace_id=$3 AND deleted=false%%s
+		RETURNING id
+	), deleted_comments AS (
+		UPDATE %[2]s
+		SET
 		    deleted=true,
 		    deleted_at=$1
-		WHERE id=$2 AND deleted=false
+		WHERE task_id IN (SELECT id FROM deleted_task) AND deleted=false
+	)
+	SELECT id FROM deleted_task
+`, constant.TasksTable, constant.TaskCommentsTable)
+
+// restoreTaskQuery restores deleted task with id $1 of workspace $2 and comments which were deleted together with it
+var restoreTaskQuery = fmt.Sprintf(`
+	WITH restored_task AS (
+		UPDATE %[1]s
+		SET 
+		    deleted=false,
+		    deleted_at=NULL,
+		    version=version+1
+		FROM (
+		    SELECT id, deleted_at
+		    FROM %[1]s
+		    WHERE id=$1 AND workspace_id=$2 AND deleted=true
+		    FOR UPDATE
+		) AS deleted_task
+		WHERE %[1]s.id=deleted_task.id
+		RETURNING %[1]s.id, deleted_task.deleted_at
+	), restored_comments AS (
+		UPDATE %[2]s
+		SET
+		    deleted=false,
+		    deleted_at=NULL
+		FROM restored_task
+		WHERE %[2]s.task_id=restored_task.id AND %[2]s.deleted_at=restored_task.deleted_at
+	)
+	SELECT id FROM restored_task
+`, constant.TasksTable, constant.TaskCommentsTable)
+
+// notChangedTaskError finds out why task was not changed: it does not exist or its version is different
+func (r *TaskRepo) notChangedTaskError(ctx context.Context, id int, version int) error {
+	if version == 0 {
+		return constant.ErrTaskIDNotExists
+	}
+
+	var exists bool
+
+	query := fmt.Sprintf(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM %[1]s
+			WHERE id=$1 AND workspace_id=$2 AND deleted=false
+		)
 	`, constant.TasksTable)
 
-	res, err := r.db.Exec(ctx, query, now, id)
+	err := pgxscan.Get(ctx, r.db, &exists, query, id, tenant.WorkspaceID(ctx))
 	if err != nil {
 		return err
 	}
 
-	if res.RowsAffected() == 0 {
+	if !exists {
 		return constant.ErrTaskIDNotExists
 	}
 
-	return nil
+	return constant.ErrTaskVersionMismatch
+}
+
+func (r *TaskRepo) ExecTaskBatch(ctx context.Context, items []entity.TaskBatchItem, atomic bool) ([]entity.TaskBatchResult, error) {
+	ctx = postgres.WithMethod(ctx, "TaskRepo.ExecTaskBatch")
+
+	results := make([]entity.TaskBatchResult, len(items))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return results, err
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now().UTC()
+	workspaceID := tenant.WorkspaceID(ctx)
+	batch := &pgx.Batch{}
+	for _, item := range items {
+		switch item.Action {
+		case entity.TaskBatchCreate:
+			batch.Queue(fmt.Sprintf(`
+				INSERT INTO %[1]s
+				(title, description, status_id, date, all_day, priority, tags, projects, deleted, created_at, deleted_at, workspace_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+				RETURNING id
+			`, constant.TasksTable),
+				item.Task.Title,
+				item.Task.Description,
+				item.Task.StatusID,
+				item.Task.Date,
+				item.Task.AllDay,
+				item.Task.Priority,
+				item.Task.Tags,
+				item.Task.Projects,
+				false,
+				now,
+				item.Task.DeletedAt,
+				workspaceID,
+			)
+		case entity.TaskBatchUpdateStatus:
+			batch.Queue(fmt.Sprintf(`
+				UPDATE %[1]s
+				SET 
+				    status_id=$1,
+				    version=version+1
+				WHERE id=$2 AND workspace_id=$3 AND deleted=false
+			`, constant.TasksTable), item.Task.StatusID, item.Task.ID, workspaceID)
+		case entity.TaskBatchDelete:
+			batch.Queue(fmt.Sprintf(deleteTaskQuery, ""), now, item.Task.ID, workspaceID)
+		case entity.TaskBatchRestore:
+			batch.Queue(restoreTaskQuery, item.Task.ID, workspaceID)
+		default:
+			return results, constant.ErrUnknownBatchAction
+		}
+	}
+
+	br := tx.SendBatch(ctx, batch)
+
+	failed := false
+	for i, item := range items {
+		if item.Action == entity.TaskBatchCreate {
+			err = br.QueryRow().Scan(&results[i].ID)
+			if err != nil {
+				br.Close()
+				return results, err
+			}
+			continue
+		}
+
+		results[i].ID = item.Task.ID
+
+		res, err := br.Exec()
+		if err != nil {
+			br.Close()
+			return results, err
+		}
+
+		if res.RowsAffected() == 0 {
+			results[i].Err = constant.ErrTaskIDNotExists
+			failed = true
+		}
+	}
+
+	err = br.Close()
+	if err != nil {
+		return results, err
+	}
+
+	if atomic && failed {
+		return results, constant.ErrTaskBatchRolledBack
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return results, err
+	}
+
+	return results, nil
 }
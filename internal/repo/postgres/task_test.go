@@ -8,6 +8,7 @@ import (
 	"github.com/pashagolub/pgxmock/v3"
 	"github.com/romandnk/todo/internal/constant"
 	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/pkg/tenant"
 	"github.com/romandnk/todo/pkg/utils"
 	"github.com/stretchr/testify/require"
 	"regexp"
@@ -28,6 +29,9 @@ func TestTaskRepoCreateTask(t *testing.T) {
 		Description: "Test",
 		StatusID:    1,
 		Date:        now,
+		Priority:    1,
+		Tags:        []string{"home"},
+		Projects:    []string{},
 		Deleted:     false,
 		DeletedAt:   time.Time{},
 	}
@@ -35,8 +39,8 @@ func TestTaskRepoCreateTask(t *testing.T) {
 
 	query := fmt.Sprintf(`
 		INSERT INTO %[1]s
-		(title, description, status_id, date, deleted, created_at, deleted_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		(title, description, status_id, date, all_day, priority, tags, projects, deleted, created_at, deleted_at, workspace_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id
 	`, constant.TasksTable)
 
@@ -48,9 +52,14 @@ func TestTaskRepoCreateTask(t *testing.T) {
 		inputTask.Description,
 		inputTask.StatusID,
 		inputTask.Date,
+		inputTask.AllDay,
+		inputTask.Priority,
+		inputTask.Tags,
+		inputTask.Projects,
 		inputTask.Deleted,
 		pgxmock.AnyArg(),
 		inputTask.DeletedAt,
+		tenant.DefaultWorkspaceID,
 	).WillReturnRows(rows)
 
 	storage := NewTaskRepo(mock)
@@ -64,6 +73,7 @@ func TestTaskRepoCreateTask(t *testing.T) {
 
 func TestTaskRepo_GetAllTasks(t *testing.T) {
 	now := time.Now().UTC()
+	moscow := time.FixedZone("MSK", 3*60*60)
 
 	testCases := []struct {
 		name          string
@@ -72,6 +82,8 @@ func TestTaskRepo_GetAllTasks(t *testing.T) {
 		limit         int
 		lastID        int
 		date          time.Time
+		completion    entity.CompletionFilter
+		members       entity.MemberFilter
 		args          []any
 		expectedTasks []*entity.Task
 		expectedError error
@@ -85,21 +97,41 @@ func TestTaskRepo_GetAllTasks(t *testing.T) {
 		    		description, 
 		    		status_id, 
 		    		date,  
-		    		created_at
+		    		all_day, 
+		    		priority, 
+		    		tags, 
+		    		projects, 
+		    		version, 
+		    		created_at,
+		    		(
+		    		    SELECT COUNT(*)
+		    		    FROM task_comments
+		    		    WHERE task_comments.task_id=tasks.id AND task_comments.deleted=false
+		    		) AS comments_count,
+		    		checklist.total AS checklist_total,
+		    		checklist.done AS checklist_done
 				FROM tasks
-				WHERE deleted=false AND status_id=$1 AND date BETWEEN $2 AND $3 AND id>$4
+				LEFT JOIN LATERAL (
+				    SELECT
+				        COUNT(*) AS total,
+				        COUNT(*) FILTER (WHERE done) AS done
+				    FROM task_checklist_items
+				    WHERE task_checklist_items.task_id=tasks.id
+				) AS checklist ON true
+				WHERE workspace_id=$1 AND deleted=false AND status_id=$2 AND ((all_day AND date=$3) OR (NOT all_day AND date BETWEEN $4 AND $5)) AND id>$6
 				ORDER BY id
-				LIMIT $5
+				LIMIT $7
 			`,
 			statusID: 1,
 			limit:    5,
 			lastID:   1,
-			date:     now,
+			date:     time.Date(2023, 11, 5, 0, 0, 0, 0, moscow),
 			args: []any{
+				tenant.DefaultWorkspaceID,
 				1,
-				time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
-				time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).
-					AddDate(0, 0, 1).Add(-time.Nanosecond),
+				time.Date(2023, 11, 5, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 11, 5, 0, 0, 0, 0, moscow),
+				time.Date(2023, 11, 6, 0, 0, 0, 0, moscow).Add(-time.Nanosecond),
 				1,
 				5,
 			},
@@ -136,9 +168,28 @@ func TestTaskRepo_GetAllTasks(t *testing.T) {
 		    		description, 
 		    		status_id, 
 		    		date,  
-		    		created_at
+		    		all_day, 
+		    		priority, 
+		    		tags, 
+		    		projects, 
+		    		version, 
+		    		created_at,
+		    		(
+		    		    SELECT COUNT(*)
+		    		    FROM task_comments
+		    		    WHERE task_comments.task_id=tasks.id AND task_comments.deleted=false
+		    		) AS comments_count,
+		    		checklist.total AS checklist_total,
+		    		checklist.done AS checklist_done
 				FROM tasks
-				WHERE deleted=false AND status_id=$1 AND date BETWEEN $2 AND $3 AND id>$4
+				LEFT JOIN LATERAL (
+				    SELECT
+				        COUNT(*) AS total,
+				        COUNT(*) FILTER (WHERE done) AS done
+				    FROM task_checklist_items
+				    WHERE task_checklist_items.task_id=tasks.id
+				) AS checklist ON true
+				WHERE workspace_id=$1 AND deleted=false AND status_id=$2 AND ((all_day AND date=$3) OR (NOT all_day AND date BETWEEN $4 AND $5)) AND id>$6
 				ORDER BY id
 			`,
 			statusID: 1,
@@ -146,8 +197,10 @@ func TestTaskRepo_GetAllTasks(t *testing.T) {
 			lastID:   1,
 			date:     now,
 			args: []any{
+				tenant.DefaultWorkspaceID,
 				1,
 				time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+				time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
 				time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).
 					AddDate(0, 0, 1).Add(-time.Nanosecond),
 				1,
@@ -185,16 +238,35 @@ func TestTaskRepo_GetAllTasks(t *testing.T) {
 		    		description, 
 		    		status_id, 
 		    		date,  
-		    		created_at
+		    		all_day, 
+		    		priority, 
+		    		tags, 
+		    		projects, 
+		    		version, 
+		    		created_at,
+		    		(
+		    		    SELECT COUNT(*)
+		    		    FROM task_comments
+		    		    WHERE task_comments.task_id=tasks.id AND task_comments.deleted=false
+		    		) AS comments_count,
+		    		checklist.total AS checklist_total,
+		    		checklist.done AS checklist_done
 				FROM tasks
-				WHERE deleted=false AND id>$1
+				LEFT JOIN LATERAL (
+				    SELECT
+				        COUNT(*) AS total,
+				        COUNT(*) FILTER (WHERE done) AS done
+				    FROM task_checklist_items
+				    WHERE task_checklist_items.task_id=tasks.id
+				) AS checklist ON true
+				WHERE workspace_id=$1 AND deleted=false AND id>$2
 				ORDER BY id
 			`,
 			statusID: 0,
 			limit:    0,
 			lastID:   0,
 			date:     time.Time{},
-			args:     []any{0},
+			args:     []any{tenant.DefaultWorkspaceID, 0},
 			expectedTasks: []*entity.Task{
 				{
 					ID:          1,
@@ -219,6 +291,104 @@ func TestTaskRepo_GetAllTasks(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "OK with checklist completion",
+			query: `
+				SELECT 
+		    		id, 
+		    		title, 
+		    		description, 
+		    		status_id, 
+		    		date,  
+		    		all_day, 
+		    		priority, 
+		    		tags, 
+		    		projects, 
+		    		version, 
+		    		created_at,
+		    		(
+		    		    SELECT COUNT(*)
+		    		    FROM task_comments
+		    		    WHERE task_comments.task_id=tasks.id AND task_comments.deleted=false
+		    		) AS comments_count,
+		    		checklist.total AS checklist_total,
+		    		checklist.done AS checklist_done
+				FROM tasks
+				LEFT JOIN LATERAL (
+				    SELECT
+				        COUNT(*) AS total,
+				        COUNT(*) FILTER (WHERE done) AS done
+				    FROM task_checklist_items
+				    WHERE task_checklist_items.task_id=tasks.id
+				) AS checklist ON true
+				WHERE workspace_id=$1 AND deleted=false AND checklist.total>0 AND checklist.done*100/checklist.total BETWEEN $2 AND $3 AND id>$4
+				ORDER BY id
+			`,
+			completion: entity.CompletionFilter{Min: 50, Max: 100, Valid: true},
+			args:       []any{tenant.DefaultWorkspaceID, 50, 100, 0},
+			expectedTasks: []*entity.Task{
+				{
+					ID:             1,
+					Title:          "Test",
+					Description:    "Test",
+					StatusID:       1,
+					Date:           now,
+					CreatedAt:      now,
+					ChecklistTotal: 4,
+					ChecklistDone:  3,
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "OK with assignee and watcher",
+			query: `
+				SELECT 
+		    		id, 
+		    		title, 
+		    		description, 
+		    		status_id, 
+		    		date,  
+		    		all_day, 
+		    		priority, 
+		    		tags, 
+		    		projects, 
+		    		version, 
+		    		created_at,
+		    		(
+		    		    SELECT COUNT(*)
+		    		    FROM task_comments
+		    		    WHERE task_comments.task_id=tasks.id AND task_comments.deleted=false
+		    		) AS comments_count,
+		    		checklist.total AS checklist_total,
+		    		checklist.done AS checklist_done
+				FROM tasks
+				LEFT JOIN LATERAL (
+				    SELECT
+				        COUNT(*) AS total,
+				        COUNT(*) FILTER (WHERE done) AS done
+				    FROM task_checklist_items
+				    WHERE task_checklist_items.task_id=tasks.id
+				) AS checklist ON true
+				WHERE workspace_id=$1 AND deleted=false AND EXISTS (SELECT 1 FROM task_members WHERE task_members.task_id=tasks.id AND task_members.role=$2 AND task_members.username=$3) AND EXISTS (SELECT 1 FROM task_members WHERE task_members.task_id=tasks.id AND task_members.role=$4 AND task_members.username=$5) AND id>$6
+				ORDER BY id
+			`,
+			members: entity.MemberFilter{Assignee: "ivan", Watcher: "petr"},
+			args:    []any{tenant.DefaultWorkspaceID, "assignee", "ivan", "watcher", "petr", 0},
+			expectedTasks: []*entity.Task{
+				{
+					ID:             1,
+					Title:          "Test",
+					Description:    "Test",
+					StatusID:       1,
+					Date:           now,
+					CreatedAt:      now,
+					ChecklistTotal: 4,
+					ChecklistDone:  3,
+				},
+			},
+			expectedError: nil,
+		},
 		{
 			name: "No rows in result set",
 			query: `
@@ -228,16 +398,35 @@ func TestTaskRepo_GetAllTasks(t *testing.T) {
 		    		description, 
 		    		status_id, 
 		    		date,  
-		    		created_at
+		    		all_day, 
+		    		priority, 
+		    		tags, 
+		    		projects, 
+		    		version, 
+		    		created_at,
+		    		(
+		    		    SELECT COUNT(*)
+		    		    FROM task_comments
+		    		    WHERE task_comments.task_id=tasks.id AND task_comments.deleted=false
+		    		) AS comments_count,
+		    		checklist.total AS checklist_total,
+		    		checklist.done AS checklist_done
 				FROM tasks
-				WHERE deleted=false AND id>$1
+				LEFT JOIN LATERAL (
+				    SELECT
+				        COUNT(*) AS total,
+				        COUNT(*) FILTER (WHERE done) AS done
+				    FROM task_checklist_items
+				    WHERE task_checklist_items.task_id=tasks.id
+				) AS checklist ON true
+				WHERE workspace_id=$1 AND deleted=false AND id>$2
 				ORDER BY id
 			`,
 			statusID:      0,
 			limit:         0,
 			lastID:        0,
 			date:          time.Time{},
-			args:          []any{0},
+			args:          []any{tenant.DefaultWorkspaceID, 0},
 			expectedTasks: []*entity.Task{},
 			expectedError: pgx.ErrNoRows,
 		},
@@ -252,7 +441,7 @@ func TestTaskRepo_GetAllTasks(t *testing.T) {
 
 			ctx := context.Background()
 
-			columns := []string{"id", "title", "description", "status_id", "date", "created_at"}
+			columns := []string{"id", "title", "description", "status_id", "date", "all_day", "priority", "tags", "projects", "version", "created_at", "comments_count", "checklist_total", "checklist_done"}
 			rows := pgxmock.NewRows(columns)
 			for _, task := range tc.expectedTasks {
 				rows.AddRow(
@@ -261,7 +450,15 @@ func TestTaskRepo_GetAllTasks(t *testing.T) {
 					task.Description,
 					task.StatusID,
 					task.Date,
+					task.AllDay,
+					task.Priority,
+					task.Tags,
+					task.Projects,
+					task.Version,
 					task.CreatedAt,
+					task.CommentsCount,
+					task.ChecklistTotal,
+					task.ChecklistDone,
 				)
 			}
 
@@ -273,7 +470,7 @@ func TestTaskRepo_GetAllTasks(t *testing.T) {
 
 			storage := NewTaskRepo(mock)
 
-			tasks, err := storage.GetAllTasks(ctx, tc.statusID, tc.limit, tc.lastID, tc.date)
+			tasks, err := storage.GetAllTasks(ctx, tc.statusID, tc.limit, tc.lastID, tc.date, tc.completion, tc.members)
 			require.ErrorIs(t, err, tc.expectedError)
 			require.ElementsMatch(t, tc.expectedTasks, tasks)
 
@@ -300,6 +497,7 @@ func TestTaskRepo_GetTaskByID(t *testing.T) {
 				Description: "Test",
 				StatusID:    1,
 				Date:        now,
+				Version:     1,
 				Deleted:     false,
 				CreatedAt:   now,
 				DeletedAt:   time.Time{},
@@ -330,12 +528,31 @@ func TestTaskRepo_GetTaskByID(t *testing.T) {
 		    		description, 
 		    		status_id, 
 		    		date, 
-		    		created_at
+		    		all_day, 
+		    		priority, 
+		    		tags, 
+		    		projects, 
+		    		version, 
+		    		created_at,
+		    		(
+		    		    SELECT COUNT(*)
+		    		    FROM %[2]s
+		    		    WHERE %[2]s.task_id=%[1]s.id AND %[2]s.deleted=false
+		    		) AS comments_count,
+		    		checklist.total AS checklist_total,
+		    		checklist.done AS checklist_done
 				FROM %[1]s
-				WHERE id=$1
-			`, constant.TasksTable)
-
-			columns := []string{"id", "title", "description", "status_id", "date", "created_at"}
+				LEFT JOIN LATERAL (
+				    SELECT
+				        COUNT(*) AS total,
+				        COUNT(*) FILTER (WHERE done) AS done
+				    FROM %[3]s
+				    WHERE %[3]s.task_id=%[1]s.id
+				) AS checklist ON true
+				WHERE id=$1 AND workspace_id=$2 AND deleted=false
+			`, constant.TasksTable, constant.TaskCommentsTable, constant.TaskChecklistTable)
+
+			columns := []string{"id", "title", "description", "status_id", "date", "all_day", "priority", "tags", "projects", "version", "created_at", "comments_count", "checklist_total", "checklist_done"}
 			rows := pgxmock.NewRows(columns).
 				AddRow(
 					tc.expectedTask.ID,
@@ -343,13 +560,21 @@ func TestTaskRepo_GetTaskByID(t *testing.T) {
 					tc.expectedTask.Description,
 					tc.expectedTask.StatusID,
 					tc.expectedTask.Date,
+					tc.expectedTask.AllDay,
+					tc.expectedTask.Priority,
+					tc.expectedTask.Tags,
+					tc.expectedTask.Projects,
+					tc.expectedTask.Version,
 					tc.expectedTask.CreatedAt,
+					tc.expectedTask.CommentsCount,
+					tc.expectedTask.ChecklistTotal,
+					tc.expectedTask.ChecklistDone,
 				)
 
 			if tc.expectedError == nil {
-				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(tc.expectedID).WillReturnRows(rows)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(tc.expectedID, tenant.DefaultWorkspaceID).WillReturnRows(rows)
 			} else {
-				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(tc.expectedID).WillReturnError(tc.expectedError)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(tc.expectedID, tenant.DefaultWorkspaceID).WillReturnError(tc.expectedError)
 			}
 
 			storage := NewTaskRepo(mock)
@@ -369,11 +594,22 @@ func TestTaskRepo_DeleteTaskByID(t *testing.T) {
 	testCases := []struct {
 		name          string
 		expectedID    int
+		version       int
+		rowsAffected  int64
+		exists        bool
 		expectedError error
 	}{
 		{
 			name:          "OK",
 			expectedID:    1,
+			rowsAffected:  1,
+			expectedError: nil,
+		},
+		{
+			name:          "OK with version",
+			expectedID:    1,
+			version:       2,
+			rowsAffected:  1,
 			expectedError: nil,
 		},
 		{
@@ -381,6 +617,20 @@ func TestTaskRepo_DeleteTaskByID(t *testing.T) {
 			expectedID:    1,
 			expectedError: constant.ErrTaskIDNotExists,
 		},
+		{
+			name:          "Task with id and version isn't found",
+			expectedID:    1,
+			version:       2,
+			exists:        false,
+			expectedError: constant.ErrTaskIDNotExists,
+		},
+		{
+			name:          "Task version mismatch",
+			expectedID:    1,
+			version:       2,
+			exists:        true,
+			expectedError: constant.ErrTaskVersionMismatch,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -392,23 +642,40 @@ func TestTaskRepo_DeleteTaskByID(t *testing.T) {
 
 			ctx := context.Background()
 
+			versionCondition := ""
+			args := []any{pgxmock.AnyArg(), tc.expectedID, tenant.DefaultWorkspaceID}
+			if tc.version != 0 {
+				versionCondition = " AND version=$4"
+				args = append(args, tc.version)
+			}
 			query := fmt.Sprintf(`
-				UPDATE %[1]s
-				SET 
-				    deleted=true,
-				    deleted_at=$1
-				WHERE id=$2 AND deleted=false
-			`, constant.TasksTable)
+				WITH deleted_task AS (
+					UPDATE %[1]s
+					SET 
+					    deleted=true,
+					    deleted_at=$1,
+					    version=version+1
+					WHERE id=$2 AND workspace_id=$3 AND deleted=false%[3]s
+					RETURNING id
+				), deleted_comments AS (
+					UPDATE %[2]s
+					SET
+					    deleted=true,
+					    deleted_at=$1
+					WHERE task_id IN (SELECT id FROM deleted_task) AND deleted=false
+				)
+				SELECT id FROM deleted_task
+			`, constant.TasksTable, constant.TaskCommentsTable, versionCondition)
 
-			if tc.expectedError == nil {
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(pgxmock.AnyArg(), tc.expectedID).WillReturnResult(pgxmock.NewResult(update, 1))
-			} else {
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(pgxmock.AnyArg(), tc.expectedID).WillReturnError(tc.expectedError)
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(args...).WillReturnResult(pgxmock.NewResult(update, tc.rowsAffected))
+			if tc.rowsAffected == 0 && tc.version != 0 {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS")).WithArgs(tc.expectedID, tenant.DefaultWorkspaceID).
+					WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(tc.exists))
 			}
 
 			storage := NewTaskRepo(mock)
 
-			err = storage.DeleteTaskByID(ctx, tc.expectedID)
+			err = storage.DeleteTaskByID(ctx, tc.expectedID, tc.version)
 			require.ErrorIs(t, err, tc.expectedError)
 
 			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
@@ -418,7 +685,6 @@ func TestTaskRepo_DeleteTaskByID(t *testing.T) {
 
 func TestTaskRepo_UpdateTaskByID(t *testing.T) {
 	now := time.Now().UTC()
-	update := "update"
 
 	testCases := []struct {
 		name                string
@@ -435,6 +701,8 @@ func TestTaskRepo_UpdateTaskByID(t *testing.T) {
 				Description: "test",
 				StatusID:    2,
 				Date:        now,
+				Priority:    3,
+				Tags:        []string{"home"},
 			},
 			expectedInput: utils.TaskToUpdate{
 				Title: sql.NullString{
@@ -453,6 +721,15 @@ func TestTaskRepo_UpdateTaskByID(t *testing.T) {
 					Time:  now,
 					Valid: true,
 				},
+				AllDay: sql.NullBool{
+					Bool:  false,
+					Valid: true,
+				},
+				Priority: sql.NullInt16{
+					Int16: 3,
+					Valid: true,
+				},
+				Tags: []string{"home"},
 			},
 			expectedError: nil,
 		},
@@ -483,6 +760,20 @@ func TestTaskRepo_UpdateTaskByID(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name:       "OK with clearing priority",
+			expectedID: 1,
+			expectedUpdatedTask: entity.Task{
+				ClearPriority: true,
+			},
+			expectedInput: utils.TaskToUpdate{
+				Priority: sql.NullInt16{
+					Int16: 0,
+					Valid: true,
+				},
+			},
+			expectedError: nil,
+		},
 		{
 			name:                "No rows in result set",
 			expectedID:          1,
@@ -490,6 +781,21 @@ func TestTaskRepo_UpdateTaskByID(t *testing.T) {
 			expectedInput:       utils.TaskToUpdate{},
 			expectedError:       constant.ErrTaskIDNotExists,
 		},
+		{
+			name:       "Task version mismatch",
+			expectedID: 1,
+			expectedUpdatedTask: entity.Task{
+				Title:   "test",
+				Version: 3,
+			},
+			expectedInput: utils.TaskToUpdate{
+				Title: sql.NullString{
+					String: "test",
+					Valid:  true,
+				},
+			},
+			expectedError: constant.ErrTaskVersionMismatch,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -507,32 +813,50 @@ func TestTaskRepo_UpdateTaskByID(t *testing.T) {
 					title=COALESCE($1, title),
 					description=COALESCE($2, description),
 					status_id=COALESCE($3, status_id),
-					date=COALESCE($4, date)
-				WHERE id=$5 AND deleted=false
+					date=COALESCE($4, date),
+					priority=COALESCE($5, priority),
+					tags=COALESCE($6, tags),
+					projects=COALESCE($7, projects),
+					all_day=COALESCE($8, all_day),
+					version=version+1
+				WHERE id=$9 AND workspace_id=$10 AND deleted=false
 			`, constant.TasksTable)
+			args := []any{
+				tc.expectedInput.Title,
+				tc.expectedInput.Description,
+				tc.expectedInput.StatusID,
+				pgxmock.AnyArg(),
+				tc.expectedInput.Priority,
+				tc.expectedInput.Tags,
+				tc.expectedInput.Projects,
+				tc.expectedInput.AllDay,
+				tc.expectedID,
+				tenant.DefaultWorkspaceID,
+			}
+			if tc.expectedUpdatedTask.Version != 0 {
+				query += " AND version=$11"
+				args = append(args, tc.expectedUpdatedTask.Version)
+			}
+			query += " RETURNING version"
 
 			if tc.expectedError == nil {
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(
-					tc.expectedInput.Title,
-					tc.expectedInput.Description,
-					tc.expectedInput.StatusID,
-					pgxmock.AnyArg(),
-					tc.expectedID,
-				).WillReturnResult(pgxmock.NewResult(update, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(args...).
+					WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(2))
 			} else {
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(
-					tc.expectedInput.Title,
-					tc.expectedInput.Description,
-					tc.expectedInput.StatusID,
-					pgxmock.AnyArg(),
-					tc.expectedID,
-				).WillReturnError(tc.expectedError)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(args...).WillReturnError(pgx.ErrNoRows)
+			}
+			if tc.expectedError != nil && tc.expectedUpdatedTask.Version != 0 {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS")).WithArgs(tc.expectedID, tenant.DefaultWorkspaceID).
+					WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
 			}
 
 			storage := NewTaskRepo(mock)
 
-			err = storage.UpdateTaskByID(ctx, tc.expectedID, tc.expectedUpdatedTask)
+			version, err := storage.UpdateTaskByID(ctx, tc.expectedID, tc.expectedUpdatedTask)
 			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, 2, version)
+			}
 
 			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
 		})
@@ -0,0 +1,306 @@
+package postgresrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	postgres "github.com/romandnk/todo/pkg/storage"
+	"time"
+)
+
+type WorkspaceRepo struct {
+	db postgres.PgxPool
+}
+
+func NewWorkspaceRepo(db postgres.PgxPool) *WorkspaceRepo {
+	return &WorkspaceRepo{db: db}
+}
+
+// CreateWorkspace creates workspace with the owner and default statuses
+func (r *WorkspaceRepo) CreateWorkspace(ctx context.Context, workspace entity.Workspace, owner string) (entity.Workspace, error) {
+	ctx = postgres.WithMethod(ctx, "WorkspaceRepo.CreateWorkspace")
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return workspace, err
+	}
+	defer tx.Rollback(ctx)
+
+	workspace.CreatedAt = time.Now().UTC()
+
+	err = tx.QueryRow(ctx, fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(name, created_at)
+		VALUES ($1, $2)
+		RETURNING id
+	`, constant.WorkspacesTable), workspace.Name, workspace.CreatedAt).Scan(&workspace.ID)
+	if err != nil {
+		return workspace, err
+	}
+
+	_, err = tx.Exec(ctx, fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(workspace_id, username, role, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, constant.WorkspaceMembersTable), workspace.ID, owner, entity.RoleOwner, workspace.CreatedAt)
+	if err != nil {
+		return workspace, err
+	}
+
+	_, err = tx.Exec(ctx, fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(name, workspace_id)
+		VALUES ($1, $3), ($2, $3)
+	`, constant.StatusesTable), constant.StatusNameDone, constant.StatusNameNotDone, workspace.ID)
+	if err != nil {
+		return workspace, err
+	}
+
+	return workspace, tx.Commit(ctx)
+}
+
+// GetWorkspacesByUsername returns workspaces the user is member of in creation order
+func (r *WorkspaceRepo) GetWorkspacesByUsername(ctx context.Context, username string) ([]*entity.UserWorkspace, error) {
+	ctx = postgres.WithMethod(ctx, "WorkspaceRepo.GetWorkspacesByUsername")
+
+	var workspaces []*entity.UserWorkspace
+
+	query := fmt.Sprintf(`
+		SELECT
+		    w.id,
+		    w.name,
+		    m.role,
+		    w.created_at
+		FROM %[1]s w
+		JOIN %[2]s m ON m.workspace_id=w.id
+		WHERE m.username=$1
+		ORDER BY w.id
+	`, constant.WorkspacesTable, constant.WorkspaceMembersTable)
+
+	err := pgxscan.Select(ctx, r.db, &workspaces, query, username)
+	if err != nil {
+		return workspaces, err
+	}
+
+	return workspaces, nil
+}
+
+func (r *WorkspaceRepo) GetMemberRole(ctx context.Context, workspaceID int, username string) (string, error) {
+	ctx = postgres.WithMethod(ctx, "WorkspaceRepo.GetMemberRole")
+
+	var role string
+
+	query := fmt.Sprintf(`
+		SELECT role
+		FROM %[1]s
+		WHERE workspace_id=$1 AND username=$2
+	`, constant.WorkspaceMembersTable)
+
+	err := r.db.QueryRow(ctx, query, workspaceID, username).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return role, constant.ErrWorkspaceMemberNotExists
+		}
+		return role, err
+	}
+
+	return role, nil
+}
+
+// GetWorkspaceMembers returns workspace members in order of joining
+func (r *WorkspaceRepo) GetWorkspaceMembers(ctx context.Context, workspaceID int) ([]*entity.WorkspaceMember, error) {
+	ctx = postgres.WithMethod(ctx, "WorkspaceRepo.GetWorkspaceMembers")
+
+	var members []*entity.WorkspaceMember
+
+	query := fmt.Sprintf(`
+		SELECT
+		    workspace_id,
+		    username,
+		    role,
+		    created_at
+		FROM %[1]s
+		WHERE workspace_id=$1
+		ORDER BY created_at, username
+	`, constant.WorkspaceMembersTable)
+
+	err := pgxscan.Select(ctx, r.db, &members, query, workspaceID)
+	if err != nil {
+		return members, err
+	}
+
+	return members, nil
+}
+
+// UpdateMemberRole changes role of the workspace member, the last owner cannot lose the owner role
+func (r *WorkspaceRepo) UpdateMemberRole(ctx context.Context, member entity.WorkspaceMember) (entity.WorkspaceMember, error) {
+	ctx = postgres.WithMethod(ctx, "WorkspaceRepo.UpdateMemberRole")
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return member, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = r.checkLastOwner(ctx, tx, member.WorkspaceID, member.Username, member.Role)
+	if err != nil {
+		return member, err
+	}
+
+	err = tx.QueryRow(ctx, fmt.Sprintf(`
+		UPDATE %[1]s
+		SET role=$1
+		WHERE workspace_id=$2 AND username=$3
+		RETURNING created_at
+	`, constant.WorkspaceMembersTable), member.Role, member.WorkspaceID, member.Username).Scan(&member.CreatedAt)
+	if err != nil {
+		return member, err
+	}
+
+	return member, tx.Commit(ctx)
+}
+
+// DeleteMember removes user from the workspace, the last owner cannot be removed
+func (r *WorkspaceRepo) DeleteMember(ctx context.Context, workspaceID int, username string) error {
+	ctx = postgres.WithMethod(ctx, "WorkspaceRepo.DeleteMember")
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = r.checkLastOwner(ctx, tx, workspaceID, username, "")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE workspace_id=$1 AND username=$2
+	`, constant.WorkspaceMembersTable), workspaceID, username)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// checkLastOwner locks workspace owners and the member, it fails if the member
+// does not exist or is the only owner and is going to get another role
+func (r *WorkspaceRepo) checkLastOwner(ctx context.Context, tx pgx.Tx, workspaceID int, username, newRole string) error {
+	var owners []string
+	err := pgxscan.Select(ctx, tx, &owners, fmt.Sprintf(`
+		SELECT username
+		FROM %[1]s
+		WHERE workspace_id=$1 AND role=$2
+		FOR UPDATE
+	`, constant.WorkspaceMembersTable), workspaceID, entity.RoleOwner)
+	if err != nil {
+		return err
+	}
+
+	var role string
+	err = tx.QueryRow(ctx, fmt.Sprintf(`
+		SELECT role
+		FROM %[1]s
+		WHERE workspace_id=$1 AND username=$2
+		FOR UPDATE
+	`, constant.WorkspaceMembersTable), workspaceID, username).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return constant.ErrWorkspaceMemberNotExists
+		}
+		return err
+	}
+
+	if role == entity.RoleOwner && newRole != entity.RoleOwner && len(owners) == 1 {
+		return constant.ErrWorkspaceWithoutOwner
+	}
+
+	return nil
+}
+
+func (r *WorkspaceRepo) CreateInvitation(ctx context.Context, invitation entity.Invitation) (entity.Invitation, error) {
+	ctx = postgres.WithMethod(ctx, "WorkspaceRepo.CreateInvitation")
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(workspace_id, token_hash, role, created_by, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`, constant.WorkspaceInvitationsTable)
+
+	err := r.db.QueryRow(ctx, query,
+		invitation.WorkspaceID,
+		invitation.TokenHash,
+		invitation.Role,
+		invitation.CreatedBy,
+		invitation.CreatedAt,
+		invitation.ExpiresAt,
+	).Scan(&invitation.ID)
+	if err != nil {
+		return invitation, err
+	}
+
+	return invitation, nil
+}
+
+// AcceptInvitation marks not expired and not accepted invitation as accepted by the user
+// and adds the user to its workspace in one statement, so the invitation is accepted once.
+// Existing member keeps the role
+func (r *WorkspaceRepo) AcceptInvitation(ctx context.Context, tokenHash, username string, now time.Time) (entity.WorkspaceMember, error) {
+	ctx = postgres.WithMethod(ctx, "WorkspaceRepo.AcceptInvitation")
+
+	var member entity.WorkspaceMember
+
+	query := fmt.Sprintf(`
+		WITH accepted AS (
+			UPDATE %[2]s
+			SET accepted_by=$2, accepted_at=$3
+			WHERE token_hash=$1 AND expires_at>$3 AND accepted_at IS NULL
+			RETURNING workspace_id, role
+		)
+		INSERT INTO %[1]s
+		(workspace_id, username, role, created_at)
+		SELECT workspace_id, $2, role, $3
+		FROM accepted
+		ON CONFLICT (workspace_id, username) DO UPDATE SET role=%[1]s.role
+		RETURNING workspace_id, username, role, created_at
+	`, constant.WorkspaceMembersTable, constant.WorkspaceInvitationsTable)
+
+	err := pgxscan.Get(ctx, r.db, &member, query, tokenHash, username, now)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return member, constant.ErrInvitationNotExists
+		}
+		return member, err
+	}
+
+	return member, nil
+}
+
+// AddOwnerIfNone makes the user the owner of the workspace without owners, existing member gets owner role.
+// It returns false if the workspace already has an owner
+func (r *WorkspaceRepo) AddOwnerIfNone(ctx context.Context, workspaceID int, username string, now time.Time) (bool, error) {
+	ctx = postgres.WithMethod(ctx, "WorkspaceRepo.AddOwnerIfNone")
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(workspace_id, username, role, created_at)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (SELECT 1 FROM %[1]s WHERE workspace_id=$1 AND role=$3)
+		ON CONFLICT (workspace_id, username) DO UPDATE SET role=EXCLUDED.role
+	`, constant.WorkspaceMembersTable)
+
+	tag, err := r.db.Exec(ctx, query, workspaceID, username, entity.RoleOwner, now)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
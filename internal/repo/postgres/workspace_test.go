@@ -0,0 +1,257 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestWorkspaceRepo_CreateWorkspace(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(name, created_at)
+		VALUES ($1, $2)
+		RETURNING id
+	`, constant.WorkspacesTable))).WithArgs("team", pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectExec(regexp.QuoteMeta(fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(workspace_id, username, role, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, constant.WorkspaceMembersTable))).WithArgs(2, "ivan", entity.RoleOwner, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(regexp.QuoteMeta(fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(name, workspace_id)
+		VALUES ($1, $3), ($2, $3)
+	`, constant.StatusesTable))).WithArgs(constant.StatusNameDone, constant.StatusNameNotDone, 2).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	mock.ExpectCommit()
+	mock.ExpectRollback()
+
+	storage := NewWorkspaceRepo(mock)
+
+	workspace, err := storage.CreateWorkspace(ctx, entity.Workspace{Name: "team"}, "ivan")
+	require.NoError(t, err)
+	require.Equal(t, 2, workspace.ID)
+	require.Equal(t, "team", workspace.Name)
+
+	require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+}
+
+func TestWorkspaceRepo_UpdateMemberRole(t *testing.T) {
+	ownersQuery := fmt.Sprintf(`
+		SELECT username
+		FROM %[1]s
+		WHERE workspace_id=$1 AND role=$2
+		FOR UPDATE
+	`, constant.WorkspaceMembersTable)
+	roleQuery := fmt.Sprintf(`
+		SELECT role
+		FROM %[1]s
+		WHERE workspace_id=$1 AND username=$2
+		FOR UPDATE
+	`, constant.WorkspaceMembersTable)
+	updateQuery := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET role=$1
+		WHERE workspace_id=$2 AND username=$3
+		RETURNING created_at
+	`, constant.WorkspaceMembersTable)
+
+	testCases := []struct {
+		name          string
+		owners        []string
+		currentRole   string
+		newRole       string
+		expectedError error
+	}{
+		{
+			name:        "OK",
+			owners:      []string{"petr"},
+			currentRole: entity.RoleMember,
+			newRole:     entity.RoleAdmin,
+		},
+		{
+			name:        "one of owners",
+			owners:      []string{"ivan", "petr"},
+			currentRole: entity.RoleOwner,
+			newRole:     entity.RoleMember,
+		},
+		{
+			name:          "last owner",
+			owners:        []string{"ivan"},
+			currentRole:   entity.RoleOwner,
+			newRole:       entity.RoleAdmin,
+			expectedError: constant.ErrWorkspaceWithoutOwner,
+		},
+		{
+			name:          "member not exists",
+			owners:        []string{"petr"},
+			newRole:       entity.RoleAdmin,
+			expectedError: constant.ErrWorkspaceMemberNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+			now := time.Now().UTC()
+
+			owners := pgxmock.NewRows([]string{"username"})
+			for _, owner := range tc.owners {
+				owners.AddRow(owner)
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(ownersQuery)).WithArgs(2, entity.RoleOwner).WillReturnRows(owners)
+			if tc.currentRole != "" {
+				mock.ExpectQuery(regexp.QuoteMeta(roleQuery)).WithArgs(2, "ivan").
+					WillReturnRows(pgxmock.NewRows([]string{"role"}).AddRow(tc.currentRole))
+			} else {
+				mock.ExpectQuery(regexp.QuoteMeta(roleQuery)).WithArgs(2, "ivan").WillReturnError(pgx.ErrNoRows)
+			}
+			if tc.expectedError == nil {
+				mock.ExpectQuery(regexp.QuoteMeta(updateQuery)).WithArgs(tc.newRole, 2, "ivan").
+					WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(now))
+				mock.ExpectCommit()
+			}
+			mock.ExpectRollback()
+
+			storage := NewWorkspaceRepo(mock)
+
+			member, err := storage.UpdateMemberRole(ctx, entity.WorkspaceMember{
+				WorkspaceID: 2,
+				Username:    "ivan",
+				Role:        tc.newRole,
+			})
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, now, member.CreatedAt)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestWorkspaceRepo_AcceptInvitation(t *testing.T) {
+	query := fmt.Sprintf(`
+		WITH accepted AS (
+			UPDATE %[2]s
+			SET accepted_by=$2, accepted_at=$3
+			WHERE token_hash=$1 AND expires_at>$3 AND accepted_at IS NULL
+			RETURNING workspace_id, role
+		)
+		INSERT INTO %[1]s
+		(workspace_id, username, role, created_at)
+		SELECT workspace_id, $2, role, $3
+		FROM accepted
+		ON CONFLICT (workspace_id, username) DO UPDATE SET role=%[1]s.role
+		RETURNING workspace_id, username, role, created_at
+	`, constant.WorkspaceMembersTable, constant.WorkspaceInvitationsTable)
+
+	testCases := []struct {
+		name          string
+		valid         bool
+		expectedError error
+	}{
+		{
+			name:  "OK",
+			valid: true,
+		},
+		{
+			name:          "expired, accepted or unknown token",
+			valid:         false,
+			expectedError: constant.ErrInvitationNotExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			ctx := context.Background()
+			now := time.Now().UTC()
+
+			expectation := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("token", "ivan", now)
+			if tc.valid {
+				expectation.WillReturnRows(pgxmock.NewRows([]string{"workspace_id", "username", "role", "created_at"}).
+					AddRow(2, "ivan", entity.RoleMember, now))
+			} else {
+				expectation.WillReturnError(pgx.ErrNoRows)
+			}
+
+			storage := NewWorkspaceRepo(mock)
+
+			member, err := storage.AcceptInvitation(ctx, "token", "ivan", now)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, entity.WorkspaceMember{
+					WorkspaceID: 2,
+					Username:    "ivan",
+					Role:        entity.RoleMember,
+					CreatedAt:   now,
+				}, member)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
+
+func TestWorkspaceRepo_AddOwnerIfNone(t *testing.T) {
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		(workspace_id, username, role, created_at)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (SELECT 1 FROM %[1]s WHERE workspace_id=$1 AND role=$3)
+		ON CONFLICT (workspace_id, username) DO UPDATE SET role=EXCLUDED.role
+	`, constant.WorkspaceMembersTable)
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1, "ivan", entity.RoleOwner, now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1, "ivan", entity.RoleOwner, now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+
+	storage := NewWorkspaceRepo(mock)
+
+	added, err := storage.AddOwnerIfNone(ctx, 1, "ivan", now)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	added, err = storage.AddOwnerIfNone(ctx, 1, "ivan", now)
+	require.NoError(t, err)
+	require.False(t, added)
+
+	require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+}
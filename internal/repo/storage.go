@@ -12,10 +12,11 @@ import (
 
 type Task interface {
 	CreateTask(ctx context.Context, task entity.Task) (int, error)
-	GetAllTasks(ctx context.Context, statusID, limit, lastID int, date time.Time) ([]*entity.Task, error)
+	GetAllTasks(ctx context.Context, statusID, limit, lastID int, date time.Time, completion entity.CompletionFilter, members entity.MemberFilter) ([]*entity.Task, error)
 	GetTaskByID(ctx context.Context, id int) (entity.Task, error)
-	UpdateTaskByID(ctx context.Context, id int, task entity.Task) error
-	DeleteTaskByID(ctx context.Context, id int) error
+	UpdateTaskByID(ctx context.Context, id int, task entity.Task) (int, error)
+	DeleteTaskByID(ctx context.Context, id int, version int) error
+	ExecTaskBatch(ctx context.Context, items []entity.TaskBatchItem, atomic bool) ([]entity.TaskBatchResult, error)
 }
 
 type Status interface {
@@ -25,14 +26,99 @@ type Status interface {
 	GetStatusByID(ctx context.Context, id int) (entity.Status, error)
 }
 
+type Feed interface {
+	CreateFeed(ctx context.Context, feed entity.Feed) (int, error)
+	GetFeedByTokenHash(ctx context.Context, tokenHash string) (entity.Feed, error)
+	DeleteFeedByTokenHash(ctx context.Context, tokenHash string) error
+}
+
+type Comment interface {
+	CreateComment(ctx context.Context, comment entity.Comment) (entity.Comment, error)
+	GetCommentsByTaskID(ctx context.Context, taskID, limit, lastID int) ([]*entity.Comment, error)
+	GetCommentByID(ctx context.Context, taskID, id int) (entity.Comment, error)
+	UpdateComment(ctx context.Context, comment entity.Comment) (entity.Comment, error)
+	DeleteComment(ctx context.Context, taskID, id int) error
+}
+
+type Attachment interface {
+	CreateAttachment(ctx context.Context, attachment entity.Attachment) (entity.Attachment, error)
+	GetAttachmentsByTaskID(ctx context.Context, taskID int) ([]*entity.Attachment, error)
+	GetAttachmentByID(ctx context.Context, taskID, id int) (entity.Attachment, error)
+	DeleteAttachment(ctx context.Context, taskID, id int) (string, error)
+}
+
+type Checklist interface {
+	AddChecklistItem(ctx context.Context, item entity.ChecklistItem) (entity.ChecklistItem, error)
+	GetChecklistItemsByTaskID(ctx context.Context, taskID int) ([]*entity.ChecklistItem, error)
+	ToggleChecklistItem(ctx context.Context, taskID, id int) (entity.ChecklistItem, error)
+	ReorderChecklistItems(ctx context.Context, taskID int, ids []int) error
+	DeleteChecklistItem(ctx context.Context, taskID, id int) error
+}
+
+type Member interface {
+	AddTaskMember(ctx context.Context, member entity.TaskMember) (entity.TaskMember, bool, error)
+	GetTaskMembers(ctx context.Context, taskID int) ([]*entity.TaskMember, error)
+	DeleteTaskMember(ctx context.Context, member entity.TaskMember) error
+}
+
+type Workspace interface {
+	CreateWorkspace(ctx context.Context, workspace entity.Workspace, owner string) (entity.Workspace, error)
+	GetWorkspacesByUsername(ctx context.Context, username string) ([]*entity.UserWorkspace, error)
+	GetMemberRole(ctx context.Context, workspaceID int, username string) (string, error)
+	GetWorkspaceMembers(ctx context.Context, workspaceID int) ([]*entity.WorkspaceMember, error)
+	UpdateMemberRole(ctx context.Context, member entity.WorkspaceMember) (entity.WorkspaceMember, error)
+	DeleteMember(ctx context.Context, workspaceID int, username string) error
+	CreateInvitation(ctx context.Context, invitation entity.Invitation) (entity.Invitation, error)
+	AcceptInvitation(ctx context.Context, tokenHash, username string, now time.Time) (entity.WorkspaceMember, error)
+	AddOwnerIfNone(ctx context.Context, workspaceID int, username string, now time.Time) (bool, error)
+}
+
+type APIKey interface {
+	CreateAPIKey(ctx context.Context, key entity.APIKey) (entity.APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]*entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int) error
+	UseAPIKey(ctx context.Context, keyHash string, now time.Time) (entity.APIKey, error)
+}
+
+type Idempotency interface {
+	CreateIdempotencyKey(ctx context.Context, key entity.IdempotencyKey) (bool, error)
+	GetIdempotencyKey(ctx context.Context, workspaceID int, principal, key string) (entity.IdempotencyKey, error)
+	SaveIdempotencyResponse(ctx context.Context, key entity.IdempotencyKey) error
+	DeleteIdempotencyKey(ctx context.Context, workspaceID int, principal, key string) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+type Health interface {
+	Ping(ctx context.Context) error
+	GetSchemaVersion(ctx context.Context) (entity.SchemaVersion, error)
+}
+
 type Repository struct {
-	Task   Task
-	Status Status
+	Task        Task
+	Status      Status
+	Feed        Feed
+	Comment     Comment
+	Attachment  Attachment
+	Checklist   Checklist
+	Member      Member
+	Workspace   Workspace
+	APIKey      APIKey
+	Idempotency Idempotency
+	Health      Health
 }
 
 func NewRepository(db postgres.PgxPool) *Repository {
 	return &Repository{
-		Task:   postgresrepo.NewTaskRepo(db),
-		Status: postgresrepo.NewStatusRepo(db),
+		Task:        postgresrepo.NewTaskRepo(db),
+		Status:      postgresrepo.NewStatusRepo(db),
+		Feed:        postgresrepo.NewFeedRepo(db),
+		Comment:     postgresrepo.NewCommentRepo(db),
+		Attachment:  postgresrepo.NewAttachmentRepo(db),
+		Checklist:   postgresrepo.NewChecklistRepo(db),
+		Member:      postgresrepo.NewMemberRepo(db),
+		Workspace:   postgresrepo.NewWorkspaceRepo(db),
+		APIKey:      postgresrepo.NewAPIKeyRepo(db),
+		Idempotency: postgresrepo.NewIdempotencyRepo(db),
+		Health:      postgresrepo.NewHealthRepo(db),
 	}
 }
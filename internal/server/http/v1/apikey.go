@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/internal/service"
+	apikeyservice "github.com/romandnk/todo/internal/service/apikey"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/tenant"
+	"net/http"
+	"strings"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+// APIKey puts workspace and scopes of the API key from Authorization header into the request context,
+// request without API key is authorized by the workspace role
+func (m *MW) APIKey() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key, err := bearerToken(ctx)
+		if err != nil {
+			m.logger.ErrorContext(ctx, "error parsing authorization header")
+			sentErrorResponse(ctx, err)
+			return
+		}
+		if !strings.HasPrefix(key, entity.APIKeyPrefix) {
+			ctx.Next()
+			return
+		}
+
+		apiKey, err := m.apiKey.Authenticate(ctx, key)
+		if err != nil {
+			m.logger.ErrorContext(ctx, "error authenticating api key", logger.Error(err))
+			sentErrorResponse(ctx, err)
+			return
+		}
+
+		reqCtx := tenant.WithWorkspace(ctx.Request.Context(), apiKey.WorkspaceID, "")
+		reqCtx = tenant.WithAPIKeyID(reqCtx, apiKey.ID)
+		reqCtx = logger.WithFields(reqCtx, "api_key_id", apiKey.ID)
+		ctx.Request = ctx.Request.WithContext(tenant.WithScopes(reqCtx, apiKey.Scopes))
+
+		ctx.Next()
+	}
+}
+
+// bearerToken returns the token of Authorization header, empty for request without header
+func bearerToken(ctx *gin.Context) (string, error) {
+	header := strings.TrimSpace(ctx.GetHeader(authorizationHeader))
+	if header == "" {
+		return "", nil
+	}
+
+	token, ok := strings.CutPrefix(header, bearerPrefix)
+	token = strings.TrimSpace(token)
+	if !ok || token == "" {
+		return "", constant.ErrInvalidAuthorization
+	}
+
+	return token, nil
+}
+
+type apiKeyRoutes struct {
+	apiKey service.APIKey
+	logger logger.Logger
+}
+
+func newAPIKeyRoutes(g *gin.RouterGroup, apiKey service.APIKey, logger logger.Logger) {
+	r := &apiKeyRoutes{
+		apiKey: apiKey,
+		logger: logger,
+	}
+
+	g.POST("/", r.CreateAPIKey)
+	g.GET("/", r.GetAPIKeys)
+	g.DELETE("/:key_id", r.RevokeAPIKey)
+}
+
+// CreateAPIKey
+//
+//	@Summary		Create API key
+//	@Description	Create API key of the workspace with tasks:read, tasks:write or statuses:admin scopes. The key is shown only once.
+//	@UUID			900
+//	@Param			Username		header		string								true	"Request author"
+//	@Param			Workspace-ID	header		int									false	"Workspace id"
+//	@Param			params			body		apikeyservice.CreateAPIKeyParams	true	"JSON body with key name and scopes"
+//	@Success		201				{object}	apikeyservice.CreateAPIKeyResponse	"API key was created successfully"
+//	@Failure		400				{object}	problem								"Invalid input data"
+//	@Failure		401				{object}	problem								"Username header is missing"
+//	@Failure		403				{object}	problem								"Role does not allow the action"
+//	@Failure		500				{object}	problem								"Internal error"
+//	@Router			/api-keys/ [post]
+//	@Tags			APIKey
+func (r *apiKeyRoutes) CreateAPIKey(ctx *gin.Context) {
+	var params apikeyservice.CreateAPIKeyParams
+
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	resp, err := r.apiKey.CreateAPIKey(ctx, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error creating api key", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetAPIKeys
+//
+//	@Summary		Get API keys
+//	@Description	Get API keys of the workspace including revoked ones, keys themselves are not returned.
+//	@UUID			901
+//	@Param			Username		header		string								false	"Request author"
+//	@Param			Workspace-ID	header		int									false	"Workspace id"
+//	@Success		200				{object}	apikeyservice.GetAPIKeysResponse	"API keys were received successfully"
+//	@Failure		403				{object}	problem								"Role does not allow the action"
+//	@Failure		500				{object}	problem								"Internal error"
+//	@Router			/api-keys/ [get]
+//	@Tags			APIKey
+func (r *apiKeyRoutes) GetAPIKeys(ctx *gin.Context) {
+	resp, err := r.apiKey.GetAPIKeys(ctx)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting api keys", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RevokeAPIKey
+//
+//	@Summary		Revoke API key
+//	@Description	Revoke API key of the workspace, requests with revoked key are rejected.
+//	@UUID			902
+//	@Param			key_id			path	int		true	"API key id"
+//	@Param			Username		header	string	false	"Request author"
+//	@Param			Workspace-ID	header	int		false	"Workspace id"
+//	@Success		204				"API key was revoked successfully"
+//	@Failure		400				{object}	problem	"Invalid input data"
+//	@Failure		403				{object}	problem	"Role does not allow the action"
+//	@Failure		404				{object}	problem	"API key is not found or already revoked"
+//	@Failure		500				{object}	problem	"Internal error"
+//	@Router			/api-keys/:key_id [delete]
+//	@Tags			APIKey
+func (r *apiKeyRoutes) RevokeAPIKey(ctx *gin.Context) {
+	keyID := ctx.Param("key_id")
+
+	err := r.apiKey.RevokeAPIKey(ctx, keyID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error revoking api key", logger.Error(err), logger.String("key id", keyID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	mock_service "github.com/romandnk/todo/internal/service/mock"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMW_APIKey(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		method               string
+		authorization        string
+		workspace            string
+		serviceM             func(m *mock_service.MockAPIKey)
+		workspaceM           func(m *mock_service.MockWorkspace)
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name:   "without key",
+			method: http.MethodGet,
+			workspaceM: func(m *mock_service.MockWorkspace) {
+				m.EXPECT().ResolveRole(gomock.Any(), "").Return(1, entity.RoleViewer, nil)
+			},
+			expectedResponseBody: "1",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:          "key with scope",
+			method:        http.MethodPost,
+			authorization: "Bearer todo_key",
+			serviceM: func(m *mock_service.MockAPIKey) {
+				m.EXPECT().Authenticate(gomock.Any(), "todo_key").
+					Return(entity.APIKey{WorkspaceID: 2, Scopes: []string{"tasks:write"}}, nil)
+			},
+			expectedResponseBody: "2",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:          "key without scope",
+			method:        http.MethodPost,
+			authorization: "Bearer todo_key",
+			serviceM: func(m *mock_service.MockAPIKey) {
+				m.EXPECT().Authenticate(gomock.Any(), "todo_key").
+					Return(entity.APIKey{WorkspaceID: 2, Scopes: []string{"tasks:read"}}, nil)
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error authorizing request", gomock.Any(), gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"your role in the workspace does not allow this action","instance":"/api/v1/tasks","code":"permission_denied"}`,
+			expectedHTTPCode:     http.StatusForbidden,
+		},
+		{
+			name:          "key of another workspace",
+			method:        http.MethodGet,
+			authorization: "Bearer todo_key",
+			workspace:     "3",
+			serviceM: func(m *mock_service.MockAPIKey) {
+				m.EXPECT().Authenticate(gomock.Any(), "todo_key").
+					Return(entity.APIKey{WorkspaceID: 2, Scopes: []string{"tasks:read"}}, nil)
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error resolving api key workspace", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"api key does not give access to the workspace","instance":"/api/v1/tasks","code":"api_key_workspace_denied","field":"Workspace-ID"}`,
+			expectedHTTPCode:     http.StatusForbidden,
+		},
+		{
+			name:          "not bearer",
+			method:        http.MethodGet,
+			authorization: "Basic aXZhbjpwYXNz",
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error parsing authorization header")
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"Authorization header must contain Bearer token","instance":"/api/v1/tasks","code":"invalid_authorization","field":"Authorization"}`,
+			expectedHTTPCode:     http.StatusUnauthorized,
+		},
+		{
+			name:          "revoked key",
+			method:        http.MethodGet,
+			authorization: "Bearer todo_key",
+			serviceM: func(m *mock_service.MockAPIKey) {
+				m.EXPECT().Authenticate(gomock.Any(), "todo_key").Return(entity.APIKey{}, constant.ErrInvalidAPIKey)
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error authenticating api key", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"api key is invalid or revoked","instance":"/api/v1/tasks","code":"invalid_api_key","field":"Authorization"}`,
+			expectedHTTPCode:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			log := mock_logger.NewMockLogger(ctrl)
+			if tc.loggerM != nil {
+				tc.loggerM(log)
+			}
+			apiKey := mock_service.NewMockAPIKey(ctrl)
+			if tc.serviceM != nil {
+				tc.serviceM(apiKey)
+			}
+			workspace := mock_service.NewMockWorkspace(ctrl)
+			if tc.workspaceM != nil {
+				tc.workspaceM(workspace)
+			}
+
+			mw := NewMiddlewares(log, nil, workspace, apiKey, nil, nil, nil, nil)
+
+			router := gin.New()
+			router.ContextWithFallback = true
+			router.Handle(tc.method, "/api/v1/tasks",
+				mw.APIKey(),
+				mw.Workspace(),
+				mw.Authorize(entity.PermTasksRead, entity.PermTasksWrite),
+				func(ctx *gin.Context) {
+					ctx.String(http.StatusOK, fmt.Sprintf("%d", tenant.WorkspaceID(ctx)))
+				},
+			)
+
+			req := httptest.NewRequest(tc.method, "/api/v1/tasks", nil)
+			if tc.authorization != "" {
+				req.Header.Set(authorizationHeader, tc.authorization)
+			}
+			if tc.workspace != "" {
+				req.Header.Set(workspaceHeader, tc.workspace)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/service"
+	attachmentservice "github.com/romandnk/todo/internal/service/attachment"
+	"github.com/romandnk/todo/pkg/logger"
+	"mime"
+	"net/http"
+)
+
+const (
+	// attachmentFormField is the multipart form field of the uploaded file
+	attachmentFormField = "file"
+	// multipartOverhead is the size of boundaries and part headers allowed above the max attachment size
+	multipartOverhead = 64 << 10
+)
+
+type attachmentRoutes struct {
+	attachment service.Attachment
+	logger     logger.Logger
+	maxSize    int64
+}
+
+func newAttachmentRoutes(g *gin.RouterGroup, attachment service.Attachment, logger logger.Logger, maxSize int64) {
+	r := &attachmentRoutes{
+		attachment: attachment,
+		logger:     logger,
+		maxSize:    maxSize,
+	}
+
+	g.POST("/", r.UploadAttachment)
+	g.GET("/", r.GetAttachments)
+	g.GET("/:attachment_id", r.DownloadAttachment)
+	g.DELETE("/:attachment_id", r.DeleteAttachmentByID)
+}
+
+// UploadAttachment
+//
+//	@Summary		Upload attachment
+//	@Description	Attach file to the task with multipart/form-data request. File size and content type are limited by config.
+//	@UUID			500
+//	@Accept			multipart/form-data
+//	@Param			id				path		int									true	"Task id"
+//	@Param			file			formData	file								true	"Attached file"
+//	@Param			Idempotency-Key	header		string								false	"Unique key to retry request safely"
+//	@Param			Time-Zone		header		string								false	"IANA time zone of dates, UTC by default"
+//	@Success		201				{object}	attachmentservice.AttachmentModel	"Attachment was uploaded successfully"
+//	@Failure		400				{object}	problem								"Invalid input data"
+//	@Failure		404				{object}	problem								"Task is not found"
+//	@Failure		413				{object}	problem								"File is too large"
+//	@Failure		415				{object}	problem								"File content type is not allowed"
+//	@Failure		500				{object}	problem								"Internal error"
+//	@Router			/tasks/:id/attachments/ [post]
+//	@Tags			Attachment
+func (r *attachmentRoutes) UploadAttachment(ctx *gin.Context) {
+	// multipart form is spooled to memory and disk, so too large body is rejected while it is parsed
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, r.maxSize+multipartOverhead)
+
+	fileHeader, err := ctx.FormFile(attachmentFormField)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting multipart file", logger.Error(err))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			sentErrorResponse(ctx, constant.ErrTooLargeAttachment.WithMessage(fmt.Sprintf("max attachment size is %d bytes", r.maxSize)))
+			return
+		}
+		sentErrorResponse(ctx, constant.ErrEmptyAttachmentFile.WithMessage(err.Error()))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error opening multipart file", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInternalError)
+		return
+	}
+	defer file.Close()
+
+	taskID := ctx.Param("id")
+	params := attachmentservice.UploadAttachmentParams{
+		FileName:    fileHeader.Filename,
+		ContentType: fileHeader.Header.Get("Content-Type"),
+		Size:        fileHeader.Size,
+		File:        file,
+	}
+
+	resp, err := r.attachment.UploadAttachment(ctx, taskID, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error uploading attachment",
+			logger.Error(err),
+			logger.String("task id", taskID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetAttachments
+//
+//	@Summary		Get task attachments
+//	@Description	Get metadata of task attachments in upload order.
+//	@UUID			501
+//	@Param			id			path		int											true	"Task id"
+//	@Param			Time-Zone	header		string										false	"IANA time zone of dates, UTC by default"
+//	@Success		200			{object}	attachmentservice.GetAttachmentsResponse	"Attachments were gotten successfully"
+//	@Failure		400			{object}	problem										"Invalid input data"
+//	@Failure		404			{object}	problem										"Task is not found"
+//	@Failure		500			{object}	problem										"Internal error"
+//	@Router			/tasks/:id/attachments/ [get]
+//	@Tags			Attachment
+func (r *attachmentRoutes) GetAttachments(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+
+	resp, err := r.attachment.GetAttachments(ctx, taskID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting attachments",
+			logger.Error(err),
+			logger.String("task id", taskID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DownloadAttachment
+//
+//	@Summary		Download attachment
+//	@Description	Download attached file with its content type and file name.
+//	@UUID			502
+//	@Produce		application/octet-stream
+//	@Param			id				path		int		true	"Task id"
+//	@Param			attachment_id	path		int		true	"Attachment id"
+//	@Success		200				{file}		file	"Attachment was downloaded successfully"
+//	@Failure		400				{object}	problem	"Invalid input data"
+//	@Failure		404				{object}	problem	"Attachment is not found"
+//	@Failure		500				{object}	problem	"Internal error"
+//	@Router			/tasks/:id/attachments/:attachment_id [get]
+//	@Tags			Attachment
+func (r *attachmentRoutes) DownloadAttachment(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+	id := ctx.Param("attachment_id")
+
+	attachment, content, err := r.attachment.DownloadAttachment(ctx, taskID, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error downloading attachment with id",
+			logger.Error(err),
+			logger.String("task id", taskID),
+			logger.String("attachment id", id))
+		sentErrorResponse(ctx, err)
+		return
+	}
+	defer content.Close()
+
+	headers := map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		// browser must not render the file as other type than stored one
+		"X-Content-Type-Options": "nosniff",
+	}
+	ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, headers)
+}
+
+// DeleteAttachmentByID
+//
+//	@Summary		Delete attachment by ID
+//	@Description	Delete task attachment with its file.
+//	@UUID			503
+//	@Param			id				path		int		true	"Task id"
+//	@Param			attachment_id	path		int		true	"Attachment id"
+//	@Success		200				{object}	nil		"Attachment was deleted successfully"
+//	@Failure		400				{object}	problem	"Invalid input data"
+//	@Failure		404				{object}	problem	"Attachment is not found"
+//	@Failure		500				{object}	problem	"Internal error"
+//	@Router			/tasks/:id/attachments/:attachment_id [delete]
+//	@Tags			Attachment
+func (r *attachmentRoutes) DeleteAttachmentByID(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+	id := ctx.Param("attachment_id")
+
+	err := r.attachment.DeleteAttachmentByID(ctx, taskID, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error deleting attachment with id",
+			logger.Error(err),
+			logger.String("task id", taskID),
+			logger.String("attachment id", id))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
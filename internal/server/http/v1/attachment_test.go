@@ -0,0 +1,187 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	attachmentservice "github.com/romandnk/todo/internal/service/attachment"
+	mock_service "github.com/romandnk/todo/internal/service/mock"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+func TestAttachmentRoutes_UploadAttachment(t *testing.T) {
+	route := "/api/v1/tasks/:id/attachments"
+	url := "/api/v1/tasks/1/attachments"
+
+	model := attachmentservice.AttachmentModel{
+		ID:          2,
+		TaskID:      1,
+		FileName:    "screenshot.png",
+		ContentType: "image/png",
+		Size:        10,
+		CreatedAt:   "2030-01-02T10:00:00Z",
+		URL:         "/api/v1/tasks/1/attachments/2",
+	}
+
+	testCases := []struct {
+		name                 string
+		field                string
+		content              string
+		attachmentM          func(m *mock_service.MockAttachment)
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name:    "OK",
+			field:   attachmentFormField,
+			content: "screenshot",
+			attachmentM: func(m *mock_service.MockAttachment) {
+				m.EXPECT().UploadAttachment(gomock.Any(), "1", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, params attachmentservice.UploadAttachmentParams) (attachmentservice.AttachmentModel, error) {
+						content, err := io.ReadAll(params.File)
+						require.NoError(t, err)
+						require.Equal(t, "screenshot", string(content))
+						require.Equal(t, "screenshot.png", params.FileName)
+						require.Equal(t, "image/png", params.ContentType)
+						require.Equal(t, int64(10), params.Size)
+						return model, nil
+					})
+			},
+			expectedResponseBody: `{"id":2,"task_id":1,"file_name":"screenshot.png","content_type":"image/png","size":10,"created_at":"2030-01-02T10:00:00Z","url":"/api/v1/tasks/1/attachments/2"}`,
+			expectedHTTPCode:     http.StatusCreated,
+		},
+		{
+			name:    "too large file",
+			field:   attachmentFormField,
+			content: "screenshot",
+			attachmentM: func(m *mock_service.MockAttachment) {
+				m.EXPECT().UploadAttachment(gomock.Any(), "1", gomock.Any()).
+					Return(attachmentservice.AttachmentModel{}, constant.ErrTooLargeAttachment.WithMessage("max attachment size is 5 bytes"))
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error uploading attachment", gomock.Any(), gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"max attachment size is 5 bytes","instance":"/api/v1/tasks/1/attachments","code":"too_large_attachment","field":"file"}`,
+			expectedHTTPCode:     http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:    "too large request body",
+			field:   attachmentFormField,
+			content: strings.Repeat("a", 10+multipartOverhead),
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error getting multipart file", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"max attachment size is 10 bytes","instance":"/api/v1/tasks/1/attachments","code":"too_large_attachment","field":"file"}`,
+			expectedHTTPCode:     http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:    "missing file field",
+			field:   "document",
+			content: "screenshot",
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error getting multipart file", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"http: no such file","instance":"/api/v1/tasks/1/attachments","code":"empty_attachment_file","field":"file"}`,
+			expectedHTTPCode:     http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			attachmentService := mock_service.NewMockAttachment(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			if tc.attachmentM != nil {
+				tc.attachmentM(attachmentService)
+			}
+
+			if tc.loggerM != nil {
+				tc.loggerM(logger)
+			}
+
+			attachmentR := attachmentRoutes{
+				attachment: attachmentService,
+				logger:     logger,
+				maxSize:    10,
+			}
+
+			r := gin.Default()
+			r.POST(route, attachmentR.UploadAttachment)
+
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Disposition", `form-data; name="`+tc.field+`"; filename="screenshot.png"`)
+			header.Set("Content-Type", "image/png")
+			part, err := mw.CreatePart(header)
+			require.NoError(t, err)
+			_, err = part.Write([]byte(tc.content))
+			require.NoError(t, err)
+			require.NoError(t, mw.Close())
+
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, &body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestAttachmentRoutes_DownloadAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	attachmentService := mock_service.NewMockAttachment(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+
+	attachmentService.EXPECT().DownloadAttachment(gomock.Any(), "1", "2").
+		Return(attachmentservice.AttachmentModel{
+			ID:          2,
+			TaskID:      1,
+			FileName:    "отчёт.pdf",
+			ContentType: "application/pdf",
+			Size:        6,
+		}, io.NopCloser(strings.NewReader("%PDF-1")), nil)
+
+	attachmentR := attachmentRoutes{
+		attachment: attachmentService,
+		logger:     logger,
+	}
+
+	r := gin.Default()
+	r.GET("/api/v1/tasks/:id/attachments/:attachment_id", attachmentR.DownloadAttachment)
+
+	w := httptest.NewRecorder()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/tasks/1/attachments/2", nil)
+	require.NoError(t, err)
+
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	require.Equal(t, "attachment; filename*=utf-8''%D0%BE%D1%82%D1%87%D1%91%D1%82.pdf", w.Header().Get("Content-Disposition"))
+	require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	require.Equal(t, "%PDF-1", w.Body.String())
+}
@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/internal/service"
+	authservice "github.com/romandnk/todo/internal/service/auth"
+	"github.com/romandnk/todo/pkg/currentuser"
+	"github.com/romandnk/todo/pkg/logger"
+	"net/http"
+	"strings"
+)
+
+const (
+	// stateCookie keeps login state between login and callback requests
+	stateCookie     = "oidc_state"
+	stateCookiePath = "/api/v1/auth/oidc"
+	stateCookieTTL  = 600
+)
+
+// Token puts the user of identity provider bearer token into the request context,
+// API keys are handled by APIKey middleware
+func (m *MW) Token() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token, err := bearerToken(ctx)
+		if err != nil {
+			m.logger.ErrorContext(ctx, "error parsing authorization header")
+			sentErrorResponse(ctx, err)
+			return
+		}
+		if token == "" || strings.HasPrefix(token, entity.APIKeyPrefix) {
+			ctx.Next()
+			return
+		}
+
+		username, err := m.auth.Authenticate(ctx, token)
+		if err != nil {
+			m.logger.ErrorContext(ctx, "error authenticating bearer token", logger.Error(err))
+			sentErrorResponse(ctx, err)
+			return
+		}
+
+		reqCtx := currentuser.WithAuthenticatedUsername(ctx.Request.Context(), username)
+		ctx.Request = ctx.Request.WithContext(logger.WithFields(reqCtx, "user", username))
+
+		ctx.Next()
+	}
+}
+
+type authRoutes struct {
+	auth   service.Auth
+	logger logger.Logger
+}
+
+func newAuthRoutes(g *gin.RouterGroup, auth service.Auth, logger logger.Logger) {
+	r := &authRoutes{
+		auth:   auth,
+		logger: logger,
+	}
+
+	g.GET("/login", r.Login)
+	g.GET("/callback", r.Callback)
+}
+
+// Login
+//
+//	@Summary		Login with identity provider
+//	@Description	Redirect to the login page of OpenID Connect identity provider, which redirects back to the callback.
+//	@UUID			1000
+//	@Success		302	"Redirect to the identity provider"
+//	@Failure		404	{object}	problem	"OIDC login is not configured"
+//	@Failure		500	{object}	problem	"Internal error"
+//	@Router			/auth/oidc/login [get]
+//	@Tags			Auth
+func (r *authRoutes) Login(ctx *gin.Context) {
+	resp, err := r.auth.Login(ctx)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error starting oidc login", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(stateCookie, resp.State, stateCookieTTL, stateCookiePath, "", ctx.Request.TLS != nil, true)
+
+	ctx.Redirect(http.StatusFound, resp.URL)
+}
+
+// Callback
+//
+//	@Summary		Finish login with identity provider
+//	@Description	Exchange authorization code for tokens. ID token is used in Authorization header as Bearer token.
+//	@UUID			1001
+//	@Param			code	query		string							true	"Authorization code"
+//	@Param			state	query		string							true	"Login state"
+//	@Success		200		{object}	authservice.CallbackResponse	"User was signed in successfully"
+//	@Failure		400		{object}	problem							"Invalid code or state"
+//	@Failure		401		{object}	problem							"Authorization code is invalid or expired"
+//	@Failure		404		{object}	problem							"OIDC login is not configured"
+//	@Failure		500		{object}	problem							"Internal error"
+//	@Router			/auth/oidc/callback [get]
+//	@Tags			Auth
+func (r *authRoutes) Callback(ctx *gin.Context) {
+	var params authservice.CallbackParams
+
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding query", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	// state is one-time, so the cookie is removed regardless of the result
+	expectedState, _ := ctx.Cookie(stateCookie)
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(stateCookie, "", -1, stateCookiePath, "", ctx.Request.TLS != nil, true)
+
+	resp, err := r.auth.Callback(ctx, params, expectedState)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error finishing oidc login", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
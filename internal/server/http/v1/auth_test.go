@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	authservice "github.com/romandnk/todo/internal/service/auth"
+	mock_service "github.com/romandnk/todo/internal/service/mock"
+	"github.com/romandnk/todo/pkg/currentuser"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMW_Token(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		username             string
+		authorization        string
+		serviceM             func(m *mock_service.MockAuth)
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name:                 "without token",
+			username:             "ivan",
+			expectedResponseBody: "ivan",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:          "token replaces username header",
+			username:      "ivan",
+			authorization: "Bearer eyJ.eyJ.sig",
+			serviceM: func(m *mock_service.MockAuth) {
+				m.EXPECT().Authenticate(gomock.Any(), "eyJ.eyJ.sig").Return("petr", nil)
+			},
+			expectedResponseBody: "petr",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:                 "api key is skipped",
+			authorization:        "Bearer todo_key",
+			expectedResponseBody: "",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer eyJ.eyJ.sig",
+			serviceM: func(m *mock_service.MockAuth) {
+				m.EXPECT().Authenticate(gomock.Any(), "eyJ.eyJ.sig").Return("", constant.ErrInvalidToken)
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error authenticating bearer token", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"bearer token is invalid or expired","instance":"/api/v1/tasks","code":"invalid_token","field":"Authorization"}`,
+			expectedHTTPCode:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			log := mock_logger.NewMockLogger(ctrl)
+			if tc.loggerM != nil {
+				tc.loggerM(log)
+			}
+			auth := mock_service.NewMockAuth(ctrl)
+			if tc.serviceM != nil {
+				tc.serviceM(auth)
+			}
+
+			mw := NewMiddlewares(log, nil, nil, nil, auth, nil, nil, nil)
+
+			router := gin.New()
+			router.ContextWithFallback = true
+			router.GET("/api/v1/tasks", mw.User(), mw.Token(), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, currentuser.FromContext(ctx))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+			if tc.username != "" {
+				req.Header.Set(usernameHeader, tc.username)
+			}
+			if tc.authorization != "" {
+				req.Header.Set(authorizationHeader, tc.authorization)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestAuthRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authService := mock_service.NewMockAuth(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+
+	authService.EXPECT().Login(gomock.Any()).
+		Return(authservice.LoginResponse{URL: "https://idp.example.com/authorize?state=abc", State: "abc"}, nil)
+	authService.EXPECT().Callback(gomock.Any(), authservice.CallbackParams{Code: "code", State: "abc"}, "abc").
+		Return(authservice.CallbackResponse{Username: "petr", IDToken: "id"}, nil)
+
+	r := gin.New()
+	newAuthRoutes(r.Group("/api/v1/auth/oidc"), authService, logger)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/auth/oidc/login", nil)
+	require.NoError(t, err)
+
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusFound, w.Code)
+	require.Equal(t, "https://idp.example.com/authorize?state=abc", w.Header().Get("Location"))
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, stateCookie, cookies[0].Name)
+	require.Equal(t, "abc", cookies[0].Value)
+	require.True(t, cookies[0].HttpOnly)
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/auth/oidc/callback?code=code&state=abc", nil)
+	require.NoError(t, err)
+	req.AddCookie(cookies[0])
+
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `{"username":"petr","id_token":"id"}`, w.Body.String())
+	require.Equal(t, -1, w.Result().Cookies()[0].MaxAge)
+}
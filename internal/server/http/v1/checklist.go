@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/service"
+	checklistservice "github.com/romandnk/todo/internal/service/checklist"
+	"github.com/romandnk/todo/pkg/logger"
+	"net/http"
+)
+
+type checklistRoutes struct {
+	checklist service.Checklist
+	logger    logger.Logger
+}
+
+func newChecklistRoutes(g *gin.RouterGroup, checklist service.Checklist, logger logger.Logger) {
+	r := &checklistRoutes{
+		checklist: checklist,
+		logger:    logger,
+	}
+
+	g.POST("/", r.AddChecklistItem)
+	g.GET("/", r.GetChecklist)
+	g.PUT("/order", r.ReorderChecklist)
+	g.POST("/:item_id/toggle", r.ToggleChecklistItem)
+	g.DELETE("/:item_id", r.DeleteChecklistItemByID)
+}
+
+// AddChecklistItem
+//
+//	@Summary		Add checklist item
+//	@Description	Add not done item to the end of the task checklist.
+//	@UUID			600
+//	@Param			id				path		int										true	"Task id"
+//	@Param			params			body		checklistservice.AddChecklistItemParams	true	"JSON body with item text"
+//	@Param			Idempotency-Key	header		string									false	"Unique key to retry request safely"
+//	@Param			Time-Zone		header		string									false	"IANA time zone of dates, UTC by default"
+//	@Success		201				{object}	checklistservice.ChecklistItemModel		"Checklist item was added successfully"
+//	@Failure		400				{object}	problem									"Invalid input data"
+//	@Failure		404				{object}	problem									"Task is not found"
+//	@Failure		500				{object}	problem									"Internal error"
+//	@Router			/tasks/:id/checklist/ [post]
+//	@Tags			Checklist
+func (r *checklistRoutes) AddChecklistItem(ctx *gin.Context) {
+	var params checklistservice.AddChecklistItemParams
+
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	taskID := ctx.Param("id")
+
+	resp, err := r.checklist.AddChecklistItem(ctx, taskID, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error adding checklist item",
+			logger.Error(err),
+			logger.String("task id", taskID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetChecklist
+//
+//	@Summary		Get task checklist
+//	@Description	Get task checklist items in their order with completion percentage.
+//	@UUID			601
+//	@Param			id			path		int										true	"Task id"
+//	@Param			Time-Zone	header		string									false	"IANA time zone of dates, UTC by default"
+//	@Success		200			{object}	checklistservice.GetChecklistResponse	"Checklist was gotten successfully"
+//	@Failure		400			{object}	problem									"Invalid input data"
+//	@Failure		404			{object}	problem									"Task is not found"
+//	@Failure		500			{object}	problem									"Internal error"
+//	@Router			/tasks/:id/checklist/ [get]
+//	@Tags			Checklist
+func (r *checklistRoutes) GetChecklist(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+
+	resp, err := r.checklist.GetChecklist(ctx, taskID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting checklist",
+			logger.Error(err),
+			logger.String("task id", taskID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ReorderChecklist
+//
+//	@Summary		Reorder checklist
+//	@Description	Place checklist items in order of ids. Ids must contain every item of the task once.
+//	@UUID			602
+//	@Param			id			path		int										true	"Task id"
+//	@Param			params		body		checklistservice.ReorderChecklistParams	true	"JSON body with item ids in the new order"
+//	@Param			Time-Zone	header		string									false	"IANA time zone of dates, UTC by default"
+//	@Success		200			{object}	checklistservice.GetChecklistResponse	"Checklist was reordered successfully"
+//	@Failure		400			{object}	problem									"Invalid input data"
+//	@Failure		404			{object}	problem									"Task is not found"
+//	@Failure		500			{object}	problem									"Internal error"
+//	@Router			/tasks/:id/checklist/order [put]
+//	@Tags			Checklist
+func (r *checklistRoutes) ReorderChecklist(ctx *gin.Context) {
+	var params checklistservice.ReorderChecklistParams
+
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	taskID := ctx.Param("id")
+
+	resp, err := r.checklist.ReorderChecklist(ctx, taskID, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error reordering checklist",
+			logger.Error(err),
+			logger.String("task id", taskID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ToggleChecklistItem
+//
+//	@Summary		Toggle checklist item
+//	@Description	Mark not done item as done and done item as not done.
+//	@UUID			603
+//	@Param			id			path		int									true	"Task id"
+//	@Param			item_id		path		int									true	"Checklist item id"
+//	@Param			Time-Zone	header		string								false	"IANA time zone of dates, UTC by default"
+//	@Success		200			{object}	checklistservice.ChecklistItemModel	"Checklist item was toggled successfully"
+//	@Failure		400			{object}	problem								"Invalid input data"
+//	@Failure		404			{object}	problem								"Checklist item is not found"
+//	@Failure		500			{object}	problem								"Internal error"
+//	@Router			/tasks/:id/checklist/:item_id/toggle [post]
+//	@Tags			Checklist
+func (r *checklistRoutes) ToggleChecklistItem(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+	id := ctx.Param("item_id")
+
+	resp, err := r.checklist.ToggleChecklistItem(ctx, taskID, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error toggling checklist item with id",
+			logger.Error(err),
+			logger.String("task id", taskID),
+			logger.String("checklist item id", id))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteChecklistItemByID
+//
+//	@Summary		Delete checklist item by ID
+//	@Description	Delete item from the task checklist.
+//	@UUID			604
+//	@Param			id		path		int		true	"Task id"
+//	@Param			item_id	path		int		true	"Checklist item id"
+//	@Success		200		{object}	nil		"Checklist item was deleted successfully"
+//	@Failure		400		{object}	problem	"Invalid input data"
+//	@Failure		404		{object}	problem	"Checklist item is not found"
+//	@Failure		500		{object}	problem	"Internal error"
+//	@Router			/tasks/:id/checklist/:item_id [delete]
+//	@Tags			Checklist
+func (r *checklistRoutes) DeleteChecklistItemByID(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+	id := ctx.Param("item_id")
+
+	err := r.checklist.DeleteChecklistItemByID(ctx, taskID, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error deleting checklist item with id",
+			logger.Error(err),
+			logger.String("task id", taskID),
+			logger.String("checklist item id", id))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
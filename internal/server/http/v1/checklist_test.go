@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	checklistservice "github.com/romandnk/todo/internal/service/checklist"
+	mock_service "github.com/romandnk/todo/internal/service/mock"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChecklistRoutes_ReorderChecklist(t *testing.T) {
+	route := "/api/v1/tasks/:id/checklist/order"
+	url := "/api/v1/tasks/1/checklist/order"
+
+	testCases := []struct {
+		name                 string
+		requestBody          string
+		checklistM           func(m *mock_service.MockChecklist)
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name:        "OK",
+			requestBody: `{"ids":[2,1]}`,
+			checklistM: func(m *mock_service.MockChecklist) {
+				m.EXPECT().ReorderChecklist(gomock.Any(), "1", checklistservice.ReorderChecklistParams{IDs: []int{2, 1}}).
+					Return(checklistservice.GetChecklistResponse{
+						Total:      2,
+						Done:       1,
+						Completion: 50,
+						Items: []checklistservice.ChecklistItemModel{
+							{ID: 2, TaskID: 1, Text: "pack", Done: true, Position: 1, CreatedAt: "2030-01-02T10:00:00Z"},
+							{ID: 1, TaskID: 1, Text: "buy tickets", Done: false, Position: 2, CreatedAt: "2030-01-02T09:00:00Z"},
+						},
+					}, nil)
+			},
+			expectedResponseBody: `{"total":2,"done":1,"completion":50,"items":[{"id":2,"task_id":1,"text":"pack","done":true,"position":1,"created_at":"2030-01-02T10:00:00Z"},{"id":1,"task_id":1,"text":"buy tickets","done":false,"position":2,"created_at":"2030-01-02T09:00:00Z"}]}`,
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:        "ids don't match task items",
+			requestBody: `{"ids":[2]}`,
+			checklistM: func(m *mock_service.MockChecklist) {
+				m.EXPECT().ReorderChecklist(gomock.Any(), "1", checklistservice.ReorderChecklistParams{IDs: []int{2}}).
+					Return(checklistservice.GetChecklistResponse{}, constant.ErrInvalidChecklistOrder)
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error reordering checklist", gomock.Any(), gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"ids must contain every checklist item id of the task once","instance":"/api/v1/tasks/1/checklist/order","code":"invalid_checklist_order","field":"ids"}`,
+			expectedHTTPCode:     http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			checklistService := mock_service.NewMockChecklist(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			if tc.checklistM != nil {
+				tc.checklistM(checklistService)
+			}
+
+			if tc.loggerM != nil {
+				tc.loggerM(logger)
+			}
+
+			checklistR := checklistRoutes{
+				checklist: checklistService,
+				logger:    logger,
+			}
+
+			r := gin.Default()
+			r.PUT(route, checklistR.ReorderChecklist)
+
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPut, url, bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestChecklistRoutes_ToggleChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	checklistService := mock_service.NewMockChecklist(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+
+	checklistService.EXPECT().ToggleChecklistItem(gomock.Any(), "1", "2").
+		Return(checklistservice.ChecklistItemModel{ID: 2, TaskID: 1, Text: "pack", Done: true, Position: 1, CreatedAt: "2030-01-02T10:00:00Z"}, nil)
+
+	checklistR := checklistRoutes{
+		checklist: checklistService,
+		logger:    logger,
+	}
+
+	r := gin.Default()
+	r.POST("/api/v1/tasks/:id/checklist/:item_id/toggle", checklistR.ToggleChecklistItem)
+
+	w := httptest.NewRecorder()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/tasks/1/checklist/2/toggle", nil)
+	require.NoError(t, err)
+
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `{"id":2,"task_id":1,"text":"pack","done":true,"position":1,"created_at":"2030-01-02T10:00:00Z"}`, w.Body.String())
+}
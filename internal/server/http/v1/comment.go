@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/service"
+	commentservice "github.com/romandnk/todo/internal/service/comment"
+	"github.com/romandnk/todo/pkg/logger"
+	"net/http"
+)
+
+type commentRoutes struct {
+	comment service.Comment
+	logger  logger.Logger
+}
+
+func newCommentRoutes(g *gin.RouterGroup, comment service.Comment, logger logger.Logger) {
+	r := &commentRoutes{
+		comment: comment,
+		logger:  logger,
+	}
+
+	g.POST("/", r.CreateComment)
+	g.GET("/", r.GetComments)
+	g.PATCH("/:comment_id", r.UpdateCommentByID)
+	g.DELETE("/:comment_id", r.DeleteCommentByID)
+}
+
+// CreateComment
+//
+//	@Summary		Create comment
+//	@Description	Add comment of the request author to the task. Timestamps are in Time-Zone time zone.
+//	@UUID			400
+//	@Param			id				path		int									true	"Task id"
+//	@Param			params			body		commentservice.CreateCommentParams	true	"JSON body with comment body"
+//	@Param			Idempotency-Key	header		string								false	"Unique key to retry request safely"
+//	@Param			Time-Zone		header		string								false	"IANA time zone of dates, UTC by default"
+//	@Success		201				{object}	commentservice.CommentModel			"Comment was created successfully"
+//	@Failure		400				{object}	problem								"Invalid input data"
+//	@Failure		404				{object}	problem								"Task is not found"
+//	@Failure		500				{object}	problem								"Internal error"
+//	@Router			/tasks/:id/comments/ [post]
+//	@Tags			Comment
+func (r *commentRoutes) CreateComment(ctx *gin.Context) {
+	var params commentservice.CreateCommentParams
+
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	taskID := ctx.Param("id")
+
+	resp, err := r.comment.CreateComment(ctx, taskID, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error creating comment",
+			logger.Error(err),
+			logger.String("task id", taskID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetComments
+//
+//	@Summary		Get task comments
+//	@Description	Get task comments in creation order with pagination by limit and last comment id, total is the number of all task comments.
+//	@UUID			401
+//	@Param			id			path		int									true	"Task id"
+//	@Param			limit		query		int									false	"comments limit on the page"
+//	@Param			last-id		query		int									false	"last comment id for getting next page"
+//	@Param			Time-Zone	header		string								false	"IANA time zone of dates, UTC by default"
+//	@Success		200			{object}	commentservice.GetCommentsResponse	"Comments were gotten successfully"
+//	@Failure		400			{object}	problem								"Invalid input data"
+//	@Failure		404			{object}	problem								"Task is not found"
+//	@Failure		500			{object}	problem								"Internal error"
+//	@Router			/tasks/:id/comments/ [get]
+//	@Tags			Comment
+func (r *commentRoutes) GetComments(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+	limit := ctx.Query("limit")
+	lastID := ctx.Query("last-id")
+
+	resp, err := r.comment.GetComments(ctx, taskID, limit, lastID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting comments",
+			logger.Error(err),
+			logger.String("task id", taskID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdateCommentByID
+//
+//	@Summary		Update comment by ID
+//	@Description	Edit comment body, only author or workspace admin can do it.
+//	@UUID			402
+//	@Param			id			path		int									true	"Task id"
+//	@Param			comment_id	path		int									true	"Comment id"
+//	@Param			params		body		commentservice.UpdateCommentParams	true	"JSON body with new comment body"
+//	@Param			Time-Zone	header		string								false	"IANA time zone of dates, UTC by default"
+//	@Success		200			{object}	commentservice.CommentModel			"Comment was updated successfully"
+//	@Failure		400			{object}	problem								"Invalid input data"
+//	@Failure		403			{object}	problem								"Not author of the comment"
+//	@Failure		404			{object}	problem								"Comment is not found"
+//	@Failure		500			{object}	problem								"Internal error"
+//	@Router			/tasks/:id/comments/:comment_id [patch]
+//	@Tags			Comment
+func (r *commentRoutes) UpdateCommentByID(ctx *gin.Context) {
+	var params commentservice.UpdateCommentParams
+
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	taskID := ctx.Param("id")
+	id := ctx.Param("comment_id")
+
+	resp, err := r.comment.UpdateCommentByID(ctx, taskID, id, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error updating comment with id",
+			logger.Error(err),
+			logger.String("task id", taskID),
+			logger.String("comment id", id))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteCommentByID
+//
+//	@Summary		Delete comment by ID
+//	@Description	Delete task comment by its id, only author or workspace admin can do it.
+//	@UUID			403
+//	@Param			id			path		int		true	"Task id"
+//	@Param			comment_id	path		int		true	"Comment id"
+//	@Success		200			{object}	nil		"Comment was deleted successfully"
+//	@Failure		400			{object}	problem	"Invalid input data"
+//	@Failure		403			{object}	problem	"Not author of the comment"
+//	@Failure		404			{object}	problem	"Comment is not found"
+//	@Failure		500			{object}	problem	"Internal error"
+//	@Router			/tasks/:id/comments/:comment_id [delete]
+//	@Tags			Comment
+func (r *commentRoutes) DeleteCommentByID(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+	id := ctx.Param("comment_id")
+
+	err := r.comment.DeleteCommentByID(ctx, taskID, id)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error deleting comment with id",
+			logger.Error(err),
+			logger.String("task id", taskID),
+			logger.String("comment id", id))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,219 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	commentservice "github.com/romandnk/todo/internal/service/comment"
+	mock_service "github.com/romandnk/todo/internal/service/mock"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCommentRoutes_CreateComment(t *testing.T) {
+	route := "/api/v1/tasks/:id/comments"
+	url := "/api/v1/tasks/1/comments"
+
+	testCases := []struct {
+		name                 string
+		requestBody          string
+		commentM             func(m *mock_service.MockComment)
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name:        "OK",
+			requestBody: `{"body":"Call before noon"}`,
+			commentM: func(m *mock_service.MockComment) {
+				m.EXPECT().CreateComment(gomock.Any(), "1", commentservice.CreateCommentParams{Body: "Call before noon"}).
+					Return(commentservice.CommentModel{
+						ID:        2,
+						TaskID:    1,
+						Author:    "ivan",
+						Body:      "Call before noon",
+						CreatedAt: "2030-01-02T10:00:00Z",
+						UpdatedAt: "2030-01-02T10:00:00Z",
+					}, nil)
+			},
+			expectedResponseBody: `{"id":2,"task_id":1,"author":"ivan","body":"Call before noon","created_at":"2030-01-02T10:00:00Z","updated_at":"2030-01-02T10:00:00Z"}`,
+			expectedHTTPCode:     http.StatusCreated,
+		},
+		{
+			name:        "task not found",
+			requestBody: `{"body":"Call before noon"}`,
+			commentM: func(m *mock_service.MockComment) {
+				m.EXPECT().CreateComment(gomock.Any(), "1", commentservice.CreateCommentParams{Body: "Call before noon"}).
+					Return(commentservice.CommentModel{}, constant.ErrTaskNotFound.WithMessage("task with id '1' is not found"))
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error creating comment", gomock.Any(), gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"task with id '1' is not found","instance":"/api/v1/tasks/1/comments","code":"task_not_found"}`,
+			expectedHTTPCode:     http.StatusNotFound,
+		},
+		{
+			name:        "empty body",
+			requestBody: `{}`,
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error binding json body", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Key: 'CreateCommentParams.Body' Error:Field validation for 'Body' failed on the 'required' tag","instance":"/api/v1/tasks/1/comments","code":"invalid_request_body"}`,
+			expectedHTTPCode:     http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			commentService := mock_service.NewMockComment(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			if tc.commentM != nil {
+				tc.commentM(commentService)
+			}
+
+			if tc.loggerM != nil {
+				tc.loggerM(logger)
+			}
+
+			commentR := commentRoutes{
+				comment: commentService,
+				logger:  logger,
+			}
+
+			r := gin.Default()
+			r.POST(route, commentR.CreateComment)
+
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestCommentRoutes_GetComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	commentService := mock_service.NewMockComment(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+
+	commentService.EXPECT().GetComments(gomock.Any(), "1", "1", "2").
+		Return(commentservice.GetCommentsResponse{
+			Total: 1,
+			Comments: []commentservice.CommentModel{
+				{
+					ID:        3,
+					TaskID:    1,
+					Author:    "petr",
+					Body:      "Done",
+					CreatedAt: "2030-01-02T10:00:00Z",
+					UpdatedAt: "2030-01-02T11:00:00Z",
+				},
+			},
+		}, nil)
+
+	commentR := commentRoutes{
+		comment: commentService,
+		logger:  logger,
+	}
+
+	r := gin.Default()
+	r.GET("/api/v1/tasks/:id/comments", commentR.GetComments)
+
+	w := httptest.NewRecorder()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/tasks/1/comments?limit=1&last-id=2", nil)
+	require.NoError(t, err)
+
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `{"total":1,"comments":[{"id":3,"task_id":1,"author":"petr","body":"Done","created_at":"2030-01-02T10:00:00Z","updated_at":"2030-01-02T11:00:00Z"}]}`, w.Body.String())
+}
+
+func TestCommentRoutes_DeleteCommentByID(t *testing.T) {
+	route := "/api/v1/tasks/:id/comments/:comment_id"
+	url := "/api/v1/tasks/1/comments/2"
+
+	testCases := []struct {
+		name                 string
+		commentM             func(m *mock_service.MockComment)
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name: "OK",
+			commentM: func(m *mock_service.MockComment) {
+				m.EXPECT().DeleteCommentByID(gomock.Any(), "1", "2").Return(nil)
+			},
+			expectedHTTPCode: http.StatusOK,
+		},
+		{
+			name: "comment not found",
+			commentM: func(m *mock_service.MockComment) {
+				m.EXPECT().DeleteCommentByID(gomock.Any(), "1", "2").
+					Return(constant.ErrCommentNotFound.WithMessage("comment with id '2' is not found"))
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error deleting comment with id", gomock.Any(), gomock.Any(), gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"comment with id '2' is not found","instance":"/api/v1/tasks/1/comments/2","code":"comment_not_found"}`,
+			expectedHTTPCode:     http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			commentService := mock_service.NewMockComment(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			if tc.commentM != nil {
+				tc.commentM(commentService)
+			}
+
+			if tc.loggerM != nil {
+				tc.loggerM(logger)
+			}
+
+			commentR := commentRoutes{
+				comment: commentService,
+				logger:  logger,
+			}
+
+			r := gin.Default()
+			r.DELETE(route, commentR.DeleteCommentByID)
+
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
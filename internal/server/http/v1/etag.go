@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"strconv"
+	"strings"
+)
+
+// formatETag returns strong entity tag of the task version
+func formatETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// setTaskETag sets entity tag of the task version, dates of the same version are rendered in the request
+// time zone and the task is visible only to members of the workspace, so the response varies by their headers
+func setTaskETag(ctx *gin.Context, version int) {
+	ctx.Header("ETag", formatETag(version))
+	ctx.Header("Vary", listVary)
+}
+
+// parseIfMatch returns task version from If-Match header, zero means any version
+func parseIfMatch(header string) (int, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	if len(header) < 3 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return 0, constant.ErrInvalidIfMatch
+	}
+
+	version, err := strconv.Atoi(header[1 : len(header)-1])
+	if err != nil || version <= 0 {
+		return 0, constant.ErrInvalidIfMatch
+	}
+
+	return version, nil
+}
+
+// etagMatches reports whether If-None-Match header has the entity tag, tags are compared weakly
+func etagMatches(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return true
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/service"
+	feedservice "github.com/romandnk/todo/internal/service/feed"
+	taskservice "github.com/romandnk/todo/internal/service/task"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/tenant"
+	"net/http"
+)
+
+type feedRoutes struct {
+	feed   service.Feed
+	task   service.Task
+	logger logger.Logger
+}
+
+// newFeedRoutes registers feed routes, calendar is public and found by the token alone,
+// feeds are managed in the workspace checked by access middlewares
+func newFeedRoutes(g *gin.RouterGroup, feed service.Feed, task service.Task, logger logger.Logger, access ...gin.HandlerFunc) {
+	r := &feedRoutes{
+		feed:   feed,
+		task:   task,
+		logger: logger,
+	}
+
+	g.GET("/:token/calendar.ics", r.GetFeedCalendar)
+
+	managed := g.Group("", access...)
+	managed.POST("/", r.CreateFeed)
+	managed.DELETE("/:token", r.DeleteFeed)
+}
+
+// CreateFeed
+//
+//	@Summary		Create calendar feed
+//	@Description	Create iCalendar feed of tasks with stable tokenized URL.
+//	@UUID			300
+//	@Param			params	body		feedservice.CreateFeedParams	true	"JSON body with optional status name filter and VTODO or VEVENT component"
+//	@Success		201		{object}	feedservice.CreateFeedResponse	"Feed was created successfully"
+//	@Failure		400		{object}	problem							"Invalid input data"
+//	@Failure		500		{object}	problem							"Internal error"
+//	@Router			/feeds/ [post]
+//	@Tags			Feed
+func (r *feedRoutes) CreateFeed(ctx *gin.Context) {
+	var params feedservice.CreateFeedParams
+
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	resp, err := r.feed.CreateFeed(ctx, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error creating feed", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetFeedCalendar
+//
+//	@Summary		Get calendar feed
+//	@Description	Get iCalendar document with feed tasks.
+//	@UUID			301
+//	@Produce		text/calendar
+//	@Param			token	path		string	true	"Feed token"
+//	@Success		200		{file}		file	"Calendar was generated successfully"
+//	@Failure		400		{object}	problem	"Invalid input data"
+//	@Failure		404		{object}	problem	"Feed is not found"
+//	@Failure		500		{object}	problem	"Internal error"
+//	@Router			/feeds/:token/calendar.ics [get]
+//	@Tags			Feed
+func (r *feedRoutes) GetFeedCalendar(ctx *gin.Context) {
+	token := ctx.Param("token")
+
+	feed, err := r.feed.GetFeed(ctx, token)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting feed", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	contentType, err := taskservice.ExportContentType(feed.Format)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting export content type", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInternalError)
+		return
+	}
+
+	ctx.Request = ctx.Request.WithContext(tenant.WithWorkspace(ctx.Request.Context(), feed.WorkspaceID, ""))
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Status(http.StatusOK)
+
+	err = r.task.ExportTasks(ctx, ctx.Writer, feed.Format, taskservice.TaskFilterParams{StatusName: feed.StatusName})
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error exporting feed tasks", logger.Error(err))
+		if ctx.Writer.Written() {
+			ctx.Abort()
+			return
+		}
+		ctx.Writer.Header().Del("Content-Type")
+		sentErrorResponse(ctx, err)
+		return
+	}
+}
+
+// DeleteFeed
+//
+//	@Summary		Delete calendar feed
+//	@Description	Revoke feed token.
+//	@UUID			302
+//	@Param			token	path		string	true	"Feed token"
+//	@Success		200		{object}	nil		"Feed was deleted successfully"
+//	@Failure		400		{object}	problem	"Invalid input data"
+//	@Failure		404		{object}	problem	"Feed is not found"
+//	@Failure		500		{object}	problem	"Internal error"
+//	@Router			/feeds/:token [delete]
+//	@Tags			Feed
+func (r *feedRoutes) DeleteFeed(ctx *gin.Context) {
+	token := ctx.Param("token")
+
+	err := r.feed.DeleteFeed(ctx, token)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error deleting feed", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -3,8 +3,10 @@ package v1
 import (
 	"github.com/gin-gonic/gin"
 	docs "github.com/romandnk/todo/docs"
+	"github.com/romandnk/todo/internal/entity"
 	"github.com/romandnk/todo/internal/service"
 	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/metrics"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -14,36 +16,113 @@ type Handler struct {
 	services *service.Services
 	logger   logger.Logger
 	mw       *MW
+	metrics  *metrics.Metrics
+	settings Settings
 }
 
-func NewHandler(services *service.Services, logger logger.Logger, mw *MW) *Handler {
+type Settings struct {
+	// UsernameHeader takes the request author from Username header without authentication, for development only
+	UsernameHeader bool
+	// AttachmentMaxSize is the max attachment size in bytes, it limits upload request body
+	AttachmentMaxSize int64
+}
+
+func NewHandler(services *service.Services, logger logger.Logger, mw *MW, metrics *metrics.Metrics, settings Settings) *Handler {
 	return &Handler{
 		services: services,
 		logger:   logger,
 		mw:       mw,
+		metrics:  metrics,
+		settings: settings,
 	}
 }
 
 func (h *Handler) InitRoutes() *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
+	// request context values such as time zone are available through gin context
+	router.ContextWithFallback = true
 	h.engine = router
 
 	docs.SwaggerInfo.BasePath = "/api/v1"
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
-	api := router.Group("/api/v1", h.mw.Logging())
+	// prometheus metrics
+	router.GET("/metrics", gin.WrapH(h.metrics.Handler()))
+
+	// liveness and readiness probes
+	newHealthRoutes(&router.RouterGroup, h.services.Health, h.logger)
+
+	middlewares := []gin.HandlerFunc{h.mw.RequestID(), h.mw.Tracing(), h.mw.Metrics(), h.mw.Logging(), h.mw.Timezone(), h.mw.APIKey(), h.mw.Token()}
+	// request author is authenticated by API key or OIDC token, Username header is trusted only in development
+	if h.settings.UsernameHeader {
+		middlewares = append(middlewares, h.mw.User())
+	}
+
+	// idempotency goes last in every group, so errors of authorization and rate limit are not stored for replay.
+	// Tasks group reads imported documents and attachments, other groups read JSON bodies
+	taskBodySize := max(maxImportSize, h.settings.AttachmentMaxSize+multipartOverhead)
+	api := router.Group("/api/v1", middlewares...)
 	{
+		// login with identity provider
+		auth := api.Group("/auth/oidc", h.mw.RateLimit("auth"), h.mw.Idempotency(maxRequestSize))
+		{
+			newAuthRoutes(auth, h.services.Auth, h.logger)
+		}
+
+		// workspaces are managed by their members regardless of Workspace-ID header
+		workspaces := api.Group("/workspaces", h.mw.RateLimit("workspaces"), h.mw.Idempotency(maxRequestSize))
+		{
+			newWorkspaceRoutes(workspaces, h.services.Workspace, h.logger)
+		}
+
+		// api keys of machine clients are managed by workspace admins
+		apiKeys := api.Group("/api-keys", h.mw.RateLimit("api-keys"), h.mw.Workspace(), h.mw.Authorize(entity.PermMembersAdmin, entity.PermMembersAdmin), h.mw.Idempotency(maxRequestSize))
+		{
+			newAPIKeyRoutes(apiKeys, h.services.APIKey, h.logger)
+		}
+
 		// status management group
-		statuses := api.Group("/statuses")
+		statuses := api.Group("/statuses", h.mw.RateLimit("statuses"), h.mw.Workspace(), h.mw.Authorize(entity.PermTasksRead, entity.PermStatusesAdmin), h.mw.Idempotency(maxRequestSize))
 		{
 			newStatusRoutes(statuses, h.services.Status, h.logger)
 		}
 
 		// task management group
-		tasks := api.Group("tasks")
+		tasks := api.Group("tasks", h.mw.RateLimit("tasks"), h.mw.Workspace(), h.mw.Authorize(entity.PermTasksRead, entity.PermTasksWrite), h.mw.Idempotency(taskBodySize))
+		{
+			newTaskRoutes(tasks, h.services.Task, h.logger, h.mw.ListETag())
+
+			// task comments group
+			comments := tasks.Group("/:id/comments")
+			{
+				newCommentRoutes(comments, h.services.Comment, h.logger)
+			}
+
+			// task attachments group
+			attachments := tasks.Group("/:id/attachments")
+			{
+				newAttachmentRoutes(attachments, h.services.Attachment, h.logger, h.settings.AttachmentMaxSize)
+			}
+
+			// task checklist group
+			checklist := tasks.Group("/:id/checklist")
+			{
+				newChecklistRoutes(checklist, h.services.Checklist, h.logger)
+			}
+
+			// task assignees and watchers group
+			members := tasks.Group("/:id/members")
+			{
+				newMemberRoutes(members, h.services.Member, h.logger)
+			}
+		}
+
+		// calendar feeds group
+		feeds := api.Group("/feeds", h.mw.RateLimit("feeds"))
 		{
-			newTaskRoutes(tasks, h.services.Task, h.logger)
+			newFeedRoutes(feeds, h.services.Feed, h.services.Task, h.logger,
+				h.mw.Workspace(), h.mw.Authorize(entity.PermTasksRead, entity.PermTasksWrite), h.mw.Idempotency(maxRequestSize))
 		}
 	}
 
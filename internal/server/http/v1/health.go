@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/service"
+	healthservice "github.com/romandnk/todo/internal/service/health"
+	"github.com/romandnk/todo/pkg/logger"
+	"net/http"
+)
+
+type healthRoutes struct {
+	health service.Health
+	logger logger.Logger
+}
+
+// newHealthRoutes registers probes outside of api group, so they are not logged, limited or authorized
+func newHealthRoutes(g *gin.RouterGroup, health service.Health, logger logger.Logger) {
+	r := &healthRoutes{
+		health: health,
+		logger: logger,
+	}
+
+	g.GET("/healthz", r.Live)
+	g.GET("/readyz", r.Ready)
+}
+
+// Live reports the process is alive, dependencies are not checked
+func (r *healthRoutes) Live(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, healthservice.HealthResponse{Status: healthservice.StatusOK})
+}
+
+// Ready reports the app can serve requests, not ready app responds with 503
+func (r *healthRoutes) Ready(ctx *gin.Context) {
+	resp, ready := r.health.Ready(ctx)
+	if !ready {
+		// draining is the expected state of stopping app
+		if resp.Status != healthservice.StatusDraining {
+			r.logger.WarnContext(ctx, "app is not ready", logger.Any("checks", resp.Checks))
+		}
+		ctx.JSON(http.StatusServiceUnavailable, resp)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	healthservice "github.com/romandnk/todo/internal/service/health"
+	mock_service "github.com/romandnk/todo/internal/service/mock"
+	"github.com/romandnk/todo/pkg/logger"
+	recorderlogger "github.com/romandnk/todo/pkg/logger/recorder"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthRoutes(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		path                 string
+		serviceM             func(m *mock_service.MockHealth)
+		expectedResponseBody string
+		expectedHTTPCode     int
+		expectedLogEntries   []recorderlogger.Entry
+	}{
+		{
+			name:                 "alive",
+			path:                 "/healthz",
+			expectedResponseBody: `{"status":"ok"}`,
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name: "ready",
+			path: "/readyz",
+			serviceM: func(m *mock_service.MockHealth) {
+				m.EXPECT().Ready(gomock.Any()).Return(healthservice.HealthResponse{
+					Status: healthservice.StatusReady,
+					Checks: map[string]string{"postgres": "ok", "migrations": "ok"},
+				}, true)
+			},
+			expectedResponseBody: `{"status":"ready","checks":{"migrations":"ok","postgres":"ok"}}`,
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name: "not ready",
+			path: "/readyz",
+			serviceM: func(m *mock_service.MockHealth) {
+				m.EXPECT().Ready(gomock.Any()).Return(healthservice.HealthResponse{
+					Status: healthservice.StatusNotReady,
+					Checks: map[string]string{"postgres": "unavailable", "migrations": "unavailable"},
+				}, false)
+			},
+			expectedResponseBody: `{"status":"not ready","checks":{"migrations":"unavailable","postgres":"unavailable"}}`,
+			expectedHTTPCode:     http.StatusServiceUnavailable,
+			expectedLogEntries: []recorderlogger.Entry{
+				{
+					Level:   logger.WarnLevel,
+					Message: "app is not ready",
+					Fields: []logger.Field{
+						logger.Any("checks", map[string]string{"postgres": "unavailable", "migrations": "unavailable"}),
+					},
+				},
+			},
+		},
+		{
+			name: "draining",
+			path: "/readyz",
+			serviceM: func(m *mock_service.MockHealth) {
+				m.EXPECT().Ready(gomock.Any()).Return(healthservice.HealthResponse{Status: healthservice.StatusDraining}, false)
+			},
+			expectedResponseBody: `{"status":"draining"}`,
+			expectedHTTPCode:     http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			health := mock_service.NewMockHealth(ctrl)
+			if tc.serviceM != nil {
+				tc.serviceM(health)
+			}
+
+			log := recorderlogger.NewLogger()
+
+			router := gin.New()
+			newHealthRoutes(&router.RouterGroup, health, log)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+			require.Equal(t, tc.expectedLogEntries, log.Entries())
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	idempotencyservice "github.com/romandnk/todo/internal/service/idempotency"
+	"github.com/romandnk/todo/pkg/logger"
+	"io"
+	"net/http"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	idempotencyErrorMessage  = "error checking idempotency key"
+	// maxRequestSize is the max size of JSON request body kept for the idempotency key
+	maxRequestSize = 1 << 20
+)
+
+// idempotentMethods are methods which responses are stored for Idempotency-Key header
+var idempotentMethods = map[string]struct{}{
+	http.MethodPost:   {},
+	http.MethodPatch:  {},
+	http.MethodDelete: {},
+}
+
+// responseRecorder copies response body to buffer
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency replays stored response for the retried request with the same Idempotency-Key header.
+// It goes right before the handler, after authorization and rate limit, so only responses of the handler are stored.
+// Body is hashed before the handler, so it is read to memory up to maxBodySize bytes of the routes
+func (m *MW) Idempotency(maxBodySize int64) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(idempotencyKeyHeader)
+		if _, ok := idempotentMethods[ctx.Request.Method]; !ok || key == "" {
+			ctx.Next()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBodySize))
+		if err != nil {
+			m.logger.ErrorContext(ctx, "error reading request body", logger.Error(err))
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				sentErrorResponse(ctx, constant.ErrTooLargeRequestBody.WithMessage(fmt.Sprintf("max request body size is %d bytes", maxBodySize)))
+				return
+			}
+			sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		stored, replay, err := m.idempotency.Begin(ctx, key, requestHash(ctx.Request, body))
+		if err != nil {
+			m.logger.ErrorContext(ctx, idempotencyErrorMessage, logger.Error(err))
+			sentErrorResponse(ctx, err)
+			return
+		}
+
+		if replay {
+			for name, values := range stored.Headers {
+				ctx.Writer.Header()[name] = values
+			}
+			ctx.Header(idempotentReplayedHeader, "true")
+			ctx.Status(stored.StatusCode)
+			_, _ = ctx.Writer.Write(stored.Body)
+			ctx.Abort()
+			return
+		}
+
+		w := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = w
+
+		// response must be stored and key released even if client is gone
+		storeCtx := context.WithoutCancel(ctx.Request.Context())
+
+		// key of the panicked handler or not stored response would stay in progress until it expires
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			err := m.idempotency.Release(storeCtx, key)
+			if err != nil {
+				m.logger.ErrorContext(ctx, "error releasing idempotency key", logger.Error(err))
+			}
+		}()
+
+		ctx.Next()
+
+		err = m.idempotency.Complete(storeCtx, key, idempotencyservice.StoredResponse{
+			StatusCode: w.Status(),
+			Headers:    w.Header().Clone(),
+			Body:       w.body.Bytes(),
+		})
+		if err != nil {
+			m.logger.ErrorContext(ctx, "error storing idempotent response", logger.Error(err))
+			return
+		}
+		completed = true
+	}
+}
+
+// requestHash returns hash of the request method, path, principal and body. Keys are scoped
+// by the workspace and the principal, so credentials are not hashed and retry with refreshed token is replayed
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + "\n" + r.URL.RequestURI() + "\n"))
+	h.Write([]byte(idempotencyservice.Principal(r.Context()) + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
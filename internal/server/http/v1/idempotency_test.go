@@ -0,0 +1,211 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	idempotencyservice "github.com/romandnk/todo/internal/service/idempotency"
+	mock_service "github.com/romandnk/todo/internal/service/mock"
+	"github.com/romandnk/todo/pkg/currentuser"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMW_Idempotency(t *testing.T) {
+	url := "/api/v1/tasks"
+	requestBody := `{"title":"Test"}`
+
+	testCases := []struct {
+		name                 string
+		key                  string
+		body                 string
+		idempotencyM         func(m *mock_service.MockIdempotency)
+		loggerM              func(m *mock_logger.MockLogger)
+		handlerPanics        bool
+		expectedHandlerCalls int
+		expectedResponseBody string
+		expectedReplayed     string
+		expectedHTTPCode     int
+	}{
+		{
+			name:                 "without key",
+			expectedHandlerCalls: 1,
+			expectedResponseBody: `{"id":1}`,
+			expectedHTTPCode:     http.StatusCreated,
+		},
+		{
+			name: "first request",
+			key:  "key",
+			idempotencyM: func(m *mock_service.MockIdempotency) {
+				m.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).Return(idempotencyservice.StoredResponse{}, false, nil)
+				m.EXPECT().Complete(gomock.Any(), "key", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, response idempotencyservice.StoredResponse) error {
+						require.Equal(t, http.StatusCreated, response.StatusCode)
+						require.Equal(t, `{"id":1}`, string(response.Body))
+						require.Equal(t, []string{"application/json; charset=utf-8"}, response.Headers["Content-Type"])
+						return nil
+					})
+			},
+			expectedHandlerCalls: 1,
+			expectedResponseBody: `{"id":1}`,
+			expectedHTTPCode:     http.StatusCreated,
+		},
+		{
+			name: "response is not stored",
+			key:  "key",
+			idempotencyM: func(m *mock_service.MockIdempotency) {
+				m.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).Return(idempotencyservice.StoredResponse{}, false, nil)
+				m.EXPECT().Complete(gomock.Any(), "key", gomock.Any()).Return(constant.ErrInternalError)
+				m.EXPECT().Release(gomock.Any(), "key").Return(nil)
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error storing idempotent response", gomock.Any())
+			},
+			expectedHandlerCalls: 1,
+			expectedResponseBody: `{"id":1}`,
+			expectedHTTPCode:     http.StatusCreated,
+		},
+		{
+			name: "handler panics",
+			key:  "key",
+			idempotencyM: func(m *mock_service.MockIdempotency) {
+				m.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).Return(idempotencyservice.StoredResponse{}, false, nil)
+				m.EXPECT().Release(gomock.Any(), "key").Return(nil)
+			},
+			handlerPanics:        true,
+			expectedHandlerCalls: 1,
+		},
+		{
+			name: "retried request",
+			key:  "key",
+			idempotencyM: func(m *mock_service.MockIdempotency) {
+				m.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).Return(idempotencyservice.StoredResponse{
+					StatusCode: http.StatusCreated,
+					Headers:    map[string][]string{"Content-Type": {"application/json; charset=utf-8"}},
+					Body:       []byte(`{"id":1}`),
+				}, true, nil)
+			},
+			expectedResponseBody: `{"id":1}`,
+			expectedReplayed:     "true",
+			expectedHTTPCode:     http.StatusCreated,
+		},
+		{
+			name: "key reused with a different request",
+			key:  "key",
+			idempotencyM: func(m *mock_service.MockIdempotency) {
+				m.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).
+					Return(idempotencyservice.StoredResponse{}, false, constant.ErrIdempotencyKeyReused)
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), idempotencyErrorMessage, gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"idempotency key was already used with a different request","instance":"/api/v1/tasks","code":"idempotency_key_reused","field":"Idempotency-Key"}`,
+			expectedHTTPCode:     http.StatusUnprocessableEntity,
+		},
+		{
+			name: "too large body",
+			key:  "key",
+			body: `{"title":"` + strings.Repeat("a", 64) + `"}`,
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error reading request body", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"max request body size is 64 bytes","instance":"/api/v1/tasks","code":"too_large_request_body"}`,
+			expectedHTTPCode:     http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "request is in progress",
+			key:  "key",
+			idempotencyM: func(m *mock_service.MockIdempotency) {
+				m.EXPECT().Begin(gomock.Any(), "key", gomock.Any()).
+					Return(idempotencyservice.StoredResponse{}, false, constant.ErrIdempotencyKeyInProgress)
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), idempotencyErrorMessage, gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Conflict","status":409,"detail":"request with the idempotency key is still in progress","instance":"/api/v1/tasks","code":"idempotency_key_in_progress","field":"Idempotency-Key"}`,
+			expectedHTTPCode:     http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			idempotency := mock_service.NewMockIdempotency(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			if tc.idempotencyM != nil {
+				tc.idempotencyM(idempotency)
+			}
+
+			if tc.loggerM != nil {
+				tc.loggerM(logger)
+			}
+
+			mw := NewMiddlewares(logger, idempotency, nil, nil, nil, nil, nil, nil)
+
+			handlerCalls := 0
+			r := gin.New()
+			r.POST(url, mw.Idempotency(64), func(ctx *gin.Context) {
+				handlerCalls++
+				var body map[string]string
+				require.NoError(t, ctx.ShouldBindJSON(&body))
+				require.Equal(t, "Test", body["title"])
+				if tc.handlerPanics {
+					panic("handler failed")
+				}
+				ctx.JSON(http.StatusCreated, gin.H{"id": 1})
+			})
+
+			w := httptest.NewRecorder()
+
+			body := requestBody
+			if tc.body != "" {
+				body = tc.body
+			}
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewBufferString(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			if tc.key != "" {
+				req.Header.Set(idempotencyKeyHeader, tc.key)
+			}
+
+			if tc.handlerPanics {
+				require.Panics(t, func() { r.ServeHTTP(w, req) })
+				require.Equal(t, tc.expectedHandlerCalls, handlerCalls)
+				return
+			}
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedHandlerCalls, handlerCalls)
+			require.Equal(t, tc.expectedReplayed, w.Header().Get(idempotentReplayedHeader))
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestRequestHash(t *testing.T) {
+	first := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", nil)
+	second := httptest.NewRequest(http.MethodPatch, "/api/v1/tasks", nil)
+
+	require.Equal(t, requestHash(first, []byte("body")), requestHash(first, []byte("body")))
+	require.NotEqual(t, requestHash(first, []byte("body")), requestHash(first, []byte("other")))
+	require.NotEqual(t, requestHash(first, []byte("body")), requestHash(second, []byte("body")))
+
+	// refreshed token of the same user does not change the hash, another user does
+	user := first.WithContext(currentuser.WithAuthenticatedUsername(first.Context(), "ivan"))
+	refreshed := user.Clone(user.Context())
+	refreshed.Header.Set(authorizationHeader, "Bearer refreshed")
+	other := first.WithContext(currentuser.WithAuthenticatedUsername(first.Context(), "petr"))
+	require.Equal(t, requestHash(user, []byte("body")), requestHash(refreshed, []byte("body")))
+	require.NotEqual(t, requestHash(user, []byte("body")), requestHash(other, []byte("body")))
+}
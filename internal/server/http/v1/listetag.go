@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+// listCacheControl makes clients revalidate cached list with If-None-Match on every request,
+// lists vary by user and workspace headers, so shared caches must not keep them
+const listCacheControl = "private, no-cache"
+
+// listVary names request headers which select the user, the workspace and the dates of the list,
+// so private cache of the client does not reuse the list of other principal or time zone
+var listVary = strings.Join([]string{usernameHeader, workspaceHeader, authorizationHeader, timezoneHeader}, ", ")
+
+// bufferedWriter keeps response body until the handler returns
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+// ListETag sets entity tag hashed from successful list response, so client gets 304 without the body
+// for If-None-Match of not changed list
+func (m *MW) ListETag() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		w := &bufferedWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = w
+
+		ctx.Next()
+
+		ctx.Writer = w.ResponseWriter
+		if w.Status() != http.StatusOK {
+			_, _ = w.ResponseWriter.Write(w.body.Bytes())
+			return
+		}
+
+		sum := sha256.Sum256(w.body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+		ctx.Header("ETag", etag)
+		ctx.Header("Cache-Control", listCacheControl)
+		ctx.Header("Vary", listVary)
+		if etagMatches(ctx.GetHeader("If-None-Match"), etag) {
+			ctx.Status(http.StatusNotModified)
+			ctx.Writer.WriteHeaderNow()
+			return
+		}
+
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
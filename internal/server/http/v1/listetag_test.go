@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMW_ListETag(t *testing.T) {
+	mw := NewMiddlewares(nil, nil, nil, nil, nil, nil, nil, nil)
+
+	tasks := `{"tasks":[{"id":1}]}`
+	router := gin.New()
+	router.GET("/api/v1/tasks/", mw.ListETag(), func(ctx *gin.Context) {
+		if ctx.Query("limit") == "x" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"title": "invalid limit"})
+			return
+		}
+		ctx.Data(http.StatusOK, "application/json", []byte(tasks))
+	})
+
+	get := func(url, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/v1/tasks/", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, tasks, w.Body.String())
+	require.Equal(t, listCacheControl, w.Header().Get("Cache-Control"))
+	require.Equal(t, "Username, Workspace-ID, Authorization, Time-Zone", w.Header().Get("Vary"))
+	etag := w.Header().Get("ETag")
+	require.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+	w = get("/api/v1/tasks/", `"other", W/`+etag)
+	require.Equal(t, http.StatusNotModified, w.Code)
+	require.Empty(t, w.Body.String())
+	require.Equal(t, etag, w.Header().Get("ETag"))
+	require.Equal(t, listVary, w.Header().Get("Vary"))
+
+	w = get("/api/v1/tasks/", `"other"`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, tasks, w.Body.String())
+
+	w = get("/api/v1/tasks/?limit=x", etag)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, `{"title":"invalid limit"}`, w.Body.String())
+	require.Empty(t, w.Header().Get("ETag"))
+}
+
+func TestETagMatches(t *testing.T) {
+	require.True(t, etagMatches("*", `"1"`))
+	require.True(t, etagMatches(`"1"`, `"1"`))
+	require.True(t, etagMatches(` "2", W/"1"`, `"1"`))
+	require.False(t, etagMatches(`"2"`, `"1"`))
+	require.False(t, etagMatches("", `"1"`))
+}
@@ -0,0 +1,184 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/service"
+	memberservice "github.com/romandnk/todo/internal/service/member"
+	"github.com/romandnk/todo/pkg/logger"
+	"net/http"
+)
+
+type memberRoutes struct {
+	member service.Member
+	logger logger.Logger
+}
+
+func newMemberRoutes(g *gin.RouterGroup, member service.Member, logger logger.Logger) {
+	r := &memberRoutes{
+		member: member,
+		logger: logger,
+	}
+
+	g.GET("/", r.GetMembers)
+	g.POST("/assignees", r.AddAssignee)
+	g.DELETE("/assignees/:username", r.DeleteAssignee)
+	g.POST("/watchers", r.Watch)
+	g.DELETE("/watchers", r.Unwatch)
+}
+
+// GetMembers
+//
+//	@Summary		Get task members
+//	@Description	Get task assignees and watchers in order of their addition.
+//	@UUID			700
+//	@Param			id			path		int									true	"Task id"
+//	@Param			Time-Zone	header		string								false	"IANA time zone of dates, UTC by default"
+//	@Success		200			{object}	memberservice.GetMembersResponse	"Members were gotten successfully"
+//	@Failure		400			{object}	problem								"Invalid input data"
+//	@Failure		404			{object}	problem								"Task is not found"
+//	@Failure		500			{object}	problem								"Internal error"
+//	@Router			/tasks/:id/members/ [get]
+//	@Tags			Member
+func (r *memberRoutes) GetMembers(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+
+	resp, err := r.member.GetMembers(ctx, taskID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting members",
+			logger.Error(err),
+			logger.String("task id", taskID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// AddAssignee
+//
+//	@Summary		Assign task
+//	@Description	Assign the task to the user. Assignment event is emitted if the user was not assigned before.
+//	@UUID			701
+//	@Param			id			path		int								true	"Task id"
+//	@Param			params		body		memberservice.AddAssigneeParams	true	"JSON body with assignee username"
+//	@Param			Time-Zone	header		string							false	"IANA time zone of dates, UTC by default"
+//	@Param			Username	header		string							false	"Username of the request author"
+//	@Success		201			{object}	memberservice.MemberModel		"Task was assigned successfully"
+//	@Failure		400			{object}	problem							"Invalid input data"
+//	@Failure		404			{object}	problem							"Task is not found"
+//	@Failure		500			{object}	problem							"Internal error"
+//	@Router			/tasks/:id/members/assignees [post]
+//	@Tags			Member
+func (r *memberRoutes) AddAssignee(ctx *gin.Context) {
+	var params memberservice.AddAssigneeParams
+
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	taskID := ctx.Param("id")
+
+	resp, err := r.member.AddAssignee(ctx, taskID, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error adding assignee",
+			logger.Error(err),
+			logger.String("task id", taskID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// DeleteAssignee
+//
+//	@Summary		Unassign task
+//	@Description	Remove the user from task assignees and emit unassignment event.
+//	@UUID			702
+//	@Param			id			path		int		true	"Task id"
+//	@Param			username	path		string	true	"Assignee username"
+//	@Param			Username	header		string	false	"Username of the request author"
+//	@Success		200			{object}	nil		"Task was unassigned successfully"
+//	@Failure		400			{object}	problem	"Invalid input data"
+//	@Failure		404			{object}	problem	"Assignee is not found"
+//	@Failure		500			{object}	problem	"Internal error"
+//	@Router			/tasks/:id/members/assignees/:username [delete]
+//	@Tags			Member
+func (r *memberRoutes) DeleteAssignee(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+	username := ctx.Param("username")
+
+	err := r.member.DeleteAssignee(ctx, taskID, username)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error deleting assignee",
+			logger.Error(err),
+			logger.String("task id", taskID),
+			logger.String("username", username))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// Watch
+//
+//	@Summary		Watch task
+//	@Description	Make the Username header user a watcher of the task.
+//	@UUID			703
+//	@Param			id			path		int							true	"Task id"
+//	@Param			Time-Zone	header		string						false	"IANA time zone of dates, UTC by default"
+//	@Param			Username	header		string						true	"Username of the request author"
+//	@Success		201			{object}	memberservice.MemberModel	"Task is watched successfully"
+//	@Failure		400			{object}	problem						"Invalid input data"
+//	@Failure		401			{object}	problem						"Username header is missing"
+//	@Failure		404			{object}	problem						"Task is not found"
+//	@Failure		500			{object}	problem						"Internal error"
+//	@Router			/tasks/:id/members/watchers [post]
+//	@Tags			Member
+func (r *memberRoutes) Watch(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+
+	resp, err := r.member.Watch(ctx, taskID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error watching task",
+			logger.Error(err),
+			logger.String("task id", taskID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// Unwatch
+//
+//	@Summary		Unwatch task
+//	@Description	Stop the Username header user watching the task.
+//	@UUID			704
+//	@Param			id			path		int		true	"Task id"
+//	@Param			Username	header		string	true	"Username of the request author"
+//	@Success		200			{object}	nil		"Task is not watched anymore"
+//	@Failure		400			{object}	problem	"Invalid input data"
+//	@Failure		401			{object}	problem	"Username header is missing"
+//	@Failure		404			{object}	problem	"Watcher is not found"
+//	@Failure		500			{object}	problem	"Internal error"
+//	@Router			/tasks/:id/members/watchers [delete]
+//	@Tags			Member
+func (r *memberRoutes) Unwatch(ctx *gin.Context) {
+	taskID := ctx.Param("id")
+
+	err := r.member.Unwatch(ctx, taskID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error unwatching task",
+			logger.Error(err),
+			logger.String("task id", taskID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	memberservice "github.com/romandnk/todo/internal/service/member"
+	mock_service "github.com/romandnk/todo/internal/service/mock"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMemberRoutes_AddAssignee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	memberService := mock_service.NewMockMember(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+
+	memberService.EXPECT().AddAssignee(gomock.Any(), "1", memberservice.AddAssigneeParams{Username: "petr"}).
+		Return(memberservice.MemberModel{Username: "petr", CreatedAt: "2030-01-02T10:00:00Z"}, nil)
+
+	memberR := memberRoutes{
+		member: memberService,
+		logger: logger,
+	}
+
+	r := gin.Default()
+	r.POST("/api/v1/tasks/:id/members/assignees", memberR.AddAssignee)
+
+	w := httptest.NewRecorder()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/tasks/1/members/assignees", bytes.NewBufferString(`{"username":"petr"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, `{"username":"petr","created_at":"2030-01-02T10:00:00Z"}`, w.Body.String())
+}
+
+func TestMemberRoutes_Watch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	memberService := mock_service.NewMockMember(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+
+	memberService.EXPECT().Watch(gomock.Any(), "1").Return(memberservice.MemberModel{}, constant.ErrUsernameRequired)
+	logger.EXPECT().ErrorContext(gomock.Any(), "error watching task", gomock.Any(), gomock.Any())
+
+	memberR := memberRoutes{
+		member: memberService,
+		logger: logger,
+	}
+
+	r := gin.Default()
+	r.POST("/api/v1/tasks/:id/members/watchers", memberR.Watch)
+
+	w := httptest.NewRecorder()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/tasks/1/members/watchers", nil)
+	require.NoError(t, err)
+
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Equal(t, `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"request must have Username header","instance":"/api/v1/tasks/1/members/watchers","code":"username_required","field":"Username"}`, w.Body.String())
+}
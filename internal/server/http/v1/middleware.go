@@ -2,18 +2,55 @@ package v1
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/service"
 	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/metrics"
 	"github.com/romandnk/todo/pkg/utils"
+	"go.opentelemetry.io/otel/trace"
 	"time"
 )
 
 type MW struct {
-	logger logger.Logger
+	logger      logger.Logger
+	idempotency service.Idempotency
+	workspace   service.Workspace
+	apiKey      service.APIKey
+	auth        service.Auth
+	rateLimit   service.RateLimit
+	metrics     *metrics.Metrics
+	tracing     trace.TracerProvider
 }
 
-func NewMiddlewares(logger logger.Logger) *MW {
+func NewMiddlewares(
+	logger logger.Logger,
+	idempotency service.Idempotency,
+	workspace service.Workspace,
+	apiKey service.APIKey,
+	auth service.Auth,
+	rateLimit service.RateLimit,
+	metrics *metrics.Metrics,
+	tracing trace.TracerProvider,
+) *MW {
 	return &MW{
-		logger: logger,
+		logger:      logger,
+		idempotency: idempotency,
+		workspace:   workspace,
+		apiKey:      apiKey,
+		auth:        auth,
+		rateLimit:   rateLimit,
+		metrics:     metrics,
+		tracing:     tracing,
+	}
+}
+
+// Metrics counts requests and their durations by route pattern and status code
+func (m *MW) Metrics() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		m.metrics.ObserveRequest(ctx.Request.Method, ctx.FullPath(), ctx.Writer.Status(), time.Since(start))
 	}
 }
 
@@ -31,15 +68,15 @@ func (m *MW) Logging() gin.HandlerFunc {
 
 		msg := "HTTP requests"
 
-		m.logger.Info(msg,
-			"client ip", info.ClientIP,
-			"date", info.Date,
-			"method", info.Method,
-			"method path", info.Path,
-			"HTTP version", info.HTTPVersion,
-			"status code", code,
-			"processing time", info.Latency,
-			"user agent", info.UserAgent,
+		m.logger.InfoContext(ctx.Request.Context(), msg,
+			logger.String("client ip", info.ClientIP),
+			logger.String("date", info.Date),
+			logger.String("method", info.Method),
+			logger.String("method path", info.Path),
+			logger.String("HTTP version", info.HTTPVersion),
+			logger.Int("status code", code),
+			logger.String("processing time", info.Latency),
+			logger.String("user agent", info.UserAgent),
 		)
 	}
 }
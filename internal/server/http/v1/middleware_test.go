@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/pkg/metrics"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMW_Metrics(t *testing.T) {
+	m := metrics.New()
+	mw := NewMiddlewares(nil, nil, nil, nil, nil, nil, m, nil)
+
+	router := gin.New()
+	router.GET("/metrics", gin.WrapH(m.Handler()))
+	api := router.Group("/api/v1", mw.Metrics())
+	api.GET("/tasks/:id", func(ctx *gin.Context) {
+		ctx.Status(http.StatusNoContent)
+	})
+
+	for _, path := range []string{"/api/v1/tasks/1", "/api/v1/tasks/2"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusNoContent, w.Code)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `todo_http_requests_total{method="GET",route="/api/v1/tasks/:id",status="204"} 2`)
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/pkg/currentuser"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/ratelimit"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/romandnk/todo/pkg/utils"
+	"math"
+	"strconv"
+	"time"
+)
+
+// RateLimit takes a token of the client from the bucket of the route group,
+// the client is identified by API key, user of verified token or ip address
+func (m *MW) RateLimit(group string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		result, err := m.rateLimit.Take(ctx, group, rateLimitClient(ctx))
+		if result.Limit > 0 {
+			setRateLimitHeaders(ctx, result)
+		}
+		if err != nil {
+			ctx.Header("Retry-After", seconds(result.RetryAfter))
+			m.logger.ErrorContext(ctx, "error limiting request rate", logger.Error(err), logger.String("group", group))
+			sentErrorResponse(ctx, err)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func rateLimitClient(ctx *gin.Context) string {
+	if id := tenant.APIKeyID(ctx); id != 0 {
+		return "key:" + strconv.Itoa(id)
+	}
+	// Username header is not verified, so it would let client spend quota of others or get new one on every request
+	if currentuser.Authenticated(ctx) {
+		return "user:" + currentuser.FromContext(ctx)
+	}
+	return "ip:" + utils.ClientIP(ctx.Request)
+}
+
+// setRateLimitHeaders sets headers of IETF draft "RateLimit header fields for HTTP"
+func setRateLimitHeaders(ctx *gin.Context, result ratelimit.Result) {
+	ctx.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	ctx.Header("RateLimit-Reset", seconds(result.ResetAfter))
+	ctx.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%s", result.Limit, seconds(result.Window)))
+}
+
+// seconds rounds duration up to whole seconds
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	mock_service "github.com/romandnk/todo/internal/service/mock"
+	"github.com/romandnk/todo/pkg/currentuser"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/romandnk/todo/pkg/ratelimit"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMW_RateLimit(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		username             string
+		header               bool
+		client               string
+		result               ratelimit.Result
+		err                  error
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedHeaders      map[string]string
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name:     "allowed",
+			username: "ivan",
+			client:   "user:ivan",
+			result:   ratelimit.Result{Allowed: true, Limit: 20, Remaining: 19, Window: 2 * time.Second, ResetAfter: 100 * time.Millisecond},
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit":     "20",
+				"RateLimit-Remaining": "19",
+				"RateLimit-Reset":     "1",
+				"RateLimit-Policy":    "20;w=2",
+				"Retry-After":         "",
+			},
+			expectedResponseBody: "OK",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:   "exceeded",
+			client: "ip:192.0.2.1",
+			result: ratelimit.Result{Limit: 20, Window: 2 * time.Second, RetryAfter: 1500 * time.Millisecond, ResetAfter: 2 * time.Second},
+			err:    constant.ErrRateLimitExceeded,
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error limiting request rate", gomock.Any(), gomock.Any())
+			},
+			expectedHeaders: map[string]string{
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "2",
+				"Retry-After":         "2",
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Too Many Requests","status":429,"detail":"too many requests, retry later","instance":"/api/v1/tasks","code":"rate_limit_exceeded"}`,
+			expectedHTTPCode:     http.StatusTooManyRequests,
+		},
+		{
+			name:     "username header",
+			username: "ivan",
+			header:   true,
+			client:   "ip:192.0.2.1",
+			result:   ratelimit.Result{Allowed: true},
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit": "",
+			},
+			expectedResponseBody: "OK",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:     "unlimited",
+			username: "ivan",
+			client:   "user:ivan",
+			result:   ratelimit.Result{Allowed: true},
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit": "",
+			},
+			expectedResponseBody: "OK",
+			expectedHTTPCode:     http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			log := mock_logger.NewMockLogger(ctrl)
+			if tc.loggerM != nil {
+				tc.loggerM(log)
+			}
+			rateLimit := mock_service.NewMockRateLimit(ctrl)
+			rateLimit.EXPECT().Take(gomock.Any(), "tasks", tc.client).Return(tc.result, tc.err)
+
+			mw := NewMiddlewares(log, nil, nil, nil, nil, rateLimit, nil, nil)
+
+			router := gin.New()
+			router.ContextWithFallback = true
+			// username is verified token user or Username header
+			authenticate := func(ctx *gin.Context) {
+				if tc.username != "" && !tc.header {
+					ctx.Request = ctx.Request.WithContext(currentuser.WithAuthenticatedUsername(ctx.Request.Context(), tc.username))
+				}
+			}
+			router.GET("/api/v1/tasks", authenticate, mw.User(), mw.RateLimit("tasks"), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, "OK")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+			if tc.username != "" && tc.header {
+				req.Header.Set(usernameHeader, tc.username)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+			for header, value := range tc.expectedHeaders {
+				require.Equal(t, value, w.Header().Get(header), header)
+			}
+		})
+	}
+}
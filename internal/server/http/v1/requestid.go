@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/pkg/logger"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	// maxRequestIDLength limits request id of the client, longer id is replaced
+	maxRequestIDLength = 128
+)
+
+// RequestID puts id of the request into log fields of the request context with the route,
+// id of X-Request-ID header is kept so requests are correlated across services
+func (m *MW) RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		ctx.Header(requestIDHeader, id)
+		ctx.Request = ctx.Request.WithContext(logger.WithFields(ctx.Request.Context(),
+			"request_id", id,
+			"route", ctx.Request.Method+" "+ctx.FullPath(),
+		))
+
+		ctx.Next()
+	}
+}
+
+// validRequestID allows printable ASCII id without spaces, so it is safe in logs and headers
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
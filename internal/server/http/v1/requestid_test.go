@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMW_RequestID(t *testing.T) {
+	testCases := []struct {
+		name      string
+		header    string
+		generated bool
+	}{
+		{
+			name:      "no header",
+			generated: true,
+		},
+		{
+			name:   "header is propagated",
+			header: "3f2a-9c1d",
+		},
+		{
+			name:      "header with spaces",
+			header:    "3f2a 9c1d",
+			generated: true,
+		},
+		{
+			name:      "too long header",
+			header:    strings.Repeat("a", maxRequestIDLength+1),
+			generated: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mw := NewMiddlewares(nil, nil, nil, nil, nil, nil, nil, nil)
+
+			var fields []any
+			router := gin.New()
+			router.GET("/api/v1/tasks/:id", mw.RequestID(), func(ctx *gin.Context) {
+				fields = logger.Fields(ctx.Request.Context())
+				ctx.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1", nil)
+			if tc.header != "" {
+				req.Header.Set(requestIDHeader, tc.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			id := w.Header().Get(requestIDHeader)
+			if tc.generated {
+				require.Len(t, id, 32)
+				require.NotEqual(t, tc.header, id)
+			} else {
+				require.Equal(t, tc.header, id)
+			}
+			require.Equal(t, []any{"request_id", id, "route", "GET /api/v1/tasks/:id"}, fields)
+		})
+	}
+}
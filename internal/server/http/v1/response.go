@@ -1,19 +1,85 @@
 package v1
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"net/http"
 )
 
-type response struct {
-	Message string `json:"message"`
-	Error   string `json:"error,omitempty"`
+const problemContentType = "application/problem+json"
+
+// problem is RFC 7807 problem details response
+type problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail"`
+	Instance string         `json:"instance"`
+	Code     string         `json:"code"`
+	Field    string         `json:"field,omitempty"`
+	Errors   []problemField `json:"errors,omitempty"`
+}
+
+// problemField is a single field violation of the validation problem
+type problemField struct {
+	Field  string `json:"field,omitempty"`
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
+
+// kindStatuses maps domain error kinds to HTTP status codes
+var kindStatuses = map[constant.ErrorKind]int{
+	constant.KindValidation:         http.StatusBadRequest,
+	constant.KindNotFound:           http.StatusNotFound,
+	constant.KindConflict:           http.StatusConflict,
+	constant.KindPreconditionFailed: http.StatusPreconditionFailed,
+	constant.KindUnprocessable:      http.StatusUnprocessableEntity,
+	constant.KindTooLarge:           http.StatusRequestEntityTooLarge,
+	constant.KindUnsupportedMedia:   http.StatusUnsupportedMediaType,
+	constant.KindUnauthorized:       http.StatusUnauthorized,
+	constant.KindForbidden:          http.StatusForbidden,
+	constant.KindTooManyRequests:    http.StatusTooManyRequests,
+	constant.KindInternal:           http.StatusInternalServerError,
 }
 
-func sentErrorResponse(c *gin.Context, status int, message string, err error) {
-	resp := response{
-		Message: message,
-		Error:   err.Error(),
+// sentErrorResponse aborts request with problem details of the domain error.
+// All violations of validation errors are listed, other errors are hidden behind internal error.
+func sentErrorResponse(c *gin.Context, err error) {
+	var fields []problemField
+	var domainErr *constant.Error
+
+	var validationErrs constant.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		domainErr = constant.ErrValidationFailed.WithMessage(validationErrs.Error())
+		fields = make([]problemField, 0, len(validationErrs))
+		for _, e := range validationErrs {
+			fields = append(fields, problemField{
+				Field:  e.Field,
+				Code:   e.Code,
+				Detail: e.Message,
+			})
+		}
+	} else if !errors.As(err, &domainErr) {
+		domainErr = constant.ErrInternalError
+	}
+
+	status, ok := kindStatuses[domainErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	resp := problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   domainErr.Message,
+		Instance: c.Request.URL.Path,
+		Code:     domainErr.Code,
+		Field:    domainErr.Field,
+		Errors:   fields,
 	}
 
+	c.Header("Content-Type", problemContentType)
 	c.AbortWithStatusJSON(status, resp)
 }
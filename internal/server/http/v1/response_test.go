@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSentErrorResponse(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		err                  error
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name:                 "not found error with message",
+			err:                  constant.ErrTaskNotFound.WithMessage("task with id '1' is not found"),
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"task with id '1' is not found","instance":"/api/v1/tasks/1","code":"task_not_found"}`,
+			expectedHTTPCode:     http.StatusNotFound,
+		},
+		{
+			name:                 "wrapped validation error",
+			err:                  fmt.Errorf("creating task: %w", constant.ErrEmptyTitle),
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"title cannot be empty","instance":"/api/v1/tasks/1","code":"empty_title","field":"title"}`,
+			expectedHTTPCode:     http.StatusBadRequest,
+		},
+		{
+			name: "validation errors",
+			err: constant.ValidationErrors{
+				constant.ErrEmptyTitle,
+				constant.ErrInvalidLabel.WithField("projects"),
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"title cannot be empty; tags and projects cannot contain spaces","instance":"/api/v1/tasks/1","code":"validation_failed",` +
+				`"errors":[{"field":"title","code":"empty_title","detail":"title cannot be empty"},{"field":"projects","code":"invalid_label","detail":"tags and projects cannot contain spaces"}]}`,
+			expectedHTTPCode: http.StatusBadRequest,
+		},
+		{
+			name:                 "not domain error",
+			err:                  errors.New("connection refused"),
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/api/v1/tasks/1","code":"internal_error"}`,
+			expectedHTTPCode:     http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/api/v1/tasks/:id", func(ctx *gin.Context) {
+				sentErrorResponse(ctx, tc.err)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1", nil)
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, problemContentType, w.Header().Get("Content-Type"))
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestDomainErrorIs(t *testing.T) {
+	err := constant.ErrUnknownStatusName.WithMessage("status name 'x' is not found")
+
+	require.ErrorIs(t, err, constant.ErrUnknownStatusName)
+	require.NotErrorIs(t, err, constant.ErrStatusNotFound)
+	require.Equal(t, "status name is not found", constant.ErrUnknownStatusName.Error())
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
@@ -8,58 +9,97 @@ import (
 	"github.com/romandnk/todo/internal/service"
 	"github.com/romandnk/todo/internal/service/task"
 	"github.com/romandnk/todo/pkg/logger"
-	"go.uber.org/zap"
+	"io"
 	"net/http"
 )
 
+// maxImportSize is the max size of imported document in bytes
+const maxImportSize = 10 << 20
+
 type taskRoutes struct {
 	task   service.Task
 	logger logger.Logger
 }
 
-func newTaskRoutes(g *gin.RouterGroup, task service.Task, logger logger.Logger) {
+func newTaskRoutes(g *gin.RouterGroup, task service.Task, logger logger.Logger, list ...gin.HandlerFunc) {
 	r := &taskRoutes{
 		task:   task,
 		logger: logger,
 	}
 
 	g.POST("/", r.CreateTask)
+	g.POST("/quick", r.QuickAddTask)
+	g.POST("/bulk", r.BulkTasks)
+	g.GET("/export", r.ExportTasks)
+	g.POST("/import", r.ImportTasks)
 	g.DELETE("/:id", r.DeleteTaskByID)
 	g.PATCH("/:id", r.UpdateTaskByID)
 	g.GET("/:id", r.GetTaskByID)
-	g.GET("/", r.GetListTasks)
+	g.GET("/", append(list, r.GetListTasks)...)
 }
 
 // CreateTask
 //
 //	@Summary		Create task
-//	@Description	Create new task.
+//	@Description	Create new task. Date is RFC3339, YYYY-MM-DD or natural language like "tomorrow 9am" or "завтра в 9" in Time-Zone time zone. Response of the request with Idempotency-Key header is replayed on retry.
 //	@UUID			200
-//	@Param			params	body		taskservice.CreateTaskParams	true	"Required JSON body with all required task field"
-//	@Success		201		{object}	taskservice.CreateTaskResponse	"Task was created successfully"
-//	@Failure		400		{object}	response						"Invalid input data"
-//	@Failure		500		{object}	response						"Internal error"
+//	@Param			params			body		taskservice.CreateTaskParams	true	"Required JSON body with all required task field"
+//	@Param			Idempotency-Key	header		string							false	"Unique key to retry request safely"
+//	@Param			Time-Zone		header		string							false	"IANA time zone of dates, UTC by default"
+//	@Success		201				{object}	taskservice.CreateTaskResponse	"Task was created successfully"
+//	@Failure		400				{object}	problem							"Invalid input data"
+//	@Failure		409				{object}	problem							"Request with the same idempotency key is in progress"
+//	@Failure		422				{object}	problem							"Idempotency key was used with a different request"
+//	@Failure		500				{object}	problem							"Internal error"
 //	@Router			/tasks/ [post]
 //	@Tags			Task
 func (r *taskRoutes) CreateTask(ctx *gin.Context) {
 	var params taskservice.CreateTaskParams
 
 	if err := ctx.ShouldBindJSON(&params); err != nil {
-		r.logger.Error("error binding json body", zap.String("error", err.Error()))
-		sentErrorResponse(ctx, http.StatusBadRequest, "error binding json body", err)
+		r.logger.ErrorContext(ctx, "error binding json body", logger.String("error", err.Error()))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
 		return
 	}
 
 	resp, err := r.task.CreateTask(ctx, params)
 	if err != nil {
-		code := http.StatusBadRequest
-		if errors.Is(err, constant.ErrInternalError) {
-			code = http.StatusInternalServerError
-		}
-		r.logger.Error("error creating task",
-			zap.Error(err),
-			zap.String("params", fmt.Sprintf("%+v", params)))
-		sentErrorResponse(ctx, code, "error creating task", err)
+		r.logger.ErrorContext(ctx, "error creating task",
+			logger.Error(err),
+			logger.String("params", fmt.Sprintf("%+v", params)))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// QuickAddTask
+//
+//	@Summary		Quick-add task
+//	@Description	Create task from one line like "Pay rent #finance +home !high tomorrow @done". Words with # are tags, with + are projects, with ! is priority (high, medium, low, letter or number) and with @ is status (done, todo or status name with _ instead of spaces). The longest group of the other words which is a date becomes due date, today by default, the rest is the title.
+//	@UUID			208
+//	@Param			params			body		taskservice.QuickAddTaskParams		true	"Required JSON body with task text"
+//	@Param			Idempotency-Key	header		string								false	"Unique key to retry request safely"
+//	@Param			Time-Zone		header		string								false	"IANA time zone of dates, UTC by default"
+//	@Success		201				{object}	taskservice.QuickAddTaskResponse	"Task was created successfully"
+//	@Failure		400				{object}	problem								"Invalid input data"
+//	@Failure		500				{object}	problem								"Internal error"
+//	@Router			/tasks/quick [post]
+//	@Tags			Task
+func (r *taskRoutes) QuickAddTask(ctx *gin.Context) {
+	var params taskservice.QuickAddTaskParams
+
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	resp, err := r.task.QuickAddTask(ctx, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error quick-adding task", logger.Error(err), logger.String("text", params.Text))
+		sentErrorResponse(ctx, err)
 		return
 	}
 
@@ -69,27 +109,33 @@ func (r *taskRoutes) CreateTask(ctx *gin.Context) {
 // DeleteTaskByID
 //
 //	@Summary		Delete task by ID
-//	@Description	Delete task by its id.
+//	@Description	Delete task by its id together with its comments. Task version from ETag can be checked with If-Match header.
 //	@UUID			201
-//	@Param			params	path		int			true	"Required task id for deleting"
-//	@Success		200		{object}	nil			"Task was deleted successfully"
-//	@Failure		400		{object}	response	"Invalid input data"
-//	@Failure		500		{object}	response	"Internal error"
+//	@Param			params		path		int		true	"Required task id for deleting"
+//	@Param			If-Match	header		string	false	"Task ETag for optimistic concurrency control"
+//	@Success		200			{object}	nil		"Task was deleted successfully"
+//	@Failure		400			{object}	problem	"Invalid input data"
+//	@Failure		404			{object}	problem	"Task is not found"
+//	@Failure		412			{object}	problem	"Task version does not match"
+//	@Failure		500			{object}	problem	"Internal error"
 //	@Router			/tasks/:id [delete]
 //	@Tags			Task
 func (r *taskRoutes) DeleteTaskByID(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	err := r.task.DeleteTaskByID(ctx, id)
+	version, err := parseIfMatch(ctx.GetHeader("If-Match"))
 	if err != nil {
-		code := http.StatusBadRequest
-		if errors.Is(err, constant.ErrInternalError) {
-			code = http.StatusInternalServerError
-		}
-		r.logger.Error("error deleting task with id",
-			zap.Error(err),
-			zap.String("task id", id))
-		sentErrorResponse(ctx, code, "error deleting task by id", err)
+		r.logger.ErrorContext(ctx, "error parsing If-Match header", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	err = r.task.DeleteTaskByID(ctx, id, version)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error deleting task with id",
+			logger.Error(err),
+			logger.String("task id", id))
+		sentErrorResponse(ctx, err)
 		return
 	}
 
@@ -100,52 +146,63 @@ func (r *taskRoutes) DeleteTaskByID(ctx *gin.Context) {
 // UpdateTaskByID
 //
 //	@Summary		Update task by ID
-//	@Description	Update task selected fields by its id.
+//	@Description	Update task selected fields by its id. Date is parsed as on task creation. Task version from ETag can be checked with If-Match header.
 //	@UUID			202
-//	@Param			params	path		int									true	"Required task id for updating"
-//	@Param			params	body		taskservice.UpdateTaskByIDParams	false	"Required JSON body with necessary fields to update"
-//	@Success		200		{object}	nil									"Task was updated successfully"
-//	@Failure		400		{object}	response							"Invalid input data"
-//	@Failure		500		{object}	response							"Internal error"
+//	@Param			params		path		int									true	"Required task id for updating"
+//	@Param			If-Match	header		string								false	"Task ETag for optimistic concurrency control"
+//	@Param			Time-Zone	header		string								false	"IANA time zone of dates, UTC by default"
+//	@Param			params		body		taskservice.UpdateTaskByIDParams	false	"Required JSON body with necessary fields to update"
+//	@Success		200			{object}	taskservice.UpdateTaskByIDResponse	"Task was updated successfully"
+//	@Failure		400			{object}	problem								"Invalid input data"
+//	@Failure		404			{object}	problem								"Task is not found"
+//	@Failure		412			{object}	problem								"Task version does not match"
+//	@Failure		500			{object}	problem								"Internal error"
 //	@Router			/tasks/:id [patch]
 //	@Tags			Task
 func (r *taskRoutes) UpdateTaskByID(ctx *gin.Context) {
 	var params taskservice.UpdateTaskByIDParams
 
 	if err := ctx.ShouldBindJSON(&params); err != nil {
-		r.logger.Error("error binding json body", zap.Error(err))
-		sentErrorResponse(ctx, http.StatusBadRequest, "error binding json body", err)
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
 		return
 	}
 
 	id := ctx.Param("id")
 
-	err := r.task.UpdateTaskByID(ctx, id, params)
+	version, err := parseIfMatch(ctx.GetHeader("If-Match"))
 	if err != nil {
-		code := http.StatusBadRequest
-		if errors.Is(err, constant.ErrInternalError) {
-			code = http.StatusInternalServerError
-		}
-		r.logger.Error("error updating task by id",
-			zap.Error(err),
-			zap.String("task id", id))
-		sentErrorResponse(ctx, code, "error updating task by id", err)
+		r.logger.ErrorContext(ctx, "error parsing If-Match header", logger.Error(err))
+		sentErrorResponse(ctx, err)
 		return
 	}
 
-	ctx.Status(http.StatusOK)
-	return
+	resp, err := r.task.UpdateTaskByID(ctx, id, version, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error updating task by id",
+			logger.Error(err),
+			logger.String("task id", id))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	setTaskETag(ctx, resp.Version)
+	ctx.JSON(http.StatusOK, resp)
 }
 
 // GetTaskByID
 //
 //	@Summary		Get task by ID
-//	@Description	Get task by its id.
+//	@Description	Get task by its id. Response ETag header contains task version, Vary header lists user, workspace and Time-Zone headers.
 //	@UUID			203
-//	@Param			params	path		int										true	"Required task id for getting"
-//	@Success		200		{object}	taskservice.GetTaskWithStatusNameModel	"Task was received successfully"
-//	@Failure		400		{object}	response								"Invalid input data"
-//	@Failure		500		{object}	response								"Internal error"
+//	@Param			params			path		int										true	"Required task id for getting"
+//	@Param			If-None-Match	header		string									false	"Task ETag to check whether task was changed"
+//	@Param			Time-Zone		header		string									false	"IANA time zone of dates, UTC by default"
+//	@Success		200				{object}	taskservice.GetTaskWithStatusNameModel	"Task was received successfully"
+//	@Success		304				{object}	nil										"Task was not changed"
+//	@Failure		400				{object}	problem									"Invalid input data"
+//	@Failure		404				{object}	problem									"Task is not found"
+//	@Failure		500				{object}	problem									"Internal error"
 //	@Router			/tasks/:id [get]
 //	@Tags			Task
 func (r *taskRoutes) GetTaskByID(ctx *gin.Context) {
@@ -153,14 +210,16 @@ func (r *taskRoutes) GetTaskByID(ctx *gin.Context) {
 
 	resp, err := r.task.GetTaskByID(ctx, id)
 	if err != nil {
-		code := http.StatusBadRequest
-		if errors.Is(err, constant.ErrInternalError) {
-			code = http.StatusInternalServerError
-		}
-		r.logger.Error("error getting task by id",
-			zap.Error(err),
-			zap.String("task id", id))
-		sentErrorResponse(ctx, code, "error getting task by id", err)
+		r.logger.ErrorContext(ctx, "error getting task by id",
+			logger.Error(err),
+			logger.String("task id", id))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	setTaskETag(ctx, resp.Version)
+	if etagMatches(ctx.GetHeader("If-None-Match"), formatETag(resp.Version)) {
+		ctx.Status(http.StatusNotModified)
 		return
 	}
 
@@ -170,28 +229,174 @@ func (r *taskRoutes) GetTaskByID(ctx *gin.Context) {
 // GetListTasks
 //
 //	@Summary		Get tasks
-//	@Description	Get tasks with filtration by status name or date and pagination with limit.
+//	@Description	Get tasks with filtration by status name, date, checklist completion or members and pagination with limit. Day of the date is evaluated in the Time-Zone header or tz parameter time zone. Response ETag header is the hash of the list.
 //	@UUID			204
-//	@Param			limit		query		int								false	"tasks limit on the page"
-//	@Param			last-id		query		int								false	"last task id for getting next page"
-//	@Param			status-name	query		string							false	"task status name for filtering"
-//	@Param			date		query		string							false	"date for getting task by date in RFC3339 format"
-//	@Success		200			{object}	taskservice.GetAllTasksResponse	"Tasks were gotten successfully"
-//	@Failure		500			{object}	response						"Internal error"
+//	@Param			limit			query		int								false	"tasks limit on the page"
+//	@Param			last-id			query		int								false	"last task id for getting next page"
+//	@Param			status-name		query		string							false	"task status name for filtering"
+//	@Param			date			query		string							false	"date for getting task by date in RFC3339 or YYYY-MM-DD format"
+//	@Param			min-completion	query		int								false	"min checklist completion percentage, tasks without checklist are skipped"
+//	@Param			max-completion	query		int								false	"max checklist completion percentage, tasks without checklist are skipped"
+//	@Param			assigned-to-me	query		bool							false	"only tasks assigned to the Username header user"
+//	@Param			watching		query		bool							false	"only tasks watched by the Username header user"
+//	@Param			tz				query		string							false	"IANA time zone of dates, Time-Zone header is used first"
+//	@Param			Time-Zone		header		string							false	"IANA time zone of dates, UTC by default"
+//	@Param			Username		header		string							false	"Username of the request author"
+//	@Param			If-None-Match	header		string							false	"List ETag to check whether tasks were changed"
+//	@Success		200				{object}	taskservice.GetAllTasksResponse	"Tasks were gotten successfully"
+//	@Success		304				{object}	nil								"Tasks were not changed"
+//	@Failure		400				{object}	problem							"Invalid input data"
+//	@Failure		401				{object}	problem							"Username header is required by member filters"
+//	@Failure		500				{object}	problem							"Internal error"
 //	@Router			/tasks/ [get]
 //	@Tags			Task
 func (r *taskRoutes) GetListTasks(ctx *gin.Context) {
-	limit := ctx.Query("limit")
-	lastID := ctx.Query("last-id")
-	statusName := ctx.Query("status-name")
-	date := ctx.Query("date")
+	params := taskservice.GetAllTasksParams{
+		Limit:            ctx.Query("limit"),
+		LastID:           ctx.Query("last-id"),
+		TaskFilterParams: taskFilterParams(ctx),
+	}
+
+	resp, err := r.task.GetAllTasks(ctx, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting tasks", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
 
-	resp, err := r.task.GetAllTasks(ctx, limit, lastID, statusName, date)
+// BulkTasks
+//
+//	@Summary		Bulk task operations
+//	@Description	Create many tasks, update status, delete and restore many tasks in one transaction.
+//	@UUID			205
+//	@Param			params	body		taskservice.BulkTasksParams		true	"Required JSON body with bulk operations"
+//	@Success		200		{object}	taskservice.BulkTasksResponse	"Bulk operations were committed"
+//	@Failure		400		{object}	problem							"Invalid input data"
+//	@Failure		422		{object}	taskservice.BulkTasksResponse	"Bulk operations were not committed"
+//	@Failure		500		{object}	problem							"Internal error"
+//	@Router			/tasks/bulk [post]
+//	@Tags			Task
+func (r *taskRoutes) BulkTasks(ctx *gin.Context) {
+	var params taskservice.BulkTasksParams
+
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	resp, err := r.task.BulkTasks(ctx, params)
 	if err != nil {
-		r.logger.Error("error getting tasks", zap.Error(err))
-		sentErrorResponse(ctx, http.StatusInternalServerError, "error getting task", err)
+		r.logger.ErrorContext(ctx, "error executing bulk tasks", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	code := http.StatusOK
+	if !resp.Committed {
+		code = http.StatusUnprocessableEntity
+	}
+
+	ctx.JSON(code, resp)
+}
+
+// ExportTasks
+//
+//	@Summary		Export tasks
+//	@Description	Stream all tasks matching the task list filters in csv, json, ndjson, iCalendar or todo.txt format.
+//	@UUID			206
+//	@Param			format			query		string	false	"export format: csv, json (default), ndjson, ics, ics-vevent or todotxt"
+//	@Param			status-name		query		string	false	"task status name for filtering"
+//	@Param			date			query		string	false	"date for getting task by date in RFC3339 or YYYY-MM-DD format"
+//	@Param			min-completion	query		int		false	"min checklist completion percentage, tasks without checklist are skipped"
+//	@Param			max-completion	query		int		false	"max checklist completion percentage, tasks without checklist are skipped"
+//	@Param			assigned-to-me	query		bool	false	"only tasks assigned to the request author"
+//	@Param			watching		query		bool	false	"only tasks watched by the request author"
+//	@Param			Time-Zone		header		string	false	"IANA time zone of dates, UTC by default"
+//	@Success		200				{file}		file	"Tasks were exported successfully"
+//	@Failure		400				{object}	problem	"Invalid input data"
+//	@Failure		401				{object}	problem	"Request author is required by member filters"
+//	@Failure		500				{object}	problem	"Internal error"
+//	@Router			/tasks/export [get]
+//	@Tags			Task
+func (r *taskRoutes) ExportTasks(ctx *gin.Context) {
+	format := ctx.Query("format")
+
+	contentType, err := taskservice.ExportContentType(format)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting export content type", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Status(http.StatusOK)
+
+	err = r.task.ExportTasks(ctx, ctx.Writer, format, taskFilterParams(ctx))
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error exporting tasks", logger.Error(err))
+		if ctx.Writer.Written() {
+			ctx.Abort()
+			return
+		}
+		ctx.Writer.Header().Del("Content-Type")
+		sentErrorResponse(ctx, err)
+		return
+	}
+}
+
+// ImportTasks
+//
+//	@Summary		Import tasks
+//	@Description	Import tasks from csv, json, ndjson, iCalendar or todo.txt request body validated with create task rules. Valid tasks are saved in one transaction, all or none of them.
+//	@UUID			207
+//	@Accept			plain
+//	@Param			format	query		string							false	"import format: csv, json (default), ndjson, ics or todotxt"
+//	@Param			dry-run	query		bool							false	"validate tasks without saving"
+//	@Param			params	body		string							true	"Tasks document"
+//	@Success		200		{object}	taskservice.ImportTasksResponse	"Tasks were imported or validated"
+//	@Failure		400		{object}	problem							"Invalid input data"
+//	@Failure		413		{object}	problem							"Document is too large"
+//	@Failure		500		{object}	problem							"Internal error"
+//	@Router			/tasks/import [post]
+//	@Tags			Task
+func (r *taskRoutes) ImportTasks(ctx *gin.Context) {
+	format := ctx.Query("format")
+	dryRun := ctx.Query("dry-run")
+
+	body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize))
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error reading import document", logger.Error(err))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			sentErrorResponse(ctx, constant.ErrTooLargeImport.WithMessage(fmt.Sprintf("max import size is %d bytes", maxImportSize)))
+			return
+		}
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	resp, err := r.task.ImportTasks(ctx, bytes.NewReader(body), format, dryRun)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error importing tasks", logger.Error(err))
+		sentErrorResponse(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, resp)
 }
+
+// taskFilterParams reads query values of task list filters
+func taskFilterParams(ctx *gin.Context) taskservice.TaskFilterParams {
+	return taskservice.TaskFilterParams{
+		StatusName:    ctx.Query("status-name"),
+		Date:          ctx.Query("date"),
+		MinCompletion: ctx.Query("min-completion"),
+		MaxCompletion: ctx.Query("max-completion"),
+		AssignedToMe:  ctx.Query("assigned-to-me"),
+		Watching:      ctx.Query("watching"),
+	}
+}
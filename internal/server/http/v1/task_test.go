@@ -5,14 +5,16 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
 	mock_service "github.com/romandnk/todo/internal/service/mock"
 	taskservice "github.com/romandnk/todo/internal/service/task"
+	"github.com/romandnk/todo/pkg/logger"
 	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
-	"go.uber.org/zap"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -70,17 +72,17 @@ func TestTaskRoutes_CreateTask(t *testing.T) {
 			name: "error validating json body fields (empty title)",
 			argsLogger: argsLogger{
 				msg:    "error binding json body",
-				fields: []any{zap.String("error", "Key: 'CreateTaskParams.Title' Error:Field validation for 'Title' failed on the 'required' tag")},
+				fields: []any{logger.String("error", "Key: 'CreateTaskParams.Title' Error:Field validation for 'Title' failed on the 'required' tag")},
 			},
 			loggerM: func(m *mock_logger.MockLogger, args argsLogger) {
-				m.EXPECT().Error(args.msg, args.fields)
+				m.EXPECT().ErrorContext(gomock.Any(), args.msg, args.fields)
 			},
 			requestBody: map[string]interface{}{
 				"description": "Test",
 				"status_name": "done",
 				"date":        "2024-12-07T20:49:18Z",
 			},
-			expectedResponseBody: `{"message":"error binding json body","error":"Key: 'CreateTaskParams.Title' Error:Field validation for 'Title' failed on the 'required' tag"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Key: 'CreateTaskParams.Title' Error:Field validation for 'Title' failed on the 'required' tag","instance":"/api/v1/tasks","code":"invalid_request_body"}`,
 			expectedHTTPCode:     http.StatusBadRequest,
 		},
 	}
@@ -128,3 +130,327 @@ func TestTaskRoutes_CreateTask(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskRoutes_UpdateTaskByID(t *testing.T) {
+	url := "/api/v1/tasks/:id"
+
+	testCases := []struct {
+		name                 string
+		ifMatch              string
+		taskM                func(m *mock_service.MockTask)
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedResponseBody string
+		expectedETag         string
+		expectedHTTPCode     int
+	}{
+		{
+			name:    "OK",
+			ifMatch: `"2"`,
+			taskM: func(m *mock_service.MockTask) {
+				m.EXPECT().UpdateTaskByID(gomock.Any(), "1", 2, taskservice.UpdateTaskByIDParams{Title: "Test"}).
+					Return(taskservice.UpdateTaskByIDResponse{Version: 3}, nil)
+			},
+			expectedResponseBody: `{"version":3}`,
+			expectedETag:         `"3"`,
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:    "task was modified",
+			ifMatch: `"2"`,
+			taskM: func(m *mock_service.MockTask) {
+				m.EXPECT().UpdateTaskByID(gomock.Any(), "1", 2, taskservice.UpdateTaskByIDParams{Title: "Test"}).
+					Return(taskservice.UpdateTaskByIDResponse{}, constant.ErrTaskModified)
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error updating task by id", gomock.Any(), gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Precondition Failed","status":412,"detail":"task was modified, its version does not match","instance":"/api/v1/tasks/1","code":"task_modified","field":"If-Match"}`,
+			expectedHTTPCode:     http.StatusPreconditionFailed,
+		},
+		{
+			name:    "weak entity tag",
+			ifMatch: `W/"2"`,
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error parsing If-Match header", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"If-Match header must contain one strong entity tag with task version","instance":"/api/v1/tasks/1","code":"invalid_if_match","field":"If-Match"}`,
+			expectedHTTPCode:     http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskService := mock_service.NewMockTask(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			if tc.taskM != nil {
+				tc.taskM(taskService)
+			}
+
+			if tc.loggerM != nil {
+				tc.loggerM(logger)
+			}
+
+			taskR := taskRoutes{
+				task:   taskService,
+				logger: logger,
+			}
+
+			r := gin.Default()
+			r.PATCH(url, taskR.UpdateTaskByID)
+
+			w := httptest.NewRecorder()
+
+			ctx := context.Background()
+			req, err := http.NewRequestWithContext(ctx, http.MethodPatch, "/api/v1/tasks/1", bytes.NewBufferString(`{"title":"Test"}`))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("If-Match", tc.ifMatch)
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedETag, w.Header().Get("ETag"))
+			if tc.expectedETag != "" {
+				require.Equal(t, listVary, w.Header().Get("Vary"))
+			}
+			require.Equal(t, []byte(tc.expectedResponseBody), w.Body.Bytes())
+		})
+	}
+}
+
+func TestTaskRoutes_GetTaskByID(t *testing.T) {
+	url := "/api/v1/tasks/:id"
+
+	testCases := []struct {
+		name             string
+		ifNoneMatch      string
+		expectedHTTPCode int
+	}{
+		{
+			name:             "OK",
+			expectedHTTPCode: http.StatusOK,
+		},
+		{
+			name:             "not modified",
+			ifNoneMatch:      `"2"`,
+			expectedHTTPCode: http.StatusNotModified,
+		},
+		{
+			name:             "not modified by weak tag",
+			ifNoneMatch:      `"1", W/"2"`,
+			expectedHTTPCode: http.StatusNotModified,
+		},
+		{
+			name:             "modified",
+			ifNoneMatch:      `"1"`,
+			expectedHTTPCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskService := mock_service.NewMockTask(ctrl)
+			taskService.EXPECT().GetTaskByID(gomock.Any(), "1").
+				Return(taskservice.GetTaskWithStatusNameModel{ID: 1, Version: 2}, nil)
+
+			taskR := taskRoutes{
+				task:   taskService,
+				logger: mock_logger.NewMockLogger(ctrl),
+			}
+
+			r := gin.Default()
+			r.GET(url, taskR.GetTaskByID)
+
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/tasks/1", nil)
+			require.NoError(t, err)
+			req.Header.Set("Time-Zone", "Europe/Moscow")
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, `"2"`, w.Header().Get("ETag"))
+			require.Equal(t, "Username, Workspace-ID, Authorization, Time-Zone", w.Header().Get("Vary"))
+			if tc.expectedHTTPCode == http.StatusNotModified {
+				require.Empty(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestTaskRoutes_QuickAddTask(t *testing.T) {
+	url := "/api/v1/tasks/quick"
+
+	testCases := []struct {
+		name                 string
+		requestBody          string
+		taskM                func(m *mock_service.MockTask)
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name:        "OK",
+			requestBody: `{"text":"Pay rent #finance !high tomorrow @done"}`,
+			taskM: func(m *mock_service.MockTask) {
+				m.EXPECT().QuickAddTask(gomock.Any(), taskservice.QuickAddTaskParams{Text: "Pay rent #finance !high tomorrow @done"}).
+					Return(taskservice.QuickAddTaskResponse{
+						ID:         1,
+						Title:      "Pay rent",
+						StatusName: constant.StatusNameDone,
+						DateText:   "tomorrow",
+						Date:       "2030-01-02",
+						AllDay:     true,
+						Priority:   1,
+						Tags:       []string{"finance"},
+						Projects:   []string{},
+					}, nil)
+			},
+			expectedResponseBody: `{"id":1,"title":"Pay rent","status_name":"выполнено","date_text":"tomorrow","date":"2030-01-02","all_day":true,"priority":1,"tags":["finance"],"projects":[]}`,
+			expectedHTTPCode:     http.StatusCreated,
+		},
+		{
+			name:        "invalid priority",
+			requestBody: `{"text":"Pay rent !urgent"}`,
+			taskM: func(m *mock_service.MockTask) {
+				m.EXPECT().QuickAddTask(gomock.Any(), taskservice.QuickAddTaskParams{Text: "Pay rent !urgent"}).
+					Return(taskservice.QuickAddTaskResponse{}, constant.ErrInvalidPriority.WithMessage("unknown priority 'urgent'"))
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error quick-adding task", gomock.Any(), gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"unknown priority 'urgent'","instance":"/api/v1/tasks/quick","code":"invalid_priority","field":"priority"}`,
+			expectedHTTPCode:     http.StatusBadRequest,
+		},
+		{
+			name:        "empty body",
+			requestBody: `{}`,
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error binding json body", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Key: 'QuickAddTaskParams.Text' Error:Field validation for 'Text' failed on the 'required' tag","instance":"/api/v1/tasks/quick","code":"invalid_request_body"}`,
+			expectedHTTPCode:     http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskService := mock_service.NewMockTask(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			if tc.taskM != nil {
+				tc.taskM(taskService)
+			}
+
+			if tc.loggerM != nil {
+				tc.loggerM(logger)
+			}
+
+			taskR := taskRoutes{
+				task:   taskService,
+				logger: logger,
+			}
+
+			r := gin.Default()
+			r.POST(url, taskR.QuickAddTask)
+
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestTaskRoutes_ImportTasks(t *testing.T) {
+	url := "/api/v1/tasks/import"
+
+	testCases := []struct {
+		name                 string
+		requestBody          string
+		taskM                func(m *mock_service.MockTask)
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name:        "OK",
+			requestBody: `[{"title":"Test","description":"Test","status_name":"выполнено","date":"2030-01-02"}]`,
+			taskM: func(m *mock_service.MockTask) {
+				m.EXPECT().ImportTasks(gomock.Any(), gomock.Any(), "", "").
+					Return(taskservice.ImportTasksResponse{Total: 1, Valid: 1, Imported: 1, IDs: []int{5}, Errors: []taskservice.ImportTaskError{}}, nil)
+			},
+			expectedResponseBody: `{"dry_run":false,"total":1,"valid":1,"imported":1,"ids":[5],"errors":[]}`,
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:        "too large document",
+			requestBody: strings.Repeat(" ", maxImportSize+1),
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error reading import document", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"max import size is 10485760 bytes","instance":"/api/v1/tasks/import","code":"too_large_import"}`,
+			expectedHTTPCode:     http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskService := mock_service.NewMockTask(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			if tc.taskM != nil {
+				tc.taskM(taskService)
+			}
+
+			if tc.loggerM != nil {
+				tc.loggerM(logger)
+			}
+
+			taskR := taskRoutes{
+				task:   taskService,
+				logger: logger,
+			}
+
+			r := gin.Default()
+			r.POST(url, taskR.ImportTasks)
+
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, strings.NewReader(tc.requestBody))
+			require.NoError(t, err)
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
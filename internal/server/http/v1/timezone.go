@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/timezone"
+)
+
+const (
+	timezoneHeader = "Time-Zone"
+	timezoneQuery  = "tz"
+)
+
+// Timezone puts location from Time-Zone header or tz query parameter into the request context,
+// task day boundaries are evaluated and dates are rendered in this location
+func (m *MW) Timezone() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		name := ctx.GetHeader(timezoneHeader)
+		if name == "" {
+			name = ctx.Query(timezoneQuery)
+		}
+
+		loc, err := timezone.Load(name)
+		if err != nil {
+			m.logger.ErrorContext(ctx, "error loading time zone", logger.String("time zone", name))
+			sentErrorResponse(ctx, constant.ErrInvalidTimezone)
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(timezone.WithLocation(ctx.Request.Context(), loc))
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/romandnk/todo/pkg/timezone"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMW_Timezone(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		header               string
+		query                string
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name:                 "default UTC",
+			expectedResponseBody: "UTC",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:                 "header",
+			header:               "Europe/Moscow",
+			query:                "Asia/Tokyo",
+			expectedResponseBody: "Europe/Moscow",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:                 "query parameter",
+			query:                "Asia/Tokyo",
+			expectedResponseBody: "Asia/Tokyo",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:   "unknown time zone",
+			header: "Mars/Olympus",
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error loading time zone", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"time zone must be IANA time zone name","instance":"/api/v1/tasks","code":"invalid_timezone","field":"Time-Zone"}`,
+			expectedHTTPCode:     http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			log := mock_logger.NewMockLogger(ctrl)
+			if tc.loggerM != nil {
+				tc.loggerM(log)
+			}
+
+			mw := NewMiddlewares(log, nil, nil, nil, nil, nil, nil, nil)
+
+			router := gin.New()
+			router.ContextWithFallback = true
+			router.GET("/api/v1/tasks", mw.Timezone(), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, timezone.FromContext(ctx).String())
+			})
+
+			url := "/api/v1/tasks"
+			if tc.query != "" {
+				url += "?tz=" + tc.query
+			}
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			if tc.header != "" {
+				req.Header.Set(timezoneHeader, tc.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
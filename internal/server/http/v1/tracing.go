@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/tracing"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+// traceContext propagates trace context by W3C traceparent and tracestate headers
+var traceContext = propagation.TraceContext{}
+
+// Tracing starts server span of the request continuing trace of traceparent header,
+// the trace context is returned in the response headers as well
+func (m *MW) Tracing() gin.HandlerFunc {
+	tracer := m.tracing.Tracer(tracing.Name)
+
+	return func(ctx *gin.Context) {
+		route := ctx.FullPath()
+
+		reqCtx := traceContext.Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+		reqCtx, span := tracer.Start(reqCtx, ctx.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(ctx.Request.URL.Path),
+			),
+		)
+		defer span.End()
+
+		traceContext.Inject(reqCtx, propagation.HeaderCarrier(ctx.Writer.Header()))
+		if spanContext := span.SpanContext(); spanContext.HasTraceID() {
+			reqCtx = logger.WithFields(reqCtx, "trace_id", spanContext.TraceID().String())
+		}
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+
+		ctx.Next()
+
+		code := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(code))
+		if code >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(code))
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/service/validation"
+	"github.com/romandnk/todo/pkg/currentuser"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/tenant"
+	"strings"
+)
+
+const usernameHeader = "Username"
+
+// User puts username from Username header into the request context,
+// request without header is anonymous. Requests authenticated by API key or token cannot name the user
+func (m *MW) User() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		username := strings.TrimSpace(ctx.GetHeader(usernameHeader))
+		if username == "" {
+			ctx.Next()
+			return
+		}
+
+		if tenant.APIKeyID(ctx) != 0 || currentuser.FromContext(ctx) != "" {
+			m.logger.ErrorContext(ctx, "error using username header with authorization", logger.String("username", username))
+			sentErrorResponse(ctx, constant.ErrUsernameHeaderDenied)
+			return
+		}
+
+		if err := validation.Username(usernameHeader, username); err != nil {
+			m.logger.ErrorContext(ctx, "error validating username", logger.String("username", username))
+			sentErrorResponse(ctx, err)
+			return
+		}
+
+		reqCtx := currentuser.WithUsername(ctx.Request.Context(), username)
+		ctx.Request = ctx.Request.WithContext(logger.WithFields(reqCtx, "user", username))
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/pkg/currentuser"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMW_User(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		header               string
+		apiKeyID             int
+		tokenUser            string
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name:                 "anonymous",
+			expectedResponseBody: "",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:                 "header",
+			header:               " ivan ",
+			expectedResponseBody: "ivan",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:   "invalid username",
+			header: "ivan petrov",
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error validating username", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"username cannot contain spaces or slashes","instance":"/api/v1/tasks","code":"invalid_username","field":"Username"}`,
+			expectedHTTPCode:     http.StatusBadRequest,
+		},
+		{
+			name:     "api key",
+			header:   "ivan",
+			apiKeyID: 3,
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error using username header with authorization", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Username header cannot be used with Authorization header","instance":"/api/v1/tasks","code":"username_header_denied","field":"Username"}`,
+			expectedHTTPCode:     http.StatusBadRequest,
+		},
+		{
+			name:      "token",
+			header:    "ivan",
+			tokenUser: "petr",
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error using username header with authorization", gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Username header cannot be used with Authorization header","instance":"/api/v1/tasks","code":"username_header_denied","field":"Username"}`,
+			expectedHTTPCode:     http.StatusBadRequest,
+		},
+		{
+			name:                 "token without header",
+			tokenUser:            "petr",
+			expectedResponseBody: "petr",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:                 "api key without header",
+			apiKeyID:             3,
+			expectedResponseBody: "",
+			expectedHTTPCode:     http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			log := mock_logger.NewMockLogger(ctrl)
+			if tc.loggerM != nil {
+				tc.loggerM(log)
+			}
+
+			mw := NewMiddlewares(log, nil, nil, nil, nil, nil, nil, nil)
+
+			router := gin.New()
+			router.ContextWithFallback = true
+			authenticate := func(ctx *gin.Context) {
+				if tc.apiKeyID != 0 {
+					ctx.Request = ctx.Request.WithContext(tenant.WithAPIKeyID(ctx.Request.Context(), tc.apiKeyID))
+				}
+				if tc.tokenUser != "" {
+					ctx.Request = ctx.Request.WithContext(currentuser.WithUsername(ctx.Request.Context(), tc.tokenUser))
+				}
+			}
+			router.GET("/api/v1/tasks", authenticate, mw.User(), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, currentuser.FromContext(ctx))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+			if tc.header != "" {
+				req.Header.Set(usernameHeader, tc.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/internal/service"
+	workspaceservice "github.com/romandnk/todo/internal/service/workspace"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/tenant"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const workspaceHeader = "Workspace-ID"
+
+// Workspace puts workspace from Workspace-ID header and the role of the request author in it
+// into the request context, request without header works in the default workspace
+func (m *MW) Workspace() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		header := strings.TrimSpace(ctx.GetHeader(workspaceHeader))
+
+		// workspace of API key is already in the context
+		if _, ok := tenant.Scopes(ctx); ok {
+			if header != "" && header != strconv.Itoa(tenant.WorkspaceID(ctx)) {
+				m.logger.ErrorContext(ctx, "error resolving api key workspace", logger.String("workspace id", header))
+				sentErrorResponse(ctx, constant.ErrAPIKeyWorkspaceDenied)
+				return
+			}
+			ctx.Next()
+			return
+		}
+
+		workspaceID, role, err := m.workspace.ResolveRole(ctx, header)
+		if err != nil {
+			m.logger.ErrorContext(ctx, "error resolving workspace role", logger.Error(err), logger.String("workspace id", header))
+			sentErrorResponse(ctx, err)
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(tenant.WithWorkspace(ctx.Request.Context(), workspaceID, role))
+
+		ctx.Next()
+	}
+}
+
+// Authorize checks the role put by Workspace middleware or scopes of API key,
+// safe methods require read permission and other methods require write permission
+func (m *MW) Authorize(read, write entity.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		permission := write
+		if ctx.Request.Method == http.MethodGet || ctx.Request.Method == http.MethodHead {
+			permission = read
+		}
+
+		role := tenant.Role(ctx)
+		allowed := entity.HasPermission(role, permission)
+		if scopes, ok := tenant.Scopes(ctx); ok {
+			allowed = slices.Contains(scopes, string(permission))
+		}
+		if !allowed {
+			m.logger.ErrorContext(ctx, "error authorizing request",
+				logger.String("role", role),
+				logger.String("permission", string(permission)),
+			)
+			sentErrorResponse(ctx, constant.ErrPermissionDenied)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+type workspaceRoutes struct {
+	workspace service.Workspace
+	logger    logger.Logger
+}
+
+func newWorkspaceRoutes(g *gin.RouterGroup, workspace service.Workspace, logger logger.Logger) {
+	r := &workspaceRoutes{
+		workspace: workspace,
+		logger:    logger,
+	}
+
+	g.POST("/", r.CreateWorkspace)
+	g.GET("/", r.GetWorkspaces)
+	g.GET("/:workspace_id/members", r.GetMembers)
+	g.PATCH("/:workspace_id/members/:username", r.UpdateMemberRole)
+	g.DELETE("/:workspace_id/members/:username", r.DeleteMember)
+	g.POST("/:workspace_id/invitations", r.CreateInvitation)
+	g.POST("/invitations/:token/accept", r.AcceptInvitation)
+}
+
+// CreateWorkspace
+//
+//	@Summary		Create workspace
+//	@Description	Create workspace owned by the request author with default statuses.
+//	@UUID			800
+//	@Param			Username	header		string									true	"Request author"
+//	@Param			params		body		workspaceservice.CreateWorkspaceParams	true	"JSON body with workspace name"
+//	@Success		201			{object}	workspaceservice.WorkspaceModel			"Workspace was created successfully"
+//	@Failure		400			{object}	problem									"Invalid input data"
+//	@Failure		401			{object}	problem									"Username header is missing"
+//	@Failure		500			{object}	problem									"Internal error"
+//	@Router			/workspaces/ [post]
+//	@Tags			Workspace
+func (r *workspaceRoutes) CreateWorkspace(ctx *gin.Context) {
+	var params workspaceservice.CreateWorkspaceParams
+
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	resp, err := r.workspace.CreateWorkspace(ctx, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error creating workspace", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetWorkspaces
+//
+//	@Summary		Get workspaces
+//	@Description	Get workspaces of the request author with the author roles.
+//	@UUID			801
+//	@Param			Username	header		string									true	"Request author"
+//	@Success		200			{object}	workspaceservice.GetWorkspacesResponse	"Workspaces were received successfully"
+//	@Failure		401			{object}	problem									"Username header is missing"
+//	@Failure		500			{object}	problem									"Internal error"
+//	@Router			/workspaces/ [get]
+//	@Tags			Workspace
+func (r *workspaceRoutes) GetWorkspaces(ctx *gin.Context) {
+	resp, err := r.workspace.GetWorkspaces(ctx)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting workspaces", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetMembers
+//
+//	@Summary		Get workspace members
+//	@Description	Get members of the workspace with their roles.
+//	@UUID			802
+//	@Param			workspace_id	path		int												true	"Workspace id"
+//	@Param			Username		header		string											false	"Request author"
+//	@Success		200				{object}	workspaceservice.GetWorkspaceMembersResponse	"Members were received successfully"
+//	@Failure		400				{object}	problem											"Invalid input data"
+//	@Failure		404				{object}	problem											"Workspace is not found"
+//	@Failure		500				{object}	problem											"Internal error"
+//	@Router			/workspaces/:workspace_id/members [get]
+//	@Tags			Workspace
+func (r *workspaceRoutes) GetMembers(ctx *gin.Context) {
+	workspaceID := ctx.Param("workspace_id")
+
+	resp, err := r.workspace.GetMembers(ctx, workspaceID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting workspace members", logger.Error(err), logger.String("workspace id", workspaceID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdateMemberRole
+//
+//	@Summary		Change member role
+//	@Description	Change role of the workspace member. Only owners can grant or revoke owner role, the last owner keeps it.
+//	@UUID			803
+//	@Param			workspace_id	path		int										true	"Workspace id"
+//	@Param			username		path		string									true	"Member username"
+//	@Param			Username		header		string									false	"Request author"
+//	@Param			params			body		workspaceservice.UpdateMemberRoleParams	true	"JSON body with owner, admin, member or viewer role"
+//	@Success		200				{object}	workspaceservice.WorkspaceMemberModel	"Role was changed successfully"
+//	@Failure		400				{object}	problem									"Invalid input data"
+//	@Failure		403				{object}	problem									"Role does not allow the action"
+//	@Failure		404				{object}	problem									"Workspace or member is not found"
+//	@Failure		409				{object}	problem									"Last owner cannot lose owner role"
+//	@Failure		500				{object}	problem									"Internal error"
+//	@Router			/workspaces/:workspace_id/members/:username [patch]
+//	@Tags			Workspace
+func (r *workspaceRoutes) UpdateMemberRole(ctx *gin.Context) {
+	workspaceID := ctx.Param("workspace_id")
+	username := ctx.Param("username")
+
+	var params workspaceservice.UpdateMemberRoleParams
+
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	resp, err := r.workspace.UpdateMemberRole(ctx, workspaceID, username, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error updating workspace member role", logger.Error(err),
+			logger.String("workspace id", workspaceID),
+			logger.String("username", username),
+		)
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteMember
+//
+//	@Summary		Remove workspace member
+//	@Description	Remove user from the workspace. Any member can leave, other members are removed by admins and owners.
+//	@UUID			804
+//	@Param			workspace_id	path	int		true	"Workspace id"
+//	@Param			username		path	string	true	"Member username"
+//	@Param			Username		header	string	false	"Request author"
+//	@Success		204				"Member was removed successfully"
+//	@Failure		400				{object}	problem	"Invalid input data"
+//	@Failure		403				{object}	problem	"Role does not allow the action"
+//	@Failure		404				{object}	problem	"Workspace or member is not found"
+//	@Failure		409				{object}	problem	"Last owner cannot be removed"
+//	@Failure		500				{object}	problem	"Internal error"
+//	@Router			/workspaces/:workspace_id/members/:username [delete]
+//	@Tags			Workspace
+func (r *workspaceRoutes) DeleteMember(ctx *gin.Context) {
+	workspaceID := ctx.Param("workspace_id")
+	username := ctx.Param("username")
+
+	err := r.workspace.DeleteMember(ctx, workspaceID, username)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error deleting workspace member", logger.Error(err),
+			logger.String("workspace id", workspaceID),
+			logger.String("username", username),
+		)
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// CreateInvitation
+//
+//	@Summary		Create invitation
+//	@Description	Create invitation link to join the workspace with admin, member or viewer role.
+//	@UUID			805
+//	@Param			workspace_id	path		int											true	"Workspace id"
+//	@Param			Username		header		string										true	"Request author"
+//	@Param			params			body		workspaceservice.CreateInvitationParams		true	"JSON body with role of invited users"
+//	@Success		201				{object}	workspaceservice.CreateInvitationResponse	"Invitation was created successfully"
+//	@Failure		400				{object}	problem										"Invalid input data"
+//	@Failure		401				{object}	problem										"Username header is missing"
+//	@Failure		403				{object}	problem										"Role does not allow the action"
+//	@Failure		404				{object}	problem										"Workspace is not found"
+//	@Failure		500				{object}	problem										"Internal error"
+//	@Router			/workspaces/:workspace_id/invitations [post]
+//	@Tags			Workspace
+func (r *workspaceRoutes) CreateInvitation(ctx *gin.Context) {
+	workspaceID := ctx.Param("workspace_id")
+
+	var params workspaceservice.CreateInvitationParams
+
+	if err := ctx.ShouldBindJSON(&params); err != nil {
+		r.logger.ErrorContext(ctx, "error binding json body", logger.Error(err))
+		sentErrorResponse(ctx, constant.ErrInvalidRequestBody.WithMessage(err.Error()))
+		return
+	}
+
+	resp, err := r.workspace.CreateInvitation(ctx, workspaceID, params)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error creating invitation", logger.Error(err), logger.String("workspace id", workspaceID))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// AcceptInvitation
+//
+//	@Summary		Accept invitation
+//	@Description	Join the workspace of the invitation, invitation is accepted once, existing member keeps the role.
+//	@UUID			806
+//	@Param			token		path		string										true	"Invitation token"
+//	@Param			Username	header		string										true	"Request author"
+//	@Success		200			{object}	workspaceservice.AcceptInvitationResponse	"Invitation was accepted successfully"
+//	@Failure		400			{object}	problem										"Invalid input data"
+//	@Failure		401			{object}	problem										"Username header is missing"
+//	@Failure		404			{object}	problem										"Invitation is not found, expired or already accepted"
+//	@Failure		500			{object}	problem										"Internal error"
+//	@Router			/workspaces/invitations/:token/accept [post]
+//	@Tags			Workspace
+func (r *workspaceRoutes) AcceptInvitation(ctx *gin.Context) {
+	resp, err := r.workspace.AcceptInvitation(ctx, ctx.Param("token"))
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error accepting invitation", logger.Error(err))
+		sentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	mock_service "github.com/romandnk/todo/internal/service/mock"
+	workspaceservice "github.com/romandnk/todo/internal/service/workspace"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMW_WorkspaceAuthorize(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		method               string
+		header               string
+		serviceM             func(m *mock_service.MockWorkspace)
+		loggerM              func(m *mock_logger.MockLogger)
+		expectedResponseBody string
+		expectedHTTPCode     int
+	}{
+		{
+			name:   "default workspace",
+			method: http.MethodPost,
+			serviceM: func(m *mock_service.MockWorkspace) {
+				m.EXPECT().ResolveRole(gomock.Any(), "").Return(1, entity.RoleAdmin, nil)
+			},
+			expectedResponseBody: "1 admin",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:   "viewer reads",
+			method: http.MethodGet,
+			header: "2",
+			serviceM: func(m *mock_service.MockWorkspace) {
+				m.EXPECT().ResolveRole(gomock.Any(), "2").Return(2, entity.RoleViewer, nil)
+			},
+			expectedResponseBody: "2 viewer",
+			expectedHTTPCode:     http.StatusOK,
+		},
+		{
+			name:   "viewer writes",
+			method: http.MethodPost,
+			header: "2",
+			serviceM: func(m *mock_service.MockWorkspace) {
+				m.EXPECT().ResolveRole(gomock.Any(), "2").Return(2, entity.RoleViewer, nil)
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error authorizing request", gomock.Any(), gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"your role in the workspace does not allow this action","instance":"/api/v1/tasks","code":"permission_denied"}`,
+			expectedHTTPCode:     http.StatusForbidden,
+		},
+		{
+			name:   "not member",
+			method: http.MethodGet,
+			header: "3",
+			serviceM: func(m *mock_service.MockWorkspace) {
+				m.EXPECT().ResolveRole(gomock.Any(), "3").Return(0, "", constant.ErrWorkspaceNotFound)
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error resolving workspace role", gomock.Any(), gomock.Any())
+			},
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"workspace is not found","instance":"/api/v1/tasks","code":"workspace_not_found"}`,
+			expectedHTTPCode:     http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			log := mock_logger.NewMockLogger(ctrl)
+			if tc.loggerM != nil {
+				tc.loggerM(log)
+			}
+			workspace := mock_service.NewMockWorkspace(ctrl)
+			tc.serviceM(workspace)
+
+			mw := NewMiddlewares(log, nil, workspace, nil, nil, nil, nil, nil)
+
+			router := gin.New()
+			router.ContextWithFallback = true
+			router.Handle(tc.method, "/api/v1/tasks",
+				mw.Workspace(),
+				mw.Authorize(entity.PermTasksRead, entity.PermTasksWrite),
+				func(ctx *gin.Context) {
+					ctx.String(http.StatusOK, fmt.Sprintf("%d %s", tenant.WorkspaceID(ctx), tenant.Role(ctx)))
+				},
+			)
+
+			req := httptest.NewRequest(tc.method, "/api/v1/tasks", nil)
+			if tc.header != "" {
+				req.Header.Set(workspaceHeader, tc.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedHTTPCode, w.Code)
+			require.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestWorkspaceRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceService := mock_service.NewMockWorkspace(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+
+	workspaceService.EXPECT().UpdateMemberRole(gomock.Any(), "2", "petr", workspaceservice.UpdateMemberRoleParams{Role: entity.RoleAdmin}).
+		Return(workspaceservice.WorkspaceMemberModel{Username: "petr", Role: entity.RoleAdmin, CreatedAt: "2030-01-02T10:00:00Z"}, nil)
+	workspaceService.EXPECT().AcceptInvitation(gomock.Any(), "token").
+		Return(workspaceservice.AcceptInvitationResponse{}, constant.ErrInvitationNotFound)
+	logger.EXPECT().ErrorContext(gomock.Any(), "error accepting invitation", gomock.Any())
+
+	r := gin.New()
+	newWorkspaceRoutes(r.Group("/api/v1/workspaces"), workspaceService, logger)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPatch, "/api/v1/workspaces/2/members/petr", bytes.NewBufferString(`{"role":"admin"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `{"username":"petr","role":"admin","created_at":"2030-01-02T10:00:00Z"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/workspaces/invitations/token/accept", nil)
+	require.NoError(t, err)
+
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, `{"type":"about:blank","title":"Not Found","status":404,"detail":"invitation is not found, expired or already accepted","instance":"/api/v1/workspaces/invitations/token/accept","code":"invitation_not_found"}`, w.Body.String())
+}
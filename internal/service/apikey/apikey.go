@@ -0,0 +1,204 @@
+package apikeyservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	storage "github.com/romandnk/todo/internal/repo"
+	"github.com/romandnk/todo/internal/service/validation"
+	"github.com/romandnk/todo/pkg/currentuser"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/timezone"
+	"github.com/romandnk/todo/pkg/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	secretSize = 32
+	// shownPrefixLength is the length of key beginning shown in the list of keys
+	shownPrefixLength = len(entity.APIKeyPrefix) + 8
+)
+
+type APIKeyService struct {
+	apiKey storage.APIKey
+	logger logger.Logger
+}
+
+func NewAPIKeyService(apiKey storage.APIKey, logger logger.Logger) *APIKeyService {
+	return &APIKeyService{
+		apiKey: apiKey,
+		logger: logger,
+	}
+}
+
+// CreateAPIKey creates API key of the context workspace, the key is returned only once and only its hash is stored
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, params CreateAPIKeyParams) (CreateAPIKeyResponse, error) {
+	var response CreateAPIKeyResponse
+
+	username := currentuser.FromContext(ctx)
+	if username == "" {
+		return response, constant.ErrUsernameRequired
+	}
+
+	params.Name = strings.TrimSpace(params.Name)
+	scopes, err := s.validateCreateParams(params)
+	if err != nil {
+		return response, err
+	}
+
+	secret, err := utils.GenerateToken(secretSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error generating api key", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+	key := entity.APIKeyPrefix + secret
+
+	apiKey, err := s.apiKey.CreateAPIKey(ctx, entity.APIKey{
+		Name:      params.Name,
+		Prefix:    key[:shownPrefixLength],
+		KeyHash:   hashKey(key),
+		Scopes:    scopes,
+		CreatedBy: username,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error creating repo api key", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	response.APIKeyModel = apiKeyModel(&apiKey, timezone.FromContext(ctx))
+	response.Key = key
+
+	return response, nil
+}
+
+// GetAPIKeys returns API keys of the context workspace without keys themselves
+func (s *APIKeyService) GetAPIKeys(ctx context.Context) (GetAPIKeysResponse, error) {
+	var response GetAPIKeysResponse
+
+	keys, err := s.apiKey.GetAPIKeys(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error getting repo api keys", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	loc := timezone.FromContext(ctx)
+	response.Keys = make([]APIKeyModel, 0, len(keys))
+	for _, key := range keys {
+		response.Keys = append(response.Keys, apiKeyModel(key, loc))
+	}
+
+	return response, nil
+}
+
+// RevokeAPIKey revokes API key of the context workspace, revoked key stays in the list
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, idStr string) error {
+	id, err := s.parseAPIKeyID(ctx, idStr)
+	if err != nil {
+		return err
+	}
+
+	err = s.apiKey.RevokeAPIKey(ctx, id)
+	if err != nil {
+		if errors.Is(err, constant.ErrAPIKeyIDNotExists) {
+			return constant.ErrAPIKeyNotFound.WithMessage(fmt.Sprintf("api key with id '%d' is not found", id))
+		}
+		s.logger.ErrorContext(ctx, "error revoking repo api key", logger.Error(err))
+		return constant.ErrInternalError
+	}
+
+	return nil
+}
+
+// Authenticate returns not revoked API key and records its usage
+func (s *APIKeyService) Authenticate(ctx context.Context, key string) (entity.APIKey, error) {
+	if !strings.HasPrefix(key, entity.APIKeyPrefix) {
+		return entity.APIKey{}, constant.ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.apiKey.UseAPIKey(ctx, hashKey(key), time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, constant.ErrAPIKeyNotExists) {
+			return apiKey, constant.ErrInvalidAPIKey
+		}
+		s.logger.ErrorContext(ctx, "error using repo api key", logger.Error(err))
+		return apiKey, constant.ErrInternalError
+	}
+
+	return apiKey, nil
+}
+
+// validateCreateParams checks name and scopes of the new key and returns scopes without duplicates
+func (s *APIKeyService) validateCreateParams(params CreateAPIKeyParams) ([]string, error) {
+	var v validation.Validator
+
+	v.Check(validation.APIKeyName("name", params.Name))
+
+	if len(params.Scopes) == 0 {
+		v.Check(constant.ErrEmptyAPIKeyScopes)
+	}
+	scopes := make([]string, 0, len(params.Scopes))
+	seen := make(map[string]struct{}, len(params.Scopes))
+	for _, scope := range params.Scopes {
+		if !entity.ValidAPIKeyScope(scope) {
+			v.Check(constant.ErrInvalidAPIKeyScope.WithMessage(fmt.Sprintf("api key scope '%s' must be tasks:read, tasks:write or statuses:admin", scope)))
+			continue
+		}
+		if _, ok := seen[scope]; ok {
+			continue
+		}
+		seen[scope] = struct{}{}
+		scopes = append(scopes, scope)
+	}
+
+	if !v.Valid() {
+		return nil, v.Err()
+	}
+	return scopes, nil
+}
+
+func (s *APIKeyService) parseAPIKeyID(ctx context.Context, idStr string) (int, error) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error converting string api key id to int api key id", logger.Error(err))
+		return 0, constant.ErrInvalidAPIKeyID
+	}
+	if id <= 0 {
+		return 0, constant.ErrNonPositiveAPIKeyID
+	}
+	return id, nil
+}
+
+// hashKey returns hex encoded SHA-256 of the key, keys are random so salt is not needed
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// apiKeyModel renders times in loc
+func apiKeyModel(key *entity.APIKey, loc *time.Location) APIKeyModel {
+	return APIKeyModel{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		CreatedBy:  key.CreatedBy,
+		CreatedAt:  key.CreatedAt.In(loc).Format(time.RFC3339),
+		LastUsedAt: formatNullTime(key.LastUsedAt, loc),
+		RevokedAt:  formatNullTime(key.RevokedAt, loc),
+	}
+}
+
+func formatNullTime(t sql.NullTime, loc *time.Location) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.In(loc).Format(time.RFC3339)
+}
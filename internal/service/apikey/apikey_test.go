@@ -0,0 +1,205 @@
+package apikeyservice
+
+import (
+	"context"
+	"errors"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	mock_storage "github.com/romandnk/todo/internal/repo/mock"
+	"github.com/romandnk/todo/pkg/currentuser"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	testCases := []struct {
+		name          string
+		username      string
+		params        CreateAPIKeyParams
+		expectedError error
+	}{
+		{
+			name:     "OK",
+			username: "ivan",
+			params:   CreateAPIKeyParams{Name: " ci ", Scopes: []string{"tasks:read", "tasks:write", "tasks:read"}},
+		},
+		{
+			name:          "anonymous",
+			params:        CreateAPIKeyParams{Name: "ci", Scopes: []string{"tasks:read"}},
+			expectedError: constant.ErrUsernameRequired,
+		},
+		{
+			name:          "empty scopes",
+			username:      "ivan",
+			params:        CreateAPIKeyParams{Name: "ci"},
+			expectedError: constant.ErrEmptyAPIKeyScopes,
+		},
+		{
+			name:          "unknown scope",
+			username:      "ivan",
+			params:        CreateAPIKeyParams{Name: "ci", Scopes: []string{"members:admin"}},
+			expectedError: constant.ErrInvalidAPIKeyScope,
+		},
+		{
+			name:          "too long name",
+			username:      "ivan",
+			params:        CreateAPIKeyParams{Name: strings.Repeat("a", 65), Scopes: []string{"tasks:read"}},
+			expectedError: constant.ErrTooLongAPIKeyName,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKey := mock_storage.NewMockAPIKey(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			var stored entity.APIKey
+			if tc.expectedError == nil {
+				apiKey.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, key entity.APIKey) (entity.APIKey, error) {
+						key.ID = 1
+						stored = key
+						return key, nil
+					})
+			}
+
+			service := NewAPIKeyService(apiKey, logger)
+
+			ctx := currentuser.WithUsername(context.Background(), tc.username)
+			resp, err := service.CreateAPIKey(ctx, tc.params)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				return
+			}
+
+			require.Equal(t, 1, resp.ID)
+			require.Equal(t, "ci", resp.Name)
+			require.Equal(t, []string{"tasks:read", "tasks:write"}, resp.Scopes)
+			require.True(t, strings.HasPrefix(resp.Key, entity.APIKeyPrefix))
+			require.Equal(t, resp.Key[:shownPrefixLength], resp.Prefix)
+			require.Equal(t, hashKey(resp.Key), stored.KeyHash)
+			require.NotContains(t, stored.KeyHash, resp.Key)
+		})
+	}
+}
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	testCases := []struct {
+		name          string
+		id            string
+		repoError     error
+		callRepo      bool
+		expectedError error
+	}{
+		{
+			name:     "OK",
+			id:       "1",
+			callRepo: true,
+		},
+		{
+			name:          "key not exists",
+			id:            "1",
+			repoError:     constant.ErrAPIKeyIDNotExists,
+			callRepo:      true,
+			expectedError: constant.ErrAPIKeyNotFound,
+		},
+		{
+			name:          "non positive id",
+			id:            "0",
+			expectedError: constant.ErrNonPositiveAPIKeyID,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKey := mock_storage.NewMockAPIKey(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			if tc.callRepo {
+				apiKey.EXPECT().RevokeAPIKey(gomock.Any(), 1).Return(tc.repoError)
+			}
+
+			service := NewAPIKeyService(apiKey, logger)
+
+			err := service.RevokeAPIKey(context.Background(), tc.id)
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	const key = "todo_0123456789abcdef"
+
+	testCases := []struct {
+		name          string
+		key           string
+		repoError     error
+		callRepo      bool
+		expectedError error
+	}{
+		{
+			name:     "OK",
+			key:      key,
+			callRepo: true,
+		},
+		{
+			name:          "unknown or revoked key",
+			key:           key,
+			repoError:     constant.ErrAPIKeyNotExists,
+			callRepo:      true,
+			expectedError: constant.ErrInvalidAPIKey,
+		},
+		{
+			name:          "key without prefix",
+			key:           "0123456789abcdef",
+			expectedError: constant.ErrInvalidAPIKey,
+		},
+		{
+			name:          "repo error",
+			key:           key,
+			repoError:     errors.New("connection refused"),
+			callRepo:      true,
+			expectedError: constant.ErrInternalError,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKey := mock_storage.NewMockAPIKey(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			if tc.callRepo {
+				apiKey.EXPECT().UseAPIKey(gomock.Any(), hashKey(tc.key), gomock.AssignableToTypeOf(time.Time{})).
+					Return(entity.APIKey{ID: 1, WorkspaceID: 2, Scopes: []string{"tasks:read"}}, tc.repoError)
+			}
+			if tc.expectedError == constant.ErrInternalError {
+				logger.EXPECT().ErrorContext(gomock.Any(), "error using repo api key", gomock.Any())
+			}
+
+			service := NewAPIKeyService(apiKey, logger)
+
+			key, err := service.Authenticate(context.Background(), tc.key)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, 2, key.WorkspaceID)
+				require.Equal(t, []string{"tasks:read"}, key.Scopes)
+			}
+		})
+	}
+}
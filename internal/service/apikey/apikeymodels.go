@@ -0,0 +1,27 @@
+package apikeyservice
+
+type CreateAPIKeyParams struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes"`
+}
+
+// CreateAPIKeyResponse contains the key itself, it is shown only once
+type CreateAPIKeyResponse struct {
+	APIKeyModel
+	Key string `json:"key"`
+}
+
+type APIKeyModel struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	CreatedBy  string   `json:"created_by"`
+	CreatedAt  string   `json:"created_at"`
+	LastUsedAt string   `json:"last_used_at,omitempty"`
+	RevokedAt  string   `json:"revoked_at,omitempty"`
+}
+
+type GetAPIKeysResponse struct {
+	Keys []APIKeyModel `json:"keys"`
+}
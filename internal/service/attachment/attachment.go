@@ -0,0 +1,303 @@
+package attachmentservice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	storage "github.com/romandnk/todo/internal/repo"
+	"github.com/romandnk/todo/pkg/blobstore"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/timezone"
+	"github.com/romandnk/todo/pkg/utils"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	tokenSize         = 16
+	maxFileNameLength = 255
+	// sniffLength is the number of bytes used to detect content type of the file
+	sniffLength = 512
+)
+
+// opaqueContentTypes are detected types of binary files like zip container of docx,
+// they are not rendered by browsers, so they do not contradict the declared type
+var opaqueContentTypes = map[string]struct{}{
+	"application/octet-stream": {},
+	"application/zip":          {},
+}
+
+type AttachmentService struct {
+	attachment storage.Attachment
+	task       storage.Task
+	blob       blobstore.BlobStore
+	limits     Limits
+	logger     logger.Logger
+}
+
+func NewAttachmentService(attachment storage.Attachment, task storage.Task, blob blobstore.BlobStore, limits Limits, logger logger.Logger) *AttachmentService {
+	return &AttachmentService{
+		attachment: attachment,
+		task:       task,
+		blob:       blob,
+		limits:     limits,
+		logger:     logger,
+	}
+}
+
+// UploadAttachment saves file into the blob store and attaches it to the task.
+// Declared content type is used, file without declared type is detected by its content.
+// Content type detected by the content must be allowed too, so the file cannot pretend to be of allowed type
+func (s *AttachmentService) UploadAttachment(ctx context.Context, taskIDStr string, params UploadAttachmentParams) (AttachmentModel, error) {
+	var response AttachmentModel
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	fileName := path.Base(strings.ReplaceAll(strings.TrimSpace(params.FileName), "\\", "/"))
+	if fileName == "." || fileName == "/" || utf8.RuneCountInString(fileName) > maxFileNameLength {
+		return response, constant.ErrInvalidAttachmentFileName
+	}
+	if params.Size <= 0 || params.File == nil {
+		return response, constant.ErrEmptyAttachmentFile
+	}
+	if params.Size > s.limits.MaxSize {
+		return response, constant.ErrTooLargeAttachment.WithMessage(fmt.Sprintf("max attachment size is %d bytes", s.limits.MaxSize))
+	}
+
+	contentType, sniffed, file := detectContentType(params.ContentType, params.File)
+	if !s.allowedContentType(contentType) {
+		return response, constant.ErrUnsupportedAttachmentType.WithMessage(fmt.Sprintf("attachment content type '%s' is not allowed", contentType))
+	}
+	if _, ok := opaqueContentTypes[sniffed]; !ok && !s.allowedContentType(sniffed) {
+		return response, constant.ErrUnsupportedAttachmentType.WithMessage(fmt.Sprintf("attachment content of type '%s' is not allowed", sniffed))
+	}
+
+	_, err = s.task.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return response, constant.ErrTaskNotFound.WithMessage(fmt.Sprintf("task with id '%d' is not found", taskID))
+		}
+		s.logger.ErrorContext(ctx, "error getting repo task by id", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	token, err := utils.GenerateToken(tokenSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error generating attachment token", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+	key := fmt.Sprintf("tasks/%d/%s", taskID, token)
+
+	err = s.blob.Put(ctx, key, file, params.Size, contentType)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error putting attachment blob", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	attachment, err := s.attachment.CreateAttachment(ctx, entity.Attachment{
+		TaskID:      taskID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        params.Size,
+		StorageKey:  key,
+	})
+	if err != nil {
+		s.deleteBlob(ctx, key)
+		if errors.Is(err, constant.ErrTaskIDNotExists) {
+			return response, constant.ErrTaskNotFound.WithMessage(fmt.Sprintf("task with id '%d' is not found", taskID))
+		}
+		s.logger.ErrorContext(ctx, "error creating repo attachment", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	return attachmentModel(&attachment, timezone.FromContext(ctx)), nil
+}
+
+// GetAttachments returns task attachments in upload order
+func (s *AttachmentService) GetAttachments(ctx context.Context, taskIDStr string) (GetAttachmentsResponse, error) {
+	var response GetAttachmentsResponse
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	_, err = s.task.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return response, constant.ErrTaskNotFound.WithMessage(fmt.Sprintf("task with id '%d' is not found", taskID))
+		}
+		s.logger.ErrorContext(ctx, "error getting repo task by id", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	attachments, err := s.attachment.GetAttachmentsByTaskID(ctx, taskID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error getting repo task attachments", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	loc := timezone.FromContext(ctx)
+	response.Attachments = make([]AttachmentModel, 0, len(attachments))
+	for _, attachment := range attachments {
+		response.Attachments = append(response.Attachments, attachmentModel(attachment, loc))
+	}
+	response.Total = len(response.Attachments)
+
+	return response, nil
+}
+
+// DownloadAttachment returns attachment metadata and its content, caller closes the content
+func (s *AttachmentService) DownloadAttachment(ctx context.Context, taskIDStr, idStr string) (AttachmentModel, io.ReadCloser, error) {
+	var response AttachmentModel
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, nil, err
+	}
+	id, err := s.parseAttachmentID(ctx, idStr)
+	if err != nil {
+		return response, nil, err
+	}
+
+	attachment, err := s.attachment.GetAttachmentByID(ctx, taskID, id)
+	if err != nil {
+		if errors.Is(err, constant.ErrAttachmentIDNotExists) {
+			return response, nil, constant.ErrAttachmentNotFound.WithMessage(fmt.Sprintf("attachment with id '%d' is not found", id))
+		}
+		s.logger.ErrorContext(ctx, "error getting repo attachment by id", logger.Error(err))
+		return response, nil, constant.ErrInternalError
+	}
+
+	content, err := s.blob.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error getting attachment blob", logger.Error(err), logger.String("key", attachment.StorageKey))
+		return response, nil, constant.ErrInternalError
+	}
+
+	return attachmentModel(&attachment, timezone.FromContext(ctx)), content, nil
+}
+
+// DeleteAttachmentByID deletes attachment metadata and its content
+func (s *AttachmentService) DeleteAttachmentByID(ctx context.Context, taskIDStr, idStr string) error {
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return err
+	}
+	id, err := s.parseAttachmentID(ctx, idStr)
+	if err != nil {
+		return err
+	}
+
+	key, err := s.attachment.DeleteAttachment(ctx, taskID, id)
+	if err != nil {
+		if errors.Is(err, constant.ErrAttachmentIDNotExists) {
+			return constant.ErrAttachmentNotFound.WithMessage(fmt.Sprintf("attachment with id '%d' is not found", id))
+		}
+		s.logger.ErrorContext(ctx, "error deleting repo attachment", logger.Error(err))
+		return constant.ErrInternalError
+	}
+
+	s.deleteBlob(ctx, key)
+
+	return nil
+}
+
+// deleteBlob removes content of the attachment without metadata, failure leaves orphan blob and is only logged
+func (s *AttachmentService) deleteBlob(ctx context.Context, key string) {
+	err := s.blob.Delete(ctx, key)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error deleting attachment blob", logger.Error(err), logger.String("key", key))
+	}
+}
+
+func (s *AttachmentService) allowedContentType(contentType string) bool {
+	if len(s.limits.ContentTypes) == 0 {
+		return true
+	}
+	for _, allowed := range s.limits.ContentTypes {
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok {
+			if strings.HasPrefix(contentType, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if contentType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *AttachmentService) parseTaskID(ctx context.Context, idStr string) (int, error) {
+	if idStr == "" {
+		return 0, constant.ErrEmptyTaskID
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error converting string task id to int task id", logger.Error(err))
+		return 0, constant.ErrInvalidTaskID
+	}
+	if id <= 0 {
+		return 0, constant.ErrNonPositiveTaskID
+	}
+	return id, nil
+}
+
+func (s *AttachmentService) parseAttachmentID(ctx context.Context, idStr string) (int, error) {
+	if idStr == "" {
+		return 0, constant.ErrEmptyAttachmentID
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error converting string attachment id to int attachment id", logger.Error(err))
+		return 0, constant.ErrInvalidAttachmentID
+	}
+	if id <= 0 {
+		return 0, constant.ErrNonPositiveAttachmentID
+	}
+	return id, nil
+}
+
+// detectContentType returns declared media type without parameters, media type detected by first bytes
+// of the file and reader of the whole file. Missing or generic declared type is replaced with the detected one
+func detectContentType(declared string, file io.Reader) (string, string, io.Reader) {
+	head := make([]byte, sniffLength)
+	n, _ := io.ReadFull(file, head)
+	head = head[:n]
+
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	file = io.MultiReader(bytes.NewReader(head), file)
+
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err == nil && mediaType != "application/octet-stream" {
+		return mediaType, sniffed, file
+	}
+	return sniffed, sniffed, file
+}
+
+// attachmentModel renders creation time in loc
+func attachmentModel(attachment *entity.Attachment, loc *time.Location) AttachmentModel {
+	return AttachmentModel{
+		ID:          attachment.ID,
+		TaskID:      attachment.TaskID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		CreatedAt:   attachment.CreatedAt.In(loc).Format(time.RFC3339),
+		URL:         fmt.Sprintf("/api/v1/tasks/%d/attachments/%d", attachment.TaskID, attachment.ID),
+	}
+}
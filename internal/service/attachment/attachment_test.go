@@ -0,0 +1,166 @@
+package attachmentservice
+
+import (
+	"context"
+	"errors"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	mock_storage "github.com/romandnk/todo/internal/repo/mock"
+	mock_blobstore "github.com/romandnk/todo/pkg/blobstore/mock"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testLimits = Limits{
+	MaxSize:      16,
+	ContentTypes: []string{"image/*", "application/pdf"},
+}
+
+func TestAttachmentService_UploadAttachment(t *testing.T) {
+	created := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+	png := "\x89PNG\r\n\x1a\nscreen"
+
+	type mocks struct {
+		task       *mock_storage.MockTask
+		attachment *mock_storage.MockAttachment
+		blob       *mock_blobstore.MockBlobStore
+		logger     *mock_logger.MockLogger
+	}
+
+	testCases := []struct {
+		name           string
+		input          UploadAttachmentParams
+		mock           func(m mocks)
+		expectedOutput AttachmentModel
+		expectedError  error
+	}{
+		{
+			name:  "OK with detected content type",
+			input: UploadAttachmentParams{FileName: `C:\Users\ivan\screenshot.png`, Size: int64(len(png)), File: strings.NewReader(png)},
+			mock: func(m mocks) {
+				m.task.EXPECT().GetTaskByID(gomock.Any(), 1).Return(entity.Task{ID: 1}, nil)
+				m.blob.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(len(png)), "image/png").
+					DoAndReturn(func(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+						require.True(t, strings.HasPrefix(key, "tasks/1/"))
+						content, err := io.ReadAll(r)
+						require.NoError(t, err)
+						require.Equal(t, png, string(content))
+						return nil
+					})
+				m.attachment.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, attachment entity.Attachment) (entity.Attachment, error) {
+						require.Equal(t, "screenshot.png", attachment.FileName)
+						require.Equal(t, "image/png", attachment.ContentType)
+						attachment.ID = 2
+						attachment.CreatedAt = created
+						return attachment, nil
+					})
+			},
+			expectedOutput: AttachmentModel{
+				ID:          2,
+				TaskID:      1,
+				FileName:    "screenshot.png",
+				ContentType: "image/png",
+				Size:        int64(len(png)),
+				CreatedAt:   "2030-01-02T10:00:00Z",
+				URL:         "/api/v1/tasks/1/attachments/2",
+			},
+		},
+		{
+			name:          "too large file",
+			input:         UploadAttachmentParams{FileName: "report.pdf", ContentType: "application/pdf", Size: 17, File: strings.NewReader("")},
+			expectedError: constant.ErrTooLargeAttachment,
+		},
+		{
+			name:          "not allowed content type",
+			input:         UploadAttachmentParams{FileName: "run.sh", ContentType: "text/x-shellscript; charset=utf-8", Size: 5, File: strings.NewReader("ls -l")},
+			expectedError: constant.ErrUnsupportedAttachmentType,
+		},
+		{
+			name:          "content contradicts declared type",
+			input:         UploadAttachmentParams{FileName: "screenshot.png", ContentType: "image/png", Size: 15, File: strings.NewReader("<html><script>")},
+			expectedError: constant.ErrUnsupportedAttachmentType,
+		},
+		{
+			name:          "empty file",
+			input:         UploadAttachmentParams{FileName: "report.pdf", ContentType: "application/pdf", File: strings.NewReader("")},
+			expectedError: constant.ErrEmptyAttachmentFile,
+		},
+		{
+			name:  "blob is deleted when metadata is not saved",
+			input: UploadAttachmentParams{FileName: "report.pdf", ContentType: "application/pdf", Size: 6, File: strings.NewReader("%PDF-1")},
+			mock: func(m mocks) {
+				m.task.EXPECT().GetTaskByID(gomock.Any(), 1).Return(entity.Task{ID: 1}, nil)
+				m.blob.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(6), "application/pdf").Return(nil)
+				m.attachment.EXPECT().CreateAttachment(gomock.Any(), gomock.Any()).Return(entity.Attachment{}, constant.ErrTaskIDNotExists)
+				m.blob.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: constant.ErrTaskNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				task:       mock_storage.NewMockTask(ctrl),
+				attachment: mock_storage.NewMockAttachment(ctrl),
+				blob:       mock_blobstore.NewMockBlobStore(ctrl),
+				logger:     mock_logger.NewMockLogger(ctrl),
+			}
+			if tc.mock != nil {
+				tc.mock(m)
+			}
+
+			service := NewAttachmentService(m.attachment, m.task, m.blob, testLimits, m.logger)
+
+			output, err := service.UploadAttachment(context.Background(), "1", tc.input)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, tc.expectedOutput, output)
+			}
+		})
+	}
+}
+
+func TestAttachmentService_DeleteAttachmentByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	attachment := mock_storage.NewMockAttachment(ctrl)
+	blob := mock_blobstore.NewMockBlobStore(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+
+	attachment.EXPECT().DeleteAttachment(gomock.Any(), 1, 2).Return("tasks/1/token", nil)
+	blob.EXPECT().Delete(gomock.Any(), "tasks/1/token").Return(errors.New("connection refused"))
+	logger.EXPECT().ErrorContext(gomock.Any(), "error deleting attachment blob", gomock.Any(), gomock.Any())
+
+	service := NewAttachmentService(attachment, mock_storage.NewMockTask(ctrl), blob, testLimits, logger)
+
+	err := service.DeleteAttachmentByID(context.Background(), "1", "2")
+	require.NoError(t, err)
+}
+
+func TestAttachmentService_DownloadAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	attachment := mock_storage.NewMockAttachment(ctrl)
+	blob := mock_blobstore.NewMockBlobStore(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+
+	attachment.EXPECT().GetAttachmentByID(gomock.Any(), 1, 3).Return(entity.Attachment{}, constant.ErrAttachmentIDNotExists)
+
+	service := NewAttachmentService(attachment, mock_storage.NewMockTask(ctrl), blob, testLimits, logger)
+
+	_, _, err := service.DownloadAttachment(context.Background(), "1", "3")
+	require.ErrorIs(t, err, constant.ErrAttachmentNotFound)
+}
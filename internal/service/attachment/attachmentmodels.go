@@ -0,0 +1,34 @@
+package attachmentservice
+
+import "io"
+
+// Limits restrict uploaded attachments
+type Limits struct {
+	// MaxSize is the max attachment size in bytes
+	MaxSize int64
+	// ContentTypes are allowed media types like "image/png" or "image/*", empty list allows any type
+	ContentTypes []string
+}
+
+// UploadAttachmentParams describe file of the multipart upload
+type UploadAttachmentParams struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	File        io.Reader
+}
+
+type AttachmentModel struct {
+	ID          int    `json:"id"`
+	TaskID      int    `json:"task_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	CreatedAt   string `json:"created_at"`
+	URL         string `json:"url"`
+}
+
+type GetAttachmentsResponse struct {
+	Total       int               `json:"total"`
+	Attachments []AttachmentModel `json:"attachments"`
+}
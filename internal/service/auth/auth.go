@@ -0,0 +1,121 @@
+package authservice
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/service/validation"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/oidc"
+	"github.com/romandnk/todo/pkg/utils"
+)
+
+const stateSize = 16
+
+type AuthService struct {
+	provider oidc.Provider
+	settings Settings
+	logger   logger.Logger
+}
+
+// NewAuthService creates service of OIDC login, nil provider disables login and bearer tokens
+func NewAuthService(provider oidc.Provider, settings Settings, logger logger.Logger) *AuthService {
+	return &AuthService{
+		provider: provider,
+		settings: settings,
+		logger:   logger,
+	}
+}
+
+// Login returns the url of identity provider login page and the state which must come back with the code
+func (s *AuthService) Login(ctx context.Context) (LoginResponse, error) {
+	var response LoginResponse
+
+	if s.provider == nil {
+		return response, constant.ErrOIDCDisabled
+	}
+
+	state, err := utils.GenerateToken(stateSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error generating oidc state", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	url, err := s.provider.AuthCodeURL(ctx, state)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error building oidc login url", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	response.URL = url
+	response.State = state
+
+	return response, nil
+}
+
+// Callback checks state issued by Login and exchanges authorization code for tokens
+func (s *AuthService) Callback(ctx context.Context, params CallbackParams, expectedState string) (CallbackResponse, error) {
+	var response CallbackResponse
+
+	if s.provider == nil {
+		return response, constant.ErrOIDCDisabled
+	}
+
+	if params.Code == "" {
+		return response, constant.ErrEmptyAuthCode
+	}
+	if params.State == "" || subtle.ConstantTimeCompare([]byte(params.State), []byte(expectedState)) != 1 {
+		return response, constant.ErrInvalidOIDCState
+	}
+
+	token, err := s.provider.Exchange(ctx, params.Code)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidGrant) || errors.Is(err, oidc.ErrInvalidToken) {
+			return response, constant.ErrInvalidAuthCode.WithMessage(err.Error())
+		}
+		s.logger.ErrorContext(ctx, "error exchanging oidc code", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	username, err := s.username(token.Claims)
+	if err != nil {
+		return response, err
+	}
+
+	response.Username = username
+	response.IDToken = token.IDToken
+	response.AccessToken = token.AccessToken
+	response.ExpiresIn = token.ExpiresIn
+
+	return response, nil
+}
+
+// Authenticate verifies bearer token of the identity provider and returns the local username
+func (s *AuthService) Authenticate(ctx context.Context, token string) (string, error) {
+	if s.provider == nil {
+		return "", constant.ErrInvalidToken
+	}
+
+	claims, err := s.provider.Verify(ctx, token)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidToken) {
+			return "", constant.ErrInvalidToken.WithMessage(err.Error())
+		}
+		s.logger.ErrorContext(ctx, "error verifying oidc token", logger.Error(err))
+		return "", constant.ErrInternalError
+	}
+
+	return s.username(claims)
+}
+
+// username maps the configured claim to the local username
+func (s *AuthService) username(claims oidc.Claims) (string, error) {
+	username := claims.String(s.settings.UsernameClaim)
+	if validation.Username(s.settings.UsernameClaim, username) != nil {
+		return "", constant.ErrInvalidUsernameClaim.WithMessage(
+			fmt.Sprintf("token claim '%s' must contain valid username", s.settings.UsernameClaim))
+	}
+	return username, nil
+}
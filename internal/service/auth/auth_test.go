@@ -0,0 +1,164 @@
+package authservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/romandnk/todo/internal/constant"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/romandnk/todo/pkg/oidc"
+	mock_oidc "github.com/romandnk/todo/pkg/oidc/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+var settings = Settings{UsernameClaim: "preferred_username"}
+
+func TestAuthService_Callback(t *testing.T) {
+	testCases := []struct {
+		name          string
+		params        CallbackParams
+		expectedState string
+		providerM     func(m *mock_oidc.MockProvider)
+		expected      CallbackResponse
+		expectedError error
+	}{
+		{
+			name:          "OK",
+			params:        CallbackParams{Code: "code", State: "state"},
+			expectedState: "state",
+			providerM: func(m *mock_oidc.MockProvider) {
+				m.EXPECT().Exchange(gomock.Any(), "code").Return(oidc.Token{
+					IDToken:   "id",
+					ExpiresIn: 3600,
+					Claims:    oidc.Claims{"preferred_username": "petr"},
+				}, nil)
+			},
+			expected: CallbackResponse{Username: "petr", IDToken: "id", ExpiresIn: 3600},
+		},
+		{
+			name:          "state mismatch",
+			params:        CallbackParams{Code: "code", State: "state"},
+			expectedState: "another",
+			expectedError: constant.ErrInvalidOIDCState,
+		},
+		{
+			name:          "without state cookie",
+			params:        CallbackParams{Code: "code", State: ""},
+			expectedError: constant.ErrInvalidOIDCState,
+		},
+		{
+			name:          "empty code",
+			params:        CallbackParams{State: "state"},
+			expectedState: "state",
+			expectedError: constant.ErrEmptyAuthCode,
+		},
+		{
+			name:          "rejected code",
+			params:        CallbackParams{Code: "code", State: "state"},
+			expectedState: "state",
+			providerM: func(m *mock_oidc.MockProvider) {
+				m.EXPECT().Exchange(gomock.Any(), "code").Return(oidc.Token{}, fmt.Errorf("%w: expired", oidc.ErrInvalidGrant))
+			},
+			expectedError: constant.ErrInvalidAuthCode,
+		},
+		{
+			name:          "token without username",
+			params:        CallbackParams{Code: "code", State: "state"},
+			expectedState: "state",
+			providerM: func(m *mock_oidc.MockProvider) {
+				m.EXPECT().Exchange(gomock.Any(), "code").Return(oidc.Token{
+					IDToken: "id",
+					Claims:  oidc.Claims{"preferred_username": "petr ivanov"},
+				}, nil)
+			},
+			expectedError: constant.ErrInvalidUsernameClaim,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			provider := mock_oidc.NewMockProvider(ctrl)
+			if tc.providerM != nil {
+				tc.providerM(provider)
+			}
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			service := NewAuthService(provider, settings, logger)
+
+			resp, err := service.Callback(context.Background(), tc.params, tc.expectedState)
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expected, resp)
+		})
+	}
+}
+
+func TestAuthService_Authenticate(t *testing.T) {
+	testCases := []struct {
+		name             string
+		claims           oidc.Claims
+		verifyError      error
+		expectedUsername string
+		expectedError    error
+	}{
+		{
+			name:             "OK",
+			claims:           oidc.Claims{"preferred_username": "petr"},
+			expectedUsername: "petr",
+		},
+		{
+			name:          "invalid token",
+			verifyError:   fmt.Errorf("%w: token is expired", oidc.ErrInvalidToken),
+			expectedError: constant.ErrInvalidToken,
+		},
+		{
+			name:          "provider unavailable",
+			verifyError:   errors.New("connection refused"),
+			expectedError: constant.ErrInternalError,
+		},
+		{
+			name:          "without username claim",
+			claims:        oidc.Claims{"email": "petr@example.com"},
+			expectedError: constant.ErrInvalidUsernameClaim,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			provider := mock_oidc.NewMockProvider(ctrl)
+			provider.EXPECT().Verify(gomock.Any(), "token").Return(tc.claims, tc.verifyError)
+			logger := mock_logger.NewMockLogger(ctrl)
+			if tc.expectedError == constant.ErrInternalError {
+				logger.EXPECT().ErrorContext(gomock.Any(), "error verifying oidc token", gomock.Any())
+			}
+
+			service := NewAuthService(provider, settings, logger)
+
+			username, err := service.Authenticate(context.Background(), "token")
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expectedUsername, username)
+		})
+	}
+}
+
+func TestAuthService_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewAuthService(nil, settings, mock_logger.NewMockLogger(ctrl))
+
+	_, err := service.Login(context.Background())
+	require.ErrorIs(t, err, constant.ErrOIDCDisabled)
+
+	_, err = service.Authenticate(context.Background(), "token")
+	require.ErrorIs(t, err, constant.ErrInvalidToken)
+}
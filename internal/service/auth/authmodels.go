@@ -0,0 +1,26 @@
+package authservice
+
+// Settings configure mapping of identity provider users to local users
+type Settings struct {
+	// UsernameClaim is the token claim used as the local username
+	UsernameClaim string
+}
+
+// LoginResponse contains state which must come back with authorization code
+type LoginResponse struct {
+	URL   string
+	State string
+}
+
+type CallbackParams struct {
+	Code  string `form:"code"`
+	State string `form:"state"`
+}
+
+// CallbackResponse contains tokens of the signed in user, IDToken is used as bearer token
+type CallbackResponse struct {
+	Username    string `json:"username"`
+	IDToken     string `json:"id_token"`
+	AccessToken string `json:"access_token,omitempty"`
+	ExpiresIn   int    `json:"expires_in,omitempty"`
+}
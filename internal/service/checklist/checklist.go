@@ -0,0 +1,238 @@
+package checklistservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	storage "github.com/romandnk/todo/internal/repo"
+	"github.com/romandnk/todo/internal/service/validation"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/timezone"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ChecklistService struct {
+	checklist storage.Checklist
+	task      storage.Task
+	logger    logger.Logger
+}
+
+func NewChecklistService(checklist storage.Checklist, task storage.Task, logger logger.Logger) *ChecklistService {
+	return &ChecklistService{
+		checklist: checklist,
+		task:      task,
+		logger:    logger,
+	}
+}
+
+// AddChecklistItem adds not done item to the end of the task checklist
+func (s *ChecklistService) AddChecklistItem(ctx context.Context, taskIDStr string, params AddChecklistItemParams) (ChecklistItemModel, error) {
+	var response ChecklistItemModel
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	params.Text = strings.TrimSpace(params.Text)
+	var v validation.Validator
+	v.Check(validation.ChecklistItemText(params.Text))
+	if !v.Valid() {
+		return response, v.Err()
+	}
+
+	item, err := s.checklist.AddChecklistItem(ctx, entity.ChecklistItem{
+		TaskID: taskID,
+		Text:   params.Text,
+	})
+	if err != nil {
+		if errors.Is(err, constant.ErrTaskIDNotExists) {
+			return response, constant.ErrTaskNotFound.WithMessage(fmt.Sprintf("task with id '%d' is not found", taskID))
+		}
+		s.logger.ErrorContext(ctx, "error adding repo checklist item", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	return checklistItemModel(&item, timezone.FromContext(ctx)), nil
+}
+
+// GetChecklist returns task checklist items in their order with completion percentage
+func (s *ChecklistService) GetChecklist(ctx context.Context, taskIDStr string) (GetChecklistResponse, error) {
+	var response GetChecklistResponse
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	err = s.checkTask(ctx, taskID)
+	if err != nil {
+		return response, err
+	}
+
+	return s.checklistResponse(ctx, taskID)
+}
+
+// ToggleChecklistItem marks not done item as done and done item as not done
+func (s *ChecklistService) ToggleChecklistItem(ctx context.Context, taskIDStr, idStr string) (ChecklistItemModel, error) {
+	var response ChecklistItemModel
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, err
+	}
+	id, err := s.parseItemID(ctx, idStr)
+	if err != nil {
+		return response, err
+	}
+
+	item, err := s.checklist.ToggleChecklistItem(ctx, taskID, id)
+	if err != nil {
+		if errors.Is(err, constant.ErrChecklistItemIDNotExists) {
+			return response, constant.ErrChecklistItemNotFound.WithMessage(fmt.Sprintf("checklist item with id '%d' is not found", id))
+		}
+		s.logger.ErrorContext(ctx, "error toggling repo checklist item", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	return checklistItemModel(&item, timezone.FromContext(ctx)), nil
+}
+
+// ReorderChecklist places task checklist items in order of params ids and returns reordered checklist
+func (s *ChecklistService) ReorderChecklist(ctx context.Context, taskIDStr string, params ReorderChecklistParams) (GetChecklistResponse, error) {
+	var response GetChecklistResponse
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	seen := make(map[int]struct{}, len(params.IDs))
+	for _, id := range params.IDs {
+		if _, ok := seen[id]; ok || id <= 0 {
+			return response, constant.ErrInvalidChecklistOrder
+		}
+		seen[id] = struct{}{}
+	}
+
+	err = s.checkTask(ctx, taskID)
+	if err != nil {
+		return response, err
+	}
+
+	err = s.checklist.ReorderChecklistItems(ctx, taskID, params.IDs)
+	if err != nil {
+		if errors.Is(err, constant.ErrChecklistOrderMismatch) {
+			return response, constant.ErrInvalidChecklistOrder
+		}
+		s.logger.ErrorContext(ctx, "error reordering repo checklist items", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	return s.checklistResponse(ctx, taskID)
+}
+
+func (s *ChecklistService) DeleteChecklistItemByID(ctx context.Context, taskIDStr, idStr string) error {
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return err
+	}
+	id, err := s.parseItemID(ctx, idStr)
+	if err != nil {
+		return err
+	}
+
+	err = s.checklist.DeleteChecklistItem(ctx, taskID, id)
+	if err != nil {
+		if errors.Is(err, constant.ErrChecklistItemIDNotExists) {
+			return constant.ErrChecklistItemNotFound.WithMessage(fmt.Sprintf("checklist item with id '%d' is not found", id))
+		}
+		s.logger.ErrorContext(ctx, "error deleting repo checklist item", logger.Error(err))
+		return constant.ErrInternalError
+	}
+
+	return nil
+}
+
+// checkTask returns not found error if the task is deleted or doesn't exist
+func (s *ChecklistService) checkTask(ctx context.Context, taskID int) error {
+	_, err := s.task.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return constant.ErrTaskNotFound.WithMessage(fmt.Sprintf("task with id '%d' is not found", taskID))
+		}
+		s.logger.ErrorContext(ctx, "error getting repo task by id", logger.Error(err))
+		return constant.ErrInternalError
+	}
+	return nil
+}
+
+func (s *ChecklistService) checklistResponse(ctx context.Context, taskID int) (GetChecklistResponse, error) {
+	var response GetChecklistResponse
+
+	items, err := s.checklist.GetChecklistItemsByTaskID(ctx, taskID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error getting repo task checklist items", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	loc := timezone.FromContext(ctx)
+	response.Items = make([]ChecklistItemModel, 0, len(items))
+	for _, item := range items {
+		if item.Done {
+			response.Done++
+		}
+		response.Items = append(response.Items, checklistItemModel(item, loc))
+	}
+	response.Total = len(response.Items)
+	response.Completion = entity.ChecklistCompletion(response.Done, response.Total)
+
+	return response, nil
+}
+
+func (s *ChecklistService) parseTaskID(ctx context.Context, idStr string) (int, error) {
+	if idStr == "" {
+		return 0, constant.ErrEmptyTaskID
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error converting string task id to int task id", logger.Error(err))
+		return 0, constant.ErrInvalidTaskID
+	}
+	if id <= 0 {
+		return 0, constant.ErrNonPositiveTaskID
+	}
+	return id, nil
+}
+
+func (s *ChecklistService) parseItemID(ctx context.Context, idStr string) (int, error) {
+	if idStr == "" {
+		return 0, constant.ErrEmptyChecklistItemID
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error converting string checklist item id to int checklist item id", logger.Error(err))
+		return 0, constant.ErrInvalidChecklistItemID
+	}
+	if id <= 0 {
+		return 0, constant.ErrNonPositiveChecklistItemID
+	}
+	return id, nil
+}
+
+// checklistItemModel renders creation time in loc
+func checklistItemModel(item *entity.ChecklistItem, loc *time.Location) ChecklistItemModel {
+	return ChecklistItemModel{
+		ID:        item.ID,
+		TaskID:    item.TaskID,
+		Text:      item.Text,
+		Done:      item.Done,
+		Position:  item.Position,
+		CreatedAt: item.CreatedAt.In(loc).Format(time.RFC3339),
+	}
+}
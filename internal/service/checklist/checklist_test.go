@@ -0,0 +1,176 @@
+package checklistservice
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	mock_storage "github.com/romandnk/todo/internal/repo/mock"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChecklistService_AddChecklistItem(t *testing.T) {
+	created := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		input          AddChecklistItemParams
+		mock           func(m *mock_storage.MockChecklist)
+		expectedOutput ChecklistItemModel
+		expectedError  error
+	}{
+		{
+			name:  "OK",
+			input: AddChecklistItemParams{Text: "  buy milk "},
+			mock: func(m *mock_storage.MockChecklist) {
+				m.EXPECT().AddChecklistItem(gomock.Any(), entity.ChecklistItem{TaskID: 1, Text: "buy milk"}).
+					Return(entity.ChecklistItem{ID: 2, TaskID: 1, Text: "buy milk", Position: 3, CreatedAt: created}, nil)
+			},
+			expectedOutput: ChecklistItemModel{
+				ID:        2,
+				TaskID:    1,
+				Text:      "buy milk",
+				Position:  3,
+				CreatedAt: "2030-01-02T10:00:00Z",
+			},
+		},
+		{
+			name:          "too long text",
+			input:         AddChecklistItemParams{Text: strings.Repeat("a", 256)},
+			expectedError: constant.ValidationErrors{constant.ErrTooLongChecklistItemText},
+		},
+		{
+			name:  "task not found",
+			input: AddChecklistItemParams{Text: "buy milk"},
+			mock: func(m *mock_storage.MockChecklist) {
+				m.EXPECT().AddChecklistItem(gomock.Any(), gomock.Any()).Return(entity.ChecklistItem{}, constant.ErrTaskIDNotExists)
+			},
+			expectedError: constant.ErrTaskNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			checklist := mock_storage.NewMockChecklist(ctrl)
+			if tc.mock != nil {
+				tc.mock(checklist)
+			}
+
+			service := NewChecklistService(checklist, mock_storage.NewMockTask(ctrl), mock_logger.NewMockLogger(ctrl))
+
+			output, err := service.AddChecklistItem(context.Background(), "1", tc.input)
+			if validationErrs, ok := tc.expectedError.(constant.ValidationErrors); ok {
+				require.Equal(t, validationErrs, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expectedOutput, output)
+		})
+	}
+}
+
+func TestChecklistService_GetChecklist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	checklist := mock_storage.NewMockChecklist(ctrl)
+	task := mock_storage.NewMockTask(ctrl)
+
+	task.EXPECT().GetTaskByID(gomock.Any(), 1).Return(entity.Task{ID: 1}, nil)
+	checklist.EXPECT().GetChecklistItemsByTaskID(gomock.Any(), 1).Return([]*entity.ChecklistItem{
+		{ID: 1, TaskID: 1, Text: "a", Done: true, Position: 1},
+		{ID: 2, TaskID: 1, Text: "b", Done: false, Position: 2},
+		{ID: 3, TaskID: 1, Text: "c", Done: true, Position: 3},
+	}, nil)
+
+	service := NewChecklistService(checklist, task, mock_logger.NewMockLogger(ctrl))
+
+	output, err := service.GetChecklist(context.Background(), "1")
+	require.NoError(t, err)
+	require.Equal(t, 3, output.Total)
+	require.Equal(t, 2, output.Done)
+	require.Equal(t, 66, output.Completion)
+	require.Len(t, output.Items, 3)
+}
+
+func TestChecklistService_ReorderChecklist(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ids           []int
+		mock          func(task *mock_storage.MockTask, checklist *mock_storage.MockChecklist)
+		expectedError error
+	}{
+		{
+			name: "OK",
+			ids:  []int{2, 1},
+			mock: func(task *mock_storage.MockTask, checklist *mock_storage.MockChecklist) {
+				task.EXPECT().GetTaskByID(gomock.Any(), 1).Return(entity.Task{ID: 1}, nil)
+				checklist.EXPECT().ReorderChecklistItems(gomock.Any(), 1, []int{2, 1}).Return(nil)
+				checklist.EXPECT().GetChecklistItemsByTaskID(gomock.Any(), 1).Return([]*entity.ChecklistItem{}, nil)
+			},
+		},
+		{
+			name:          "duplicated id",
+			ids:           []int{2, 2},
+			expectedError: constant.ErrInvalidChecklistOrder,
+		},
+		{
+			name: "ids don't match task items",
+			ids:  []int{2, 1},
+			mock: func(task *mock_storage.MockTask, checklist *mock_storage.MockChecklist) {
+				task.EXPECT().GetTaskByID(gomock.Any(), 1).Return(entity.Task{ID: 1}, nil)
+				checklist.EXPECT().ReorderChecklistItems(gomock.Any(), 1, []int{2, 1}).Return(constant.ErrChecklistOrderMismatch)
+			},
+			expectedError: constant.ErrInvalidChecklistOrder,
+		},
+		{
+			name: "task not found",
+			ids:  []int{2, 1},
+			mock: func(task *mock_storage.MockTask, checklist *mock_storage.MockChecklist) {
+				task.EXPECT().GetTaskByID(gomock.Any(), 1).Return(entity.Task{}, pgx.ErrNoRows)
+			},
+			expectedError: constant.ErrTaskNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			task := mock_storage.NewMockTask(ctrl)
+			checklist := mock_storage.NewMockChecklist(ctrl)
+			if tc.mock != nil {
+				tc.mock(task, checklist)
+			}
+
+			service := NewChecklistService(checklist, task, mock_logger.NewMockLogger(ctrl))
+
+			_, err := service.ReorderChecklist(context.Background(), "1", ReorderChecklistParams{IDs: tc.ids})
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestChecklistService_ToggleChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	checklist := mock_storage.NewMockChecklist(ctrl)
+	checklist.EXPECT().ToggleChecklistItem(gomock.Any(), 1, 5).Return(entity.ChecklistItem{}, constant.ErrChecklistItemIDNotExists)
+
+	service := NewChecklistService(checklist, mock_storage.NewMockTask(ctrl), mock_logger.NewMockLogger(ctrl))
+
+	_, err := service.ToggleChecklistItem(context.Background(), "1", "5")
+	require.ErrorIs(t, err, constant.ErrChecklistItemNotFound)
+}
@@ -0,0 +1,27 @@
+package checklistservice
+
+type AddChecklistItemParams struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// ReorderChecklistParams contain every checklist item id of the task in the new order
+type ReorderChecklistParams struct {
+	IDs []int `json:"ids" binding:"required"`
+}
+
+type ChecklistItemModel struct {
+	ID        int    `json:"id"`
+	TaskID    int    `json:"task_id"`
+	Text      string `json:"text"`
+	Done      bool   `json:"done"`
+	Position  int    `json:"position"`
+	CreatedAt string `json:"created_at"`
+}
+
+type GetChecklistResponse struct {
+	Total int `json:"total"`
+	Done  int `json:"done"`
+	// Completion is the percentage of done items rounded down
+	Completion int                  `json:"completion"`
+	Items      []ChecklistItemModel `json:"items"`
+}
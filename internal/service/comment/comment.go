@@ -0,0 +1,260 @@
+package commentservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	storage "github.com/romandnk/todo/internal/repo"
+	"github.com/romandnk/todo/internal/service/validation"
+	"github.com/romandnk/todo/pkg/currentuser"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/romandnk/todo/pkg/timezone"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type CommentService struct {
+	comment storage.Comment
+	task    storage.Task
+	logger  logger.Logger
+}
+
+func NewCommentService(comment storage.Comment, task storage.Task, logger logger.Logger) *CommentService {
+	return &CommentService{
+		comment: comment,
+		task:    task,
+		logger:  logger,
+	}
+}
+
+// CreateComment adds comment of the request author to not deleted task
+func (s *CommentService) CreateComment(ctx context.Context, taskIDStr string, params CreateCommentParams) (CommentModel, error) {
+	var response CommentModel
+
+	author := currentuser.FromContext(ctx)
+	if author == "" {
+		return response, constant.ErrUsernameRequired
+	}
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	params.Body = strings.TrimSpace(params.Body)
+
+	var v validation.Validator
+	v.Check(validation.CommentAuthor(author))
+	v.Check(validation.CommentBody(params.Body))
+	if !v.Valid() {
+		return response, v.Err()
+	}
+
+	comment, err := s.comment.CreateComment(ctx, entity.Comment{
+		TaskID: taskID,
+		Author: author,
+		Body:   params.Body,
+	})
+	if err != nil {
+		if errors.Is(err, constant.ErrTaskIDNotExists) {
+			return response, constant.ErrTaskNotFound.WithMessage(fmt.Sprintf("task with id '%d' is not found", taskID))
+		}
+		s.logger.ErrorContext(ctx, "error creating repo comment", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	return commentModel(&comment, timezone.FromContext(ctx)), nil
+}
+
+// GetComments returns task comments in creation order, lastIDStr is the id of the last comment of the previous page
+func (s *CommentService) GetComments(ctx context.Context, taskIDStr, limitStr, lastIDStr string) (GetCommentsResponse, error) {
+	var response GetCommentsResponse
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	var limit int
+	if limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "error converting limit into int", logger.Error(err))
+			return response, constant.ErrInvalidLimit
+		}
+	}
+	if limit < 0 {
+		return response, constant.ErrNegativeLimit
+	}
+
+	var lastID int
+	if lastIDStr != "" {
+		lastID, err = strconv.Atoi(lastIDStr)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "error converting last id into int", logger.Error(err))
+			return response, constant.ErrInvalidLastCommentID
+		}
+	}
+	if lastID < 0 {
+		return response, constant.ErrNegativeLastCommentID
+	}
+
+	task, err := s.task.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return response, constant.ErrTaskNotFound.WithMessage(fmt.Sprintf("task with id '%d' is not found", taskID))
+		}
+		s.logger.ErrorContext(ctx, "error getting repo task by id", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	comments, err := s.comment.GetCommentsByTaskID(ctx, taskID, limit, lastID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error getting repo task comments", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	loc := timezone.FromContext(ctx)
+	response.Comments = make([]CommentModel, 0, len(comments))
+	for _, comment := range comments {
+		response.Comments = append(response.Comments, commentModel(comment, loc))
+	}
+	response.Total = task.CommentsCount
+
+	return response, nil
+}
+
+// UpdateCommentByID changes comment body, only its author or workspace admin can do it
+func (s *CommentService) UpdateCommentByID(ctx context.Context, taskIDStr, idStr string, params UpdateCommentParams) (CommentModel, error) {
+	var response CommentModel
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, err
+	}
+	id, err := s.parseCommentID(ctx, idStr)
+	if err != nil {
+		return response, err
+	}
+
+	params.Body = strings.TrimSpace(params.Body)
+	var v validation.Validator
+	v.Check(validation.CommentBody(params.Body))
+	if !v.Valid() {
+		return response, v.Err()
+	}
+
+	err = s.authorize(ctx, taskID, id)
+	if err != nil {
+		return response, err
+	}
+
+	comment, err := s.comment.UpdateComment(ctx, entity.Comment{
+		ID:     id,
+		TaskID: taskID,
+		Body:   params.Body,
+	})
+	if err != nil {
+		if errors.Is(err, constant.ErrCommentIDNotExists) {
+			return response, constant.ErrCommentNotFound.WithMessage(fmt.Sprintf("comment with id '%d' is not found", id))
+		}
+		s.logger.ErrorContext(ctx, "error updating repo comment", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	return commentModel(&comment, timezone.FromContext(ctx)), nil
+}
+
+// DeleteCommentByID deletes comment, only its author or workspace admin can do it
+func (s *CommentService) DeleteCommentByID(ctx context.Context, taskIDStr, idStr string) error {
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return err
+	}
+	id, err := s.parseCommentID(ctx, idStr)
+	if err != nil {
+		return err
+	}
+
+	err = s.authorize(ctx, taskID, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.comment.DeleteComment(ctx, taskID, id)
+	if err != nil {
+		if errors.Is(err, constant.ErrCommentIDNotExists) {
+			return constant.ErrCommentNotFound.WithMessage(fmt.Sprintf("comment with id '%d' is not found", id))
+		}
+		s.logger.ErrorContext(ctx, "error deleting repo comment", logger.Error(err))
+		return constant.ErrInternalError
+	}
+
+	return nil
+}
+
+// authorize allows changing the comment to its author and workspace admins,
+// author of the comment never changes, so it is checked before the change
+func (s *CommentService) authorize(ctx context.Context, taskID, id int) error {
+	comment, err := s.comment.GetCommentByID(ctx, taskID, id)
+	if err != nil {
+		if errors.Is(err, constant.ErrCommentIDNotExists) {
+			return constant.ErrCommentNotFound.WithMessage(fmt.Sprintf("comment with id '%d' is not found", id))
+		}
+		s.logger.ErrorContext(ctx, "error getting repo comment by id", logger.Error(err))
+		return constant.ErrInternalError
+	}
+
+	if comment.Author != currentuser.FromContext(ctx) && !entity.HasPermission(tenant.Role(ctx), entity.PermMembersAdmin) {
+		return constant.ErrPermissionDenied
+	}
+
+	return nil
+}
+
+func (s *CommentService) parseTaskID(ctx context.Context, idStr string) (int, error) {
+	if idStr == "" {
+		return 0, constant.ErrEmptyTaskID
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error converting string task id to int task id", logger.Error(err))
+		return 0, constant.ErrInvalidTaskID
+	}
+	if id <= 0 {
+		return 0, constant.ErrNonPositiveTaskID
+	}
+	return id, nil
+}
+
+func (s *CommentService) parseCommentID(ctx context.Context, idStr string) (int, error) {
+	if idStr == "" {
+		return 0, constant.ErrEmptyCommentID
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error converting string comment id to int comment id", logger.Error(err))
+		return 0, constant.ErrInvalidCommentID
+	}
+	if id <= 0 {
+		return 0, constant.ErrNonPositiveCommentID
+	}
+	return id, nil
+}
+
+// commentModel renders comment timestamps in loc
+func commentModel(comment *entity.Comment, loc *time.Location) CommentModel {
+	return CommentModel{
+		ID:        comment.ID,
+		TaskID:    comment.TaskID,
+		Author:    comment.Author,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt.In(loc).Format(time.RFC3339),
+		UpdatedAt: comment.UpdatedAt.In(loc).Format(time.RFC3339),
+	}
+}
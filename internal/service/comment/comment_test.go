@@ -0,0 +1,275 @@
+package commentservice
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	mock_storage "github.com/romandnk/todo/internal/repo/mock"
+	"github.com/romandnk/todo/pkg/currentuser"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/romandnk/todo/pkg/timezone"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommentService_CreateComment(t *testing.T) {
+	created := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		taskID         string
+		username       string
+		input          CreateCommentParams
+		commentMock    func(m *mock_storage.MockComment)
+		expectedOutput CommentModel
+		expectedError  error
+	}{
+		{
+			name:     "OK",
+			taskID:   "1",
+			username: "ivan",
+			input:    CreateCommentParams{Body: " Call before noon "},
+			commentMock: func(m *mock_storage.MockComment) {
+				m.EXPECT().CreateComment(gomock.Any(), entity.Comment{TaskID: 1, Author: "ivan", Body: "Call before noon"}).
+					Return(entity.Comment{
+						ID:        2,
+						TaskID:    1,
+						Author:    "ivan",
+						Body:      "Call before noon",
+						CreatedAt: created,
+						UpdatedAt: created,
+					}, nil)
+			},
+			expectedOutput: CommentModel{
+				ID:        2,
+				TaskID:    1,
+				Author:    "ivan",
+				Body:      "Call before noon",
+				CreatedAt: "2030-01-02T13:00:00+03:00",
+				UpdatedAt: "2030-01-02T13:00:00+03:00",
+			},
+		},
+		{
+			name:          "invalid task id",
+			taskID:        "one",
+			username:      "ivan",
+			input:         CreateCommentParams{Body: "Call before noon"},
+			expectedError: constant.ErrInvalidTaskID,
+		},
+		{
+			name:          "anonymous author",
+			taskID:        "1",
+			input:         CreateCommentParams{Body: "Call before noon"},
+			expectedError: constant.ErrUsernameRequired,
+		},
+		{
+			name:     "all violations",
+			taskID:   "1",
+			username: strings.Repeat("a", 65),
+			input:    CreateCommentParams{Body: " "},
+			expectedError: constant.ValidationErrors{
+				constant.ErrTooLongCommentAuthor,
+				constant.ErrEmptyCommentBody,
+			},
+		},
+		{
+			name:     "task not found",
+			taskID:   "1",
+			username: "ivan",
+			input:    CreateCommentParams{Body: "Call before noon"},
+			commentMock: func(m *mock_storage.MockComment) {
+				m.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(entity.Comment{}, constant.ErrTaskIDNotExists)
+			},
+			expectedError: constant.ErrTaskNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			comment := mock_storage.NewMockComment(ctrl)
+			task := mock_storage.NewMockTask(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+			logger.EXPECT().ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+			if tc.commentMock != nil {
+				tc.commentMock(comment)
+			}
+
+			ctx := timezone.WithLocation(context.Background(), time.FixedZone("MSK", 3*60*60))
+			ctx = currentuser.WithUsername(ctx, tc.username)
+
+			service := NewCommentService(comment, task, logger)
+
+			output, err := service.CreateComment(ctx, tc.taskID, tc.input)
+			if validationErrs, ok := tc.expectedError.(constant.ValidationErrors); ok {
+				require.Equal(t, validationErrs, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, tc.expectedOutput, output)
+			}
+		})
+	}
+}
+
+func TestCommentService_GetComments(t *testing.T) {
+	created := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		limit          string
+		lastID         string
+		taskMock       func(m *mock_storage.MockTask)
+		commentMock    func(m *mock_storage.MockComment)
+		expectedOutput GetCommentsResponse
+		expectedError  error
+	}{
+		{
+			name:   "OK",
+			limit:  "10",
+			lastID: "2",
+			taskMock: func(m *mock_storage.MockTask) {
+				m.EXPECT().GetTaskByID(gomock.Any(), 1).Return(entity.Task{ID: 1, CommentsCount: 3}, nil)
+			},
+			commentMock: func(m *mock_storage.MockComment) {
+				m.EXPECT().GetCommentsByTaskID(gomock.Any(), 1, 10, 2).Return([]*entity.Comment{
+					{ID: 3, TaskID: 1, Author: "petr", Body: "Done", CreatedAt: created, UpdatedAt: created},
+				}, nil)
+			},
+			expectedOutput: GetCommentsResponse{
+				Total: 3,
+				Comments: []CommentModel{
+					{ID: 3, TaskID: 1, Author: "petr", Body: "Done", CreatedAt: "2030-01-02T10:00:00Z", UpdatedAt: "2030-01-02T10:00:00Z"},
+				},
+			},
+		},
+		{
+			name:          "negative last id",
+			lastID:        "-1",
+			expectedError: constant.ErrNegativeLastCommentID,
+		},
+		{
+			name: "task not found",
+			taskMock: func(m *mock_storage.MockTask) {
+				m.EXPECT().GetTaskByID(gomock.Any(), 1).Return(entity.Task{}, pgx.ErrNoRows)
+			},
+			expectedError: constant.ErrTaskNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			comment := mock_storage.NewMockComment(ctrl)
+			task := mock_storage.NewMockTask(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			if tc.taskMock != nil {
+				tc.taskMock(task)
+			}
+			if tc.commentMock != nil {
+				tc.commentMock(comment)
+			}
+
+			service := NewCommentService(comment, task, logger)
+
+			output, err := service.GetComments(context.Background(), "1", tc.limit, tc.lastID)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, tc.expectedOutput, output)
+			}
+		})
+	}
+}
+
+func TestCommentService_DeleteCommentByID(t *testing.T) {
+	testCases := []struct {
+		name          string
+		id            string
+		username      string
+		role          string
+		commentMock   func(m *mock_storage.MockComment)
+		expectedError error
+	}{
+		{
+			name:     "OK by author",
+			id:       "2",
+			username: "ivan",
+			role:     entity.RoleMember,
+			commentMock: func(m *mock_storage.MockComment) {
+				m.EXPECT().GetCommentByID(gomock.Any(), 1, 2).Return(entity.Comment{ID: 2, TaskID: 1, Author: "ivan"}, nil)
+				m.EXPECT().DeleteComment(gomock.Any(), 1, 2).Return(nil)
+			},
+		},
+		{
+			name:     "OK by admin",
+			id:       "2",
+			username: "petr",
+			role:     entity.RoleAdmin,
+			commentMock: func(m *mock_storage.MockComment) {
+				m.EXPECT().GetCommentByID(gomock.Any(), 1, 2).Return(entity.Comment{ID: 2, TaskID: 1, Author: "ivan"}, nil)
+				m.EXPECT().DeleteComment(gomock.Any(), 1, 2).Return(nil)
+			},
+		},
+		{
+			name:     "not author",
+			id:       "2",
+			username: "petr",
+			role:     entity.RoleMember,
+			commentMock: func(m *mock_storage.MockComment) {
+				m.EXPECT().GetCommentByID(gomock.Any(), 1, 2).Return(entity.Comment{ID: 2, TaskID: 1, Author: "ivan"}, nil)
+			},
+			expectedError: constant.ErrPermissionDenied,
+		},
+		{
+			name:          "non positive comment id",
+			id:            "0",
+			expectedError: constant.ErrNonPositiveCommentID,
+		},
+		{
+			name:     "comment not found",
+			id:       "2",
+			username: "ivan",
+			role:     entity.RoleMember,
+			commentMock: func(m *mock_storage.MockComment) {
+				m.EXPECT().GetCommentByID(gomock.Any(), 1, 2).Return(entity.Comment{}, constant.ErrCommentIDNotExists)
+			},
+			expectedError: constant.ErrCommentNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			comment := mock_storage.NewMockComment(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			if tc.commentMock != nil {
+				tc.commentMock(comment)
+			}
+
+			service := NewCommentService(comment, mock_storage.NewMockTask(ctrl), logger)
+
+			ctx := currentuser.WithUsername(tenant.WithWorkspace(context.Background(), 1, tc.role), tc.username)
+
+			err := service.DeleteCommentByID(ctx, "1", tc.id)
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package commentservice
+
+// CreateCommentParams has no author, it is the request author
+type CreateCommentParams struct {
+	Body string `json:"body" binding:"required"`
+}
+
+type UpdateCommentParams struct {
+	Body string `json:"body" binding:"required"`
+}
+
+type CommentModel struct {
+	ID        int    `json:"id"`
+	TaskID    int    `json:"task_id"`
+	Author    string `json:"author"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type GetCommentsResponse struct {
+	// Total is the number of all task comments, not of the page
+	Total    int            `json:"total"`
+	Comments []CommentModel `json:"comments"`
+}
@@ -0,0 +1,150 @@
+package feedservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	storage "github.com/romandnk/todo/internal/repo"
+	"github.com/romandnk/todo/internal/service/task"
+	"github.com/romandnk/todo/pkg/ical"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/romandnk/todo/pkg/utils"
+	"strings"
+)
+
+const tokenSize = 32
+
+// feedFormats maps calendar components to task export formats
+var feedFormats = map[string]string{
+	ical.ComponentTodo:  taskservice.FormatICS,
+	ical.ComponentEvent: taskservice.FormatICSEvent,
+}
+
+type FeedService struct {
+	feed   storage.Feed
+	status storage.Status
+	logger logger.Logger
+}
+
+func NewFeedService(feed storage.Feed, status storage.Status, logger logger.Logger) *FeedService {
+	return &FeedService{
+		feed:   feed,
+		status: status,
+		logger: logger,
+	}
+}
+
+func (s *FeedService) CreateFeed(ctx context.Context, params CreateFeedParams) (CreateFeedResponse, error) {
+	var response CreateFeedResponse
+
+	params.StatusName = strings.ToLower(strings.TrimSpace(params.StatusName))
+	params.Component = strings.ToUpper(strings.TrimSpace(params.Component))
+	if params.Component == "" {
+		params.Component = ical.ComponentTodo
+	}
+	if _, ok := feedFormats[params.Component]; !ok {
+		return response, constant.ErrInvalidFeedComponent
+	}
+
+	var status entity.Status
+	var err error
+	if params.StatusName != "" {
+		status, err = s.status.GetStatusByName(ctx, params.StatusName)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "error getting repo status by name", logger.Error(err))
+			if errors.Is(err, pgx.ErrNoRows) {
+				return response, constant.ErrUnknownStatusName.WithMessage(fmt.Sprintf("status name '%s' is not found", params.StatusName))
+			}
+			return response, constant.ErrInternalError
+		}
+	}
+
+	token, err := utils.GenerateToken(tokenSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error generating feed token", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	feed := entity.Feed{
+		TokenHash: hashToken(token),
+		StatusID:  status.ID,
+		Component: params.Component,
+	}
+	_, err = s.feed.CreateFeed(ctx, feed)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error creating repo feed", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	response.Token = token
+	response.URL = fmt.Sprintf("/api/v1/feeds/%s/calendar.ics", token)
+
+	return response, nil
+}
+
+// GetFeed returns task filter and export format of the feed
+func (s *FeedService) GetFeed(ctx context.Context, token string) (GetFeedModel, error) {
+	var response GetFeedModel
+
+	if token == "" {
+		return response, constant.ErrEmptyFeedToken
+	}
+
+	feed, err := s.feed.GetFeedByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return response, constant.ErrFeedNotFound
+		}
+		s.logger.ErrorContext(ctx, "error getting repo feed by token", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	// feed token is valid in any workspace, the feed filter and tasks belong to the feed workspace
+	ctx = tenant.WithWorkspace(ctx, feed.WorkspaceID, "")
+	response.WorkspaceID = feed.WorkspaceID
+
+	if feed.StatusID != 0 {
+		status, err := s.status.GetStatusByID(ctx, feed.StatusID)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "error getting repo status by id", logger.Error(err))
+			if errors.Is(err, pgx.ErrNoRows) {
+				return response, constant.ErrStatusNotFound.WithMessage(fmt.Sprintf("status id '%d' is not found", feed.StatusID))
+			}
+			return response, constant.ErrInternalError
+		}
+		response.StatusName = status.Name
+	}
+
+	response.Format = feedFormats[feed.Component]
+
+	return response, nil
+}
+
+func (s *FeedService) DeleteFeed(ctx context.Context, token string) error {
+	if token == "" {
+		return constant.ErrEmptyFeedToken
+	}
+
+	err := s.feed.DeleteFeedByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, constant.ErrFeedNotExists) {
+			return constant.ErrFeedNotFound
+		}
+		s.logger.ErrorContext(ctx, "error deleting repo feed by token", logger.Error(err))
+		return constant.ErrInternalError
+	}
+
+	return nil
+}
+
+// hashToken returns hex encoded SHA-256 of the feed token, tokens are random so salt is not needed
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,68 @@
+package feedservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	mock_storage "github.com/romandnk/todo/internal/repo/mock"
+	taskservice "github.com/romandnk/todo/internal/service/task"
+	"github.com/romandnk/todo/pkg/ical"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestFeedService_CreateFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	feed := mock_storage.NewMockFeed(ctrl)
+	service := NewFeedService(feed, mock_storage.NewMockStatus(ctrl), mock_logger.NewMockLogger(ctrl))
+
+	var stored entity.Feed
+	feed.EXPECT().CreateFeed(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, f entity.Feed) (int, error) {
+			stored = f
+			return 1, nil
+		})
+
+	resp, err := service.CreateFeed(context.Background(), CreateFeedParams{})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Token)
+	require.Equal(t, "/api/v1/feeds/"+resp.Token+"/calendar.ics", resp.URL)
+
+	sum := sha256.Sum256([]byte(resp.Token))
+	require.Equal(t, hex.EncodeToString(sum[:]), stored.TokenHash)
+	require.Equal(t, ical.ComponentTodo, stored.Component)
+}
+
+func TestFeedService_GetFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	feed := mock_storage.NewMockFeed(ctrl)
+	service := NewFeedService(feed, mock_storage.NewMockStatus(ctrl), mock_logger.NewMockLogger(ctrl))
+
+	feed.EXPECT().GetFeedByTokenHash(gomock.Any(), hashToken("token")).
+		Return(entity.Feed{WorkspaceID: 2, Component: ical.ComponentEvent}, nil)
+
+	resp, err := service.GetFeed(context.Background(), "token")
+	require.NoError(t, err)
+	require.Equal(t, GetFeedModel{WorkspaceID: 2, Format: taskservice.FormatICSEvent}, resp)
+}
+
+func TestFeedService_DeleteFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	feed := mock_storage.NewMockFeed(ctrl)
+	service := NewFeedService(feed, mock_storage.NewMockStatus(ctrl), mock_logger.NewMockLogger(ctrl))
+
+	feed.EXPECT().DeleteFeedByTokenHash(gomock.Any(), hashToken("token")).Return(constant.ErrFeedNotExists)
+
+	err := service.DeleteFeed(context.Background(), "token")
+	require.ErrorIs(t, err, constant.ErrFeedNotFound)
+}
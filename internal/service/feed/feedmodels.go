@@ -0,0 +1,17 @@
+package feedservice
+
+type CreateFeedParams struct {
+	StatusName string `json:"status_name"`
+	Component  string `json:"component"`
+}
+
+type CreateFeedResponse struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+type GetFeedModel struct {
+	WorkspaceID int    `json:"workspace_id"`
+	StatusName  string `json:"status_name"`
+	Format      string `json:"format"`
+}
@@ -0,0 +1,111 @@
+package healthservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	storage "github.com/romandnk/todo/internal/repo"
+	"github.com/romandnk/todo/pkg/logger"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	checkPostgres   = "postgres"
+	checkMigrations = "migrations"
+)
+
+// Settings configure readiness checks
+type Settings struct {
+	// SchemaVersion is the version of migrations the app is built for, newer schema is ready as well
+	SchemaVersion int
+	// CheckTimeout limits every check
+	CheckTimeout time.Duration
+}
+
+type HealthService struct {
+	health   storage.Health
+	settings Settings
+	logger   logger.Logger
+	draining atomic.Bool
+}
+
+func NewHealthService(health storage.Health, settings Settings, logger logger.Logger) *HealthService {
+	return &HealthService{
+		health:   health,
+		settings: settings,
+		logger:   logger,
+	}
+}
+
+// Drain makes the app not ready before stopping, so load balancers stop sending new requests
+func (s *HealthService) Drain() {
+	s.draining.Store(true)
+}
+
+// Ready checks the app can serve requests: it is not draining, db is available and its schema is migrated
+func (s *HealthService) Ready(ctx context.Context) (HealthResponse, bool) {
+	if s.draining.Load() {
+		return HealthResponse{Status: StatusDraining}, false
+	}
+
+	response := HealthResponse{
+		Status: StatusReady,
+		Checks: map[string]string{
+			checkPostgres:   s.check(ctx, s.checkPostgres),
+			checkMigrations: s.check(ctx, s.checkMigrations),
+		},
+	}
+
+	for _, result := range response.Checks {
+		if result != StatusOK {
+			response.Status = StatusNotReady
+			return response, false
+		}
+	}
+
+	return response, true
+}
+
+// check runs the check with timeout and returns "ok" or the reason of the failure
+func (s *HealthService) check(ctx context.Context, check func(ctx context.Context) error) string {
+	ctx, cancel := context.WithTimeout(ctx, s.settings.CheckTimeout)
+	defer cancel()
+
+	err := check(ctx)
+	if err != nil {
+		return err.Error()
+	}
+
+	return StatusOK
+}
+
+func (s *HealthService) checkPostgres(ctx context.Context) error {
+	err := s.health.Ping(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error pinging postgres", logger.Error(err))
+		return errors.New("unavailable")
+	}
+	return nil
+}
+
+func (s *HealthService) checkMigrations(ctx context.Context) error {
+	version, err := s.health.GetSchemaVersion(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("no migrations applied")
+		}
+		s.logger.ErrorContext(ctx, "error getting repo schema version", logger.Error(err))
+		return errors.New("unavailable")
+	}
+
+	if version.Dirty {
+		return fmt.Errorf("migration %d is dirty", version.Version)
+	}
+	if version.Version < s.settings.SchemaVersion {
+		return fmt.Errorf("schema version %d is older than %d", version.Version, s.settings.SchemaVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package healthservice
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/entity"
+	mock_storage "github.com/romandnk/todo/internal/repo/mock"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+var settings = Settings{SchemaVersion: 13, CheckTimeout: time.Second}
+
+func TestHealthService_Ready(t *testing.T) {
+	testCases := []struct {
+		name             string
+		pingError        error
+		version          entity.SchemaVersion
+		versionError     error
+		expectedLogs     int
+		expectedResponse HealthResponse
+		expectedReady    bool
+	}{
+		{
+			name:    "OK",
+			version: entity.SchemaVersion{Version: 13},
+			expectedResponse: HealthResponse{
+				Status: StatusReady,
+				Checks: map[string]string{"postgres": "ok", "migrations": "ok"},
+			},
+			expectedReady: true,
+		},
+		{
+			name:    "newer schema",
+			version: entity.SchemaVersion{Version: 14},
+			expectedResponse: HealthResponse{
+				Status: StatusReady,
+				Checks: map[string]string{"postgres": "ok", "migrations": "ok"},
+			},
+			expectedReady: true,
+		},
+		{
+			name:    "older schema",
+			version: entity.SchemaVersion{Version: 12},
+			expectedResponse: HealthResponse{
+				Status: StatusNotReady,
+				Checks: map[string]string{"postgres": "ok", "migrations": "schema version 12 is older than 13"},
+			},
+		},
+		{
+			name:    "dirty migration",
+			version: entity.SchemaVersion{Version: 13, Dirty: true},
+			expectedResponse: HealthResponse{
+				Status: StatusNotReady,
+				Checks: map[string]string{"postgres": "ok", "migrations": "migration 13 is dirty"},
+			},
+		},
+		{
+			name:         "no migrations",
+			versionError: pgx.ErrNoRows,
+			expectedResponse: HealthResponse{
+				Status: StatusNotReady,
+				Checks: map[string]string{"postgres": "ok", "migrations": "no migrations applied"},
+			},
+		},
+		{
+			name:         "db is unavailable",
+			pingError:    errors.New("connection refused"),
+			versionError: errors.New("connection refused"),
+			expectedLogs: 2,
+			expectedResponse: HealthResponse{
+				Status: StatusNotReady,
+				Checks: map[string]string{"postgres": "unavailable", "migrations": "unavailable"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			health := mock_storage.NewMockHealth(ctrl)
+			health.EXPECT().Ping(gomock.Any()).Return(tc.pingError)
+			health.EXPECT().GetSchemaVersion(gomock.Any()).Return(tc.version, tc.versionError)
+			logger := mock_logger.NewMockLogger(ctrl)
+			logger.EXPECT().ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).Times(tc.expectedLogs)
+
+			service := NewHealthService(health, settings, logger)
+
+			response, ready := service.Ready(context.Background())
+			require.Equal(t, tc.expectedReady, ready)
+			require.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func TestHealthService_Drain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewHealthService(mock_storage.NewMockHealth(ctrl), settings, mock_logger.NewMockLogger(ctrl))
+	service.Drain()
+
+	response, ready := service.Ready(context.Background())
+	require.False(t, ready)
+	require.Equal(t, HealthResponse{Status: StatusDraining}, response)
+}
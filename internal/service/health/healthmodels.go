@@ -0,0 +1,16 @@
+package healthservice
+
+// statuses of the app and its checks
+const (
+	StatusOK       = "ok"
+	StatusReady    = "ready"
+	StatusNotReady = "not ready"
+	StatusDraining = "draining"
+)
+
+// HealthResponse is the response of liveness and readiness probes
+type HealthResponse struct {
+	Status string `json:"status"`
+	// Checks are results of dependency checks like "postgres": "ok"
+	Checks map[string]string `json:"checks,omitempty"`
+}
@@ -0,0 +1,152 @@
+package idempotencyservice
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	storage "github.com/romandnk/todo/internal/repo"
+	"github.com/romandnk/todo/pkg/currentuser"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/tenant"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const maxKeyLength = 255
+
+type IdempotencyService struct {
+	idempotency storage.Idempotency
+	ttl         time.Duration
+	logger      logger.Logger
+}
+
+func NewIdempotencyService(idempotency storage.Idempotency, ttl time.Duration, logger logger.Logger) *IdempotencyService {
+	return &IdempotencyService{
+		idempotency: idempotency,
+		ttl:         ttl,
+		logger:      logger,
+	}
+}
+
+// Principal identifies the request author, API key or anonymous request for scoping idempotency keys.
+// It does not depend on the credentials, so retry with refreshed token gets the stored response
+func Principal(ctx context.Context) string {
+	if id := tenant.APIKeyID(ctx); id != 0 {
+		return "api_key:" + strconv.Itoa(id)
+	}
+	if username := currentuser.FromContext(ctx); username != "" {
+		return "user:" + username
+	}
+	return "anonymous"
+}
+
+// Begin reserves the key of the request workspace and principal for the request with the hash.
+// If response for the same request is already stored, it is returned with true for replaying.
+func (s *IdempotencyService) Begin(ctx context.Context, key, requestHash string) (StoredResponse, bool, error) {
+	var response StoredResponse
+
+	if len(key) > maxKeyLength {
+		return response, false, constant.ErrTooLongIdempotencyKey
+	}
+
+	workspaceID, principal := tenant.WorkspaceID(ctx), Principal(ctx)
+
+	now := time.Now().UTC()
+	created, err := s.idempotency.CreateIdempotencyKey(ctx, entity.IdempotencyKey{
+		WorkspaceID: workspaceID,
+		Principal:   principal,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error creating repo idempotency key", logger.Error(err))
+		return response, false, constant.ErrInternalError
+	}
+	if created {
+		return response, false, nil
+	}
+
+	stored, err := s.idempotency.GetIdempotencyKey(ctx, workspaceID, principal, key)
+	if err != nil {
+		// key was deleted after failed request, client can retry
+		if errors.Is(err, pgx.ErrNoRows) {
+			return response, false, constant.ErrIdempotencyKeyInProgress
+		}
+		s.logger.ErrorContext(ctx, "error getting repo idempotency key", logger.Error(err))
+		return response, false, constant.ErrInternalError
+	}
+
+	if stored.RequestHash != requestHash {
+		return response, false, constant.ErrIdempotencyKeyReused
+	}
+
+	if stored.StatusCode == 0 {
+		return response, false, constant.ErrIdempotencyKeyInProgress
+	}
+
+	response.StatusCode = stored.StatusCode
+	response.Headers = stored.Headers
+	response.Body = stored.Body
+
+	return response, true, nil
+}
+
+// Complete stores response of the request. Key of the failed request
+// with server error is released, so the request can be retried.
+func (s *IdempotencyService) Complete(ctx context.Context, key string, response StoredResponse) error {
+	if response.StatusCode >= http.StatusInternalServerError {
+		return s.Release(ctx, key)
+	}
+
+	err := s.idempotency.SaveIdempotencyResponse(ctx, entity.IdempotencyKey{
+		WorkspaceID: tenant.WorkspaceID(ctx),
+		Principal:   Principal(ctx),
+		Key:         key,
+		StatusCode:  response.StatusCode,
+		Headers:     response.Headers,
+		Body:        response.Body,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error saving repo idempotency response", logger.Error(err))
+		return constant.ErrInternalError
+	}
+
+	return nil
+}
+
+// Release deletes the key of the request which response is not stored, so the request can be retried
+func (s *IdempotencyService) Release(ctx context.Context, key string) error {
+	err := s.idempotency.DeleteIdempotencyKey(ctx, tenant.WorkspaceID(ctx), Principal(ctx), key)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error deleting repo idempotency key", logger.Error(err))
+		return constant.ErrInternalError
+	}
+	return nil
+}
+
+// RunCleanup deletes expired keys every interval until ctx is done
+func (s *IdempotencyService) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.idempotency.DeleteExpiredIdempotencyKeys(ctx, time.Now().UTC())
+			if err != nil {
+				s.logger.ErrorContext(ctx, "error deleting expired repo idempotency keys", logger.Error(err))
+				continue
+			}
+			if deleted > 0 {
+				s.logger.InfoContext(ctx, "expired idempotency keys are deleted", logger.Int64("count", deleted))
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package idempotencyservice
+
+import (
+	"context"
+	"github.com/romandnk/todo/internal/entity"
+	mock_storage "github.com/romandnk/todo/internal/repo/mock"
+	"github.com/romandnk/todo/pkg/currentuser"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestPrincipal(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, "anonymous", Principal(ctx))
+	require.Equal(t, "user:ivan", Principal(currentuser.WithAuthenticatedUsername(ctx, "ivan")))
+	require.Equal(t, "api_key:3", Principal(tenant.WithAPIKeyID(currentuser.WithUsername(ctx, "ivan"), 3)))
+}
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idempotency := mock_storage.NewMockIdempotency(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+
+	service := NewIdempotencyService(idempotency, time.Hour, logger)
+
+	// the same key of another workspace is another key
+	ctx := tenant.WithWorkspace(currentuser.WithAuthenticatedUsername(context.Background(), "ivan"), 2, "")
+	idempotency.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, key entity.IdempotencyKey) (bool, error) {
+			require.Equal(t, 2, key.WorkspaceID)
+			require.Equal(t, "user:ivan", key.Principal)
+			require.Equal(t, "key", key.Key)
+			return false, nil
+		})
+	idempotency.EXPECT().GetIdempotencyKey(gomock.Any(), 2, "user:ivan", "key").
+		Return(entity.IdempotencyKey{RequestHash: "hash", StatusCode: 201, Body: []byte(`{"id":1}`)}, nil)
+
+	stored, replay, err := service.Begin(ctx, "key", "hash")
+	require.NoError(t, err)
+	require.True(t, replay)
+	require.Equal(t, StoredResponse{StatusCode: 201, Body: []byte(`{"id":1}`)}, stored)
+
+	idempotency.EXPECT().DeleteIdempotencyKey(gomock.Any(), 2, "user:ivan", "key").Return(nil)
+	require.NoError(t, service.Release(ctx, "key"))
+}
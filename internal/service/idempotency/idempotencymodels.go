@@ -0,0 +1,8 @@
+package idempotencyservice
+
+// StoredResponse is a response replayed for the request with the same idempotency key
+type StoredResponse struct {
+	StatusCode int
+	Headers    map[string][]string
+	Body       []byte
+}
@@ -0,0 +1,265 @@
+package memberservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	storage "github.com/romandnk/todo/internal/repo"
+	"github.com/romandnk/todo/internal/service/validation"
+	"github.com/romandnk/todo/pkg/currentuser"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/timezone"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AssignmentHook is called synchronously after assignee is added to or removed from the task
+type AssignmentHook func(ctx context.Context, event entity.AssignmentEvent)
+
+// LogAssignmentHook writes assignment events to the log
+func LogAssignmentHook(l logger.Logger) AssignmentHook {
+	return func(ctx context.Context, event entity.AssignmentEvent) {
+		l.InfoContext(ctx, "task assignment changed",
+			logger.String("type", event.Type),
+			logger.Int("task id", event.TaskID),
+			logger.String("username", event.Username),
+			logger.String("actor", event.Actor),
+		)
+	}
+}
+
+type MemberService struct {
+	member storage.Member
+	task   storage.Task
+	hooks  []AssignmentHook
+	logger logger.Logger
+}
+
+func NewMemberService(member storage.Member, task storage.Task, hooks []AssignmentHook, logger logger.Logger) *MemberService {
+	return &MemberService{
+		member: member,
+		task:   task,
+		hooks:  hooks,
+		logger: logger,
+	}
+}
+
+// GetMembers returns task assignees and watchers in order of their addition
+func (s *MemberService) GetMembers(ctx context.Context, taskIDStr string) (GetMembersResponse, error) {
+	var response GetMembersResponse
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	err = s.checkTask(ctx, taskID)
+	if err != nil {
+		return response, err
+	}
+
+	members, err := s.member.GetTaskMembers(ctx, taskID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error getting repo task members", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	loc := timezone.FromContext(ctx)
+	response.Assignees = make([]MemberModel, 0)
+	response.Watchers = make([]MemberModel, 0)
+	for _, member := range members {
+		switch member.Role {
+		case entity.MemberRoleAssignee:
+			response.Assignees = append(response.Assignees, memberModel(member, loc))
+		case entity.MemberRoleWatcher:
+			response.Watchers = append(response.Watchers, memberModel(member, loc))
+		}
+	}
+
+	return response, nil
+}
+
+// AddAssignee assigns the task to the user, assigned event is emitted only if the user was not assigned before
+func (s *MemberService) AddAssignee(ctx context.Context, taskIDStr string, params AddAssigneeParams) (MemberModel, error) {
+	var response MemberModel
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	params.Username = strings.TrimSpace(params.Username)
+	var v validation.Validator
+	v.Check(validation.Username("username", params.Username))
+	if !v.Valid() {
+		return response, v.Err()
+	}
+
+	member, err := s.addMember(ctx, entity.TaskMember{
+		TaskID:   taskID,
+		Username: params.Username,
+		Role:     entity.MemberRoleAssignee,
+	}, entity.AssignmentEventAssigned)
+	if err != nil {
+		return response, err
+	}
+
+	return memberModel(&member, timezone.FromContext(ctx)), nil
+}
+
+// DeleteAssignee unassigns the user from the task and emits unassigned event
+func (s *MemberService) DeleteAssignee(ctx context.Context, taskIDStr, username string) error {
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return err
+	}
+
+	if err := validation.Username("username", username); err != nil {
+		return err
+	}
+
+	return s.deleteMember(ctx, entity.TaskMember{
+		TaskID:   taskID,
+		Username: username,
+		Role:     entity.MemberRoleAssignee,
+	}, entity.AssignmentEventUnassigned)
+}
+
+// Watch makes the request author a watcher of the task
+func (s *MemberService) Watch(ctx context.Context, taskIDStr string) (MemberModel, error) {
+	var response MemberModel
+
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	username := currentuser.FromContext(ctx)
+	if username == "" {
+		return response, constant.ErrUsernameRequired
+	}
+
+	member, err := s.addMember(ctx, entity.TaskMember{
+		TaskID:   taskID,
+		Username: username,
+		Role:     entity.MemberRoleWatcher,
+	}, "")
+	if err != nil {
+		return response, err
+	}
+
+	return memberModel(&member, timezone.FromContext(ctx)), nil
+}
+
+// Unwatch stops the request author watching the task
+func (s *MemberService) Unwatch(ctx context.Context, taskIDStr string) error {
+	taskID, err := s.parseTaskID(ctx, taskIDStr)
+	if err != nil {
+		return err
+	}
+
+	username := currentuser.FromContext(ctx)
+	if username == "" {
+		return constant.ErrUsernameRequired
+	}
+
+	return s.deleteMember(ctx, entity.TaskMember{
+		TaskID:   taskID,
+		Username: username,
+		Role:     entity.MemberRoleWatcher,
+	}, "")
+}
+
+// addMember adds member to the task and emits event of eventType if the member is new, empty eventType emits nothing
+func (s *MemberService) addMember(ctx context.Context, member entity.TaskMember, eventType string) (entity.TaskMember, error) {
+	err := s.checkTask(ctx, member.TaskID)
+	if err != nil {
+		return member, err
+	}
+
+	member, added, err := s.member.AddTaskMember(ctx, member)
+	if err != nil {
+		if errors.Is(err, constant.ErrTaskIDNotExists) {
+			return member, constant.ErrTaskNotFound.WithMessage(fmt.Sprintf("task with id '%d' is not found", member.TaskID))
+		}
+		s.logger.ErrorContext(ctx, "error adding repo task member", logger.Error(err))
+		return member, constant.ErrInternalError
+	}
+
+	if added && eventType != "" {
+		s.emit(ctx, eventType, member)
+	}
+
+	return member, nil
+}
+
+// deleteMember removes member from the task and emits event of eventType, empty eventType emits nothing
+func (s *MemberService) deleteMember(ctx context.Context, member entity.TaskMember, eventType string) error {
+	err := s.member.DeleteTaskMember(ctx, member)
+	if err != nil {
+		if errors.Is(err, constant.ErrTaskMemberNotExists) {
+			return constant.ErrTaskMemberNotFound.WithMessage(fmt.Sprintf("%s '%s' of task with id '%d' is not found", member.Role, member.Username, member.TaskID))
+		}
+		s.logger.ErrorContext(ctx, "error deleting repo task member", logger.Error(err))
+		return constant.ErrInternalError
+	}
+
+	if eventType != "" {
+		s.emit(ctx, eventType, member)
+	}
+
+	return nil
+}
+
+func (s *MemberService) emit(ctx context.Context, eventType string, member entity.TaskMember) {
+	event := entity.AssignmentEvent{
+		Type:     eventType,
+		TaskID:   member.TaskID,
+		Username: member.Username,
+		Actor:    currentuser.FromContext(ctx),
+		At:       time.Now().UTC(),
+	}
+	for _, hook := range s.hooks {
+		hook(ctx, event)
+	}
+}
+
+// checkTask returns not found error if the task is deleted or doesn't exist
+func (s *MemberService) checkTask(ctx context.Context, taskID int) error {
+	_, err := s.task.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return constant.ErrTaskNotFound.WithMessage(fmt.Sprintf("task with id '%d' is not found", taskID))
+		}
+		s.logger.ErrorContext(ctx, "error getting repo task by id", logger.Error(err))
+		return constant.ErrInternalError
+	}
+	return nil
+}
+
+func (s *MemberService) parseTaskID(ctx context.Context, idStr string) (int, error) {
+	if idStr == "" {
+		return 0, constant.ErrEmptyTaskID
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error converting string task id to int task id", logger.Error(err))
+		return 0, constant.ErrInvalidTaskID
+	}
+	if id <= 0 {
+		return 0, constant.ErrNonPositiveTaskID
+	}
+	return id, nil
+}
+
+// memberModel renders addition time in loc
+func memberModel(member *entity.TaskMember, loc *time.Location) MemberModel {
+	return MemberModel{
+		Username:  member.Username,
+		CreatedAt: member.CreatedAt.In(loc).Format(time.RFC3339),
+	}
+}
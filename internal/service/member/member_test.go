@@ -0,0 +1,124 @@
+package memberservice
+
+import (
+	"context"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	mock_storage "github.com/romandnk/todo/internal/repo/mock"
+	"github.com/romandnk/todo/pkg/currentuser"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestMemberService_AddAssignee(t *testing.T) {
+	created := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		username       string
+		added          bool
+		expectedEvents []entity.AssignmentEvent
+	}{
+		{
+			name:     "new assignee",
+			username: " petr ",
+			added:    true,
+			expectedEvents: []entity.AssignmentEvent{
+				{Type: entity.AssignmentEventAssigned, TaskID: 1, Username: "petr", Actor: "ivan"},
+			},
+		},
+		{
+			name:     "already assigned",
+			username: "petr",
+			added:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			member := mock_storage.NewMockMember(ctrl)
+			task := mock_storage.NewMockTask(ctrl)
+
+			task.EXPECT().GetTaskByID(gomock.Any(), 1).Return(entity.Task{ID: 1}, nil)
+			member.EXPECT().AddTaskMember(gomock.Any(), entity.TaskMember{TaskID: 1, Username: "petr", Role: entity.MemberRoleAssignee}).
+				DoAndReturn(func(_ context.Context, m entity.TaskMember) (entity.TaskMember, bool, error) {
+					m.CreatedAt = created
+					return m, tc.added, nil
+				})
+
+			var events []entity.AssignmentEvent
+			hook := func(_ context.Context, event entity.AssignmentEvent) {
+				require.False(t, event.At.IsZero())
+				event.At = time.Time{}
+				events = append(events, event)
+			}
+
+			service := NewMemberService(member, task, []AssignmentHook{hook}, mock_logger.NewMockLogger(ctrl))
+
+			ctx := currentuser.WithUsername(context.Background(), "ivan")
+			output, err := service.AddAssignee(ctx, "1", AddAssigneeParams{Username: tc.username})
+			require.NoError(t, err)
+			require.Equal(t, MemberModel{Username: "petr", CreatedAt: "2030-01-02T10:00:00Z"}, output)
+			require.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
+
+func TestMemberService_DeleteAssignee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	member := mock_storage.NewMockMember(ctrl)
+	member.EXPECT().DeleteTaskMember(gomock.Any(), entity.TaskMember{TaskID: 1, Username: "petr", Role: entity.MemberRoleAssignee}).
+		Return(constant.ErrTaskMemberNotExists)
+
+	hook := func(context.Context, entity.AssignmentEvent) {
+		t.Fatal("event of not existing assignee is emitted")
+	}
+
+	service := NewMemberService(member, mock_storage.NewMockTask(ctrl), []AssignmentHook{hook}, mock_logger.NewMockLogger(ctrl))
+
+	err := service.DeleteAssignee(context.Background(), "1", "petr")
+	require.ErrorIs(t, err, constant.ErrTaskMemberNotFound)
+}
+
+func TestMemberService_Watch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewMemberService(mock_storage.NewMockMember(ctrl), mock_storage.NewMockTask(ctrl), nil, mock_logger.NewMockLogger(ctrl))
+
+	_, err := service.Watch(context.Background(), "1")
+	require.ErrorIs(t, err, constant.ErrUsernameRequired)
+}
+
+func TestMemberService_GetMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	member := mock_storage.NewMockMember(ctrl)
+	task := mock_storage.NewMockTask(ctrl)
+
+	created := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+	task.EXPECT().GetTaskByID(gomock.Any(), 1).Return(entity.Task{ID: 1}, nil)
+	member.EXPECT().GetTaskMembers(gomock.Any(), 1).Return([]*entity.TaskMember{
+		{TaskID: 1, Username: "petr", Role: entity.MemberRoleAssignee, CreatedAt: created},
+		{TaskID: 1, Username: "ivan", Role: entity.MemberRoleWatcher, CreatedAt: created},
+	}, nil)
+
+	service := NewMemberService(member, task, nil, mock_logger.NewMockLogger(ctrl))
+
+	output, err := service.GetMembers(context.Background(), "1")
+	require.NoError(t, err)
+	require.Equal(t, GetMembersResponse{
+		Assignees: []MemberModel{{Username: "petr", CreatedAt: "2030-01-02T10:00:00Z"}},
+		Watchers:  []MemberModel{{Username: "ivan", CreatedAt: "2030-01-02T10:00:00Z"}},
+	}, output)
+}
@@ -0,0 +1,15 @@
+package memberservice
+
+type AddAssigneeParams struct {
+	Username string `json:"username" binding:"required"`
+}
+
+type MemberModel struct {
+	Username  string `json:"username"`
+	CreatedAt string `json:"created_at"`
+}
+
+type GetMembersResponse struct {
+	Assignees []MemberModel `json:"assignees"`
+	Watchers  []MemberModel `json:"watchers"`
+}
@@ -10,10 +10,24 @@ package mock_service
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
+	entity "github.com/romandnk/todo/internal/entity"
+	apikeyservice "github.com/romandnk/todo/internal/service/apikey"
+	attachmentservice "github.com/romandnk/todo/internal/service/attachment"
+	authservice "github.com/romandnk/todo/internal/service/auth"
+	checklistservice "github.com/romandnk/todo/internal/service/checklist"
+	commentservice "github.com/romandnk/todo/internal/service/comment"
+	feedservice "github.com/romandnk/todo/internal/service/feed"
+	healthservice "github.com/romandnk/todo/internal/service/health"
+	idempotencyservice "github.com/romandnk/todo/internal/service/idempotency"
+	memberservice "github.com/romandnk/todo/internal/service/member"
 	statusservice "github.com/romandnk/todo/internal/service/status"
 	taskservice "github.com/romandnk/todo/internal/service/task"
+	workspaceservice "github.com/romandnk/todo/internal/service/workspace"
+	ratelimit "github.com/romandnk/todo/pkg/ratelimit"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +54,21 @@ func (m *MockTask) EXPECT() *MockTaskMockRecorder {
 	return m.recorder
 }
 
+// BulkTasks mocks base method.
+func (m *MockTask) BulkTasks(ctx context.Context, params taskservice.BulkTasksParams) (taskservice.BulkTasksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkTasks", ctx, params)
+	ret0, _ := ret[0].(taskservice.BulkTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkTasks indicates an expected call of BulkTasks.
+func (mr *MockTaskMockRecorder) BulkTasks(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkTasks", reflect.TypeOf((*MockTask)(nil).BulkTasks), ctx, params)
+}
+
 // CreateTask mocks base method.
 func (m *MockTask) CreateTask(ctx context.Context, params taskservice.CreateTaskParams) (taskservice.CreateTaskResponse, error) {
 	m.ctrl.T.Helper()
@@ -56,32 +85,46 @@ func (mr *MockTaskMockRecorder) CreateTask(ctx, params any) *gomock.Call {
 }
 
 // DeleteTaskByID mocks base method.
-func (m *MockTask) DeleteTaskByID(ctx context.Context, stringID string) error {
+func (m *MockTask) DeleteTaskByID(ctx context.Context, stringID string, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTaskByID", ctx, stringID)
+	ret := m.ctrl.Call(m, "DeleteTaskByID", ctx, stringID, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTaskByID indicates an expected call of DeleteTaskByID.
-func (mr *MockTaskMockRecorder) DeleteTaskByID(ctx, stringID any) *gomock.Call {
+func (mr *MockTaskMockRecorder) DeleteTaskByID(ctx, stringID, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskByID", reflect.TypeOf((*MockTask)(nil).DeleteTaskByID), ctx, stringID, version)
+}
+
+// ExportTasks mocks base method.
+func (m *MockTask) ExportTasks(ctx context.Context, w io.Writer, format string, params taskservice.TaskFilterParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTasks", ctx, w, format, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTasks indicates an expected call of ExportTasks.
+func (mr *MockTaskMockRecorder) ExportTasks(ctx, w, format, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskByID", reflect.TypeOf((*MockTask)(nil).DeleteTaskByID), ctx, stringID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTasks", reflect.TypeOf((*MockTask)(nil).ExportTasks), ctx, w, format, params)
 }
 
 // GetAllTasks mocks base method.
-func (m *MockTask) GetAllTasks(ctx context.Context, limitStr, lastIDStr, statusName, dateStr string) (taskservice.GetAllTasksResponse, error) {
+func (m *MockTask) GetAllTasks(ctx context.Context, params taskservice.GetAllTasksParams) (taskservice.GetAllTasksResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTasks", ctx, limitStr, lastIDStr, statusName, dateStr)
+	ret := m.ctrl.Call(m, "GetAllTasks", ctx, params)
 	ret0, _ := ret[0].(taskservice.GetAllTasksResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllTasks indicates an expected call of GetAllTasks.
-func (mr *MockTaskMockRecorder) GetAllTasks(ctx, limitStr, lastIDStr, statusName, dateStr any) *gomock.Call {
+func (mr *MockTaskMockRecorder) GetAllTasks(ctx, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTask)(nil).GetAllTasks), ctx, limitStr, lastIDStr, statusName, dateStr)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTask)(nil).GetAllTasks), ctx, params)
 }
 
 // GetTaskByID mocks base method.
@@ -99,18 +142,49 @@ func (mr *MockTaskMockRecorder) GetTaskByID(ctx, stringID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTask)(nil).GetTaskByID), ctx, stringID)
 }
 
+// ImportTasks mocks base method.
+func (m *MockTask) ImportTasks(ctx context.Context, r io.Reader, format, dryRunStr string) (taskservice.ImportTasksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTasks", ctx, r, format, dryRunStr)
+	ret0, _ := ret[0].(taskservice.ImportTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTasks indicates an expected call of ImportTasks.
+func (mr *MockTaskMockRecorder) ImportTasks(ctx, r, format, dryRunStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTasks", reflect.TypeOf((*MockTask)(nil).ImportTasks), ctx, r, format, dryRunStr)
+}
+
+// QuickAddTask mocks base method.
+func (m *MockTask) QuickAddTask(ctx context.Context, params taskservice.QuickAddTaskParams) (taskservice.QuickAddTaskResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuickAddTask", ctx, params)
+	ret0, _ := ret[0].(taskservice.QuickAddTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuickAddTask indicates an expected call of QuickAddTask.
+func (mr *MockTaskMockRecorder) QuickAddTask(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuickAddTask", reflect.TypeOf((*MockTask)(nil).QuickAddTask), ctx, params)
+}
+
 // UpdateTaskByID mocks base method.
-func (m *MockTask) UpdateTaskByID(ctx context.Context, stringID string, params taskservice.UpdateTaskByIDParams) error {
+func (m *MockTask) UpdateTaskByID(ctx context.Context, stringID string, version int, params taskservice.UpdateTaskByIDParams) (taskservice.UpdateTaskByIDResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTaskByID", ctx, stringID, params)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateTaskByID", ctx, stringID, version, params)
+	ret0, _ := ret[0].(taskservice.UpdateTaskByIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateTaskByID indicates an expected call of UpdateTaskByID.
-func (mr *MockTaskMockRecorder) UpdateTaskByID(ctx, stringID, params any) *gomock.Call {
+func (mr *MockTaskMockRecorder) UpdateTaskByID(ctx, stringID, version, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskByID", reflect.TypeOf((*MockTask)(nil).UpdateTaskByID), ctx, stringID, params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskByID", reflect.TypeOf((*MockTask)(nil).UpdateTaskByID), ctx, stringID, version, params)
 }
 
 // MockStatus is a mock of Status interface.
@@ -150,3 +224,915 @@ func (mr *MockStatusMockRecorder) CreateStatus(ctx, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStatus", reflect.TypeOf((*MockStatus)(nil).CreateStatus), ctx, params)
 }
+
+// MockFeed is a mock of Feed interface.
+type MockFeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedMockRecorder
+}
+
+// MockFeedMockRecorder is the mock recorder for MockFeed.
+type MockFeedMockRecorder struct {
+	mock *MockFeed
+}
+
+// NewMockFeed creates a new mock instance.
+func NewMockFeed(ctrl *gomock.Controller) *MockFeed {
+	mock := &MockFeed{ctrl: ctrl}
+	mock.recorder = &MockFeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeed) EXPECT() *MockFeedMockRecorder {
+	return m.recorder
+}
+
+// CreateFeed mocks base method.
+func (m *MockFeed) CreateFeed(ctx context.Context, params feedservice.CreateFeedParams) (feedservice.CreateFeedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeed", ctx, params)
+	ret0, _ := ret[0].(feedservice.CreateFeedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFeed indicates an expected call of CreateFeed.
+func (mr *MockFeedMockRecorder) CreateFeed(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeed", reflect.TypeOf((*MockFeed)(nil).CreateFeed), ctx, params)
+}
+
+// DeleteFeed mocks base method.
+func (m *MockFeed) DeleteFeed(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFeed", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFeed indicates an expected call of DeleteFeed.
+func (mr *MockFeedMockRecorder) DeleteFeed(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFeed", reflect.TypeOf((*MockFeed)(nil).DeleteFeed), ctx, token)
+}
+
+// GetFeed mocks base method.
+func (m *MockFeed) GetFeed(ctx context.Context, token string) (feedservice.GetFeedModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, token)
+	ret0, _ := ret[0].(feedservice.GetFeedModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockFeedMockRecorder) GetFeed(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockFeed)(nil).GetFeed), ctx, token)
+}
+
+// MockComment is a mock of Comment interface.
+type MockComment struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentMockRecorder
+}
+
+// MockCommentMockRecorder is the mock recorder for MockComment.
+type MockCommentMockRecorder struct {
+	mock *MockComment
+}
+
+// NewMockComment creates a new mock instance.
+func NewMockComment(ctrl *gomock.Controller) *MockComment {
+	mock := &MockComment{ctrl: ctrl}
+	mock.recorder = &MockCommentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComment) EXPECT() *MockCommentMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockComment) CreateComment(ctx context.Context, taskIDStr string, params commentservice.CreateCommentParams) (commentservice.CommentModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ctx, taskIDStr, params)
+	ret0, _ := ret[0].(commentservice.CommentModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentMockRecorder) CreateComment(ctx, taskIDStr, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockComment)(nil).CreateComment), ctx, taskIDStr, params)
+}
+
+// DeleteCommentByID mocks base method.
+func (m *MockComment) DeleteCommentByID(ctx context.Context, taskIDStr, idStr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCommentByID", ctx, taskIDStr, idStr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCommentByID indicates an expected call of DeleteCommentByID.
+func (mr *MockCommentMockRecorder) DeleteCommentByID(ctx, taskIDStr, idStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCommentByID", reflect.TypeOf((*MockComment)(nil).DeleteCommentByID), ctx, taskIDStr, idStr)
+}
+
+// GetComments mocks base method.
+func (m *MockComment) GetComments(ctx context.Context, taskIDStr, limitStr, lastIDStr string) (commentservice.GetCommentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, taskIDStr, limitStr, lastIDStr)
+	ret0, _ := ret[0].(commentservice.GetCommentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentMockRecorder) GetComments(ctx, taskIDStr, limitStr, lastIDStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockComment)(nil).GetComments), ctx, taskIDStr, limitStr, lastIDStr)
+}
+
+// UpdateCommentByID mocks base method.
+func (m *MockComment) UpdateCommentByID(ctx context.Context, taskIDStr, idStr string, params commentservice.UpdateCommentParams) (commentservice.CommentModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCommentByID", ctx, taskIDStr, idStr, params)
+	ret0, _ := ret[0].(commentservice.CommentModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCommentByID indicates an expected call of UpdateCommentByID.
+func (mr *MockCommentMockRecorder) UpdateCommentByID(ctx, taskIDStr, idStr, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCommentByID", reflect.TypeOf((*MockComment)(nil).UpdateCommentByID), ctx, taskIDStr, idStr, params)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// DeleteAttachmentByID mocks base method.
+func (m *MockAttachment) DeleteAttachmentByID(ctx context.Context, taskIDStr, idStr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachmentByID", ctx, taskIDStr, idStr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachmentByID indicates an expected call of DeleteAttachmentByID.
+func (mr *MockAttachmentMockRecorder) DeleteAttachmentByID(ctx, taskIDStr, idStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachmentByID", reflect.TypeOf((*MockAttachment)(nil).DeleteAttachmentByID), ctx, taskIDStr, idStr)
+}
+
+// DownloadAttachment mocks base method.
+func (m *MockAttachment) DownloadAttachment(ctx context.Context, taskIDStr, idStr string) (attachmentservice.AttachmentModel, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadAttachment", ctx, taskIDStr, idStr)
+	ret0, _ := ret[0].(attachmentservice.AttachmentModel)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadAttachment indicates an expected call of DownloadAttachment.
+func (mr *MockAttachmentMockRecorder) DownloadAttachment(ctx, taskIDStr, idStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadAttachment", reflect.TypeOf((*MockAttachment)(nil).DownloadAttachment), ctx, taskIDStr, idStr)
+}
+
+// GetAttachments mocks base method.
+func (m *MockAttachment) GetAttachments(ctx context.Context, taskIDStr string) (attachmentservice.GetAttachmentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", ctx, taskIDStr)
+	ret0, _ := ret[0].(attachmentservice.GetAttachmentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockAttachmentMockRecorder) GetAttachments(ctx, taskIDStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockAttachment)(nil).GetAttachments), ctx, taskIDStr)
+}
+
+// UploadAttachment mocks base method.
+func (m *MockAttachment) UploadAttachment(ctx context.Context, taskIDStr string, params attachmentservice.UploadAttachmentParams) (attachmentservice.AttachmentModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAttachment", ctx, taskIDStr, params)
+	ret0, _ := ret[0].(attachmentservice.AttachmentModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAttachment indicates an expected call of UploadAttachment.
+func (mr *MockAttachmentMockRecorder) UploadAttachment(ctx, taskIDStr, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockAttachment)(nil).UploadAttachment), ctx, taskIDStr, params)
+}
+
+// MockChecklist is a mock of Checklist interface.
+type MockChecklist struct {
+	ctrl     *gomock.Controller
+	recorder *MockChecklistMockRecorder
+}
+
+// MockChecklistMockRecorder is the mock recorder for MockChecklist.
+type MockChecklistMockRecorder struct {
+	mock *MockChecklist
+}
+
+// NewMockChecklist creates a new mock instance.
+func NewMockChecklist(ctrl *gomock.Controller) *MockChecklist {
+	mock := &MockChecklist{ctrl: ctrl}
+	mock.recorder = &MockChecklistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecklist) EXPECT() *MockChecklistMockRecorder {
+	return m.recorder
+}
+
+// AddChecklistItem mocks base method.
+func (m *MockChecklist) AddChecklistItem(ctx context.Context, taskIDStr string, params checklistservice.AddChecklistItemParams) (checklistservice.ChecklistItemModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", ctx, taskIDStr, params)
+	ret0, _ := ret[0].(checklistservice.ChecklistItemModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *MockChecklistMockRecorder) AddChecklistItem(ctx, taskIDStr, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*MockChecklist)(nil).AddChecklistItem), ctx, taskIDStr, params)
+}
+
+// DeleteChecklistItemByID mocks base method.
+func (m *MockChecklist) DeleteChecklistItemByID(ctx context.Context, taskIDStr, idStr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItemByID", ctx, taskIDStr, idStr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChecklistItemByID indicates an expected call of DeleteChecklistItemByID.
+func (mr *MockChecklistMockRecorder) DeleteChecklistItemByID(ctx, taskIDStr, idStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItemByID", reflect.TypeOf((*MockChecklist)(nil).DeleteChecklistItemByID), ctx, taskIDStr, idStr)
+}
+
+// GetChecklist mocks base method.
+func (m *MockChecklist) GetChecklist(ctx context.Context, taskIDStr string) (checklistservice.GetChecklistResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecklist", ctx, taskIDStr)
+	ret0, _ := ret[0].(checklistservice.GetChecklistResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChecklist indicates an expected call of GetChecklist.
+func (mr *MockChecklistMockRecorder) GetChecklist(ctx, taskIDStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecklist", reflect.TypeOf((*MockChecklist)(nil).GetChecklist), ctx, taskIDStr)
+}
+
+// ReorderChecklist mocks base method.
+func (m *MockChecklist) ReorderChecklist(ctx context.Context, taskIDStr string, params checklistservice.ReorderChecklistParams) (checklistservice.GetChecklistResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderChecklist", ctx, taskIDStr, params)
+	ret0, _ := ret[0].(checklistservice.GetChecklistResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderChecklist indicates an expected call of ReorderChecklist.
+func (mr *MockChecklistMockRecorder) ReorderChecklist(ctx, taskIDStr, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderChecklist", reflect.TypeOf((*MockChecklist)(nil).ReorderChecklist), ctx, taskIDStr, params)
+}
+
+// ToggleChecklistItem mocks base method.
+func (m *MockChecklist) ToggleChecklistItem(ctx context.Context, taskIDStr, idStr string) (checklistservice.ChecklistItemModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToggleChecklistItem", ctx, taskIDStr, idStr)
+	ret0, _ := ret[0].(checklistservice.ChecklistItemModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToggleChecklistItem indicates an expected call of ToggleChecklistItem.
+func (mr *MockChecklistMockRecorder) ToggleChecklistItem(ctx, taskIDStr, idStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleChecklistItem", reflect.TypeOf((*MockChecklist)(nil).ToggleChecklistItem), ctx, taskIDStr, idStr)
+}
+
+// MockMember is a mock of Member interface.
+type MockMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberMockRecorder
+}
+
+// MockMemberMockRecorder is the mock recorder for MockMember.
+type MockMemberMockRecorder struct {
+	mock *MockMember
+}
+
+// NewMockMember creates a new mock instance.
+func NewMockMember(ctrl *gomock.Controller) *MockMember {
+	mock := &MockMember{ctrl: ctrl}
+	mock.recorder = &MockMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMember) EXPECT() *MockMemberMockRecorder {
+	return m.recorder
+}
+
+// AddAssignee mocks base method.
+func (m *MockMember) AddAssignee(ctx context.Context, taskIDStr string, params memberservice.AddAssigneeParams) (memberservice.MemberModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAssignee", ctx, taskIDStr, params)
+	ret0, _ := ret[0].(memberservice.MemberModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAssignee indicates an expected call of AddAssignee.
+func (mr *MockMemberMockRecorder) AddAssignee(ctx, taskIDStr, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAssignee", reflect.TypeOf((*MockMember)(nil).AddAssignee), ctx, taskIDStr, params)
+}
+
+// DeleteAssignee mocks base method.
+func (m *MockMember) DeleteAssignee(ctx context.Context, taskIDStr, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAssignee", ctx, taskIDStr, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAssignee indicates an expected call of DeleteAssignee.
+func (mr *MockMemberMockRecorder) DeleteAssignee(ctx, taskIDStr, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssignee", reflect.TypeOf((*MockMember)(nil).DeleteAssignee), ctx, taskIDStr, username)
+}
+
+// GetMembers mocks base method.
+func (m *MockMember) GetMembers(ctx context.Context, taskIDStr string) (memberservice.GetMembersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, taskIDStr)
+	ret0, _ := ret[0].(memberservice.GetMembersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockMemberMockRecorder) GetMembers(ctx, taskIDStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockMember)(nil).GetMembers), ctx, taskIDStr)
+}
+
+// Unwatch mocks base method.
+func (m *MockMember) Unwatch(ctx context.Context, taskIDStr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unwatch", ctx, taskIDStr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unwatch indicates an expected call of Unwatch.
+func (mr *MockMemberMockRecorder) Unwatch(ctx, taskIDStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unwatch", reflect.TypeOf((*MockMember)(nil).Unwatch), ctx, taskIDStr)
+}
+
+// Watch mocks base method.
+func (m *MockMember) Watch(ctx context.Context, taskIDStr string) (memberservice.MemberModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, taskIDStr)
+	ret0, _ := ret[0].(memberservice.MemberModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockMemberMockRecorder) Watch(ctx, taskIDStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockMember)(nil).Watch), ctx, taskIDStr)
+}
+
+// MockWorkspace is a mock of Workspace interface.
+type MockWorkspace struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceMockRecorder
+}
+
+// MockWorkspaceMockRecorder is the mock recorder for MockWorkspace.
+type MockWorkspaceMockRecorder struct {
+	mock *MockWorkspace
+}
+
+// NewMockWorkspace creates a new mock instance.
+func NewMockWorkspace(ctrl *gomock.Controller) *MockWorkspace {
+	mock := &MockWorkspace{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspace) EXPECT() *MockWorkspaceMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockWorkspace) AcceptInvitation(ctx context.Context, token string) (workspaceservice.AcceptInvitationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, token)
+	ret0, _ := ret[0].(workspaceservice.AcceptInvitationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockWorkspaceMockRecorder) AcceptInvitation(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockWorkspace)(nil).AcceptInvitation), ctx, token)
+}
+
+// BootstrapOwner mocks base method.
+func (m *MockWorkspace) BootstrapOwner(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapOwner", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BootstrapOwner indicates an expected call of BootstrapOwner.
+func (mr *MockWorkspaceMockRecorder) BootstrapOwner(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapOwner", reflect.TypeOf((*MockWorkspace)(nil).BootstrapOwner), ctx)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockWorkspace) CreateInvitation(ctx context.Context, workspaceIDStr string, params workspaceservice.CreateInvitationParams) (workspaceservice.CreateInvitationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, workspaceIDStr, params)
+	ret0, _ := ret[0].(workspaceservice.CreateInvitationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockWorkspaceMockRecorder) CreateInvitation(ctx, workspaceIDStr, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockWorkspace)(nil).CreateInvitation), ctx, workspaceIDStr, params)
+}
+
+// CreateWorkspace mocks base method.
+func (m *MockWorkspace) CreateWorkspace(ctx context.Context, params workspaceservice.CreateWorkspaceParams) (workspaceservice.WorkspaceModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", ctx, params)
+	ret0, _ := ret[0].(workspaceservice.WorkspaceModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockWorkspaceMockRecorder) CreateWorkspace(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockWorkspace)(nil).CreateWorkspace), ctx, params)
+}
+
+// DeleteMember mocks base method.
+func (m *MockWorkspace) DeleteMember(ctx context.Context, workspaceIDStr, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", ctx, workspaceIDStr, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockWorkspaceMockRecorder) DeleteMember(ctx, workspaceIDStr, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockWorkspace)(nil).DeleteMember), ctx, workspaceIDStr, username)
+}
+
+// GetMembers mocks base method.
+func (m *MockWorkspace) GetMembers(ctx context.Context, workspaceIDStr string) (workspaceservice.GetWorkspaceMembersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, workspaceIDStr)
+	ret0, _ := ret[0].(workspaceservice.GetWorkspaceMembersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockWorkspaceMockRecorder) GetMembers(ctx, workspaceIDStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockWorkspace)(nil).GetMembers), ctx, workspaceIDStr)
+}
+
+// GetWorkspaces mocks base method.
+func (m *MockWorkspace) GetWorkspaces(ctx context.Context) (workspaceservice.GetWorkspacesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaces", ctx)
+	ret0, _ := ret[0].(workspaceservice.GetWorkspacesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaces indicates an expected call of GetWorkspaces.
+func (mr *MockWorkspaceMockRecorder) GetWorkspaces(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaces", reflect.TypeOf((*MockWorkspace)(nil).GetWorkspaces), ctx)
+}
+
+// ResolveRole mocks base method.
+func (m *MockWorkspace) ResolveRole(ctx context.Context, workspaceIDStr string) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveRole", ctx, workspaceIDStr)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveRole indicates an expected call of ResolveRole.
+func (mr *MockWorkspaceMockRecorder) ResolveRole(ctx, workspaceIDStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRole", reflect.TypeOf((*MockWorkspace)(nil).ResolveRole), ctx, workspaceIDStr)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockWorkspace) UpdateMemberRole(ctx context.Context, workspaceIDStr, username string, params workspaceservice.UpdateMemberRoleParams) (workspaceservice.WorkspaceMemberModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", ctx, workspaceIDStr, username, params)
+	ret0, _ := ret[0].(workspaceservice.WorkspaceMemberModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockWorkspaceMockRecorder) UpdateMemberRole(ctx, workspaceIDStr, username, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockWorkspace)(nil).UpdateMemberRole), ctx, workspaceIDStr, username, params)
+}
+
+// MockAPIKey is a mock of APIKey interface.
+type MockAPIKey struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyMockRecorder
+}
+
+// MockAPIKeyMockRecorder is the mock recorder for MockAPIKey.
+type MockAPIKeyMockRecorder struct {
+	mock *MockAPIKey
+}
+
+// NewMockAPIKey creates a new mock instance.
+func NewMockAPIKey(ctrl *gomock.Controller) *MockAPIKey {
+	mock := &MockAPIKey{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKey) EXPECT() *MockAPIKeyMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockAPIKey) Authenticate(ctx context.Context, key string) (entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, key)
+	ret0, _ := ret[0].(entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAPIKeyMockRecorder) Authenticate(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAPIKey)(nil).Authenticate), ctx, key)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKey) CreateAPIKey(ctx context.Context, params apikeyservice.CreateAPIKeyParams) (apikeyservice.CreateAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, params)
+	ret0, _ := ret[0].(apikeyservice.CreateAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyMockRecorder) CreateAPIKey(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKey)(nil).CreateAPIKey), ctx, params)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockAPIKey) GetAPIKeys(ctx context.Context) (apikeyservice.GetAPIKeysResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", ctx)
+	ret0, _ := ret[0].(apikeyservice.GetAPIKeysResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockAPIKeyMockRecorder) GetAPIKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockAPIKey)(nil).GetAPIKeys), ctx)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKey) RevokeAPIKey(ctx context.Context, idStr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, idStr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyMockRecorder) RevokeAPIKey(ctx, idStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKey)(nil).RevokeAPIKey), ctx, idStr)
+}
+
+// MockAuth is a mock of Auth interface.
+type MockAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthMockRecorder
+}
+
+// MockAuthMockRecorder is the mock recorder for MockAuth.
+type MockAuthMockRecorder struct {
+	mock *MockAuth
+}
+
+// NewMockAuth creates a new mock instance.
+func NewMockAuth(ctrl *gomock.Controller) *MockAuth {
+	mock := &MockAuth{ctrl: ctrl}
+	mock.recorder = &MockAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuth) EXPECT() *MockAuthMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockAuth) Authenticate(ctx context.Context, token string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAuthMockRecorder) Authenticate(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAuth)(nil).Authenticate), ctx, token)
+}
+
+// Callback mocks base method.
+func (m *MockAuth) Callback(ctx context.Context, params authservice.CallbackParams, expectedState string) (authservice.CallbackResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Callback", ctx, params, expectedState)
+	ret0, _ := ret[0].(authservice.CallbackResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Callback indicates an expected call of Callback.
+func (mr *MockAuthMockRecorder) Callback(ctx, params, expectedState any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Callback", reflect.TypeOf((*MockAuth)(nil).Callback), ctx, params, expectedState)
+}
+
+// Login mocks base method.
+func (m *MockAuth) Login(ctx context.Context) (authservice.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx)
+	ret0, _ := ret[0].(authservice.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockAuthMockRecorder) Login(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuth)(nil).Login), ctx)
+}
+
+// MockRateLimit is a mock of RateLimit interface.
+type MockRateLimit struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitMockRecorder
+}
+
+// MockRateLimitMockRecorder is the mock recorder for MockRateLimit.
+type MockRateLimitMockRecorder struct {
+	mock *MockRateLimit
+}
+
+// NewMockRateLimit creates a new mock instance.
+func NewMockRateLimit(ctrl *gomock.Controller) *MockRateLimit {
+	mock := &MockRateLimit{ctrl: ctrl}
+	mock.recorder = &MockRateLimitMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimit) EXPECT() *MockRateLimitMockRecorder {
+	return m.recorder
+}
+
+// RunCleanup mocks base method.
+func (m *MockRateLimit) RunCleanup(ctx context.Context, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RunCleanup", ctx, interval)
+}
+
+// RunCleanup indicates an expected call of RunCleanup.
+func (mr *MockRateLimitMockRecorder) RunCleanup(ctx, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCleanup", reflect.TypeOf((*MockRateLimit)(nil).RunCleanup), ctx, interval)
+}
+
+// Take mocks base method.
+func (m *MockRateLimit) Take(ctx context.Context, group, client string) (ratelimit.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, group, client)
+	ret0, _ := ret[0].(ratelimit.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockRateLimitMockRecorder) Take(ctx, group, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockRateLimit)(nil).Take), ctx, group, client)
+}
+
+// MockIdempotency is a mock of Idempotency interface.
+type MockIdempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyMockRecorder
+}
+
+// MockIdempotencyMockRecorder is the mock recorder for MockIdempotency.
+type MockIdempotencyMockRecorder struct {
+	mock *MockIdempotency
+}
+
+// NewMockIdempotency creates a new mock instance.
+func NewMockIdempotency(ctrl *gomock.Controller) *MockIdempotency {
+	mock := &MockIdempotency{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotency) EXPECT() *MockIdempotencyMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockIdempotency) Begin(ctx context.Context, key, requestHash string) (idempotencyservice.StoredResponse, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", ctx, key, requestHash)
+	ret0, _ := ret[0].(idempotencyservice.StoredResponse)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIdempotencyMockRecorder) Begin(ctx, key, requestHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIdempotency)(nil).Begin), ctx, key, requestHash)
+}
+
+// Complete mocks base method.
+func (m *MockIdempotency) Complete(ctx context.Context, key string, response idempotencyservice.StoredResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, key, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyMockRecorder) Complete(ctx, key, response any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotency)(nil).Complete), ctx, key, response)
+}
+
+// Release mocks base method.
+func (m *MockIdempotency) Release(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIdempotencyMockRecorder) Release(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotency)(nil).Release), ctx, key)
+}
+
+// RunCleanup mocks base method.
+func (m *MockIdempotency) RunCleanup(ctx context.Context, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RunCleanup", ctx, interval)
+}
+
+// RunCleanup indicates an expected call of RunCleanup.
+func (mr *MockIdempotencyMockRecorder) RunCleanup(ctx, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCleanup", reflect.TypeOf((*MockIdempotency)(nil).RunCleanup), ctx, interval)
+}
+
+// MockHealth is a mock of Health interface.
+type MockHealth struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthMockRecorder
+}
+
+// MockHealthMockRecorder is the mock recorder for MockHealth.
+type MockHealthMockRecorder struct {
+	mock *MockHealth
+}
+
+// NewMockHealth creates a new mock instance.
+func NewMockHealth(ctrl *gomock.Controller) *MockHealth {
+	mock := &MockHealth{ctrl: ctrl}
+	mock.recorder = &MockHealthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealth) EXPECT() *MockHealthMockRecorder {
+	return m.recorder
+}
+
+// Drain mocks base method.
+func (m *MockHealth) Drain() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Drain")
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MockHealthMockRecorder) Drain() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MockHealth)(nil).Drain))
+}
+
+// Ready mocks base method.
+func (m *MockHealth) Ready(ctx context.Context) (healthservice.HealthResponse, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready", ctx)
+	ret0, _ := ret[0].(healthservice.HealthResponse)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *MockHealthMockRecorder) Ready(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockHealth)(nil).Ready), ctx)
+}
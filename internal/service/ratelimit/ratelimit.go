@@ -0,0 +1,74 @@
+package ratelimitservice
+
+import (
+	"context"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/ratelimit"
+	"time"
+)
+
+// Settings configure quotas of route groups
+type Settings struct {
+	// Default is the quota of route groups without their own quota
+	Default ratelimit.Limit
+	Groups  map[string]ratelimit.Limit
+}
+
+type RateLimitService struct {
+	store    ratelimit.Store
+	settings Settings
+	logger   logger.Logger
+	now      func() time.Time
+}
+
+func NewRateLimitService(store ratelimit.Store, settings Settings, logger logger.Logger) *RateLimitService {
+	return &RateLimitService{
+		store:    store,
+		settings: settings,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Take takes a token of the client from the bucket of the route group.
+// Unavailable store lets requests through, so it does not stop the app
+func (s *RateLimitService) Take(ctx context.Context, group, client string) (ratelimit.Result, error) {
+	limit, ok := s.settings.Groups[group]
+	if !ok {
+		limit = s.settings.Default
+	}
+	if limit.Unlimited() {
+		return ratelimit.Result{Allowed: true}, nil
+	}
+
+	result, err := s.store.Take(ctx, group+":"+client, limit, s.now().UTC())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error taking rate limit token", logger.Error(err), logger.String("group", group))
+		return ratelimit.Result{Allowed: true}, nil
+	}
+
+	if !result.Allowed {
+		return result, constant.ErrRateLimitExceeded
+	}
+
+	return result, nil
+}
+
+// RunCleanup removes full buckets every interval until ctx is done
+func (s *RateLimitService) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.store.Cleanup(ctx, s.now().UTC())
+			if err != nil {
+				s.logger.ErrorContext(ctx, "error cleaning up rate limit buckets", logger.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package ratelimitservice
+
+import (
+	"context"
+	"errors"
+	"github.com/romandnk/todo/internal/constant"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/romandnk/todo/pkg/ratelimit"
+	mock_ratelimit "github.com/romandnk/todo/pkg/ratelimit/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestRateLimitService_Take(t *testing.T) {
+	settings := Settings{
+		Default: ratelimit.Limit{Rate: 10, Burst: 20},
+		Groups: map[string]ratelimit.Limit{
+			"auth":  {Rate: 1, Burst: 5},
+			"feeds": {},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		group         string
+		storeM        func(m *mock_ratelimit.MockStore)
+		loggerM       func(m *mock_logger.MockLogger)
+		expected      ratelimit.Result
+		expectedError error
+	}{
+		{
+			name:  "group quota",
+			group: "auth",
+			storeM: func(m *mock_ratelimit.MockStore) {
+				m.EXPECT().Take(gomock.Any(), "auth:user:ivan", ratelimit.Limit{Rate: 1, Burst: 5}, gomock.Any()).
+					Return(ratelimit.Result{Allowed: true, Limit: 5, Remaining: 4}, nil)
+			},
+			expected: ratelimit.Result{Allowed: true, Limit: 5, Remaining: 4},
+		},
+		{
+			name:  "default quota",
+			group: "tasks",
+			storeM: func(m *mock_ratelimit.MockStore) {
+				m.EXPECT().Take(gomock.Any(), "tasks:user:ivan", ratelimit.Limit{Rate: 10, Burst: 20}, gomock.Any()).
+					Return(ratelimit.Result{Limit: 20, RetryAfter: time.Second}, nil)
+			},
+			expected:      ratelimit.Result{Limit: 20, RetryAfter: time.Second},
+			expectedError: constant.ErrRateLimitExceeded,
+		},
+		{
+			name:     "unlimited group",
+			group:    "feeds",
+			expected: ratelimit.Result{Allowed: true},
+		},
+		{
+			name:  "store is unavailable",
+			group: "tasks",
+			storeM: func(m *mock_ratelimit.MockStore) {
+				m.EXPECT().Take(gomock.Any(), "tasks:user:ivan", gomock.Any(), gomock.Any()).
+					Return(ratelimit.Result{}, errors.New("connection refused"))
+			},
+			loggerM: func(m *mock_logger.MockLogger) {
+				m.EXPECT().ErrorContext(gomock.Any(), "error taking rate limit token", gomock.Any(), gomock.Any())
+			},
+			expected: ratelimit.Result{Allowed: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_ratelimit.NewMockStore(ctrl)
+			if tc.storeM != nil {
+				tc.storeM(store)
+			}
+			logger := mock_logger.NewMockLogger(ctrl)
+			if tc.loggerM != nil {
+				tc.loggerM(logger)
+			}
+
+			service := NewRateLimitService(store, settings, logger)
+
+			result, err := service.Take(context.Background(), tc.group, "user:ivan")
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
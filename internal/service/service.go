@@ -4,37 +4,179 @@ package service
 
 import (
 	"context"
+	"github.com/romandnk/todo/internal/entity"
 	storage "github.com/romandnk/todo/internal/repo"
+	apikeyservice "github.com/romandnk/todo/internal/service/apikey"
+	attachmentservice "github.com/romandnk/todo/internal/service/attachment"
+	authservice "github.com/romandnk/todo/internal/service/auth"
+	checklistservice "github.com/romandnk/todo/internal/service/checklist"
+	commentservice "github.com/romandnk/todo/internal/service/comment"
+	feedservice "github.com/romandnk/todo/internal/service/feed"
+	healthservice "github.com/romandnk/todo/internal/service/health"
+	idempotencyservice "github.com/romandnk/todo/internal/service/idempotency"
+	memberservice "github.com/romandnk/todo/internal/service/member"
+	ratelimitservice "github.com/romandnk/todo/internal/service/ratelimit"
 	statusservice "github.com/romandnk/todo/internal/service/status"
 	"github.com/romandnk/todo/internal/service/task"
+	workspaceservice "github.com/romandnk/todo/internal/service/workspace"
+	"github.com/romandnk/todo/pkg/blobstore"
 	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/oidc"
+	"github.com/romandnk/todo/pkg/ratelimit"
+	"github.com/romandnk/todo/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"time"
 )
 
 type Task interface {
 	CreateTask(ctx context.Context, params taskservice.CreateTaskParams) (taskservice.CreateTaskResponse, error)
-	GetAllTasks(ctx context.Context, limitStr, lastIDStr, statusName, dateStr string) (taskservice.GetAllTasksResponse, error)
+	QuickAddTask(ctx context.Context, params taskservice.QuickAddTaskParams) (taskservice.QuickAddTaskResponse, error)
+	GetAllTasks(ctx context.Context, params taskservice.GetAllTasksParams) (taskservice.GetAllTasksResponse, error)
 	GetTaskByID(ctx context.Context, stringID string) (taskservice.GetTaskWithStatusNameModel, error)
-	UpdateTaskByID(ctx context.Context, stringID string, params taskservice.UpdateTaskByIDParams) error
-	DeleteTaskByID(ctx context.Context, stringID string) error
+	UpdateTaskByID(ctx context.Context, stringID string, version int, params taskservice.UpdateTaskByIDParams) (taskservice.UpdateTaskByIDResponse, error)
+	DeleteTaskByID(ctx context.Context, stringID string, version int) error
+	BulkTasks(ctx context.Context, params taskservice.BulkTasksParams) (taskservice.BulkTasksResponse, error)
+	ExportTasks(ctx context.Context, w io.Writer, format string, params taskservice.TaskFilterParams) error
+	ImportTasks(ctx context.Context, r io.Reader, format, dryRunStr string) (taskservice.ImportTasksResponse, error)
 }
 
 type Status interface {
 	CreateStatus(ctx context.Context, params statusservice.CreateStatusParams) (statusservice.CreateStatusResponse, error)
 }
 
+type Feed interface {
+	CreateFeed(ctx context.Context, params feedservice.CreateFeedParams) (feedservice.CreateFeedResponse, error)
+	GetFeed(ctx context.Context, token string) (feedservice.GetFeedModel, error)
+	DeleteFeed(ctx context.Context, token string) error
+}
+
+type Comment interface {
+	CreateComment(ctx context.Context, taskIDStr string, params commentservice.CreateCommentParams) (commentservice.CommentModel, error)
+	GetComments(ctx context.Context, taskIDStr, limitStr, lastIDStr string) (commentservice.GetCommentsResponse, error)
+	UpdateCommentByID(ctx context.Context, taskIDStr, idStr string, params commentservice.UpdateCommentParams) (commentservice.CommentModel, error)
+	DeleteCommentByID(ctx context.Context, taskIDStr, idStr string) error
+}
+
+type Attachment interface {
+	UploadAttachment(ctx context.Context, taskIDStr string, params attachmentservice.UploadAttachmentParams) (attachmentservice.AttachmentModel, error)
+	GetAttachments(ctx context.Context, taskIDStr string) (attachmentservice.GetAttachmentsResponse, error)
+	DownloadAttachment(ctx context.Context, taskIDStr, idStr string) (attachmentservice.AttachmentModel, io.ReadCloser, error)
+	DeleteAttachmentByID(ctx context.Context, taskIDStr, idStr string) error
+}
+
+type Checklist interface {
+	AddChecklistItem(ctx context.Context, taskIDStr string, params checklistservice.AddChecklistItemParams) (checklistservice.ChecklistItemModel, error)
+	GetChecklist(ctx context.Context, taskIDStr string) (checklistservice.GetChecklistResponse, error)
+	ToggleChecklistItem(ctx context.Context, taskIDStr, idStr string) (checklistservice.ChecklistItemModel, error)
+	ReorderChecklist(ctx context.Context, taskIDStr string, params checklistservice.ReorderChecklistParams) (checklistservice.GetChecklistResponse, error)
+	DeleteChecklistItemByID(ctx context.Context, taskIDStr, idStr string) error
+}
+
+type Member interface {
+	GetMembers(ctx context.Context, taskIDStr string) (memberservice.GetMembersResponse, error)
+	AddAssignee(ctx context.Context, taskIDStr string, params memberservice.AddAssigneeParams) (memberservice.MemberModel, error)
+	DeleteAssignee(ctx context.Context, taskIDStr, username string) error
+	Watch(ctx context.Context, taskIDStr string) (memberservice.MemberModel, error)
+	Unwatch(ctx context.Context, taskIDStr string) error
+}
+
+type Workspace interface {
+	CreateWorkspace(ctx context.Context, params workspaceservice.CreateWorkspaceParams) (workspaceservice.WorkspaceModel, error)
+	GetWorkspaces(ctx context.Context) (workspaceservice.GetWorkspacesResponse, error)
+	GetMembers(ctx context.Context, workspaceIDStr string) (workspaceservice.GetWorkspaceMembersResponse, error)
+	UpdateMemberRole(ctx context.Context, workspaceIDStr, username string, params workspaceservice.UpdateMemberRoleParams) (workspaceservice.WorkspaceMemberModel, error)
+	DeleteMember(ctx context.Context, workspaceIDStr, username string) error
+	CreateInvitation(ctx context.Context, workspaceIDStr string, params workspaceservice.CreateInvitationParams) (workspaceservice.CreateInvitationResponse, error)
+	AcceptInvitation(ctx context.Context, token string) (workspaceservice.AcceptInvitationResponse, error)
+	ResolveRole(ctx context.Context, workspaceIDStr string) (int, string, error)
+	BootstrapOwner(ctx context.Context) (bool, error)
+}
+
+type APIKey interface {
+	CreateAPIKey(ctx context.Context, params apikeyservice.CreateAPIKeyParams) (apikeyservice.CreateAPIKeyResponse, error)
+	GetAPIKeys(ctx context.Context) (apikeyservice.GetAPIKeysResponse, error)
+	RevokeAPIKey(ctx context.Context, idStr string) error
+	Authenticate(ctx context.Context, key string) (entity.APIKey, error)
+}
+
+type Auth interface {
+	Login(ctx context.Context) (authservice.LoginResponse, error)
+	Callback(ctx context.Context, params authservice.CallbackParams, expectedState string) (authservice.CallbackResponse, error)
+	Authenticate(ctx context.Context, token string) (string, error)
+}
+
+type RateLimit interface {
+	Take(ctx context.Context, group, client string) (ratelimit.Result, error)
+	RunCleanup(ctx context.Context, interval time.Duration)
+}
+
+type Idempotency interface {
+	Begin(ctx context.Context, key, requestHash string) (idempotencyservice.StoredResponse, bool, error)
+	Complete(ctx context.Context, key string, response idempotencyservice.StoredResponse) error
+	Release(ctx context.Context, key string) error
+	RunCleanup(ctx context.Context, interval time.Duration)
+}
+
+type Health interface {
+	Ready(ctx context.Context) (healthservice.HealthResponse, bool)
+	Drain()
+}
+
 type Services struct {
-	Status Status
-	Task   Task
+	Status      Status
+	Task        Task
+	Feed        Feed
+	Comment     Comment
+	Attachment  Attachment
+	Checklist   Checklist
+	Member      Member
+	Workspace   Workspace
+	APIKey      APIKey
+	Auth        Auth
+	RateLimit   RateLimit
+	Idempotency Idempotency
+	Health      Health
 }
 
 type Dependencies struct {
-	Repo   *storage.Repository
-	Logger logger.Logger
+	Repo           *storage.Repository
+	Logger         logger.Logger
+	IdempotencyTTL time.Duration
+	BlobStore      blobstore.BlobStore
+	Attachments    attachmentservice.Limits
+	// TaskHooks are notified about created and deleted tasks
+	TaskHooks []taskservice.TaskHook
+	// AssignmentHooks are notified about changes of task assignees
+	AssignmentHooks []memberservice.AssignmentHook
+	Workspaces      workspaceservice.Settings
+	// OIDC is the identity provider of login and bearer tokens, nil disables them
+	OIDC oidc.Provider
+	Auth authservice.Settings
+	// RateLimitStore keeps token buckets of clients
+	RateLimitStore ratelimit.Store
+	RateLimit      ratelimitservice.Settings
+	Health         healthservice.Settings
+	// TracerProvider creates spans of task and status services
+	TracerProvider trace.TracerProvider
 }
 
 func NewServices(dep Dependencies) *Services {
+	tracer := dep.TracerProvider.Tracer(tracing.Name)
+
 	return &Services{
-		Status: statusservice.NewStatusService(dep.Repo.Status, dep.Logger),
-		Task:   taskservice.NewTaskService(dep.Repo.Task, dep.Repo.Status, dep.Logger),
+		Status:      newTracedStatus(statusservice.NewStatusService(dep.Repo.Status, dep.Logger), tracer),
+		Task:        newTracedTask(taskservice.NewTaskService(dep.Repo.Task, dep.Repo.Status, dep.TaskHooks, dep.Logger), tracer),
+		Feed:        feedservice.NewFeedService(dep.Repo.Feed, dep.Repo.Status, dep.Logger),
+		Comment:     commentservice.NewCommentService(dep.Repo.Comment, dep.Repo.Task, dep.Logger),
+		Attachment:  attachmentservice.NewAttachmentService(dep.Repo.Attachment, dep.Repo.Task, dep.BlobStore, dep.Attachments, dep.Logger),
+		Checklist:   checklistservice.NewChecklistService(dep.Repo.Checklist, dep.Repo.Task, dep.Logger),
+		Member:      memberservice.NewMemberService(dep.Repo.Member, dep.Repo.Task, dep.AssignmentHooks, dep.Logger),
+		Workspace:   workspaceservice.NewWorkspaceService(dep.Repo.Workspace, dep.Workspaces, dep.Logger),
+		APIKey:      apikeyservice.NewAPIKeyService(dep.Repo.APIKey, dep.Logger),
+		Auth:        authservice.NewAuthService(dep.OIDC, dep.Auth, dep.Logger),
+		RateLimit:   ratelimitservice.NewRateLimitService(dep.RateLimitStore, dep.RateLimit, dep.Logger),
+		Idempotency: idempotencyservice.NewIdempotencyService(dep.Repo.Idempotency, dep.IdempotencyTTL, dep.Logger),
+		Health:      healthservice.NewHealthService(dep.Repo.Health, dep.Health, dep.Logger),
 	}
 }
@@ -7,10 +7,9 @@ import (
 	"github.com/romandnk/todo/internal/constant"
 	"github.com/romandnk/todo/internal/entity"
 	storage "github.com/romandnk/todo/internal/repo"
+	"github.com/romandnk/todo/internal/service/validation"
 	"github.com/romandnk/todo/pkg/logger"
-	"go.uber.org/zap"
 	"strings"
-	"unicode/utf8"
 )
 
 type StatusService struct {
@@ -30,12 +29,10 @@ func (s *StatusService) CreateStatus(ctx context.Context, params CreateStatusPar
 
 	params.Name = strings.ToLower(strings.TrimSpace(params.Name))
 
-	if params.Name == "" {
-		return response, constant.ErrEmptyStatusName
-	}
-
-	if utf8.RuneCountInString(params.Name) > 36 {
-		return response, constant.ErrTooLongStatusName
+	var v validation.Validator
+	v.Check(validation.StatusName("name", params.Name))
+	if !v.Valid() {
+		return response, v.Err()
 	}
 
 	status := entity.Status{
@@ -43,7 +40,7 @@ func (s *StatusService) CreateStatus(ctx context.Context, params CreateStatusPar
 	}
 	id, err := s.status.CreateStatus(ctx, status)
 	if err != nil {
-		s.logger.Error("error creating repo task", zap.Error(err))
+		s.logger.ErrorContext(ctx, "error creating repo task", logger.Error(err))
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			if pgErr.Code == "23505" {
@@ -0,0 +1,182 @@
+package taskservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/internal/service/validation"
+	"github.com/romandnk/todo/pkg/logger"
+	"strings"
+)
+
+const maxBulkItems = 1000
+
+// errors of valid items of the rejected all or nothing batch
+const (
+	bulkNotExecutedError = "not executed, batch rejected"
+	bulkRolledBackError  = "rolled back, batch rejected"
+)
+
+// BulkTasks validates every operation and executes the valid ones in a single transaction.
+// With AllOrNothing nothing is saved if at least one item fails, and valid items are reported as not executed.
+func (s *TaskService) BulkTasks(ctx context.Context, params BulkTasksParams) (BulkTasksResponse, error) {
+	var response BulkTasksResponse
+
+	total := len(params.Create) + len(params.Delete) + len(params.Restore)
+	if params.UpdateStatus != nil {
+		total += len(params.UpdateStatus.IDs)
+	}
+	if total == 0 {
+		return response, constant.ErrEmptyBulkOperations
+	}
+	if total > maxBulkItems {
+		return response, constant.ErrTooManyBulkItems
+	}
+
+	response.Results = make([]BulkTaskResult, 0, total)
+	items := make([]entity.TaskBatchItem, 0, total)
+	// positions of the items sent to repo inside response results
+	positions := make([]int, 0, total)
+
+	addResult := func(action entity.TaskBatchAction, index, id int, err error) {
+		result := BulkTaskResult{
+			Action:  string(action),
+			Index:   index,
+			ID:      id,
+			Success: err == nil,
+		}
+		if err != nil {
+			result.Error = err.Error()
+			result.Violations = fieldViolations(err)
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	addItem := func(action entity.TaskBatchAction, index int, task entity.Task) {
+		positions = append(positions, len(response.Results))
+		items = append(items, entity.TaskBatchItem{
+			Action: action,
+			Task:   task,
+		})
+		addResult(action, index, task.ID, nil)
+	}
+
+	for i, createParams := range params.Create {
+		task, err := s.newTaskFromParams(ctx, createParams)
+		if err != nil {
+			if errors.Is(err, constant.ErrInternalError) {
+				return BulkTasksResponse{}, err
+			}
+			addResult(entity.TaskBatchCreate, i, 0, err)
+			continue
+		}
+		addItem(entity.TaskBatchCreate, i, task)
+	}
+
+	if params.UpdateStatus != nil && len(params.UpdateStatus.IDs) > 0 {
+		status, statusErr := s.getBulkStatus(ctx, params.UpdateStatus.StatusName)
+		if errors.Is(statusErr, constant.ErrInternalError) {
+			return BulkTasksResponse{}, statusErr
+		}
+		for i, id := range params.UpdateStatus.IDs {
+			err := statusErr
+			if err == nil {
+				err = validateBulkTaskID(id)
+			}
+			if err != nil {
+				addResult(entity.TaskBatchUpdateStatus, i, id, err)
+				continue
+			}
+			addItem(entity.TaskBatchUpdateStatus, i, entity.Task{ID: id, StatusID: status.ID})
+		}
+	}
+
+	for i, id := range params.Delete {
+		if err := validateBulkTaskID(id); err != nil {
+			addResult(entity.TaskBatchDelete, i, id, err)
+			continue
+		}
+		addItem(entity.TaskBatchDelete, i, entity.Task{ID: id})
+	}
+
+	for i, id := range params.Restore {
+		if err := validateBulkTaskID(id); err != nil {
+			addResult(entity.TaskBatchRestore, i, id, err)
+			continue
+		}
+		addItem(entity.TaskBatchRestore, i, entity.Task{ID: id})
+	}
+
+	invalid := len(items) != len(response.Results)
+	if len(items) == 0 {
+		return response, nil
+	}
+	if params.AllOrNothing && invalid {
+		for _, position := range positions {
+			r := &response.Results[position]
+			r.Success = false
+			r.Error = bulkNotExecutedError
+		}
+		return response, nil
+	}
+
+	results, err := s.task.ExecTaskBatch(ctx, items, params.AllOrNothing)
+	if err != nil && !errors.Is(err, constant.ErrTaskBatchRolledBack) {
+		s.logger.ErrorContext(ctx, "error executing repo task batch", logger.Error(err))
+		return BulkTasksResponse{}, constant.ErrInternalError
+	}
+
+	response.Committed = err == nil
+
+	for i, result := range results {
+		r := &response.Results[positions[i]]
+		// id of the rolled back created task is never visible
+		if response.Committed || items[i].Action != entity.TaskBatchCreate {
+			r.ID = result.ID
+		}
+		switch {
+		case result.Err != nil:
+			r.Success = false
+			r.Error = fmt.Sprintf("%s %d", result.Err.Error(), result.ID)
+		case !response.Committed:
+			r.Success = false
+			r.Error = bulkRolledBackError
+		}
+	}
+
+	if response.Committed {
+		for i, result := range results {
+			if result.Err != nil {
+				continue
+			}
+			switch items[i].Action {
+			case entity.TaskBatchCreate:
+				s.emit(ctx, entity.TaskEventCreated, result.ID)
+			case entity.TaskBatchDelete:
+				s.emit(ctx, entity.TaskEventDeleted, result.ID)
+			}
+		}
+	}
+
+	return response, nil
+}
+
+func (s *TaskService) getBulkStatus(ctx context.Context, statusName string) (entity.Status, error) {
+	var v validation.Validator
+
+	status, err := s.statusByName(ctx, strings.ToLower(strings.TrimSpace(statusName)), true, &v)
+	if err != nil {
+		return status, err
+	}
+
+	return status, v.Err()
+}
+
+func validateBulkTaskID(id int) error {
+	if id <= 0 {
+		return constant.ErrNonPositiveTaskID
+	}
+	return nil
+}
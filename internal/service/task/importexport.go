@@ -0,0 +1,649 @@
+package taskservice
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/internal/service/validation"
+	"github.com/romandnk/todo/pkg/ical"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/todotxt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// supported import/export formats
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	// FormatICS is iCalendar with VTODO components
+	FormatICS = "ics"
+	// FormatICSEvent is iCalendar with VEVENT components, it is supported by export only
+	FormatICSEvent = "ics-vevent"
+	FormatTodoTxt  = "todotxt"
+)
+
+const icalProdID = "-//romandnk//todo//EN"
+
+// icalStatuses maps task status names to VTODO statuses, unknown names are NEEDS-ACTION
+var icalStatuses = map[string]string{
+	constant.StatusNameDone:    ical.StatusCompleted,
+	constant.StatusNameNotDone: ical.StatusNeedsAction,
+	"done":                     ical.StatusCompleted,
+	"completed":                ical.StatusCompleted,
+	"в работе":                 ical.StatusInProcess,
+	"in progress":              ical.StatusInProcess,
+	"отменено":                 ical.StatusCancelled,
+	"cancelled":                ical.StatusCancelled,
+}
+
+const exportPageSize = 500
+
+// taskEncoder writes exported tasks one by one, Close finishes the document
+type taskEncoder interface {
+	Encode(task GetTaskWithStatusNameModel) error
+	Close() error
+}
+
+// importRow is a decoded task with its position in the imported document
+type importRow struct {
+	row    int
+	params CreateTaskParams
+	err    error
+}
+
+type taskFormat struct {
+	contentType string
+	newEncoder  func(w io.Writer) taskEncoder
+	decode      func(r io.Reader) ([]importRow, error)
+}
+
+var taskFormats = map[string]taskFormat{
+	FormatCSV: {
+		contentType: "text/csv; charset=utf-8",
+		newEncoder:  newCSVTaskEncoder,
+		decode:      decodeCSVTasks,
+	},
+	FormatJSON: {
+		contentType: "application/json; charset=utf-8",
+		newEncoder:  newJSONTaskEncoder,
+		decode:      decodeJSONTasks,
+	},
+	FormatNDJSON: {
+		contentType: "application/x-ndjson; charset=utf-8",
+		newEncoder:  newNDJSONTaskEncoder,
+		decode:      decodeNDJSONTasks,
+	},
+	FormatICS: {
+		contentType: "text/calendar; charset=utf-8",
+		newEncoder: func(w io.Writer) taskEncoder {
+			return &icalTaskEncoder{w: ical.NewWriter(w, icalProdID, ical.ComponentTodo)}
+		},
+		decode: decodeICalTasks,
+	},
+	FormatICSEvent: {
+		contentType: "text/calendar; charset=utf-8",
+		newEncoder: func(w io.Writer) taskEncoder {
+			return &icalTaskEncoder{w: ical.NewWriter(w, icalProdID, ical.ComponentEvent)}
+		},
+	},
+	FormatTodoTxt: {
+		contentType: "text/plain; charset=utf-8",
+		newEncoder:  newTodoTxtTaskEncoder,
+		decode:      decodeTodoTxtTasks,
+	},
+}
+
+func getTaskFormat(format string) (taskFormat, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = FormatJSON
+	}
+
+	f, ok := taskFormats[format]
+	if !ok {
+		return f, constant.ErrUnknownTaskFormat
+	}
+
+	return f, nil
+}
+
+// ExportContentType returns content type of the exported document in the selected format
+func ExportContentType(format string) (string, error) {
+	f, err := getTaskFormat(format)
+	if err != nil {
+		return "", err
+	}
+
+	return f.contentType, nil
+}
+
+// ExportTasks streams all tasks matching list filters into w page by page.
+func (s *TaskService) ExportTasks(ctx context.Context, w io.Writer, format string, params TaskFilterParams) error {
+	f, err := getTaskFormat(format)
+	if err != nil {
+		return err
+	}
+
+	filter, err := s.newListFilter(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	enc := f.newEncoder(w)
+
+	lastID := 0
+	for {
+		tasks, err := s.task.GetAllTasks(ctx, filter.status.ID, exportPageSize, lastID, filter.date, filter.completion, filter.members)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			s.logger.ErrorContext(ctx, "error getting repo all tasks", logger.Error(err))
+			return constant.ErrInternalError
+		}
+
+		for _, task := range tasks {
+			err = enc.Encode(filter.taskModel(task))
+			if err != nil {
+				s.logger.ErrorContext(ctx, "error encoding exported task", logger.Error(err))
+				return constant.ErrInternalError
+			}
+			lastID = task.ID
+		}
+
+		if len(tasks) < exportPageSize {
+			break
+		}
+	}
+
+	err = enc.Close()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error closing exported tasks encoder", logger.Error(err))
+		return constant.ErrInternalError
+	}
+
+	return nil
+}
+
+// ImportTasks validates every imported task with create task rules and saves valid ones in one transaction.
+// Nothing is saved in dry-run mode, the report is returned only.
+func (s *TaskService) ImportTasks(ctx context.Context, r io.Reader, format, dryRunStr string) (ImportTasksResponse, error) {
+	var response ImportTasksResponse
+
+	f, err := getTaskFormat(format)
+	if err != nil {
+		return response, err
+	}
+
+	if dryRunStr != "" {
+		response.DryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "error converting dry-run into bool", logger.Error(err))
+			return response, constant.ErrInvalidDryRun
+		}
+	}
+
+	if f.decode == nil {
+		return response, constant.ErrUnsupportedImportFormat
+	}
+
+	rows, err := f.decode(r)
+	if err != nil {
+		return response, err
+	}
+	if len(rows) == 0 {
+		return response, constant.ErrEmptyImport
+	}
+
+	response.Total = len(rows)
+	response.IDs = make([]int, 0)
+	response.Errors = make([]ImportTaskError, 0)
+
+	items := make([]entity.TaskBatchItem, 0, len(rows))
+	for _, row := range rows {
+		if row.err == nil {
+			var task entity.Task
+			task, row.err = s.newTaskFromParams(ctx, row.params)
+			if row.err == nil {
+				items = append(items, entity.TaskBatchItem{
+					Action: entity.TaskBatchCreate,
+					Task:   task,
+				})
+				continue
+			}
+			if errors.Is(row.err, constant.ErrInternalError) {
+				return ImportTasksResponse{}, row.err
+			}
+		}
+
+		response.Errors = append(response.Errors, ImportTaskError{
+			Row:        row.row,
+			Error:      row.err.Error(),
+			Violations: fieldViolations(row.err),
+		})
+	}
+
+	response.Valid = len(items)
+
+	if response.DryRun || len(items) == 0 {
+		return response, nil
+	}
+
+	// valid tasks are saved in one transaction, so failed import saves none of them
+	results, err := s.task.ExecTaskBatch(ctx, items, true)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error executing repo task batch", logger.Error(err))
+		return ImportTasksResponse{}, constant.ErrInternalError
+	}
+
+	for _, result := range results {
+		response.IDs = append(response.IDs, result.ID)
+		s.emit(ctx, entity.TaskEventCreated, result.ID)
+	}
+
+	response.Imported = len(response.IDs)
+
+	return response, nil
+}
+
+var csvTaskHeader = []string{"id", "title", "description", "status_name", "date", "priority", "tags", "projects", "created_at"}
+
+type csvTaskEncoder struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func newCSVTaskEncoder(w io.Writer) taskEncoder {
+	return &csvTaskEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvTaskEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+
+	return e.w.Write(csvTaskHeader)
+}
+
+func (e *csvTaskEncoder) Encode(task GetTaskWithStatusNameModel) error {
+	err := e.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	err = e.w.Write([]string{
+		strconv.Itoa(task.ID),
+		task.Title,
+		task.Description,
+		task.StatusName,
+		task.Date,
+		strconv.Itoa(task.Priority),
+		strings.Join(task.Tags, " "),
+		strings.Join(task.Projects, " "),
+		task.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvTaskEncoder) Close() error {
+	err := e.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type jsonTaskEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONTaskEncoder(w io.Writer) taskEncoder {
+	return &jsonTaskEncoder{w: w}
+}
+
+func (e *jsonTaskEncoder) Encode(task GetTaskWithStatusNameModel) error {
+	b, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	prefix := ","
+	if e.count == 0 {
+		prefix = "["
+	}
+	e.count++
+
+	_, err = io.WriteString(e.w, prefix+string(b))
+	return err
+}
+
+func (e *jsonTaskEncoder) Close() error {
+	if e.count == 0 {
+		_, err := io.WriteString(e.w, "[]")
+		return err
+	}
+
+	_, err := io.WriteString(e.w, "]")
+	return err
+}
+
+type ndjsonTaskEncoder struct {
+	enc *json.Encoder
+}
+
+func newNDJSONTaskEncoder(w io.Writer) taskEncoder {
+	return &ndjsonTaskEncoder{enc: json.NewEncoder(w)}
+}
+
+func (e *ndjsonTaskEncoder) Encode(task GetTaskWithStatusNameModel) error {
+	return e.enc.Encode(task)
+}
+
+func (e *ndjsonTaskEncoder) Close() error {
+	return nil
+}
+
+func decodeCSVTasks(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, constant.ErrEmptyImport
+		}
+		return nil, constant.ErrInvalidImport.WithMessage(fmt.Sprintf("invalid csv header: %s", err.Error()))
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	required := []string{"title", "description", "status_name", "date"}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, constant.ErrInvalidCSVImportHeader
+		}
+	}
+
+	var rows []importRow
+	for rowNumber := 1; ; rowNumber++ {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rows = append(rows, importRow{row: rowNumber, err: err})
+				continue
+			}
+			return nil, constant.ErrInvalidImport.WithMessage(fmt.Sprintf("invalid csv: %s", err.Error()))
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok {
+				return ""
+			}
+			if i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+
+		row := importRow{
+			row: rowNumber,
+			params: CreateTaskParams{
+				Title:       field("title"),
+				Description: field("description"),
+				StatusName:  field("status_name"),
+				Date:        field("date"),
+				Tags:        strings.Fields(field("tags")),
+				Projects:    strings.Fields(field("projects")),
+			},
+		}
+		if priority := strings.TrimSpace(field("priority")); priority != "" {
+			row.params.Priority, err = strconv.Atoi(priority)
+			if err != nil {
+				row.err = constant.ErrInvalidPriority
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func decodeJSONTasks(r io.Reader) ([]importRow, error) {
+	dec := json.NewDecoder(r)
+
+	token, err := dec.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, constant.ErrEmptyImport
+		}
+		return nil, constant.ErrInvalidImport.WithMessage(fmt.Sprintf("invalid json: %s", err.Error()))
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, constant.ErrInvalidImport.WithMessage("json import must be an array of tasks")
+	}
+
+	var rows []importRow
+	for rowNumber := 1; dec.More(); rowNumber++ {
+		var params CreateTaskParams
+		err = dec.Decode(&params)
+		if err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				rows = append(rows, importRow{row: rowNumber, err: err})
+				continue
+			}
+			return nil, constant.ErrInvalidImport.WithMessage(fmt.Sprintf("invalid json task %d: %s", rowNumber, err.Error()))
+		}
+
+		rows = append(rows, importRow{row: rowNumber, params: params})
+	}
+
+	return rows, nil
+}
+
+func decodeNDJSONTasks(r io.Reader) ([]importRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var rows []importRow
+	for rowNumber := 1; scanner.Scan(); rowNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var params CreateTaskParams
+		err := json.Unmarshal([]byte(line), &params)
+		rows = append(rows, importRow{row: rowNumber, params: params, err: err})
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, constant.ErrInvalidImport.WithMessage(fmt.Sprintf("invalid ndjson: %s", err.Error()))
+	}
+
+	return rows, nil
+}
+
+type icalTaskEncoder struct {
+	w *ical.Writer
+}
+
+// modelDate parses date of the task model rendered by formatTaskDate
+func modelDate(task GetTaskWithStatusNameModel) (time.Time, error) {
+	if task.AllDay {
+		return time.Parse(validation.DateOnlyLayout, task.Date)
+	}
+	return time.Parse(time.RFC3339, task.Date)
+}
+
+func (e *icalTaskEncoder) Encode(task GetTaskWithStatusNameModel) error {
+	due, err := modelDate(task)
+	if err != nil {
+		return err
+	}
+	created, err := time.Parse(time.RFC3339, task.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	status, ok := icalStatuses[task.StatusName]
+	if !ok {
+		status = ical.StatusNeedsAction
+	}
+
+	return e.w.Write(ical.Item{
+		UID:         fmt.Sprintf("task-%d@todo", task.ID),
+		Summary:     task.Title,
+		Description: task.Description,
+		Status:      status,
+		Due:         due,
+		AllDay:      task.AllDay,
+		Created:     created,
+	})
+}
+
+func (e *icalTaskEncoder) Close() error {
+	return e.w.Close()
+}
+
+// decodeICalTasks imports VTODO items, completed ones get done status and the others get not done status.
+// Item with malformed property is the row error
+func decodeICalTasks(r io.Reader) ([]importRow, error) {
+	items, err := ical.Parse(r, ical.ComponentTodo)
+	if err != nil {
+		return nil, constant.ErrInvalidImport.WithMessage(fmt.Sprintf("invalid ics: %s", err.Error()))
+	}
+
+	rows := make([]importRow, 0, len(items))
+	for i, item := range items {
+		if item.Err != nil {
+			rows = append(rows, importRow{row: i + 1, err: item.Err})
+			continue
+		}
+
+		params := CreateTaskParams{
+			Title:       item.Summary,
+			Description: item.Description,
+			StatusName:  constant.StatusNameNotDone,
+		}
+		if params.Description == "" {
+			params.Description = item.Summary
+		}
+		if item.Status == ical.StatusCompleted {
+			params.StatusName = constant.StatusNameDone
+		}
+		if !item.Due.IsZero() {
+			params.Date = item.Due.Format(time.RFC3339)
+			if item.AllDay {
+				params.Date = item.Due.Format(validation.DateOnlyLayout)
+			}
+		}
+
+		rows = append(rows, importRow{row: i + 1, params: params})
+	}
+
+	return rows, nil
+}
+
+func isDoneStatus(statusName string) bool {
+	return icalStatuses[statusName] == ical.StatusCompleted
+}
+
+type todoTxtTaskEncoder struct {
+	w io.Writer
+}
+
+func newTodoTxtTaskEncoder(w io.Writer) taskEncoder {
+	return &todoTxtTaskEncoder{w: w}
+}
+
+func (e *todoTxtTaskEncoder) Encode(task GetTaskWithStatusNameModel) error {
+	due, err := modelDate(task)
+	if err != nil {
+		return err
+	}
+	created, err := time.Parse(time.RFC3339, task.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	line := todotxt.Task{
+		Done:        isDoneStatus(task.StatusName),
+		Priority:    task.Priority,
+		Created:     created,
+		Text:        task.Title,
+		Projects:    task.Projects,
+		Contexts:    task.Tags,
+		Due:         due,
+		DueDateOnly: task.AllDay,
+	}
+
+	_, err = io.WriteString(e.w, line.String()+"\n")
+	return err
+}
+
+func (e *todoTxtTaskEncoder) Close() error {
+	return nil
+}
+
+// decodeTodoTxtTasks maps contexts to tags and completed tasks to done status,
+// due dates without time make all-day tasks
+func decodeTodoTxtTasks(r io.Reader) ([]importRow, error) {
+	lines, err := todotxt.ParseAll(r)
+	if err != nil {
+		return nil, constant.ErrInvalidImport.WithMessage(fmt.Sprintf("invalid todo.txt: %s", err.Error()))
+	}
+
+	rows := make([]importRow, 0, len(lines))
+	for _, line := range lines {
+		// line without due tag is due today in the request time zone like quick-add task
+		params := CreateTaskParams{
+			Title:       line.Task.Text,
+			Description: line.Task.Text,
+			StatusName:  constant.StatusNameNotDone,
+			Date:        defaultQuickAddDate,
+			Priority:    line.Task.Priority,
+			Tags:        line.Task.Contexts,
+			Projects:    line.Task.Projects,
+		}
+		if line.Task.Done {
+			params.StatusName = constant.StatusNameDone
+		}
+		if !line.Task.Due.IsZero() {
+			params.Date = line.Task.Due.Format(time.RFC3339)
+			if line.Task.DueDateOnly {
+				params.Date = line.Task.Due.Format(validation.DateOnlyLayout)
+			}
+		}
+
+		rows = append(rows, importRow{row: line.Number, params: params, err: line.Err})
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,164 @@
+package taskservice
+
+import (
+	"context"
+	"fmt"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	"github.com/romandnk/todo/internal/service/validation"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/timezone"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// defaultQuickAddDate is the due date of the quick-add task without date
+	defaultQuickAddDate = "today"
+	// maxQuickAddDateWords is the max number of words of the due date in the quick-add text
+	maxQuickAddDateWords = 6
+)
+
+// quickAddPriorities are named priorities of the quick-add text, letters and numbers are accepted as well
+var quickAddPriorities = map[string]int{
+	"high": 1, "высокий": 1,
+	"medium": 2, "средний": 2,
+	"low": 3, "низкий": 3,
+}
+
+// quickAddStatuses are status name aliases of the quick-add text
+var quickAddStatuses = map[string]string{
+	"done": constant.StatusNameDone,
+	"todo": constant.StatusNameNotDone,
+}
+
+// QuickAddTask parses one line into the task and creates it.
+// Line words "#tag", "+project", "!priority" and "@status" are extracted first,
+// the longest group of the remaining words which is a date becomes the due date and the rest is the title
+func (s *TaskService) QuickAddTask(ctx context.Context, params QuickAddTaskParams) (QuickAddTaskResponse, error) {
+	var response QuickAddTaskResponse
+
+	params.Text = strings.TrimSpace(params.Text)
+	if params.Text == "" {
+		return response, constant.ErrEmptyQuickAddText
+	}
+
+	loc := timezone.FromContext(ctx)
+	createParams, dateText, err := parseQuickAdd(params.Text, time.Now().In(loc))
+	if err != nil {
+		return response, err
+	}
+
+	task, err := s.newTaskFromParams(ctx, createParams)
+	if err != nil {
+		return response, err
+	}
+
+	id, err := s.task.CreateTask(ctx, task)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error creating repo task", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	s.emit(ctx, entity.TaskEventCreated, id)
+
+	response = QuickAddTaskResponse{
+		ID:         id,
+		Title:      task.Title,
+		StatusName: createParams.StatusName,
+		DateText:   dateText,
+		Date:       formatTaskDate(&task, loc),
+		AllDay:     task.AllDay,
+		Priority:   task.Priority,
+		Tags:       labelsOrEmpty(task.Tags),
+		Projects:   labelsOrEmpty(task.Projects),
+	}
+
+	return response, nil
+}
+
+// parseQuickAdd splits quick-add text into create task params, the whole text is the description.
+// Recognized due date words are returned as well
+func parseQuickAdd(text string, now time.Time) (CreateTaskParams, string, error) {
+	params := CreateTaskParams{
+		Description: text,
+		StatusName:  constant.StatusNameNotDone,
+		Date:        defaultQuickAddDate,
+	}
+
+	words := strings.Fields(text)
+	rest := make([]string, 0, len(words))
+
+	for _, word := range words {
+		if utf8.RuneCountInString(word) < 2 {
+			rest = append(rest, word)
+			continue
+		}
+
+		switch value := word[1:]; word[0] {
+		case '#':
+			params.Tags = append(params.Tags, value)
+		case '+':
+			params.Projects = append(params.Projects, value)
+		case '!':
+			priority, err := parseQuickAddPriority(value)
+			if err != nil {
+				return params, "", err
+			}
+			params.Priority = priority
+		case '@':
+			params.StatusName = parseQuickAddStatus(value)
+		default:
+			rest = append(rest, word)
+		}
+	}
+
+	start, end := findDateWords(rest, now)
+	dateText := strings.Join(rest[start:end], " ")
+	if dateText != "" {
+		params.Date = dateText
+	}
+	params.Title = strings.Join(append(rest[:start:start], rest[end:]...), " ")
+
+	return params, dateText, nil
+}
+
+// findDateWords returns bounds of the longest group of words which is a date, the last group wins among equal ones.
+// Start equals end if there is no date
+func findDateWords(words []string, now time.Time) (int, int) {
+	for size := min(len(words), maxQuickAddDateWords); size > 0; size-- {
+		for start := len(words) - size; start >= 0; start-- {
+			_, _, err := validation.ParseDate(strings.Join(words[start:start+size], " "), now)
+			if err == nil {
+				return start, start + size
+			}
+		}
+	}
+	return 0, 0
+}
+
+// parseQuickAddPriority parses named priority, letter A to Z or number 1 to 26
+func parseQuickAddPriority(value string) (int, error) {
+	lower := strings.ToLower(value)
+	if priority, ok := quickAddPriorities[lower]; ok {
+		return priority, nil
+	}
+	if len(lower) == 1 && lower[0] >= 'a' && lower[0] <= 'z' {
+		return int(lower[0]-'a') + 1, nil
+	}
+	if priority, err := strconv.Atoi(value); err == nil && priority > 0 && priority <= validation.MaxPriority {
+		return priority, nil
+	}
+	return 0, constant.ErrInvalidPriority.WithMessage(fmt.Sprintf("unknown priority '%s'", value))
+}
+
+// parseQuickAddStatus maps status alias to status name, underscores of other names are spaces
+func parseQuickAddStatus(value string) string {
+	value = strings.ToLower(value)
+	if statusName, ok := quickAddStatuses[value]; ok {
+		return statusName
+	}
+	return strings.ReplaceAll(value, "_", " ")
+}
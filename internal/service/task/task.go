@@ -8,24 +8,47 @@ import (
 	"github.com/romandnk/todo/internal/constant"
 	"github.com/romandnk/todo/internal/entity"
 	storage "github.com/romandnk/todo/internal/repo"
+	"github.com/romandnk/todo/internal/service/validation"
+	"github.com/romandnk/todo/pkg/currentuser"
 	"github.com/romandnk/todo/pkg/logger"
-	"go.uber.org/zap"
+	"github.com/romandnk/todo/pkg/timezone"
 	"strconv"
 	"strings"
 	"time"
-	"unicode/utf8"
 )
 
+// TaskHook is called synchronously after task is created or deleted, including bulk operations and import
+type TaskHook func(ctx context.Context, event entity.TaskEvent)
+
+// Counter is incremented by CountTaskHook, prometheus counters implement it
+type Counter interface {
+	Inc()
+}
+
+// CountTaskHook counts created and deleted tasks
+func CountTaskHook(created, deleted Counter) TaskHook {
+	return func(_ context.Context, event entity.TaskEvent) {
+		switch event.Type {
+		case entity.TaskEventCreated:
+			created.Inc()
+		case entity.TaskEventDeleted:
+			deleted.Inc()
+		}
+	}
+}
+
 type TaskService struct {
 	task   storage.Task
 	status storage.Status
+	hooks  []TaskHook
 	logger logger.Logger
 }
 
-func NewTaskService(task storage.Task, status storage.Status, logger logger.Logger) *TaskService {
+func NewTaskService(task storage.Task, status storage.Status, hooks []TaskHook, logger logger.Logger) *TaskService {
 	return &TaskService{
 		task:   task,
 		status: status,
+		hooks:  hooks,
 		logger: logger,
 	}
 }
@@ -33,71 +56,77 @@ func NewTaskService(task storage.Task, status storage.Status, logger logger.Logg
 func (s *TaskService) CreateTask(ctx context.Context, params CreateTaskParams) (CreateTaskResponse, error) {
 	var response CreateTaskResponse
 
+	task, err := s.newTaskFromParams(ctx, params)
+	if err != nil {
+		return response, err
+	}
+
+	id, err := s.task.CreateTask(ctx, task)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error creating repo task", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	response.ID = id
+
+	s.emit(ctx, entity.TaskEventCreated, id)
+
+	return response, nil
+}
+
+// newTaskFromParams validates create params and resolves status name into the task ready for saving
+func (s *TaskService) newTaskFromParams(ctx context.Context, params CreateTaskParams) (entity.Task, error) {
+	var task entity.Task
+
 	params.Title = strings.TrimSpace(params.Title)
 	params.Description = strings.TrimSpace(params.Description)
 	params.StatusName = strings.ToLower(strings.TrimSpace(params.StatusName))
 	params.Date = strings.TrimSpace(params.Date)
 
-	if params.Title == "" {
-		return response, constant.ErrEmptyTitle
-	}
-	if utf8.RuneCountInString(params.Title) > 64 {
-		return response, constant.ErrTooLongTitle
-	}
-	if params.Description == "" {
-		return response, constant.ErrEmptyDescription
-	}
-	if params.StatusName == "" {
-		return response, constant.ErrEmptyStatusName
-	}
-	if params.Date == "" {
-		return response, constant.ErrEmptyDate
-	}
-	date, err := time.Parse(time.RFC3339, params.Date)
+	var v validation.Validator
+	v.Check(validation.Title(params.Title))
+	v.Check(validation.Description(params.Description))
+	date, allDay, dateErr := validation.Date(params.Date, params.AllDay, time.Now().In(timezone.FromContext(ctx)))
+	v.Check(dateErr)
+	v.Check(validation.Priority(params.Priority))
+	tags, tagsErr := validation.Labels("tags", params.Tags)
+	v.Check(tagsErr)
+	projects, projectsErr := validation.Labels("projects", params.Projects)
+	v.Check(projectsErr)
+
+	status, err := s.statusByName(ctx, params.StatusName, true, &v)
 	if err != nil {
-		s.logger.Error("error parsing date", zap.Error(err))
-		return response, constant.ErrInvalidDateFormat
-	}
-	now := time.Now().UTC()
-	if date.UTC().Before(now) {
-		return response, constant.ErrOutdatedDate
+		return task, err
 	}
 
-	status, err := s.status.GetStatusByName(ctx, params.StatusName)
-	if err != nil {
-		s.logger.Error("error getting repo status by name", zap.Error(err))
-		if errors.Is(err, pgx.ErrNoRows) {
-			return response, errors.New(fmt.Sprintf("status name '%s' is not found", params.StatusName))
-		}
-		return response, constant.ErrInternalError
+	if !v.Valid() {
+		return task, v.Err()
 	}
 
-	task := entity.Task{
+	task = entity.Task{
 		Title:       params.Title,
 		Description: params.Description,
 		StatusID:    status.ID,
-		Date:        date.UTC(),
+		Date:        date,
+		AllDay:      allDay,
+		Priority:    params.Priority,
+		Tags:        tags,
+		Projects:    projects,
 		Deleted:     false,
 		DeletedAt:   time.Time{},
 	}
-	id, err := s.task.CreateTask(ctx, task)
-	if err != nil {
-		s.logger.Error("error creating repo task", zap.Error(err))
-		return response, constant.ErrInternalError
-	}
-
-	response.ID = id
 
-	return response, nil
+	return task, nil
 }
 
-func (s *TaskService) DeleteTaskByID(ctx context.Context, stringID string) error {
+// DeleteTaskByID deletes task, not zero version must match current task version
+func (s *TaskService) DeleteTaskByID(ctx context.Context, stringID string, version int) error {
 	if stringID == "" {
 		return constant.ErrEmptyTaskID
 	}
 	id, err := strconv.Atoi(stringID)
 	if err != nil {
-		s.logger.Error("error converting string task id to int task id", zap.Error(err))
+		s.logger.ErrorContext(ctx, "error converting string task id to int task id", logger.Error(err))
 		return constant.ErrInvalidTaskID
 	}
 
@@ -105,88 +134,142 @@ func (s *TaskService) DeleteTaskByID(ctx context.Context, stringID string) error
 		return constant.ErrNonPositiveTaskID
 	}
 
-	err = s.task.DeleteTaskByID(ctx, id)
+	if version < 0 {
+		return constant.ErrNegativeTaskVersion
+	}
+
+	err = s.task.DeleteTaskByID(ctx, id, version)
 	if err != nil {
 		if errors.Is(err, constant.ErrTaskIDNotExists) {
-			return errors.New(fmt.Sprintf("%s %d", err.Error(), id))
+			return constant.ErrTaskNotFound.WithMessage(fmt.Sprintf("task with id '%d' is not found", id))
 		}
-		s.logger.Error("error deleting repo task by id", zap.Error(err))
+		if errors.Is(err, constant.ErrTaskVersionMismatch) {
+			return constant.ErrTaskModified
+		}
+		s.logger.ErrorContext(ctx, "error deleting repo task by id", logger.Error(err))
 		return constant.ErrInternalError
 	}
 
+	s.emit(ctx, entity.TaskEventDeleted, id)
+
 	return nil
 }
 
-func (s *TaskService) UpdateTaskByID(ctx context.Context, stringID string, params UpdateTaskByIDParams) error {
+func (s *TaskService) emit(ctx context.Context, eventType string, taskID int) {
+	event := entity.TaskEvent{
+		Type:   eventType,
+		TaskID: taskID,
+		Actor:  currentuser.FromContext(ctx),
+		At:     time.Now().UTC(),
+	}
+	for _, hook := range s.hooks {
+		hook(ctx, event)
+	}
+}
+
+// UpdateTaskByID updates task and returns its new version, not zero version must match current task version
+func (s *TaskService) UpdateTaskByID(ctx context.Context, stringID string, version int, params UpdateTaskByIDParams) (UpdateTaskByIDResponse, error) {
+	var response UpdateTaskByIDResponse
+
 	if stringID == "" {
-		return constant.ErrEmptyTaskID
+		return response, constant.ErrEmptyTaskID
 	}
 	id, err := strconv.Atoi(stringID)
 	if err != nil {
-		s.logger.Error("error converting string task id to int task id", zap.Error(err))
-		return constant.ErrInvalidTaskID
+		s.logger.ErrorContext(ctx, "error converting string task id to int task id", logger.Error(err))
+		return response, constant.ErrInvalidTaskID
 	}
 
 	if id <= 0 {
-		return constant.ErrNonPositiveTaskID
+		return response, constant.ErrNonPositiveTaskID
+	}
+
+	if version < 0 {
+		return response, constant.ErrNegativeTaskVersion
 	}
 
 	params.Title = strings.TrimSpace(params.Title)
 	params.Description = strings.TrimSpace(params.Description)
 	params.StatusName = strings.ToLower(strings.TrimSpace(params.StatusName))
-	var status entity.Status
-	if params.StatusName != "" {
-		status, err = s.status.GetStatusByName(ctx, params.StatusName)
-		if err != nil {
-			s.logger.Error("error getting repo status by name", zap.Error(err))
-			if errors.Is(err, pgx.ErrNoRows) {
-				return errors.New(fmt.Sprintf("status name '%s' is not found", params.StatusName))
-			}
-			return constant.ErrInternalError
-		}
-	}
+	params.Date = strings.TrimSpace(params.Date)
 
+	// only not empty fields are updated and validated
+	var v validation.Validator
+	if params.Title != "" {
+		v.Check(validation.Title(params.Title))
+	}
 	var date time.Time
-	params.Date = strings.TrimSpace(params.Date)
+	var allDay bool
 	if params.Date != "" {
-		date, err = time.Parse(time.RFC3339, params.Date)
-		if err != nil {
-			s.logger.Error("error parsing date", zap.Error(err))
-			return constant.ErrInvalidDateFormat
-		}
-		now := time.Now().UTC()
-		if date.UTC().Before(now) {
-			return constant.ErrOutdatedDate
-		}
+		var dateErr *constant.Error
+		date, allDay, dateErr = validation.Date(params.Date, params.AllDay != nil && *params.AllDay,
+			time.Now().In(timezone.FromContext(ctx)))
+		v.Check(dateErr)
+	} else if params.AllDay != nil {
+		v.Check(constant.ErrAllDayWithoutDate)
+	}
+	var priority int
+	if params.Priority != nil {
+		priority = *params.Priority
+		v.Check(validation.Priority(priority))
+	}
+	var tags, projects []string
+	if params.Tags != nil {
+		var tagsErr *constant.Error
+		tags, tagsErr = validation.Labels("tags", params.Tags)
+		v.Check(tagsErr)
+	}
+	if params.Projects != nil {
+		var projectsErr *constant.Error
+		projects, projectsErr = validation.Labels("projects", params.Projects)
+		v.Check(projectsErr)
+	}
+
+	status, err := s.statusByName(ctx, params.StatusName, false, &v)
+	if err != nil {
+		return response, err
 	}
 
-	task := entity.Task{
-		Title:       params.Title,
-		Description: params.Description,
-		StatusID:    status.ID,
-		Date:        date,
+	if !v.Valid() {
+		return response, v.Err()
 	}
-	err = s.task.UpdateTaskByID(ctx, id, task)
+
+	task := entity.Task{
+		Title:         params.Title,
+		Description:   params.Description,
+		StatusID:      status.ID,
+		Date:          date,
+		AllDay:        allDay,
+		Priority:      priority,
+		ClearPriority: params.Priority != nil && priority == 0,
+		Tags:          tags,
+		Projects:      projects,
+		Version:       version,
+	}
+	response.Version, err = s.task.UpdateTaskByID(ctx, id, task)
 	if err != nil {
 		if errors.Is(err, constant.ErrTaskIDNotExists) {
-			return errors.New(fmt.Sprintf("%s %d", err.Error(), id))
+			return response, constant.ErrTaskNotFound.WithMessage(fmt.Sprintf("task with id '%d' is not found", id))
 		}
-		s.logger.Error("error updating repo task by id", zap.Error(err))
-		return constant.ErrInternalError
+		if errors.Is(err, constant.ErrTaskVersionMismatch) {
+			return response, constant.ErrTaskModified
+		}
+		s.logger.ErrorContext(ctx, "error updating repo task by id", logger.Error(err))
+		return response, constant.ErrInternalError
 	}
 
-	return nil
+	return response, nil
 }
 
-func (s *TaskService) GetAllTasks(ctx context.Context, limitStr, lastIDStr, statusName, dateStr string) (GetAllTasksResponse, error) {
+func (s *TaskService) GetAllTasks(ctx context.Context, params GetAllTasksParams) (GetAllTasksResponse, error) {
 	var response GetAllTasksResponse
 
 	var limit int
 	var err error
-	if limitStr != "" {
-		limit, err = strconv.Atoi(limitStr)
+	if params.Limit != "" {
+		limit, err = strconv.Atoi(params.Limit)
 		if err != nil {
-			s.logger.Error("error converting limit into int", zap.Error(err))
+			s.logger.ErrorContext(ctx, "error converting limit into int", logger.Error(err))
 			return response, constant.ErrInvalidLimit
 		}
 	}
@@ -195,10 +278,10 @@ func (s *TaskService) GetAllTasks(ctx context.Context, limitStr, lastIDStr, stat
 	}
 
 	var lastID int
-	if lastIDStr != "" {
-		lastID, err = strconv.Atoi(lastIDStr)
+	if params.LastID != "" {
+		lastID, err = strconv.Atoi(params.LastID)
 		if err != nil {
-			s.logger.Error("error converting last id into int", zap.Error(err))
+			s.logger.ErrorContext(ctx, "error converting last id into int", logger.Error(err))
 			return response, constant.ErrInvalidLastTaskID
 		}
 	}
@@ -206,88 +289,223 @@ func (s *TaskService) GetAllTasks(ctx context.Context, limitStr, lastIDStr, stat
 		return response, constant.ErrNegativeLastTaskID
 	}
 
+	filter, err := s.newListFilter(ctx, params.TaskFilterParams)
+	if err != nil {
+		return response, err
+	}
+
+	tasks, err := s.task.GetAllTasks(ctx, filter.status.ID, limit, lastID, filter.date, filter.completion, filter.members)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error getting repo all tasks", logger.Error(err))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return response, nil
+		}
+		return response, constant.ErrInternalError
+	}
+
+	response.Tasks = make([]GetTaskWithStatusNameModel, 0, len(tasks))
+	for _, task := range tasks {
+		response.Tasks = append(response.Tasks, filter.taskModel(task))
+	}
+
+	response.Total = len(response.Tasks)
+
+	return response, nil
+}
+
+// parseCompletionFilter parses checklist completion percentage bounds, missing min is 0 and missing max is 100.
+// Filter is valid if any bound is set
+func parseCompletionFilter(minStr, maxStr string) (entity.CompletionFilter, error) {
+	if minStr == "" && maxStr == "" {
+		return entity.CompletionFilter{}, nil
+	}
+	filter := entity.CompletionFilter{Min: 0, Max: 100, Valid: true}
+
+	var err error
+	if minStr != "" {
+		filter.Min, err = strconv.Atoi(minStr)
+		if err != nil || filter.Min < 0 || filter.Min > 100 {
+			return filter, constant.ErrInvalidMinCompletion
+		}
+	}
+	if maxStr != "" {
+		filter.Max, err = strconv.Atoi(maxStr)
+		if err != nil || filter.Max < 0 || filter.Max > 100 {
+			return filter, constant.ErrInvalidMaxCompletion
+		}
+	}
+	if filter.Min > filter.Max {
+		return filter, constant.ErrInvalidCompletionRange
+	}
+
+	return filter, nil
+}
+
+// parseMemberFilter selects tasks assigned to or watched by the request author
+func parseMemberFilter(ctx context.Context, assignedToMeStr, watchingStr string) (entity.MemberFilter, error) {
+	var filter entity.MemberFilter
+
+	var assignedToMe, watching bool
+	var err error
+	if assignedToMeStr != "" {
+		assignedToMe, err = strconv.ParseBool(assignedToMeStr)
+		if err != nil {
+			return filter, constant.ErrInvalidAssignedToMe
+		}
+	}
+	if watchingStr != "" {
+		watching, err = strconv.ParseBool(watchingStr)
+		if err != nil {
+			return filter, constant.ErrInvalidWatching
+		}
+	}
+	if !assignedToMe && !watching {
+		return filter, nil
+	}
+
+	username := currentuser.FromContext(ctx)
+	if username == "" {
+		return filter, constant.ErrUsernameRequired
+	}
+	if assignedToMe {
+		filter.Assignee = username
+	}
+	if watching {
+		filter.Watcher = username
+	}
+
+	return filter, nil
+}
+
+// listFilter holds resolved list filters and statuses names used to build task models.
+// Dates of task models are rendered in loc
+type listFilter struct {
+	status      entity.Status
+	mapStatuses map[int]string
+	date        time.Time
+	completion  entity.CompletionFilter
+	members     entity.MemberFilter
+	loc         *time.Location
+}
+
+func (f listFilter) taskModel(task *entity.Task) GetTaskWithStatusNameModel {
+	statusName := f.status.Name
+	if f.status.ID == 0 {
+		statusName = f.mapStatuses[task.StatusID]
+	}
+
+	return GetTaskWithStatusNameModel{
+		ID:                  task.ID,
+		Title:               task.Title,
+		Description:         task.Description,
+		StatusName:          statusName,
+		Date:                formatTaskDate(task, f.loc),
+		AllDay:              task.AllDay,
+		Priority:            task.Priority,
+		Tags:                labelsOrEmpty(task.Tags),
+		Projects:            labelsOrEmpty(task.Projects),
+		Version:             task.Version,
+		CreatedAt:           task.CreatedAt.In(f.loc).Format(time.RFC3339),
+		CommentsCount:       task.CommentsCount,
+		ChecklistTotal:      task.ChecklistTotal,
+		ChecklistCompletion: entity.ChecklistCompletion(task.ChecklistDone, task.ChecklistTotal),
+	}
+}
+
+// formatTaskDate renders calendar day of all-day task and time of the other tasks in loc
+func formatTaskDate(task *entity.Task, loc *time.Location) string {
+	if task.AllDay {
+		return task.Date.UTC().Format(validation.DateOnlyLayout)
+	}
+	return task.Date.In(loc).Format(time.RFC3339)
+}
+
+// statusByName validates task status name and finds the status, violations are added to v.
+// Error is returned only if status cannot be found because of internal error
+func (s *TaskService) statusByName(ctx context.Context, statusName string, required bool, v *validation.Validator) (entity.Status, error) {
 	var status entity.Status
-	var mapStatuses = make(map[int]string)
-	statusName = strings.ToLower(statusName)
+
+	if statusName == "" && !required {
+		return status, nil
+	}
+
+	if err := validation.StatusName("status_name", statusName); err != nil {
+		v.Check(err)
+		return status, nil
+	}
+
+	status, err := s.status.GetStatusByName(ctx, statusName)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error getting repo status by name", logger.Error(err))
+		if errors.Is(err, pgx.ErrNoRows) {
+			v.Check(constant.ErrUnknownStatusName.WithMessage(fmt.Sprintf("status name '%s' is not found", statusName)))
+			return status, nil
+		}
+		return status, constant.ErrInternalError
+	}
+
+	return status, nil
+}
+
+// newListFilter resolves filters of task list and export, date filter is the day of the date in the request location
+func (s *TaskService) newListFilter(ctx context.Context, params TaskFilterParams) (listFilter, error) {
+	filter := listFilter{loc: timezone.FromContext(ctx)}
+	var err error
+
+	filter.completion, err = parseCompletionFilter(params.MinCompletion, params.MaxCompletion)
+	if err != nil {
+		return filter, err
+	}
+
+	filter.members, err = parseMemberFilter(ctx, params.AssignedToMe, params.Watching)
+	if err != nil {
+		return filter, err
+	}
+
+	statusName := strings.ToLower(params.StatusName)
 	if statusName != "" {
-		status, err = s.status.GetStatusByName(ctx, statusName)
+		filter.status, err = s.status.GetStatusByName(ctx, statusName)
 		if err != nil {
-			s.logger.Error("error getting repo status by name", zap.Error(err))
+			s.logger.ErrorContext(ctx, "error getting repo status by name", logger.Error(err))
 			if errors.Is(err, pgx.ErrNoRows) {
-				return response, errors.New(fmt.Sprintf("status name '%s' is not found", statusName))
+				return filter, constant.ErrUnknownStatusName.WithMessage(fmt.Sprintf("status name '%s' is not found", statusName)).WithField("status_name")
 			}
-			return response, constant.ErrInternalError
+			return filter, constant.ErrInternalError
 		}
 	} else {
 		statuses, err := s.status.GetAllStatuses(ctx)
 		if err != nil {
-			s.logger.Error("error getting repo all statuses", zap.Error(err))
+			s.logger.ErrorContext(ctx, "error getting repo all statuses", logger.Error(err))
 			if errors.Is(err, pgx.ErrNoRows) {
-				return response, errors.New("statuses are not found")
+				return filter, constant.ErrStatusNotFound.WithMessage("statuses are not found")
 			}
-			return response, constant.ErrInternalError
+			return filter, constant.ErrInternalError
 		}
 
-		mapStatuses = make(map[int]string, len(statuses))
+		filter.mapStatuses = make(map[int]string, len(statuses))
 		for _, status := range statuses {
 			if status != nil {
-				mapStatuses[status.ID] = status.Name
+				filter.mapStatuses[status.ID] = status.Name
 			} else {
-				s.logger.Error("error status is nil")
-				return response, constant.ErrInternalError
+				s.logger.ErrorContext(ctx, "error status is nil")
+				return filter, constant.ErrInternalError
 			}
 		}
 	}
 
-	var date time.Time
-	if dateStr != "" {
-		date, err = time.Parse(time.RFC3339, dateStr)
+	if params.Date != "" {
+		filter.date, err = time.Parse(time.RFC3339, params.Date)
 		if err != nil {
-			s.logger.Error("error parsing date", zap.Error(err))
-			return response, constant.ErrInvalidDateFormat
+			filter.date, err = time.ParseInLocation(validation.DateOnlyLayout, params.Date, filter.loc)
 		}
-	}
-
-	date = date.UTC()
-
-	tasks, err := s.task.GetAllTasks(ctx, status.ID, limit, lastID, date)
-	if err != nil {
-		s.logger.Error("error getting repo all tasks", zap.Error(err))
-		if errors.Is(err, pgx.ErrNoRows) {
-			return response, nil
-		}
-		return response, constant.ErrInternalError
-	}
-
-	response.Tasks = make([]GetTaskWithStatusNameModel, 0, len(tasks))
-	taskWithStatusName := GetTaskWithStatusNameModel{}
-	for _, task := range tasks {
-		if status.ID == 0 {
-			taskWithStatusName = GetTaskWithStatusNameModel{
-				ID:          task.ID,
-				Title:       task.Title,
-				Description: task.Description,
-				StatusName:  mapStatuses[task.StatusID],
-				Date:        task.Date.Format(time.RFC3339),
-				CreatedAt:   task.CreatedAt.Format(time.RFC3339),
-			}
-		} else {
-			taskWithStatusName = GetTaskWithStatusNameModel{
-				ID:          task.ID,
-				Title:       task.Title,
-				Description: task.Description,
-				StatusName:  status.Name,
-				Date:        task.Date.Format(time.RFC3339),
-				CreatedAt:   task.CreatedAt.Format(time.RFC3339),
-			}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "error parsing date", logger.Error(err))
+			return filter, constant.ErrInvalidDateFormat
 		}
-
-		response.Tasks = append(response.Tasks, taskWithStatusName)
+		filter.date = timezone.StartOfDay(filter.date.In(filter.loc))
 	}
 
-	response.Total = len(response.Tasks)
-
-	return response, nil
+	return filter, nil
 }
 
 func (s *TaskService) GetTaskByID(ctx context.Context, stringID string) (GetTaskWithStatusNameModel, error) {
@@ -298,7 +516,7 @@ func (s *TaskService) GetTaskByID(ctx context.Context, stringID string) (GetTask
 	}
 	id, err := strconv.Atoi(stringID)
 	if err != nil {
-		s.logger.Error("error converting string task id to int task id", zap.Error(err))
+		s.logger.ErrorContext(ctx, "error converting string task id to int task id", logger.Error(err))
 		return response, constant.ErrInvalidTaskID
 	}
 
@@ -308,18 +526,18 @@ func (s *TaskService) GetTaskByID(ctx context.Context, stringID string) (GetTask
 
 	task, err := s.task.GetTaskByID(ctx, id)
 	if err != nil {
-		s.logger.Error("error getting repo task by id", zap.Error(err))
+		s.logger.ErrorContext(ctx, "error getting repo task by id", logger.Error(err))
 		if errors.Is(err, pgx.ErrNoRows) {
-			return response, errors.New(fmt.Sprintf("task with id '%d' is not found", id))
+			return response, constant.ErrTaskNotFound.WithMessage(fmt.Sprintf("task with id '%d' is not found", id))
 		}
 		return response, constant.ErrInternalError
 	}
 
 	status, err := s.status.GetStatusByID(ctx, task.StatusID)
 	if err != nil {
-		s.logger.Error("error getting repo status by id", zap.Error(err))
+		s.logger.ErrorContext(ctx, "error getting repo status by id", logger.Error(err))
 		if errors.Is(err, pgx.ErrNoRows) {
-			return response, errors.New(fmt.Sprintf("status id '%d' is not found", task.StatusID))
+			return response, constant.ErrStatusNotFound.WithMessage(fmt.Sprintf("status id '%d' is not found", task.StatusID))
 		}
 		return response, constant.ErrInternalError
 	}
@@ -327,9 +545,18 @@ func (s *TaskService) GetTaskByID(ctx context.Context, stringID string) (GetTask
 	response.ID = task.ID
 	response.Title = task.Title
 	response.Description = task.Description
-	response.Date = task.Date.Format(time.RFC3339)
+	loc := timezone.FromContext(ctx)
+	response.Date = formatTaskDate(&task, loc)
+	response.AllDay = task.AllDay
 	response.StatusName = status.Name
-	response.CreatedAt = task.CreatedAt.Format(time.RFC3339)
+	response.Priority = task.Priority
+	response.Tags = labelsOrEmpty(task.Tags)
+	response.Projects = labelsOrEmpty(task.Projects)
+	response.Version = task.Version
+	response.CreatedAt = task.CreatedAt.In(loc).Format(time.RFC3339)
+	response.CommentsCount = task.CommentsCount
+	response.ChecklistTotal = task.ChecklistTotal
+	response.ChecklistCompletion = entity.ChecklistCompletion(task.ChecklistDone, task.ChecklistTotal)
 
 	return response, nil
 }
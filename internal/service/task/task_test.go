@@ -1,17 +1,22 @@
 package taskservice
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5"
 	"github.com/romandnk/todo/internal/constant"
 	"github.com/romandnk/todo/internal/entity"
 	mock_storage "github.com/romandnk/todo/internal/repo/mock"
+	"github.com/romandnk/todo/pkg/currentuser"
+	"github.com/romandnk/todo/pkg/logger"
 	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/romandnk/todo/pkg/timezone"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
-	"go.uber.org/zap"
+	"strings"
 	"testing"
 	"time"
 )
@@ -22,16 +27,16 @@ const (
 )
 
 func TestTaskService_CreateTask(t *testing.T) {
-	date := time.Date(2024, 12, 07, 20, 49, 18, 0, time.UTC)
+	date := time.Now().UTC().AddDate(1, 0, 0).Truncate(time.Second)
 
-	type logger func(mock *mock_logger.MockLogger, msg string, args ...any)
+	type loggerBehavior func(mock *mock_logger.MockLogger, msg string, args ...any)
 	type createTask func(mock *mock_storage.MockTask, ctx context.Context, task entity.Task, expectedID int, expectedError error)
 	type getStatusByName func(mock *mock_storage.MockStatus, ctx context.Context, name string, expectedStatus entity.Status, expectedError error)
 
 	testCases := []struct {
 		name                string
 		input               CreateTaskParams
-		loggerMock          logger
+		loggerMock          loggerBehavior
 		loggerMsg           string
 		loggerArgs          []any
 		statusMock          getStatusByName
@@ -51,7 +56,7 @@ func TestTaskService_CreateTask(t *testing.T) {
 				Title:       "Test",
 				Description: "Test",
 				StatusName:  "Выполнено",
-				Date:        "2024-12-07T20:49:18Z",
+				Date:        date.Format(time.RFC3339),
 			},
 			statusMock: func(mock *mock_storage.MockStatus, ctx context.Context, name string, expectedStatus entity.Status, expectedError error) {
 				mock.EXPECT().GetStatusByName(ctx, name).Return(expectedStatus, expectedError)
@@ -69,6 +74,8 @@ func TestTaskService_CreateTask(t *testing.T) {
 				Description: "Test",
 				StatusID:    1,
 				Date:        date,
+				Tags:        []string{},
+				Projects:    []string{},
 				Deleted:     false,
 				DeletedAt:   time.Time{},
 			},
@@ -83,9 +90,36 @@ func TestTaskService_CreateTask(t *testing.T) {
 				Title:       "",
 				Description: "Test",
 				StatusName:  "Выполнено",
-				Date:        "2024-12-07T20:49:18Z",
+				Date:        date.Format(time.RFC3339),
+			},
+			statusMock: func(mock *mock_storage.MockStatus, ctx context.Context, name string, expectedStatus entity.Status, expectedError error) {
+				mock.EXPECT().GetStatusByName(ctx, name).Return(expectedStatus, expectedError)
+			},
+			expectedStatusName: "выполнено",
+			expectedStatus: entity.Status{
+				ID:   1,
+				Name: "выполнено",
+			},
+			expectedError: constant.ValidationErrors{constant.ErrEmptyTitle},
+		},
+		{
+			name: "all invalid fields are reported",
+			input: CreateTaskParams{
+				Title:       strings.Repeat("a", 65),
+				Description: "",
+				StatusName:  strings.Repeat("b", 17),
+				Date:        date.AddDate(-2, 0, 0).Format(time.RFC3339),
+				Priority:    27,
+				Tags:        []string{"two words"},
+			},
+			expectedError: constant.ValidationErrors{
+				constant.ErrTooLongTitle,
+				constant.ErrEmptyDescription,
+				constant.ErrOutdatedDate,
+				constant.ErrInvalidPriority,
+				constant.ErrInvalidLabel,
+				constant.ErrTooLongStatusName,
 			},
-			expectedError: constant.ErrEmptyTitle,
 		},
 		{
 			name: "OK",
@@ -93,13 +127,13 @@ func TestTaskService_CreateTask(t *testing.T) {
 				Title:       "Test",
 				Description: "Test",
 				StatusName:  "test",
-				Date:        "2024-12-07T20:49:18Z",
+				Date:        date.Format(time.RFC3339),
 			},
 			loggerMock: func(mock *mock_logger.MockLogger, msg string, args ...any) {
-				mock.EXPECT().Error(msg, args...)
+				mock.EXPECT().ErrorContext(gomock.Any(), msg, args...)
 			},
 			loggerMsg:  "error getting repo status by name",
-			loggerArgs: []any{zap.Error(pgx.ErrNoRows)},
+			loggerArgs: []any{logger.Error(pgx.ErrNoRows)},
 			statusMock: func(mock *mock_storage.MockStatus, ctx context.Context, name string, expectedStatus entity.Status, expectedError error) {
 				mock.EXPECT().GetStatusByName(ctx, name).Return(expectedStatus, expectedError)
 			},
@@ -121,7 +155,7 @@ func TestTaskService_CreateTask(t *testing.T) {
 			statusStorage := mock_storage.NewMockStatus(ctrl)
 			log := mock_logger.NewMockLogger(ctrl)
 
-			taskService := NewTaskService(taskStorage, statusStorage, log)
+			taskService := NewTaskService(taskStorage, statusStorage, nil, log)
 
 			if tc.statusMock != nil {
 				tc.statusMock(statusStorage, ctx, tc.expectedStatusName, tc.expectedStatus, tc.expectedStatusError)
@@ -138,10 +172,671 @@ func TestTaskService_CreateTask(t *testing.T) {
 			output, err := taskService.CreateTask(ctx, tc.input)
 			if err != nil {
 				require.EqualError(t, err, tc.expectedError.Error())
+				var expectedErrs constant.ValidationErrors
+				if errors.As(tc.expectedError, &expectedErrs) {
+					for _, expectedErr := range expectedErrs {
+						require.ErrorIs(t, err, expectedErr)
+					}
+				}
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedOutput, output)
+		})
+	}
+}
+
+func TestTaskService_BulkTasks(t *testing.T) {
+	date := time.Now().UTC().AddDate(1, 0, 0).Truncate(time.Second)
+
+	type repoMock func(task *mock_storage.MockTask, status *mock_storage.MockStatus, ctx context.Context)
+
+	testCases := []struct {
+		name           string
+		input          BulkTasksParams
+		repoMock       repoMock
+		expectedOutput BulkTasksResponse
+		expectedEvents []entity.TaskEvent
+		expectedError  error
+	}{
+		{
+			name: "OK",
+			input: BulkTasksParams{
+				Create: []CreateTaskParams{
+					{
+						Title:       "Test",
+						Description: "Test",
+						StatusName:  "Выполнено",
+						Date:        date.Format(time.RFC3339),
+					},
+				},
+				UpdateStatus: &BulkUpdateStatusParams{
+					IDs:        []int{1},
+					StatusName: "выполнено",
+				},
+				Delete:  []int{2},
+				Restore: []int{3},
+			},
+			repoMock: func(task *mock_storage.MockTask, status *mock_storage.MockStatus, ctx context.Context) {
+				status.EXPECT().GetStatusByName(ctx, "выполнено").Return(entity.Status{ID: 1, Name: "выполнено"}, nil).Times(2)
+				task.EXPECT().ExecTaskBatch(ctx, []entity.TaskBatchItem{
+					{
+						Action: entity.TaskBatchCreate,
+						Task: entity.Task{
+							Title:       "Test",
+							Description: "Test",
+							StatusID:    1,
+							Date:        date,
+							Tags:        []string{},
+							Projects:    []string{},
+						},
+					},
+					{Action: entity.TaskBatchUpdateStatus, Task: entity.Task{ID: 1, StatusID: 1}},
+					{Action: entity.TaskBatchDelete, Task: entity.Task{ID: 2}},
+					{Action: entity.TaskBatchRestore, Task: entity.Task{ID: 3}},
+				}, false).Return([]entity.TaskBatchResult{
+					{ID: 4},
+					{ID: 1},
+					{ID: 2, Err: constant.ErrTaskIDNotExists},
+					{ID: 3},
+				}, nil)
+			},
+			expectedOutput: BulkTasksResponse{
+				Committed: true,
+				Results: []BulkTaskResult{
+					{Action: "create", Index: 0, ID: 4, Success: true},
+					{Action: "update_status", Index: 0, ID: 1, Success: true},
+					{Action: "delete", Index: 0, ID: 2, Success: false, Error: "no task with id 2"},
+					{Action: "restore", Index: 0, ID: 3, Success: true},
+				},
+			},
+			expectedEvents: []entity.TaskEvent{
+				{Type: entity.TaskEventCreated, TaskID: 4},
+			},
+		},
+		{
+			name: "all or nothing with invalid item",
+			input: BulkTasksParams{
+				Create: []CreateTaskParams{
+					{
+						Title:       "",
+						Description: "Test",
+						StatusName:  "выполнено",
+						Date:        date.Format(time.RFC3339),
+					},
+				},
+				Delete:       []int{1},
+				AllOrNothing: true,
+			},
+			repoMock: func(task *mock_storage.MockTask, status *mock_storage.MockStatus, ctx context.Context) {
+				status.EXPECT().GetStatusByName(ctx, "выполнено").Return(entity.Status{ID: 1, Name: "выполнено"}, nil)
+			},
+			expectedOutput: BulkTasksResponse{
+				Committed: false,
+				Results: []BulkTaskResult{
+					{
+						Action:  "create",
+						Index:   0,
+						Success: false,
+						Error:   constant.ErrEmptyTitle.Error(),
+						Violations: []FieldViolation{
+							{Field: "title", Code: "empty_title", Message: constant.ErrEmptyTitle.Error()},
+						},
+					},
+					{Action: "delete", Index: 0, ID: 1, Success: false, Error: "not executed, batch rejected"},
+				},
+			},
+		},
+		{
+			name: "all or nothing rolled back",
+			input: BulkTasksParams{
+				Delete:       []int{1, 2},
+				AllOrNothing: true,
+			},
+			repoMock: func(task *mock_storage.MockTask, status *mock_storage.MockStatus, ctx context.Context) {
+				task.EXPECT().ExecTaskBatch(ctx, []entity.TaskBatchItem{
+					{Action: entity.TaskBatchDelete, Task: entity.Task{ID: 1}},
+					{Action: entity.TaskBatchDelete, Task: entity.Task{ID: 2}},
+				}, true).Return([]entity.TaskBatchResult{
+					{ID: 1},
+					{ID: 2, Err: constant.ErrTaskIDNotExists},
+				}, constant.ErrTaskBatchRolledBack)
+			},
+			expectedOutput: BulkTasksResponse{
+				Committed: false,
+				Results: []BulkTaskResult{
+					{Action: "delete", Index: 0, ID: 1, Success: false, Error: "rolled back, batch rejected"},
+					{Action: "delete", Index: 1, ID: 2, Success: false, Error: "no task with id 2"},
+				},
+			},
+		},
+		{
+			name:          "empty operations",
+			input:         BulkTasksParams{},
+			expectedError: constant.ErrEmptyBulkOperations,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			taskStorage := mock_storage.NewMockTask(ctrl)
+			statusStorage := mock_storage.NewMockStatus(ctrl)
+			log := mock_logger.NewMockLogger(ctrl)
+
+			var events []entity.TaskEvent
+			hook := func(_ context.Context, event entity.TaskEvent) {
+				event.At = time.Time{}
+				events = append(events, event)
+			}
+
+			taskService := NewTaskService(taskStorage, statusStorage, []TaskHook{hook}, log)
+
+			if tc.repoMock != nil {
+				tc.repoMock(taskStorage, statusStorage, ctx)
+			}
+
+			output, err := taskService.BulkTasks(ctx, tc.input)
+			if tc.expectedError != nil {
+				require.EqualError(t, err, tc.expectedError.Error())
 			} else {
 				require.NoError(t, err)
 			}
 			require.Equal(t, tc.expectedOutput, output)
+			require.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
+
+type counter int
+
+func (c *counter) Inc() {
+	*c++
+}
+
+func TestCountTaskHook(t *testing.T) {
+	var created, deleted counter
+	hook := CountTaskHook(&created, &deleted)
+
+	ctx := context.Background()
+	hook(ctx, entity.TaskEvent{Type: entity.TaskEventCreated, TaskID: 1})
+	hook(ctx, entity.TaskEvent{Type: entity.TaskEventCreated, TaskID: 2})
+	hook(ctx, entity.TaskEvent{Type: entity.TaskEventDeleted, TaskID: 1})
+
+	require.Equal(t, counter(2), created)
+	require.Equal(t, counter(1), deleted)
+}
+
+func TestTaskService_GetAllTasksInTimezone(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	date := time.Date(2030, 1, 1, 22, 30, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := timezone.WithLocation(context.Background(), moscow)
+
+	taskStorage := mock_storage.NewMockTask(ctrl)
+	statusStorage := mock_storage.NewMockStatus(ctrl)
+	log := mock_logger.NewMockLogger(ctrl)
+
+	taskService := NewTaskService(taskStorage, statusStorage, nil, log)
+
+	statusStorage.EXPECT().GetAllStatuses(ctx).Return([]*entity.Status{{ID: 2, Name: constant.StatusNameNotDone}}, nil)
+	taskStorage.EXPECT().GetAllTasks(ctx, 0, 0, 0, time.Date(2030, 1, 2, 0, 0, 0, 0, moscow), entity.CompletionFilter{}, entity.MemberFilter{}).Return([]*entity.Task{
+		{ID: 1, StatusID: 2, Date: date, CreatedAt: date},
+		{ID: 2, StatusID: 2, Date: time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC), AllDay: true, CreatedAt: date},
+	}, nil)
+
+	output, err := taskService.GetAllTasks(ctx, GetAllTasksParams{TaskFilterParams: TaskFilterParams{Date: "2030-01-02"}})
+	require.NoError(t, err)
+	require.Equal(t, 2, output.Total)
+	require.Equal(t, "2030-01-02T01:30:00+03:00", output.Tasks[0].Date)
+	require.False(t, output.Tasks[0].AllDay)
+	require.Equal(t, "2030-01-02", output.Tasks[1].Date)
+	require.True(t, output.Tasks[1].AllDay)
+	require.Equal(t, "2030-01-02T01:30:00+03:00", output.Tasks[1].CreatedAt)
+}
+
+func TestTaskService_GetAllTasksUnknownStatusName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	statusStorage := mock_storage.NewMockStatus(ctrl)
+	log := mock_logger.NewMockLogger(ctrl)
+
+	taskService := NewTaskService(mock_storage.NewMockTask(ctrl), statusStorage, nil, log)
+
+	statusStorage.EXPECT().GetStatusByName(ctx, "archived").Return(entity.Status{}, pgx.ErrNoRows)
+	log.EXPECT().ErrorContext(ctx, "error getting repo status by name", gomock.Any())
+
+	_, err := taskService.GetAllTasks(ctx, GetAllTasksParams{TaskFilterParams: TaskFilterParams{StatusName: "Archived"}})
+	require.ErrorIs(t, err, constant.ErrUnknownStatusName)
+
+	var serviceErr *constant.Error
+	require.ErrorAs(t, err, &serviceErr)
+	require.Equal(t, "status_name", serviceErr.Field)
+	require.Equal(t, "status name 'archived' is not found", serviceErr.Message)
+}
+
+func TestParseMemberFilter(t *testing.T) {
+	ctx := currentuser.WithUsername(context.Background(), "ivan")
+
+	filter, err := parseMemberFilter(ctx, "true", "")
+	require.NoError(t, err)
+	require.Equal(t, entity.MemberFilter{Assignee: "ivan"}, filter)
+
+	filter, err = parseMemberFilter(ctx, "false", "1")
+	require.NoError(t, err)
+	require.Equal(t, entity.MemberFilter{Watcher: "ivan"}, filter)
+
+	_, err = parseMemberFilter(ctx, "yes", "")
+	require.ErrorIs(t, err, constant.ErrInvalidAssignedToMe)
+
+	_, err = parseMemberFilter(context.Background(), "", "true")
+	require.ErrorIs(t, err, constant.ErrUsernameRequired)
+
+	filter, err = parseMemberFilter(context.Background(), "", "")
+	require.NoError(t, err)
+	require.Equal(t, entity.MemberFilter{}, filter)
+}
+
+func TestTaskService_ExportTasks(t *testing.T) {
+	date := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		format         string
+		expectedOutput string
+		expectedError  error
+	}{
+		{
+			name:   "csv",
+			format: "csv",
+			expectedOutput: "id,title,description,status_name,date,priority,tags,projects,created_at\n" +
+				"1,Test,\"Test, with comma\",выполнено,2030-01-02T10:00:00Z,1,home phone,family,2030-01-02T10:00:00Z\n",
+		},
+		{
+			name:           "json",
+			format:         "",
+			expectedOutput: `[{"id":1,"title":"Test","description":"Test, with comma","status_name":"выполнено","date":"2030-01-02T10:00:00Z","all_day":false,"priority":1,"tags":["home","phone"],"projects":["family"],"version":1,"created_at":"2030-01-02T10:00:00Z","comments_count":0,"checklist_total":0,"checklist_completion":0}]`,
+		},
+		{
+			name:           "ndjson",
+			format:         "ndjson",
+			expectedOutput: `{"id":1,"title":"Test","description":"Test, with comma","status_name":"выполнено","date":"2030-01-02T10:00:00Z","all_day":false,"priority":1,"tags":["home","phone"],"projects":["family"],"version":1,"created_at":"2030-01-02T10:00:00Z","comments_count":0,"checklist_total":0,"checklist_completion":0}` + "\n",
+		},
+		{
+			name:           "todotxt",
+			format:         "todotxt",
+			expectedOutput: "x Test +family @home @phone due:2030-01-02T10:00:00Z pri:A\n",
+		},
+		{
+			name:          "unknown format",
+			format:        "xml",
+			expectedError: constant.ErrUnknownTaskFormat,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			taskStorage := mock_storage.NewMockTask(ctrl)
+			statusStorage := mock_storage.NewMockStatus(ctrl)
+			log := mock_logger.NewMockLogger(ctrl)
+
+			taskService := NewTaskService(taskStorage, statusStorage, nil, log)
+
+			if tc.expectedError == nil {
+				statusStorage.EXPECT().GetAllStatuses(ctx).Return([]*entity.Status{{ID: 1, Name: "выполнено"}}, nil)
+				taskStorage.EXPECT().GetAllTasks(ctx, 0, exportPageSize, 0, time.Time{}, entity.CompletionFilter{}, entity.MemberFilter{}).Return([]*entity.Task{
+					{
+						ID:          1,
+						Title:       "Test",
+						Description: "Test, with comma",
+						StatusID:    1,
+						Date:        date,
+						Priority:    1,
+						Tags:        []string{"home", "phone"},
+						Projects:    []string{"family"},
+						Version:     1,
+						CreatedAt:   date,
+					},
+				}, nil)
+			}
+
+			var output bytes.Buffer
+			err := taskService.ExportTasks(ctx, &output, tc.format, TaskFilterParams{})
+			if tc.expectedError != nil {
+				require.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, output.String())
+		})
+	}
+}
+
+func TestTaskService_ExportTasksFiltered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := currentuser.WithUsername(context.Background(), "ivan")
+	date := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	taskStorage := mock_storage.NewMockTask(ctrl)
+	statusStorage := mock_storage.NewMockStatus(ctrl)
+	log := mock_logger.NewMockLogger(ctrl)
+
+	taskService := NewTaskService(taskStorage, statusStorage, nil, log)
+
+	params := TaskFilterParams{MinCompletion: "50", AssignedToMe: "true"}
+	completion := entity.CompletionFilter{Min: 50, Max: 100, Valid: true}
+	members := entity.MemberFilter{Assignee: "ivan"}
+	tasks := []*entity.Task{{ID: 1, Title: "Test", Description: "Test", StatusID: 1, Date: date, Version: 1, CreatedAt: date, ChecklistTotal: 2, ChecklistDone: 1}}
+
+	statusStorage.EXPECT().GetAllStatuses(ctx).Return([]*entity.Status{{ID: 1, Name: "выполнено"}}, nil).Times(2)
+	taskStorage.EXPECT().GetAllTasks(ctx, 0, 0, 0, time.Time{}, completion, members).Return(tasks, nil)
+	taskStorage.EXPECT().GetAllTasks(ctx, 0, exportPageSize, 0, time.Time{}, completion, members).Return(tasks, nil)
+
+	list, err := taskService.GetAllTasks(ctx, GetAllTasksParams{TaskFilterParams: params})
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+	err = taskService.ExportTasks(ctx, &output, FormatJSON, params)
+	require.NoError(t, err)
+
+	var exported []GetTaskWithStatusNameModel
+	require.NoError(t, json.Unmarshal(output.Bytes(), &exported))
+	require.Equal(t, list.Tasks, exported)
+
+	// member filters of export require the request author like the list
+	err = taskService.ExportTasks(context.Background(), &output, FormatJSON, TaskFilterParams{Watching: "true"})
+	require.ErrorIs(t, err, constant.ErrUsernameRequired)
+}
+
+func TestTaskService_ImportTasks(t *testing.T) {
+	date := time.Now().UTC().AddDate(1, 0, 0).Truncate(time.Second)
+
+	input := "title,description,status_name,date\n" +
+		"Test,Test,выполнено," + date.Format(time.RFC3339) + "\n" +
+		",,выполнено," + date.Format(time.RFC3339) + "\n"
+
+	importErr := ImportTaskError{
+		Row:   2,
+		Error: "title cannot be empty; description cannot be empty",
+		Violations: []FieldViolation{
+			{Field: "title", Code: "empty_title", Message: constant.ErrEmptyTitle.Error()},
+			{Field: "description", Code: "empty_description", Message: constant.ErrEmptyDescription.Error()},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		dryRun         string
+		expectedOutput ImportTasksResponse
+	}{
+		{
+			name:   "dry run",
+			dryRun: "true",
+			expectedOutput: ImportTasksResponse{
+				DryRun: true,
+				Total:  2,
+				Valid:  1,
+				IDs:    []int{},
+				Errors: []ImportTaskError{importErr},
+			},
+		},
+		{
+			name: "import",
+			expectedOutput: ImportTasksResponse{
+				Total:    2,
+				Valid:    1,
+				Imported: 1,
+				IDs:      []int{5},
+				Errors:   []ImportTaskError{importErr},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+
+			taskStorage := mock_storage.NewMockTask(ctrl)
+			statusStorage := mock_storage.NewMockStatus(ctrl)
+			log := mock_logger.NewMockLogger(ctrl)
+
+			taskService := NewTaskService(taskStorage, statusStorage, nil, log)
+
+			statusStorage.EXPECT().GetStatusByName(ctx, "выполнено").Return(entity.Status{ID: 1, Name: "выполнено"}, nil).Times(2)
+			if tc.dryRun == "" {
+				taskStorage.EXPECT().ExecTaskBatch(ctx, []entity.TaskBatchItem{
+					{
+						Action: entity.TaskBatchCreate,
+						Task: entity.Task{
+							Title:       "Test",
+							Description: "Test",
+							StatusID:    1,
+							Date:        date,
+							Tags:        []string{},
+							Projects:    []string{},
+						},
+					},
+				}, true).Return([]entity.TaskBatchResult{{ID: 5}}, nil)
+			}
+
+			output, err := taskService.ImportTasks(ctx, strings.NewReader(input), "csv", tc.dryRun)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, output)
 		})
 	}
 }
+
+func TestTaskService_ImportTodoTxtTasks(t *testing.T) {
+	dueDate := time.Now().UTC().AddDate(1, 0, 0)
+
+	input := "(B) Pay rent +finance @home due:" + dueDate.Format("2006-01-02") + "\n" +
+		"\n" +
+		"x Read a book\n"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	taskStorage := mock_storage.NewMockTask(ctrl)
+	statusStorage := mock_storage.NewMockStatus(ctrl)
+	log := mock_logger.NewMockLogger(ctrl)
+
+	taskService := NewTaskService(taskStorage, statusStorage, nil, log)
+
+	statusStorage.EXPECT().GetStatusByName(ctx, constant.StatusNameNotDone).
+		Return(entity.Status{ID: 2, Name: constant.StatusNameNotDone}, nil)
+	statusStorage.EXPECT().GetStatusByName(ctx, constant.StatusNameDone).
+		Return(entity.Status{ID: 1, Name: constant.StatusNameDone}, nil)
+
+	output, err := taskService.ImportTasks(ctx, strings.NewReader(input), "todotxt", "true")
+	require.NoError(t, err)
+	require.Equal(t, ImportTasksResponse{
+		DryRun: true,
+		Total:  2,
+		Valid:  2,
+		IDs:    []int{},
+		Errors: []ImportTaskError{},
+	}, output)
+
+	// line without due tag is due today in the request time zone
+	loc, err := time.LoadLocation("Pacific/Kiritimati")
+	require.NoError(t, err)
+	ctx = timezone.WithLocation(ctx, loc)
+
+	statusStorage.EXPECT().GetStatusByName(ctx, constant.StatusNameDone).
+		Return(entity.Status{ID: 1, Name: constant.StatusNameDone}, nil)
+	taskStorage.EXPECT().ExecTaskBatch(ctx, gomock.Any(), true).
+		DoAndReturn(func(_ context.Context, items []entity.TaskBatchItem, _ bool) ([]entity.TaskBatchResult, error) {
+			require.Len(t, items, 1)
+			require.Equal(t, "Read a book", items[0].Task.Title)
+			require.Equal(t, timezone.CalendarDate(time.Now().In(loc)), items[0].Task.Date)
+			require.True(t, items[0].Task.AllDay)
+			return []entity.TaskBatchResult{{ID: 7}}, nil
+		})
+
+	output, err = taskService.ImportTasks(ctx, strings.NewReader("x Read a book\n"), "todotxt", "false")
+	require.NoError(t, err)
+	require.Equal(t, []int{7}, output.IDs)
+}
+
+func TestParseQuickAdd(t *testing.T) {
+	// Wednesday 2030-01-02 10:30 UTC
+	now := time.Date(2030, 1, 2, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name             string
+		input            string
+		expectedParams   CreateTaskParams
+		expectedDateText string
+		expectedError    error
+	}{
+		{
+			name:  "all markers",
+			input: "Pay rent #finance !high tomorrow @done",
+			expectedParams: CreateTaskParams{
+				Title:       "Pay rent",
+				Description: "Pay rent #finance !high tomorrow @done",
+				StatusName:  constant.StatusNameDone,
+				Date:        "tomorrow",
+				Priority:    1,
+				Tags:        []string{"finance"},
+			},
+			expectedDateText: "tomorrow",
+		},
+		{
+			name:  "date in the middle and projects",
+			input: "Call mom next friday at 5pm about +family trip",
+			expectedParams: CreateTaskParams{
+				Title:       "Call mom about trip",
+				Description: "Call mom next friday at 5pm about +family trip",
+				StatusName:  constant.StatusNameNotDone,
+				Date:        "next friday at 5pm",
+				Projects:    []string{"family"},
+			},
+			expectedDateText: "next friday at 5pm",
+		},
+		{
+			name:  "russian text",
+			input: "Оплатить счета завтра в 9 !b #дом @не_выполнено",
+			expectedParams: CreateTaskParams{
+				Title:       "Оплатить счета",
+				Description: "Оплатить счета завтра в 9 !b #дом @не_выполнено",
+				StatusName:  constant.StatusNameNotDone,
+				Date:        "завтра в 9",
+				Priority:    2,
+				Tags:        []string{"дом"},
+			},
+			expectedDateText: "завтра в 9",
+		},
+		{
+			name:  "without date",
+			input: "Read a book !3",
+			expectedParams: CreateTaskParams{
+				Title:       "Read a book",
+				Description: "Read a book !3",
+				StatusName:  constant.StatusNameNotDone,
+				Date:        defaultQuickAddDate,
+				Priority:    3,
+			},
+		},
+		{
+			name:  "RFC3339 date",
+			input: "Deploy 2030-02-01T10:00:00Z",
+			expectedParams: CreateTaskParams{
+				Title:       "Deploy",
+				Description: "Deploy 2030-02-01T10:00:00Z",
+				StatusName:  constant.StatusNameNotDone,
+				Date:        "2030-02-01T10:00:00Z",
+			},
+			expectedDateText: "2030-02-01T10:00:00Z",
+		},
+		{
+			name:          "unknown priority",
+			input:         "Pay rent !urgent",
+			expectedError: constant.ErrInvalidPriority,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			params, dateText, err := parseQuickAdd(tc.input, now)
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedParams, params)
+			require.Equal(t, tc.expectedDateText, dateText)
+		})
+	}
+}
+
+func TestTaskService_QuickAddTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	taskStorage := mock_storage.NewMockTask(ctrl)
+	statusStorage := mock_storage.NewMockStatus(ctrl)
+	log := mock_logger.NewMockLogger(ctrl)
+
+	taskService := NewTaskService(taskStorage, statusStorage, nil, log)
+
+	tomorrow := timezone.CalendarDate(time.Now().UTC().AddDate(0, 0, 1))
+
+	statusStorage.EXPECT().GetStatusByName(ctx, constant.StatusNameDone).
+		Return(entity.Status{ID: 1, Name: constant.StatusNameDone}, nil)
+	taskStorage.EXPECT().CreateTask(ctx, entity.Task{
+		Title:       "Pay rent",
+		Description: "Pay rent #finance !high tomorrow @done",
+		StatusID:    1,
+		Date:        tomorrow,
+		AllDay:      true,
+		Priority:    1,
+		Tags:        []string{"finance"},
+		Projects:    []string{},
+	}).Return(5, nil)
+
+	output, err := taskService.QuickAddTask(ctx, QuickAddTaskParams{Text: " Pay rent #finance !high tomorrow @done "})
+	require.NoError(t, err)
+	require.Equal(t, QuickAddTaskResponse{
+		ID:         5,
+		Title:      "Pay rent",
+		StatusName: constant.StatusNameDone,
+		DateText:   "tomorrow",
+		Date:       tomorrow.Format("2006-01-02"),
+		AllDay:     true,
+		Priority:   1,
+		Tags:       []string{"finance"},
+		Projects:   []string{},
+	}, output)
+
+	_, err = taskService.QuickAddTask(ctx, QuickAddTaskParams{Text: "   "})
+	require.ErrorIs(t, err, constant.ErrEmptyQuickAddText)
+}
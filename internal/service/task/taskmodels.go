@@ -1,33 +1,138 @@
 package taskservice
 
 type CreateTaskParams struct {
-	Title       string `json:"title" binding:"required"`
-	Description string `json:"description" binding:"required"`
-	StatusName  string `json:"status_name" binding:"required"`
-	Date        string `json:"date" binding:"required"`
+	Title       string   `json:"title" binding:"required"`
+	Description string   `json:"description" binding:"required"`
+	StatusName  string   `json:"status_name" binding:"required"`
+	Date        string   `json:"date" binding:"required"`
+	AllDay      bool     `json:"all_day"`
+	Priority    int      `json:"priority"`
+	Tags        []string `json:"tags"`
+	Projects    []string `json:"projects"`
 }
 
 type CreateTaskResponse struct {
 	ID int `json:"id"`
 }
 
+type QuickAddTaskParams struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// QuickAddTaskResponse is the created task as it was understood from the quick-add text
+type QuickAddTaskResponse struct {
+	ID         int      `json:"id"`
+	Title      string   `json:"title"`
+	StatusName string   `json:"status_name"`
+	DateText   string   `json:"date_text"`
+	Date       string   `json:"date"`
+	AllDay     bool     `json:"all_day"`
+	Priority   int      `json:"priority"`
+	Tags       []string `json:"tags"`
+	Projects   []string `json:"projects"`
+}
+
+// UpdateTaskByIDParams has only changed fields, omitted priority is kept and zero priority clears it
 type UpdateTaskByIDParams struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	StatusName  string `json:"status_name"`
-	Date        string `json:"date"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	StatusName  string   `json:"status_name"`
+	Date        string   `json:"date"`
+	AllDay      *bool    `json:"all_day"`
+	Priority    *int     `json:"priority"`
+	Tags        []string `json:"tags"`
+	Projects    []string `json:"projects"`
+}
+
+type UpdateTaskByIDResponse struct {
+	Version int `json:"version"`
 }
 
 type GetTaskWithStatusNameModel struct {
-	ID          int    `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	StatusName  string `json:"status_name"`
-	Date        string `json:"date"`
-	CreatedAt   string `json:"created_at"`
+	ID             int      `json:"id"`
+	Title          string   `json:"title"`
+	Description    string   `json:"description"`
+	StatusName     string   `json:"status_name"`
+	Date           string   `json:"date"`
+	AllDay         bool     `json:"all_day"`
+	Priority       int      `json:"priority"`
+	Tags           []string `json:"tags"`
+	Projects       []string `json:"projects"`
+	Version        int      `json:"version"`
+	CreatedAt      string   `json:"created_at"`
+	CommentsCount  int      `json:"comments_count"`
+	ChecklistTotal int      `json:"checklist_total"`
+	// ChecklistCompletion is the percentage of done checklist items
+	ChecklistCompletion int `json:"checklist_completion"`
+}
+
+// TaskFilterParams are raw query values of task list filters, they are validated by the service
+type TaskFilterParams struct {
+	StatusName    string
+	Date          string
+	MinCompletion string
+	MaxCompletion string
+	AssignedToMe  string
+	Watching      string
+}
+
+// GetAllTasksParams are raw query values of the task list page
+type GetAllTasksParams struct {
+	Limit  string
+	LastID string
+	TaskFilterParams
 }
 
 type GetAllTasksResponse struct {
 	Total int                          `json:"total"`
 	Tasks []GetTaskWithStatusNameModel `json:"tasks" json:"tasks"`
 }
+
+type BulkUpdateStatusParams struct {
+	IDs        []int  `json:"ids"`
+	StatusName string `json:"status_name"`
+}
+
+type BulkTasksParams struct {
+	Create       []CreateTaskParams      `json:"create"`
+	UpdateStatus *BulkUpdateStatusParams `json:"update_status"`
+	Delete       []int                   `json:"delete"`
+	Restore      []int                   `json:"restore"`
+	AllOrNothing bool                    `json:"all_or_nothing"`
+}
+
+type BulkTaskResult struct {
+	Action     string           `json:"action"`
+	Index      int              `json:"index"`
+	ID         int              `json:"id,omitempty"`
+	Success    bool             `json:"success"`
+	Error      string           `json:"error,omitempty"`
+	Violations []FieldViolation `json:"violations,omitempty"`
+}
+
+type BulkTasksResponse struct {
+	Committed bool             `json:"committed"`
+	Results   []BulkTaskResult `json:"results"`
+}
+
+type ImportTaskError struct {
+	Row        int              `json:"row"`
+	Error      string           `json:"error"`
+	Violations []FieldViolation `json:"violations,omitempty"`
+}
+
+// FieldViolation is a failed validation rule of the task field
+type FieldViolation struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ImportTasksResponse struct {
+	DryRun   bool              `json:"dry_run"`
+	Total    int               `json:"total"`
+	Valid    int               `json:"valid"`
+	Imported int               `json:"imported"`
+	IDs      []int             `json:"ids"`
+	Errors   []ImportTaskError `json:"errors"`
+}
@@ -0,0 +1,29 @@
+package taskservice
+
+import (
+	"errors"
+	"github.com/romandnk/todo/internal/constant"
+)
+
+// fieldViolations returns violations of the validation error
+func fieldViolations(err error) []FieldViolation {
+	var validationErrs constant.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		var domainErr *constant.Error
+		if !errors.As(err, &domainErr) {
+			return nil
+		}
+		validationErrs = constant.ValidationErrors{domainErr}
+	}
+
+	violations := make([]FieldViolation, 0, len(validationErrs))
+	for _, e := range validationErrs {
+		violations = append(violations, FieldViolation{
+			Field:   e.Field,
+			Code:    e.Code,
+			Message: e.Message,
+		})
+	}
+
+	return violations
+}
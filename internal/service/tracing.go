@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	statusservice "github.com/romandnk/todo/internal/service/status"
+	"github.com/romandnk/todo/internal/service/task"
+	"github.com/romandnk/todo/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+)
+
+// tracedTask creates span of every method of the task service
+type tracedTask struct {
+	next   Task
+	tracer trace.Tracer
+}
+
+func newTracedTask(next Task, tracer trace.Tracer) *tracedTask {
+	return &tracedTask{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (t *tracedTask) CreateTask(ctx context.Context, params taskservice.CreateTaskParams) (resp taskservice.CreateTaskResponse, err error) {
+	ctx, span := t.tracer.Start(ctx, "TaskService.CreateTask")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.CreateTask(ctx, params)
+}
+
+func (t *tracedTask) QuickAddTask(ctx context.Context, params taskservice.QuickAddTaskParams) (resp taskservice.QuickAddTaskResponse, err error) {
+	ctx, span := t.tracer.Start(ctx, "TaskService.QuickAddTask")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.QuickAddTask(ctx, params)
+}
+
+func (t *tracedTask) GetAllTasks(ctx context.Context, params taskservice.GetAllTasksParams) (resp taskservice.GetAllTasksResponse, err error) {
+	ctx, span := t.tracer.Start(ctx, "TaskService.GetAllTasks")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.GetAllTasks(ctx, params)
+}
+
+func (t *tracedTask) GetTaskByID(ctx context.Context, stringID string) (resp taskservice.GetTaskWithStatusNameModel, err error) {
+	ctx, span := t.tracer.Start(ctx, "TaskService.GetTaskByID")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.GetTaskByID(ctx, stringID)
+}
+
+func (t *tracedTask) UpdateTaskByID(ctx context.Context, stringID string, version int, params taskservice.UpdateTaskByIDParams) (resp taskservice.UpdateTaskByIDResponse, err error) {
+	ctx, span := t.tracer.Start(ctx, "TaskService.UpdateTaskByID")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.UpdateTaskByID(ctx, stringID, version, params)
+}
+
+func (t *tracedTask) DeleteTaskByID(ctx context.Context, stringID string, version int) (err error) {
+	ctx, span := t.tracer.Start(ctx, "TaskService.DeleteTaskByID")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.DeleteTaskByID(ctx, stringID, version)
+}
+
+func (t *tracedTask) BulkTasks(ctx context.Context, params taskservice.BulkTasksParams) (resp taskservice.BulkTasksResponse, err error) {
+	ctx, span := t.tracer.Start(ctx, "TaskService.BulkTasks")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.BulkTasks(ctx, params)
+}
+
+func (t *tracedTask) ExportTasks(ctx context.Context, w io.Writer, format string, params taskservice.TaskFilterParams) (err error) {
+	ctx, span := t.tracer.Start(ctx, "TaskService.ExportTasks")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.ExportTasks(ctx, w, format, params)
+}
+
+func (t *tracedTask) ImportTasks(ctx context.Context, r io.Reader, format, dryRunStr string) (resp taskservice.ImportTasksResponse, err error) {
+	ctx, span := t.tracer.Start(ctx, "TaskService.ImportTasks")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.ImportTasks(ctx, r, format, dryRunStr)
+}
+
+// tracedStatus creates span of every method of the status service
+type tracedStatus struct {
+	next   Status
+	tracer trace.Tracer
+}
+
+func newTracedStatus(next Status, tracer trace.Tracer) *tracedStatus {
+	return &tracedStatus{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (t *tracedStatus) CreateStatus(ctx context.Context, params statusservice.CreateStatusParams) (resp statusservice.CreateStatusResponse, err error) {
+	ctx, span := t.tracer.Start(ctx, "StatusService.CreateStatus")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.CreateStatus(ctx, params)
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"github.com/romandnk/todo/internal/constant"
+	mock_service "github.com/romandnk/todo/internal/service/mock"
+	"github.com/romandnk/todo/internal/service/task"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestTracedTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var serviceSpan trace.SpanContext
+	next := mock_service.NewMockTask(ctrl)
+	next.EXPECT().GetAllTasks(gomock.Any(), taskservice.GetAllTasksParams{Limit: "10"}).
+		DoAndReturn(func(ctx context.Context, _ taskservice.GetAllTasksParams) (taskservice.GetAllTasksResponse, error) {
+			serviceSpan = trace.SpanContextFromContext(ctx)
+			return taskservice.GetAllTasksResponse{}, nil
+		})
+	next.EXPECT().DeleteTaskByID(gomock.Any(), "1", 0).Return(constant.ErrTaskNotFound)
+
+	task := newTracedTask(next, provider.Tracer("test"))
+
+	_, err := task.GetAllTasks(context.Background(), taskservice.GetAllTasksParams{Limit: "10"})
+	require.NoError(t, err)
+	err = task.DeleteTaskByID(context.Background(), "1", 0)
+	require.ErrorIs(t, err, constant.ErrTaskNotFound)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "TaskService.GetAllTasks", spans[0].Name())
+	require.Equal(t, spans[0].SpanContext(), serviceSpan)
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	require.Equal(t, "TaskService.DeleteTaskByID", spans[1].Name())
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+}
@@ -0,0 +1,224 @@
+package validation
+
+import (
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/pkg/naturaldate"
+	"github.com/romandnk/todo/pkg/timezone"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// rules shared by status, task, comment, checklist and member services
+const (
+	MaxTitleLength         = 64
+	MaxStatusNameLength    = 16
+	MaxLabelLength         = 32
+	MaxCommentAuthorLength = 64
+	MaxCommentBodyLength   = 4096
+	MaxChecklistItemLength = 255
+	MaxUsernameLength      = 64
+	MaxWorkspaceNameLength = 64
+	MaxAPIKeyNameLength    = 64
+	// MaxPriority is the lowest priority, priorities are 1 (A) to 26 (Z) and 0 means no priority
+	MaxPriority = 26
+)
+
+// Validator collects all violations instead of stopping at the first one
+type Validator struct {
+	errs constant.ValidationErrors
+}
+
+// Check adds violation if err is not nil
+func (v *Validator) Check(err *constant.Error) {
+	if err != nil {
+		v.errs = append(v.errs, err)
+	}
+}
+
+// Valid reports whether no violations were found
+func (v *Validator) Valid() bool {
+	return len(v.errs) == 0
+}
+
+// Err returns all found violations or nil
+func (v *Validator) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	return v.errs
+}
+
+func Title(title string) *constant.Error {
+	if title == "" {
+		return constant.ErrEmptyTitle
+	}
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return constant.ErrTooLongTitle
+	}
+	return nil
+}
+
+func Description(description string) *constant.Error {
+	if description == "" {
+		return constant.ErrEmptyDescription
+	}
+	return nil
+}
+
+func CommentAuthor(author string) *constant.Error {
+	if author == "" {
+		return constant.ErrEmptyCommentAuthor
+	}
+	if utf8.RuneCountInString(author) > MaxCommentAuthorLength {
+		return constant.ErrTooLongCommentAuthor
+	}
+	return nil
+}
+
+func CommentBody(body string) *constant.Error {
+	if body == "" {
+		return constant.ErrEmptyCommentBody
+	}
+	if utf8.RuneCountInString(body) > MaxCommentBodyLength {
+		return constant.ErrTooLongCommentBody
+	}
+	return nil
+}
+
+func ChecklistItemText(text string) *constant.Error {
+	if text == "" {
+		return constant.ErrEmptyChecklistItemText
+	}
+	if utf8.RuneCountInString(text) > MaxChecklistItemLength {
+		return constant.ErrTooLongChecklistItemText
+	}
+	return nil
+}
+
+// Username checks username of the task member or request author, field is the name of validated field
+func Username(field, username string) *constant.Error {
+	if username == "" {
+		return constant.ErrEmptyUsername.WithField(field)
+	}
+	if utf8.RuneCountInString(username) > MaxUsernameLength {
+		return constant.ErrTooLongUsername.WithField(field)
+	}
+	if strings.ContainsFunc(username, func(r rune) bool { return unicode.IsSpace(r) || r == '/' }) {
+		return constant.ErrInvalidUsername.WithField(field)
+	}
+	return nil
+}
+
+// WorkspaceName checks workspace name, field is the name of validated field
+func WorkspaceName(field, name string) *constant.Error {
+	if name == "" {
+		return constant.ErrEmptyWorkspaceName.WithField(field)
+	}
+	if utf8.RuneCountInString(name) > MaxWorkspaceNameLength {
+		return constant.ErrTooLongWorkspaceName.WithField(field)
+	}
+	return nil
+}
+
+// APIKeyName checks name of API key, field is the name of validated field
+func APIKeyName(field, name string) *constant.Error {
+	if name == "" {
+		return constant.ErrEmptyAPIKeyName.WithField(field)
+	}
+	if utf8.RuneCountInString(name) > MaxAPIKeyNameLength {
+		return constant.ErrTooLongAPIKeyName.WithField(field)
+	}
+	return nil
+}
+
+// StatusName checks status name, field is the name of validated field
+func StatusName(field, name string) *constant.Error {
+	if name == "" {
+		return constant.ErrEmptyStatusName.WithField(field)
+	}
+	if utf8.RuneCountInString(name) > MaxStatusNameLength {
+		return constant.ErrTooLongStatusName.WithField(field)
+	}
+	return nil
+}
+
+// DateOnlyLayout is the layout of all-day task dates
+const DateOnlyLayout = "2006-01-02"
+
+// Date parses task date which cannot be in the past. Date is RFC3339, YYYY-MM-DD or natural language
+// like "tomorrow 9am" relative to now. Date without time or allDay flag makes all-day date
+// of the calendar day in now location, timed dates are returned in UTC
+func Date(date string, allDay bool, now time.Time) (time.Time, bool, *constant.Error) {
+	if date == "" {
+		return time.Time{}, false, constant.ErrEmptyDate
+	}
+
+	parsed, dateOnly, err := ParseDate(date, now)
+	if err != nil {
+		return time.Time{}, false, constant.ErrInvalidDateFormat
+	}
+	allDay = allDay || dateOnly
+
+	if allDay {
+		day := timezone.CalendarDate(parsed.In(now.Location()))
+		if day.Before(timezone.CalendarDate(now)) {
+			return time.Time{}, false, constant.ErrOutdatedDate
+		}
+		return day, true, nil
+	}
+
+	if parsed.Before(now) {
+		return time.Time{}, false, constant.ErrOutdatedDate
+	}
+	return parsed.UTC(), false, nil
+}
+
+// ParseDate parses RFC3339, YYYY-MM-DD or natural language date relative to now in now location,
+// dateOnly is true if date has no time
+func ParseDate(date string, now time.Time) (time.Time, bool, error) {
+	parsed, err := time.Parse(time.RFC3339, date)
+	if err == nil {
+		return parsed, false, nil
+	}
+	parsed, err = time.ParseInLocation(DateOnlyLayout, date, now.Location())
+	if err == nil {
+		return parsed, true, nil
+	}
+	return naturaldate.Parse(date, now)
+}
+
+func Priority(priority int) *constant.Error {
+	if priority < 0 || priority > MaxPriority {
+		return constant.ErrInvalidPriority
+	}
+	return nil
+}
+
+// Labels trims tags or projects, removes their #, @ and + prefixes and duplicates.
+// Field is the name of validated labels field
+func Labels(field string, labels []string) ([]string, *constant.Error) {
+	result := make([]string, 0, len(labels))
+	seen := make(map[string]struct{}, len(labels))
+
+	for _, label := range labels {
+		label = strings.TrimLeft(strings.TrimSpace(label), "#@+")
+		if label == "" {
+			continue
+		}
+		if strings.IndexFunc(label, unicode.IsSpace) >= 0 {
+			return nil, constant.ErrInvalidLabel.WithField(field)
+		}
+		if utf8.RuneCountInString(label) > MaxLabelLength {
+			return nil, constant.ErrTooLongLabel.WithField(field)
+		}
+		if _, ok := seen[label]; ok {
+			continue
+		}
+		seen[label] = struct{}{}
+		result = append(result, label)
+	}
+
+	return result, nil
+}
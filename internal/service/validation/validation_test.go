@@ -0,0 +1,125 @@
+package validation
+
+import (
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidator(t *testing.T) {
+	var v Validator
+	require.True(t, v.Valid())
+	require.NoError(t, v.Err())
+
+	v.Check(Title(""))
+	v.Check(Description("Test"))
+	v.Check(StatusName("status_name", strings.Repeat("a", MaxStatusNameLength+1)))
+
+	require.False(t, v.Valid())
+	err := v.Err()
+	require.ErrorIs(t, err, constant.ErrEmptyTitle)
+	require.ErrorIs(t, err, constant.ErrTooLongStatusName)
+	require.NotErrorIs(t, err, constant.ErrEmptyDescription)
+	require.Equal(t, "title cannot be empty; max status name length is 16", err.Error())
+}
+
+func TestDate(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	// 2030-01-01 01:00 in Moscow, it is still 31 December in UTC
+	now := time.Date(2029, 12, 31, 22, 0, 0, 0, time.UTC).In(moscow)
+
+	testCases := []struct {
+		name           string
+		input          string
+		allDay         bool
+		expectedDate   time.Time
+		expectedAllDay bool
+		expectedError  *constant.Error
+	}{
+		{
+			name:         "OK",
+			input:        "2030-01-02T03:00:00+03:00",
+			expectedDate: time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "date only is all-day",
+			input:          "2030-01-01",
+			expectedDate:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedAllDay: true,
+		},
+		{
+			name:           "all-day flag uses calendar day in location",
+			input:          "2029-12-31T23:00:00Z",
+			allDay:         true,
+			expectedDate:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedAllDay: true,
+		},
+		{
+			name:           "natural language day is all-day",
+			input:          "tomorrow",
+			expectedDate:   time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC),
+			expectedAllDay: true,
+		},
+		{
+			name:         "natural language time in location",
+			input:        "завтра в 9",
+			expectedDate: time.Date(2030, 1, 2, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "natural language time with all-day flag",
+			input:          "in 2 hours",
+			allDay:         true,
+			expectedDate:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedAllDay: true,
+		},
+		{
+			name:          "natural language in the past",
+			input:         "yesterday",
+			expectedError: constant.ErrOutdatedDate,
+		},
+		{
+			name:          "empty",
+			expectedError: constant.ErrEmptyDate,
+		},
+		{
+			name:          "invalid format",
+			input:         "02.01.2030",
+			expectedError: constant.ErrInvalidDateFormat,
+		},
+		{
+			name:          "in the past",
+			input:         "2029-12-31T21:59:59Z",
+			expectedError: constant.ErrOutdatedDate,
+		},
+		{
+			name:          "all-day in the past in location",
+			input:         "2029-12-31",
+			expectedError: constant.ErrOutdatedDate,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			date, allDay, err := Date(tc.input, tc.allDay, now)
+			require.Equal(t, tc.expectedError, err)
+			require.Equal(t, tc.expectedDate, date)
+			require.Equal(t, tc.expectedAllDay, allDay)
+		})
+	}
+}
+
+func TestLabels(t *testing.T) {
+	labels, err := Labels("tags", []string{" #home", "@home", "", "+work"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"home", "work"}, labels)
+
+	_, err = Labels("projects", []string{"two words"})
+	require.ErrorIs(t, err, constant.ErrInvalidLabel)
+	require.Equal(t, "projects", err.Field)
+
+	_, err = Labels("tags", []string{strings.Repeat("a", MaxLabelLength+1)})
+	require.ErrorIs(t, err, constant.ErrTooLongLabel)
+}
@@ -0,0 +1,400 @@
+package workspaceservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	storage "github.com/romandnk/todo/internal/repo"
+	"github.com/romandnk/todo/internal/service/validation"
+	"github.com/romandnk/todo/pkg/currentuser"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/romandnk/todo/pkg/tenant"
+	"github.com/romandnk/todo/pkg/timezone"
+	"github.com/romandnk/todo/pkg/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const invitationTokenSize = 32
+
+type WorkspaceService struct {
+	workspace storage.Workspace
+	settings  Settings
+	logger    logger.Logger
+}
+
+func NewWorkspaceService(workspace storage.Workspace, settings Settings, logger logger.Logger) *WorkspaceService {
+	return &WorkspaceService{
+		workspace: workspace,
+		settings:  settings,
+		logger:    logger,
+	}
+}
+
+// CreateWorkspace creates workspace owned by the request author with default statuses
+func (s *WorkspaceService) CreateWorkspace(ctx context.Context, params CreateWorkspaceParams) (WorkspaceModel, error) {
+	var response WorkspaceModel
+
+	username := currentuser.FromContext(ctx)
+	if username == "" {
+		return response, constant.ErrUsernameRequired
+	}
+
+	params.Name = strings.TrimSpace(params.Name)
+	if err := validation.WorkspaceName("name", params.Name); err != nil {
+		return response, err
+	}
+
+	workspace, err := s.workspace.CreateWorkspace(ctx, entity.Workspace{Name: params.Name}, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error creating repo workspace", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	return workspaceModel(&entity.UserWorkspace{
+		ID:        workspace.ID,
+		Name:      workspace.Name,
+		Role:      entity.RoleOwner,
+		CreatedAt: workspace.CreatedAt,
+	}, timezone.FromContext(ctx)), nil
+}
+
+// GetWorkspaces returns workspaces of the request author with the author roles
+func (s *WorkspaceService) GetWorkspaces(ctx context.Context) (GetWorkspacesResponse, error) {
+	var response GetWorkspacesResponse
+
+	username := currentuser.FromContext(ctx)
+	if username == "" {
+		return response, constant.ErrUsernameRequired
+	}
+
+	workspaces, err := s.workspace.GetWorkspacesByUsername(ctx, username)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error getting repo workspaces by username", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	loc := timezone.FromContext(ctx)
+	response.Workspaces = make([]WorkspaceModel, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		response.Workspaces = append(response.Workspaces, workspaceModel(workspace, loc))
+	}
+
+	return response, nil
+}
+
+// GetMembers returns workspace members, any member can see them
+func (s *WorkspaceService) GetMembers(ctx context.Context, workspaceIDStr string) (GetWorkspaceMembersResponse, error) {
+	var response GetWorkspaceMembersResponse
+
+	workspaceID, err := s.parseWorkspaceID(ctx, "workspace_id", workspaceIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	_, err = s.role(ctx, workspaceID)
+	if err != nil {
+		return response, err
+	}
+
+	members, err := s.workspace.GetWorkspaceMembers(ctx, workspaceID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error getting repo workspace members", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	loc := timezone.FromContext(ctx)
+	response.Members = make([]WorkspaceMemberModel, 0, len(members))
+	for _, member := range members {
+		response.Members = append(response.Members, memberModel(member, loc))
+	}
+
+	return response, nil
+}
+
+// UpdateMemberRole changes role of the workspace member.
+// Only owners can grant or revoke owner role, the last owner keeps it
+func (s *WorkspaceService) UpdateMemberRole(ctx context.Context, workspaceIDStr, username string, params UpdateMemberRoleParams) (WorkspaceMemberModel, error) {
+	var response WorkspaceMemberModel
+
+	workspaceID, err := s.parseWorkspaceID(ctx, "workspace_id", workspaceIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	var v validation.Validator
+	v.Check(validation.Username("username", username))
+	if !entity.ValidRole(params.Role) {
+		v.Check(constant.ErrInvalidRole)
+	}
+	if !v.Valid() {
+		return response, v.Err()
+	}
+
+	actorRole, err := s.role(ctx, workspaceID)
+	if err != nil {
+		return response, err
+	}
+	if !entity.HasPermission(actorRole, entity.PermMembersAdmin) {
+		return response, constant.ErrPermissionDenied
+	}
+
+	currentRole, err := s.workspace.GetMemberRole(ctx, workspaceID, username)
+	if err != nil {
+		return response, s.memberError(ctx, err, workspaceID, username)
+	}
+
+	ownership := params.Role == entity.RoleOwner || currentRole == entity.RoleOwner
+	if ownership && !entity.HasPermission(actorRole, entity.PermWorkspaceAdmin) {
+		return response, constant.ErrPermissionDenied.WithMessage("only owners can grant or revoke owner role")
+	}
+
+	member, err := s.workspace.UpdateMemberRole(ctx, entity.WorkspaceMember{
+		WorkspaceID: workspaceID,
+		Username:    username,
+		Role:        params.Role,
+	})
+	if err != nil {
+		return response, s.memberError(ctx, err, workspaceID, username)
+	}
+
+	return memberModel(&member, timezone.FromContext(ctx)), nil
+}
+
+// DeleteMember removes user from the workspace, any member can leave the workspace
+func (s *WorkspaceService) DeleteMember(ctx context.Context, workspaceIDStr, username string) error {
+	workspaceID, err := s.parseWorkspaceID(ctx, "workspace_id", workspaceIDStr)
+	if err != nil {
+		return err
+	}
+
+	if err := validation.Username("username", username); err != nil {
+		return err
+	}
+
+	actorRole, err := s.role(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if username != currentuser.FromContext(ctx) {
+		if !entity.HasPermission(actorRole, entity.PermMembersAdmin) {
+			return constant.ErrPermissionDenied
+		}
+
+		role, err := s.workspace.GetMemberRole(ctx, workspaceID, username)
+		if err != nil {
+			return s.memberError(ctx, err, workspaceID, username)
+		}
+		if role == entity.RoleOwner && !entity.HasPermission(actorRole, entity.PermWorkspaceAdmin) {
+			return constant.ErrPermissionDenied.WithMessage("only owners can remove owners")
+		}
+	}
+
+	err = s.workspace.DeleteMember(ctx, workspaceID, username)
+	if err != nil {
+		return s.memberError(ctx, err, workspaceID, username)
+	}
+
+	return nil
+}
+
+// CreateInvitation creates invitation to the workspace with not owner role
+func (s *WorkspaceService) CreateInvitation(ctx context.Context, workspaceIDStr string, params CreateInvitationParams) (CreateInvitationResponse, error) {
+	var response CreateInvitationResponse
+
+	username := currentuser.FromContext(ctx)
+	if username == "" {
+		return response, constant.ErrUsernameRequired
+	}
+
+	workspaceID, err := s.parseWorkspaceID(ctx, "workspace_id", workspaceIDStr)
+	if err != nil {
+		return response, err
+	}
+
+	if !entity.ValidRole(params.Role) || params.Role == entity.RoleOwner {
+		return response, constant.ErrInvalidRole.WithMessage("invitation role must be admin, member or viewer")
+	}
+
+	role, err := s.role(ctx, workspaceID)
+	if err != nil {
+		return response, err
+	}
+	if !entity.HasPermission(role, entity.PermMembersAdmin) {
+		return response, constant.ErrPermissionDenied
+	}
+
+	token, err := utils.GenerateToken(invitationTokenSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error generating invitation token", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	now := time.Now().UTC()
+	invitation, err := s.workspace.CreateInvitation(ctx, entity.Invitation{
+		WorkspaceID: workspaceID,
+		TokenHash:   hashToken(token),
+		Role:        params.Role,
+		CreatedBy:   username,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.settings.InvitationTTL),
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error creating repo invitation", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	// token is shown only once, it cannot be restored from the hash
+	response.Token = token
+	response.URL = fmt.Sprintf("/api/v1/workspaces/invitations/%s/accept", token)
+	response.Role = invitation.Role
+	response.ExpiresAt = invitation.ExpiresAt.In(timezone.FromContext(ctx)).Format(time.RFC3339)
+
+	return response, nil
+}
+
+// AcceptInvitation makes the request author a member of the invitation workspace,
+// the invitation is accepted once. Existing member keeps the role
+func (s *WorkspaceService) AcceptInvitation(ctx context.Context, token string) (AcceptInvitationResponse, error) {
+	var response AcceptInvitationResponse
+
+	username := currentuser.FromContext(ctx)
+	if username == "" {
+		return response, constant.ErrUsernameRequired
+	}
+
+	if token == "" {
+		return response, constant.ErrEmptyInvitationToken
+	}
+
+	member, err := s.workspace.AcceptInvitation(ctx, hashToken(token), username, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, constant.ErrInvitationNotExists) {
+			return response, constant.ErrInvitationNotFound
+		}
+		s.logger.ErrorContext(ctx, "error accepting repo invitation", logger.Error(err))
+		return response, constant.ErrInternalError
+	}
+
+	response.WorkspaceID = member.WorkspaceID
+	response.Role = member.Role
+
+	return response, nil
+}
+
+// ResolveRole returns workspace of the Workspace-ID header value and the role of the request author in it,
+// empty value means the default workspace
+func (s *WorkspaceService) ResolveRole(ctx context.Context, workspaceIDStr string) (int, string, error) {
+	workspaceID := tenant.DefaultWorkspaceID
+	if workspaceIDStr != "" {
+		id, err := s.parseWorkspaceID(ctx, "Workspace-ID", workspaceIDStr)
+		if err != nil {
+			return 0, "", err
+		}
+		workspaceID = id
+	}
+
+	role, err := s.role(ctx, workspaceID)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return workspaceID, role, nil
+}
+
+// BootstrapOwner makes the configured user the owner of the default workspace without owners,
+// so data created before workspaces can be administered after upgrade. It returns false if nothing was changed
+func (s *WorkspaceService) BootstrapOwner(ctx context.Context) (bool, error) {
+	if s.settings.BootstrapOwner == "" {
+		return false, nil
+	}
+	if err := validation.Username("bootstrap_owner", s.settings.BootstrapOwner); err != nil {
+		return false, err
+	}
+
+	added, err := s.workspace.AddOwnerIfNone(ctx, tenant.DefaultWorkspaceID, s.settings.BootstrapOwner, time.Now().UTC())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error adding repo default workspace owner", logger.Error(err))
+		return false, constant.ErrInternalError
+	}
+
+	return added, nil
+}
+
+// role returns the role of the request author in the workspace. Not members get the anonymous role
+// in the default workspace and not found error in other workspaces, so their existence is not revealed
+func (s *WorkspaceService) role(ctx context.Context, workspaceID int) (string, error) {
+	username := currentuser.FromContext(ctx)
+	if username != "" {
+		role, err := s.workspace.GetMemberRole(ctx, workspaceID, username)
+		if err == nil {
+			return role, nil
+		}
+		if !errors.Is(err, constant.ErrWorkspaceMemberNotExists) {
+			s.logger.ErrorContext(ctx, "error getting repo workspace member role", logger.Error(err))
+			return "", constant.ErrInternalError
+		}
+	}
+
+	if workspaceID == tenant.DefaultWorkspaceID && s.settings.AnonymousRole != "" {
+		return s.settings.AnonymousRole, nil
+	}
+
+	return "", constant.ErrWorkspaceNotFound.WithMessage(fmt.Sprintf("workspace with id '%d' is not found", workspaceID))
+}
+
+func (s *WorkspaceService) memberError(ctx context.Context, err error, workspaceID int, username string) error {
+	switch {
+	case errors.Is(err, constant.ErrWorkspaceMemberNotExists):
+		return constant.ErrWorkspaceMemberNotFound.WithMessage(fmt.Sprintf("member '%s' of workspace with id '%d' is not found", username, workspaceID))
+	case errors.Is(err, constant.ErrWorkspaceWithoutOwner):
+		return constant.ErrLastWorkspaceOwner
+	default:
+		s.logger.ErrorContext(ctx, "error changing repo workspace member", logger.Error(err))
+		return constant.ErrInternalError
+	}
+}
+
+// parseWorkspaceID parses workspace id of path parameter or header, field is its name
+func (s *WorkspaceService) parseWorkspaceID(ctx context.Context, field, idStr string) (int, error) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error converting string workspace id to int workspace id", logger.Error(err))
+		return 0, constant.ErrInvalidWorkspaceID.WithField(field)
+	}
+	if id <= 0 {
+		return 0, constant.ErrNonPositiveWorkspaceID.WithField(field)
+	}
+	return id, nil
+}
+
+// hashToken returns hex encoded SHA-256 of the invitation token, tokens are random so salt is not needed
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// workspaceModel renders creation time in loc
+func workspaceModel(workspace *entity.UserWorkspace, loc *time.Location) WorkspaceModel {
+	return WorkspaceModel{
+		ID:        workspace.ID,
+		Name:      workspace.Name,
+		Role:      workspace.Role,
+		CreatedAt: workspace.CreatedAt.In(loc).Format(time.RFC3339),
+	}
+}
+
+// memberModel renders joining time in loc
+func memberModel(member *entity.WorkspaceMember, loc *time.Location) WorkspaceMemberModel {
+	return WorkspaceMemberModel{
+		Username:  member.Username,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt.In(loc).Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,366 @@
+package workspaceservice
+
+import (
+	"context"
+	"errors"
+	"github.com/romandnk/todo/internal/constant"
+	"github.com/romandnk/todo/internal/entity"
+	mock_storage "github.com/romandnk/todo/internal/repo/mock"
+	"github.com/romandnk/todo/pkg/currentuser"
+	mock_logger "github.com/romandnk/todo/pkg/logger/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestWorkspaceService_ResolveRole(t *testing.T) {
+	testCases := []struct {
+		name          string
+		header        string
+		username      string
+		memberRole    string
+		expectedID    int
+		expectedRole  string
+		expectedError error
+	}{
+		{
+			name:         "anonymous in default workspace",
+			header:       "",
+			expectedID:   1,
+			expectedRole: entity.RoleViewer,
+		},
+		{
+			name:         "member of default workspace",
+			header:       "",
+			username:     "ivan",
+			memberRole:   entity.RoleOwner,
+			expectedID:   1,
+			expectedRole: entity.RoleOwner,
+		},
+		{
+			name:         "not member of default workspace",
+			header:       "1",
+			username:     "ivan",
+			expectedID:   1,
+			expectedRole: entity.RoleViewer,
+		},
+		{
+			name:         "member of workspace",
+			header:       "2",
+			username:     "ivan",
+			memberRole:   entity.RoleMember,
+			expectedID:   2,
+			expectedRole: entity.RoleMember,
+		},
+		{
+			name:          "not member of workspace",
+			header:        "2",
+			username:      "ivan",
+			expectedError: constant.ErrWorkspaceNotFound,
+		},
+		{
+			name:          "anonymous in workspace",
+			header:        "2",
+			expectedError: constant.ErrWorkspaceNotFound,
+		},
+		{
+			name:          "non positive workspace id",
+			header:        "0",
+			expectedError: constant.ErrNonPositiveWorkspaceID,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			workspace := mock_storage.NewMockWorkspace(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			ctx := context.Background()
+			if tc.username != "" {
+				ctx = currentuser.WithUsername(ctx, tc.username)
+
+				id := tc.expectedID
+				if id == 0 {
+					id = 2
+				}
+				if tc.memberRole != "" {
+					workspace.EXPECT().GetMemberRole(gomock.Any(), id, tc.username).Return(tc.memberRole, nil)
+				} else {
+					workspace.EXPECT().GetMemberRole(gomock.Any(), id, tc.username).Return("", constant.ErrWorkspaceMemberNotExists)
+				}
+			}
+
+			service := NewWorkspaceService(workspace, Settings{AnonymousRole: entity.RoleViewer}, logger)
+
+			id, role, err := service.ResolveRole(ctx, tc.header)
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expectedID, id)
+			require.Equal(t, tc.expectedRole, role)
+		})
+	}
+}
+
+func TestWorkspaceService_UpdateMemberRole(t *testing.T) {
+	created := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		actorRole     string
+		currentRole   string
+		newRole       string
+		repoErr       error
+		expectedError error
+	}{
+		{
+			name:        "admin changes member",
+			actorRole:   entity.RoleAdmin,
+			currentRole: entity.RoleMember,
+			newRole:     entity.RoleViewer,
+		},
+		{
+			name:          "member cannot change roles",
+			actorRole:     entity.RoleMember,
+			newRole:       entity.RoleViewer,
+			expectedError: constant.ErrPermissionDenied,
+		},
+		{
+			name:          "admin cannot grant owner",
+			actorRole:     entity.RoleAdmin,
+			currentRole:   entity.RoleMember,
+			newRole:       entity.RoleOwner,
+			expectedError: constant.ErrPermissionDenied,
+		},
+		{
+			name:          "admin cannot demote owner",
+			actorRole:     entity.RoleAdmin,
+			currentRole:   entity.RoleOwner,
+			newRole:       entity.RoleMember,
+			expectedError: constant.ErrPermissionDenied,
+		},
+		{
+			name:          "last owner",
+			actorRole:     entity.RoleOwner,
+			currentRole:   entity.RoleOwner,
+			newRole:       entity.RoleAdmin,
+			repoErr:       constant.ErrWorkspaceWithoutOwner,
+			expectedError: constant.ErrLastWorkspaceOwner,
+		},
+		{
+			name:          "invalid role",
+			newRole:       "guest",
+			expectedError: constant.ErrInvalidRole,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			workspace := mock_storage.NewMockWorkspace(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			ctx := currentuser.WithUsername(context.Background(), "ivan")
+
+			if tc.actorRole != "" {
+				workspace.EXPECT().GetMemberRole(gomock.Any(), 2, "ivan").Return(tc.actorRole, nil)
+			}
+			if tc.currentRole != "" {
+				workspace.EXPECT().GetMemberRole(gomock.Any(), 2, "petr").Return(tc.currentRole, nil)
+			}
+			if tc.expectedError == nil || tc.repoErr != nil {
+				member := entity.WorkspaceMember{WorkspaceID: 2, Username: "petr", Role: tc.newRole}
+				workspace.EXPECT().UpdateMemberRole(gomock.Any(), member).
+					DoAndReturn(func(_ context.Context, m entity.WorkspaceMember) (entity.WorkspaceMember, error) {
+						m.CreatedAt = created
+						return m, tc.repoErr
+					})
+			}
+
+			service := NewWorkspaceService(workspace, Settings{}, logger)
+
+			member, err := service.UpdateMemberRole(ctx, "2", "petr", UpdateMemberRoleParams{Role: tc.newRole})
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, WorkspaceMemberModel{Username: "petr", Role: tc.newRole, CreatedAt: "2030-01-02T10:00:00Z"}, member)
+			}
+		})
+	}
+}
+
+func TestWorkspaceService_DeleteMember(t *testing.T) {
+	testCases := []struct {
+		name          string
+		username      string
+		actorRole     string
+		targetRole    string
+		expectedError error
+	}{
+		{
+			name:      "member leaves",
+			username:  "ivan",
+			actorRole: entity.RoleViewer,
+		},
+		{
+			name:          "viewer cannot remove others",
+			username:      "petr",
+			actorRole:     entity.RoleViewer,
+			expectedError: constant.ErrPermissionDenied,
+		},
+		{
+			name:       "admin removes member",
+			username:   "petr",
+			actorRole:  entity.RoleAdmin,
+			targetRole: entity.RoleMember,
+		},
+		{
+			name:          "admin cannot remove owner",
+			username:      "petr",
+			actorRole:     entity.RoleAdmin,
+			targetRole:    entity.RoleOwner,
+			expectedError: constant.ErrPermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			workspace := mock_storage.NewMockWorkspace(ctrl)
+			logger := mock_logger.NewMockLogger(ctrl)
+
+			ctx := currentuser.WithUsername(context.Background(), "ivan")
+
+			workspace.EXPECT().GetMemberRole(gomock.Any(), 2, "ivan").Return(tc.actorRole, nil)
+			if tc.targetRole != "" {
+				workspace.EXPECT().GetMemberRole(gomock.Any(), 2, tc.username).Return(tc.targetRole, nil)
+			}
+			if tc.expectedError == nil {
+				workspace.EXPECT().DeleteMember(gomock.Any(), 2, tc.username).Return(nil)
+			}
+
+			service := NewWorkspaceService(workspace, Settings{}, logger)
+
+			err := service.DeleteMember(ctx, "2", tc.username)
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestWorkspaceService_CreateInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspace := mock_storage.NewMockWorkspace(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+
+	ctx := currentuser.WithUsername(context.Background(), "ivan")
+
+	workspace.EXPECT().GetMemberRole(gomock.Any(), 2, "ivan").Return(entity.RoleAdmin, nil)
+	var tokenHash string
+	workspace.EXPECT().CreateInvitation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, invitation entity.Invitation) (entity.Invitation, error) {
+			require.Equal(t, 2, invitation.WorkspaceID)
+			require.Equal(t, entity.RoleMember, invitation.Role)
+			require.Equal(t, "ivan", invitation.CreatedBy)
+			tokenHash = invitation.TokenHash
+			require.Equal(t, 24*time.Hour, invitation.ExpiresAt.Sub(invitation.CreatedAt))
+			invitation.ID = 1
+			return invitation, nil
+		})
+
+	service := NewWorkspaceService(workspace, Settings{InvitationTTL: 24 * time.Hour}, logger)
+
+	resp, err := service.CreateInvitation(ctx, "2", CreateInvitationParams{Role: entity.RoleMember})
+	require.NoError(t, err)
+	require.Equal(t, "/api/v1/workspaces/invitations/"+resp.Token+"/accept", resp.URL)
+	require.Equal(t, hashToken(resp.Token), tokenHash)
+	require.NotEqual(t, resp.Token, tokenHash)
+
+	_, err = service.CreateInvitation(ctx, "2", CreateInvitationParams{Role: entity.RoleOwner})
+	require.ErrorIs(t, err, constant.ErrInvalidRole)
+}
+
+func TestWorkspaceService_AcceptInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspace := mock_storage.NewMockWorkspace(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+
+	service := NewWorkspaceService(workspace, Settings{}, logger)
+
+	_, err := service.AcceptInvitation(context.Background(), "token")
+	require.ErrorIs(t, err, constant.ErrUsernameRequired)
+
+	ctx := currentuser.WithUsername(context.Background(), "petr")
+
+	workspace.EXPECT().AcceptInvitation(gomock.Any(), hashToken("token"), "petr", gomock.Any()).
+		Return(entity.WorkspaceMember{WorkspaceID: 2, Username: "petr", Role: entity.RoleMember}, nil)
+	workspace.EXPECT().AcceptInvitation(gomock.Any(), hashToken("expired"), "petr", gomock.Any()).
+		Return(entity.WorkspaceMember{}, constant.ErrInvitationNotExists)
+	workspace.EXPECT().AcceptInvitation(gomock.Any(), hashToken("broken"), "petr", gomock.Any()).
+		Return(entity.WorkspaceMember{}, errors.New("connection refused"))
+	logger.EXPECT().ErrorContext(gomock.Any(), "error accepting repo invitation", gomock.Any())
+
+	resp, err := service.AcceptInvitation(ctx, "token")
+	require.NoError(t, err)
+	require.Equal(t, AcceptInvitationResponse{WorkspaceID: 2, Role: entity.RoleMember}, resp)
+
+	_, err = service.AcceptInvitation(ctx, "expired")
+	require.ErrorIs(t, err, constant.ErrInvitationNotFound)
+
+	_, err = service.AcceptInvitation(ctx, "broken")
+	require.ErrorIs(t, err, constant.ErrInternalError)
+}
+
+func TestWorkspaceService_BootstrapOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspace := mock_storage.NewMockWorkspace(ctrl)
+	logger := mock_logger.NewMockLogger(ctrl)
+	ctx := context.Background()
+
+	added, err := NewWorkspaceService(workspace, Settings{}, logger).BootstrapOwner(ctx)
+	require.NoError(t, err)
+	require.False(t, added)
+
+	_, err = NewWorkspaceService(workspace, Settings{BootstrapOwner: "ivan petrov"}, logger).BootstrapOwner(ctx)
+	require.ErrorIs(t, err, constant.ErrInvalidUsername)
+
+	// default workspace of upgraded database has no members, so it is administered by the bootstrap owner only
+	service := NewWorkspaceService(workspace, Settings{BootstrapOwner: "ivan"}, logger)
+	gomock.InOrder(
+		workspace.EXPECT().AddOwnerIfNone(gomock.Any(), 1, "ivan", gomock.Any()).Return(true, nil),
+		workspace.EXPECT().GetMemberRole(gomock.Any(), 1, "ivan").Return(entity.RoleOwner, nil),
+		workspace.EXPECT().AddOwnerIfNone(gomock.Any(), 1, "ivan", gomock.Any()).Return(false, nil),
+		workspace.EXPECT().AddOwnerIfNone(gomock.Any(), 1, "ivan", gomock.Any()).Return(false, errors.New("connection refused")),
+	)
+	logger.EXPECT().ErrorContext(gomock.Any(), "error adding repo default workspace owner", gomock.Any())
+
+	added, err = service.BootstrapOwner(ctx)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	id, role, err := service.ResolveRole(currentuser.WithUsername(ctx, "ivan"), "")
+	require.NoError(t, err)
+	require.Equal(t, 1, id)
+	require.True(t, entity.HasPermission(role, entity.PermMembersAdmin))
+
+	// restart keeps the owner
+	added, err = service.BootstrapOwner(ctx)
+	require.NoError(t, err)
+	require.False(t, added)
+
+	_, err = service.BootstrapOwner(ctx)
+	require.ErrorIs(t, err, constant.ErrInternalError)
+}
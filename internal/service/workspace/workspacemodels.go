@@ -0,0 +1,58 @@
+package workspaceservice
+
+import "time"
+
+// Settings configure access to workspaces
+type Settings struct {
+	// AnonymousRole is the role in the default workspace of users who are not its members
+	AnonymousRole string
+	// InvitationTTL is how long invitation can be accepted
+	InvitationTTL time.Duration
+	// BootstrapOwner becomes the owner of the default workspace if it has no owner
+	BootstrapOwner string
+}
+
+type CreateWorkspaceParams struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type WorkspaceModel struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at"`
+}
+
+type GetWorkspacesResponse struct {
+	Workspaces []WorkspaceModel `json:"workspaces"`
+}
+
+type UpdateMemberRoleParams struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type WorkspaceMemberModel struct {
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at"`
+}
+
+type GetWorkspaceMembersResponse struct {
+	Members []WorkspaceMemberModel `json:"members"`
+}
+
+type CreateInvitationParams struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type CreateInvitationResponse struct {
+	Token     string `json:"token"`
+	URL       string `json:"url"`
+	Role      string `json:"role"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type AcceptInvitationResponse struct {
+	WorkspaceID int    `json:"workspace_id"`
+	Role        string `json:"role"`
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_feeds;
\ No newline at end of file
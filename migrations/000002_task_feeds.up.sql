@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS task_feeds (
+    id BIGSERIAL PRIMARY KEY,
+    token VARCHAR(64) UNIQUE NOT NULL,
+    status_id SMALLINT,
+    component VARCHAR(16) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (status_id) REFERENCES statuses (id)
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_tasks_projects;
+DROP INDEX IF EXISTS idx_tasks_tags;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS projects,
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,7 @@
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS priority SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS projects TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_tasks_tags ON tasks USING gin (tags);
+CREATE INDEX idx_tasks_projects ON tasks USING gin (projects);
@@ -0,0 +1 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS all_day;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_attachments;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS task_checklist_items (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL,
+    text VARCHAR(255) NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT false,
+    position INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_checklist_items_task_id ON task_checklist_items (task_id, position);
\ No newline at end of file
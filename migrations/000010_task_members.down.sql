@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_members;
\ No newline at end of file
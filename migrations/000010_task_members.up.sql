@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS task_members (
+    task_id BIGINT NOT NULL,
+    username VARCHAR(64) NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (task_id, role, username),
+    FOREIGN KEY (task_id) REFERENCES tasks (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_members_username ON task_members (username, role);
\ No newline at end of file
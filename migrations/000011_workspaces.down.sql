@@ -0,0 +1,13 @@
+ALTER TABLE task_feeds DROP COLUMN IF EXISTS workspace_id;
+
+DROP INDEX IF EXISTS idx_tasks_workspace_id;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS workspace_id;
+
+ALTER TABLE statuses DROP CONSTRAINT IF EXISTS statuses_workspace_id_name_key;
+ALTER TABLE statuses DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE statuses ADD CONSTRAINT statuses_name_key UNIQUE (name);
+
+DROP TABLE IF EXISTS workspace_invitations;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
\ No newline at end of file
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS workspaces (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+INSERT INTO workspaces (id, name, created_at)
+VALUES (1, 'default', now());
+
+SELECT setval('workspaces_id_seq', 1);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id BIGINT NOT NULL,
+    username VARCHAR(64) NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (workspace_id, username),
+    FOREIGN KEY (workspace_id) REFERENCES workspaces (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workspace_members_username ON workspace_members (username);
+
+CREATE TABLE IF NOT EXISTS workspace_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL,
+    token VARCHAR(64) UNIQUE NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    created_by VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (workspace_id) REFERENCES workspaces (id)
+);
+
+-- data created before workspaces belongs to the default workspace
+ALTER TABLE statuses ADD COLUMN IF NOT EXISTS workspace_id BIGINT NOT NULL DEFAULT 1 REFERENCES workspaces (id);
+ALTER TABLE statuses ALTER COLUMN workspace_id DROP DEFAULT;
+ALTER TABLE statuses DROP CONSTRAINT IF EXISTS statuses_name_key;
+ALTER TABLE statuses ADD CONSTRAINT statuses_workspace_id_name_key UNIQUE (workspace_id, name);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS workspace_id BIGINT NOT NULL DEFAULT 1 REFERENCES workspaces (id);
+ALTER TABLE tasks ALTER COLUMN workspace_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_workspace_id ON tasks (workspace_id, id);
+
+ALTER TABLE task_feeds ADD COLUMN IF NOT EXISTS workspace_id BIGINT NOT NULL DEFAULT 1 REFERENCES workspaces (id);
+ALTER TABLE task_feeds ALTER COLUMN workspace_id DROP DEFAULT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- tokens cannot be restored from their hashes
+DELETE FROM workspace_invitations;
+
+ALTER TABLE workspace_invitations DROP COLUMN IF EXISTS accepted_at;
+ALTER TABLE workspace_invitations DROP COLUMN IF EXISTS accepted_by;
+ALTER TABLE workspace_invitations RENAME COLUMN token_hash TO token;
\ No newline at end of file
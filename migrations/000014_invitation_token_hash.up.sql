@@ -0,0 +1,6 @@
+-- invitations keep hash of the token like api keys and are accepted once
+ALTER TABLE workspace_invitations RENAME COLUMN token TO token_hash;
+UPDATE workspace_invitations SET token_hash = encode(sha256(convert_to(token_hash, 'UTF8')), 'hex');
+
+ALTER TABLE workspace_invitations ADD COLUMN IF NOT EXISTS accepted_by VARCHAR(64);
+ALTER TABLE workspace_invitations ADD COLUMN IF NOT EXISTS accepted_at TIMESTAMPTZ;
\ No newline at end of file
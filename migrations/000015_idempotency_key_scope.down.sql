@@ -0,0 +1,6 @@
+DELETE FROM idempotency_keys;
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS principal;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (key);
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- keys are unique per workspace and principal, stored responses of global keys cannot be attributed to them
+DELETE FROM idempotency_keys;
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS workspace_id BIGINT NOT NULL;
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS principal VARCHAR(128) NOT NULL;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (workspace_id, principal, key);
\ No newline at end of file
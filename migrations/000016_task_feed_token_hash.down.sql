@@ -0,0 +1,4 @@
+-- tokens cannot be restored from their hashes
+DELETE FROM task_feeds;
+
+ALTER TABLE task_feeds RENAME COLUMN token_hash TO token;
\ No newline at end of file
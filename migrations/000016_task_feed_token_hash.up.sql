@@ -0,0 +1,3 @@
+-- feeds keep hash of the token like invitations and api keys, the token is shown once on creation
+ALTER TABLE task_feeds RENAME COLUMN token TO token_hash;
+UPDATE task_feeds SET token_hash = encode(sha256(convert_to(token_hash, 'UTF8')), 'hex');
\ No newline at end of file
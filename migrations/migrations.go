@@ -0,0 +1,46 @@
+// Package migrations embeds migrations of the db schema applied by golang-migrate,
+// so the app knows the schema version it is built for
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.up.sql
+var files embed.FS
+
+// LatestVersion returns the version of the newest migration
+func LatestVersion() (int, error) {
+	return latestVersion(files)
+}
+
+// latestVersion parses versions of files like 000013_rate_limit_buckets.up.sql
+func latestVersion(fsys fs.FS) (int, error) {
+	names, err := fs.Glob(fsys, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int
+	for _, name := range names {
+		prefix, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return 0, fmt.Errorf("migration '%s' has no version", name)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return 0, fmt.Errorf("migration '%s' has invalid version: %w", name, err)
+		}
+		latest = max(latest, version)
+	}
+
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations")
+	}
+
+	return latest, nil
+}
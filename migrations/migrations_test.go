@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLatestVersion(t *testing.T) {
+	version, err := LatestVersion()
+	require.NoError(t, err)
+	require.Positive(t, version)
+
+	version, err = latestVersion(fstest.MapFS{
+		"000002_task_feeds.up.sql": {},
+		"000010_workspaces.up.sql": {},
+		"000001_todo.up.sql":       {},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 10, version)
+
+	_, err = latestVersion(fstest.MapFS{"todo.up.sql": {}})
+	require.EqualError(t, err, "migration 'todo.up.sql' has no version")
+
+	_, err = latestVersion(fstest.MapFS{})
+	require.EqualError(t, err, "no migrations")
+}
@@ -0,0 +1,28 @@
+// Package blobstore describes storage of binary objects like task attachments,
+// implementations are in local and s3 packages
+package blobstore
+
+//go:generate mockgen -source=blobstore.go -destination=mock/mock.go blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+var ErrNotFound = errors.New("blobstore: object is not found")
+
+// BlobStore keeps objects by slash separated keys like "tasks/1/token"
+type BlobStore interface {
+	// Put saves size bytes of r under key, existing object is replaced
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get returns object content, ErrNotFound means there is no object with the key
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes object, deleting missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
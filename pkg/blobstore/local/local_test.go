@@ -0,0 +1,60 @@
+package localblobstore
+
+import (
+	"context"
+	"github.com/romandnk/todo/pkg/blobstore"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(filepath.Join(dir, "attachments"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	err = store.Put(ctx, "tasks/1/token", strings.NewReader("screenshot"), 10, "image/png")
+	require.NoError(t, err)
+
+	r, err := store.Get(ctx, "tasks/1/token")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "screenshot", string(content))
+
+	require.NoError(t, store.Delete(ctx, "tasks/1/token"))
+	require.NoError(t, store.Delete(ctx, "tasks/1/token"))
+
+	_, err = store.Get(ctx, "tasks/1/token")
+	require.ErrorIs(t, err, blobstore.ErrNotFound)
+}
+
+func TestStore_PutSizeMismatch(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(dir)
+	require.NoError(t, err)
+
+	err = store.Put(context.Background(), "tasks/1/token", strings.NewReader("screenshot"), 5, "image/png")
+	require.Error(t, err)
+
+	entries, err := os.ReadDir(filepath.Join(dir, "tasks", "1"))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestStore_InvalidKey(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../secret", "/etc/passwd", "tasks/.upload-1"} {
+		_, err = store.Get(context.Background(), key)
+		require.Error(t, err, key)
+		require.NotErrorIs(t, err, blobstore.ErrNotFound, key)
+	}
+}
@@ -0,0 +1,32 @@
+// Package cache keeps encoded values by key with TTL, implementations are in memory and redis packages.
+//
+// Notifier spreads invalidated keys between app instances, so memory caches of other instances
+// drop values changed by this one. Shared redis cache does not need notifications
+package cache
+
+//go:generate mockgen -source=cache.go -destination=mock/mock.go cache
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	BackendNone   = "none"
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+type Cache interface {
+	// Get returns value of the key, ok is false if the key is missing or expired
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+type Notifier interface {
+	// Notify sends invalidated keys to all app instances including this one
+	Notify(ctx context.Context, keys ...string) error
+	// Listen calls handle with keys invalidated by app instances until ctx is done or connection is lost
+	Listen(ctx context.Context, handle func(keys []string)) error
+}
@@ -0,0 +1,45 @@
+package memorycache
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	c := NewCache(2)
+	c.now = func() time.Time { return now }
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), time.Second))
+
+	value, ok, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), value)
+
+	// b is least recently used after reading a
+	require.NoError(t, c.Set(ctx, "c", []byte("3"), time.Minute))
+	_, ok, _ = c.Get(ctx, "b")
+	require.False(t, ok)
+	require.Equal(t, 2, c.Len())
+
+	// updating value moves it to front and resets ttl
+	require.NoError(t, c.Set(ctx, "a", []byte("4"), time.Minute))
+	value, ok, _ = c.Get(ctx, "a")
+	require.True(t, ok)
+	require.Equal(t, []byte("4"), value)
+
+	now = now.Add(time.Minute)
+	_, ok, _ = c.Get(ctx, "a")
+	require.False(t, ok)
+	require.Equal(t, 1, c.Len())
+
+	require.NoError(t, c.Set(ctx, "d", []byte("5"), time.Minute))
+	require.NoError(t, c.Delete(ctx, "c", "d", "missing"))
+	require.Equal(t, 0, c.Len())
+}
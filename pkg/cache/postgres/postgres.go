@@ -0,0 +1,98 @@
+package postgrescache
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5"
+	postgres "github.com/romandnk/todo/pkg/storage"
+)
+
+// Channel is the notification channel of invalidated keys
+const Channel = "todo_cache_invalidation"
+
+// maxPayload keeps payload below 8000 bytes limit of postgres notifications
+const maxPayload = 7900
+
+// Notifier sends invalidated keys with NOTIFY, every app instance receives them with LISTEN
+type Notifier struct {
+	db postgres.PgxPool
+}
+
+func NewNotifier(db postgres.PgxPool) *Notifier {
+	return &Notifier{db: db}
+}
+
+// Notify sends keys as JSON arrays, keys which do not fit one notification are split into several
+func (n *Notifier) Notify(ctx context.Context, keys ...string) error {
+	for _, payload := range payloads(keys) {
+		_, err := n.db.Exec(ctx, "SELECT pg_notify($1, $2)", Channel, payload)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Listen takes connection out of the pool for the time of listening, the connection is closed on return
+func (n *Notifier) Listen(ctx context.Context, handle func(keys []string)) error {
+	conn, err := n.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	pgConn := conn.Hijack()
+	defer pgConn.Close(context.Background())
+
+	_, err = pgConn.Exec(ctx, "LISTEN "+pgx.Identifier{Channel}.Sanitize())
+	if err != nil {
+		return err
+	}
+
+	for {
+		notification, err := pgConn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		var keys []string
+		err = json.Unmarshal([]byte(notification.Payload), &keys)
+		if err != nil {
+			continue
+		}
+		handle(keys)
+	}
+}
+
+// payloads encodes keys to JSON arrays of at most maxPayload bytes, too long key is sent alone
+func payloads(keys []string) []string {
+	var (
+		result []string
+		batch  []string
+		size   int
+	)
+
+	for _, key := range keys {
+		encoded, _ := json.Marshal(key)
+		// comma or brackets of the array
+		keySize := len(encoded) + 1
+
+		if len(batch) > 0 && size+keySize+1 > maxPayload {
+			result = append(result, encode(batch))
+			batch, size = nil, 0
+		}
+		batch = append(batch, key)
+		size += keySize
+	}
+	if len(batch) > 0 {
+		result = append(result, encode(batch))
+	}
+
+	return result
+}
+
+func encode(keys []string) string {
+	data, _ := json.Marshal(keys)
+	return string(data)
+}
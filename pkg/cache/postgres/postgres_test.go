@@ -0,0 +1,44 @@
+package postgrescache
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestPayloads(t *testing.T) {
+	require.Empty(t, payloads(nil))
+	require.Equal(t, []string{`["todo:ws:1:task:1","todo:ws:1:task:2"]`}, payloads([]string{"todo:ws:1:task:1", "todo:ws:1:task:2"}))
+
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = "todo:ws:1:task:" + strings.Repeat("1", 10)
+	}
+
+	var decoded []string
+	for _, payload := range payloads(keys) {
+		require.LessOrEqual(t, len(payload), maxPayload)
+
+		var batch []string
+		require.NoError(t, json.Unmarshal([]byte(payload), &batch))
+		decoded = append(decoded, batch...)
+	}
+	require.Equal(t, keys, decoded)
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("SELECT pg_notify").
+		WithArgs(Channel, `["todo:ws:1:statuses"]`).
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+
+	err = NewNotifier(mock).Notify(context.Background(), "todo:ws:1:statuses")
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,279 @@
+package rediscache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/romandnk/todo/config"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Error is the error reply of the server, connection is still usable after it
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+var errUnexpectedReply = errors.New("redis: unexpected reply")
+
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// Cache keeps values in redis compatible server, so they are shared by app instances.
+// It speaks RESP protocol over the pool of at most cfg.PoolSize connections
+type Cache struct {
+	cfg   config.Redis
+	idle  chan *conn
+	slots chan struct{}
+}
+
+func NewCache(cfg config.Redis) *Cache {
+	return &Cache{
+		cfg:   cfg,
+		idle:  make(chan *conn, cfg.PoolSize),
+		slots: make(chan struct{}, cfg.PoolSize),
+	}
+}
+
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := c.do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, errUnexpectedReply
+	}
+	return value, true, nil
+}
+
+func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	// PX expects positive number of milliseconds
+	ms := ttl.Milliseconds()
+	if ms < 1 {
+		ms = 1
+	}
+
+	_, err := c.do(ctx, "SET", key, value, "PX", strconv.FormatInt(ms, 10))
+	return err
+}
+
+func (c *Cache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(keys)+1)
+	args = append(args, "DEL")
+	for _, key := range keys {
+		args = append(args, key)
+	}
+
+	_, err := c.do(ctx, args...)
+	return err
+}
+
+// Close closes idle connections
+func (c *Cache) Close() {
+	for {
+		select {
+		case cn := <-c.idle:
+			_ = cn.Close()
+			<-c.slots
+		default:
+			return
+		}
+	}
+}
+
+// do sends the command and reads its reply, connection with network error is closed
+func (c *Cache) do(ctx context.Context, args ...any) (any, error) {
+	cn, err := c.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := c.exec(ctx, cn, args...)
+	var replyErr Error
+	if err != nil && !errors.As(err, &replyErr) {
+		_ = cn.Close()
+		<-c.slots
+		return nil, err
+	}
+
+	c.idle <- cn
+	return reply, err
+}
+
+func (c *Cache) exec(ctx context.Context, cn *conn, args ...any) (any, error) {
+	deadline := time.Now().Add(c.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	err := cn.SetDeadline(deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeCommand(cn.w, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return readReply(cn.r)
+}
+
+// conn takes idle connection or dials new one if the pool is not full
+func (c *Cache) conn(ctx context.Context) (*conn, error) {
+	select {
+	case cn := <-c.idle:
+		return cn, nil
+	default:
+	}
+
+	select {
+	case cn := <-c.idle:
+		return cn, nil
+	case c.slots <- struct{}{}:
+		cn, err := c.dial(ctx)
+		if err != nil {
+			<-c.slots
+			return nil, err
+		}
+		return cn, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Cache) dial(ctx context.Context) (*conn, error) {
+	d := net.Dialer{Timeout: c.cfg.Timeout}
+	nc, err := d.DialContext(ctx, "tcp", c.cfg.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	cn := &conn{
+		Conn: nc,
+		r:    bufio.NewReader(nc),
+		w:    bufio.NewWriter(nc),
+	}
+
+	if c.cfg.Password != "" {
+		_, err = c.exec(ctx, cn, "AUTH", c.cfg.Password)
+		if err != nil {
+			_ = cn.Close()
+			return nil, err
+		}
+	}
+	if c.cfg.DB != 0 {
+		_, err = c.exec(ctx, cn, "SELECT", strconv.Itoa(c.cfg.DB))
+		if err != nil {
+			_ = cn.Close()
+			return nil, err
+		}
+	}
+
+	return cn, nil
+}
+
+// writeCommand writes array of bulk strings, args are strings or byte slices
+func writeCommand(w *bufio.Writer, args ...any) error {
+	_, _ = fmt.Fprintf(w, "*%d\r\n", len(args))
+
+	for _, arg := range args {
+		var b []byte
+		switch a := arg.(type) {
+		case string:
+			b = []byte(a)
+		case []byte:
+			b = a
+		default:
+			return fmt.Errorf("redis: unsupported argument type %T", arg)
+		}
+
+		_, _ = fmt.Fprintf(w, "$%d\r\n", len(b))
+		_, _ = w.Write(b)
+		_, _ = w.WriteString("\r\n")
+	}
+
+	return w.Flush()
+}
+
+// readReply reads reply of RESP2 protocol. Bulk strings are byte slices,
+// null bulk string and null array are nil
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errUnexpectedReply
+	}
+
+	switch line[0] {
+	case '+':
+		return string(line[1:]), nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		return strconv.ParseInt(string(line[1:]), 10, 64)
+	case '$':
+		n, err := strconv.Atoi(string(line[1:]))
+		if err != nil {
+			return nil, errUnexpectedReply
+		}
+		if n < 0 {
+			return nil, nil
+		}
+
+		b := make([]byte, n+2)
+		_, err = io.ReadFull(r, b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	case '*':
+		n, err := strconv.Atoi(string(line[1:]))
+		if err != nil {
+			return nil, errUnexpectedReply
+		}
+		if n < 0 {
+			return nil, nil
+		}
+
+		items := make([]any, n)
+		for i := range items {
+			items[i], err = readReply(r)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, errUnexpectedReply
+	}
+}
+
+func readLine(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, errUnexpectedReply
+	}
+	return line[:len(line)-2], nil
+}
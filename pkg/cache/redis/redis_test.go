@@ -0,0 +1,156 @@
+package rediscache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/romandnk/todo/config"
+	"github.com/stretchr/testify/require"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// server is the redis compatible server of GET, SET, DEL, AUTH and SELECT commands
+type server struct {
+	mu       sync.Mutex
+	values   map[string]string
+	commands []string
+	password string
+}
+
+func newServer(t *testing.T, password string) (*server, string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	s := &server{values: make(map[string]string), password: password}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s, ln.Addr().String()
+}
+
+func (s *server) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			return
+		}
+		items := reply.([]any)
+		args := make([]string, len(items))
+		for i, item := range items {
+			args[i] = string(item.([]byte))
+		}
+
+		_, _ = conn.Write([]byte(s.exec(args)))
+	}
+}
+
+func (s *server) exec(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.commands = append(s.commands, strings.Join(args, " "))
+
+	switch args[0] {
+	case "AUTH":
+		if args[1] != s.password {
+			return "-WRONGPASS invalid password\r\n"
+		}
+		return "+OK\r\n"
+	case "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		value, ok := s.values[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		s.values[args[1]] = args[2]
+		return "+OK\r\n"
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := s.values[key]; ok {
+				delete(s.values, key)
+				deleted++
+			}
+		}
+		return ":" + strconv.Itoa(deleted) + "\r\n"
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
+
+func TestCache(t *testing.T) {
+	s, addr := newServer(t, "secret")
+	ctx := context.Background()
+
+	c := NewCache(config.Redis{Addr: addr, Password: "secret", DB: 2, PoolSize: 2, Timeout: time.Second})
+	defer c.Close()
+
+	_, ok, err := c.Get(ctx, "todo:ws:1:task:1")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, c.Set(ctx, "todo:ws:1:task:1", []byte("{\"ID\":1}\r\n"), time.Minute))
+
+	value, ok, err := c.Get(ctx, "todo:ws:1:task:1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("{\"ID\":1}\r\n"), value)
+
+	require.NoError(t, c.Delete(ctx, "todo:ws:1:task:1", "todo:ws:1:statuses"))
+	require.NoError(t, c.Delete(ctx))
+
+	_, ok, err = c.Get(ctx, "todo:ws:1:task:1")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	require.Equal(t, []string{
+		"AUTH secret",
+		"SELECT 2",
+		"GET todo:ws:1:task:1",
+		"SET todo:ws:1:task:1 {\"ID\":1}\r\n PX 60000",
+		"GET todo:ws:1:task:1",
+		"DEL todo:ws:1:task:1 todo:ws:1:statuses",
+		"GET todo:ws:1:task:1",
+	}, s.commands)
+}
+
+func TestCache_Errors(t *testing.T) {
+	_, addr := newServer(t, "secret")
+	ctx := context.Background()
+
+	c := NewCache(config.Redis{Addr: addr, Password: "wrong", PoolSize: 1, Timeout: time.Second})
+	_, _, err := c.Get(ctx, "key")
+	require.Equal(t, Error("WRONGPASS invalid password"), err)
+
+	// failed connection does not take the slot of the pool
+	c.cfg.Password = "secret"
+	_, _, err = c.Get(ctx, "key")
+	require.NoError(t, err)
+
+	// error reply keeps connection usable
+	_, err = c.do(ctx, "FLUSHALL")
+	require.Equal(t, Error("ERR unknown command"), err)
+	_, _, err = c.Get(ctx, "key")
+	require.NoError(t, err)
+}
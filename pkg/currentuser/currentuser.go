@@ -0,0 +1,33 @@
+package currentuser
+
+import "context"
+
+type ctxKey struct{}
+
+type user struct {
+	username      string
+	authenticated bool
+}
+
+// WithUsername returns context carrying the username of the request author
+func WithUsername(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, user{username: username})
+}
+
+// WithAuthenticatedUsername returns context carrying the username of the request author
+// verified by the credentials of the request like bearer token
+func WithAuthenticatedUsername(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, user{username: username, authenticated: true})
+}
+
+// FromContext returns the username of the request author, empty for anonymous request
+func FromContext(ctx context.Context) string {
+	u, _ := ctx.Value(ctxKey{}).(user)
+	return u.username
+}
+
+// Authenticated reports whether the username of the request author is verified
+func Authenticated(ctx context.Context) bool {
+	u, _ := ctx.Value(ctxKey{}).(user)
+	return u.authenticated
+}
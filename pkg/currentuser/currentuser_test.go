@@ -0,0 +1,20 @@
+package currentuser
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	require.Equal(t, "", FromContext(context.Background()))
+	require.False(t, Authenticated(context.Background()))
+
+	ctx := WithUsername(context.Background(), "ivan")
+	require.Equal(t, "ivan", FromContext(ctx))
+	require.False(t, Authenticated(ctx))
+
+	ctx = WithAuthenticatedUsername(context.Background(), "petr")
+	require.Equal(t, "petr", FromContext(ctx))
+	require.True(t, Authenticated(ctx))
+}
@@ -0,0 +1,342 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// calendar components
+const (
+	ComponentTodo  = "VTODO"
+	ComponentEvent = "VEVENT"
+)
+
+// VTODO statuses
+const (
+	StatusNeedsAction = "NEEDS-ACTION"
+	StatusInProcess   = "IN-PROCESS"
+	StatusCompleted   = "COMPLETED"
+	StatusCancelled   = "CANCELLED"
+)
+
+const (
+	dateTimeLayout = "20060102T150405Z"
+	dateLayout     = "20060102"
+	maxLineLength  = 75
+)
+
+var ErrNoCalendar = errors.New("ical: VCALENDAR is not found")
+
+// Item is a single VTODO or VEVENT calendar component
+type Item struct {
+	UID         string
+	Summary     string
+	Description string
+	Status      string
+	Due         time.Time
+	// AllDay items are due on the calendar day of Due and are written as DATE values
+	AllDay  bool
+	Created time.Time
+	// Err is the error of the first malformed property, the other properties of the item are still read
+	Err error
+}
+
+// Writer writes calendar items as components of one VCALENDAR
+type Writer struct {
+	w             io.Writer
+	prodID        string
+	component     string
+	headerWritten bool
+}
+
+func NewWriter(w io.Writer, prodID, component string) *Writer {
+	return &Writer{
+		w:         w,
+		prodID:    prodID,
+		component: component,
+	}
+}
+
+func (w *Writer) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+
+	return w.writeLines(
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:"+escapeText(w.prodID),
+		"CALSCALE:GREGORIAN",
+	)
+}
+
+func (w *Writer) Write(item Item) error {
+	err := w.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	lines := []string{
+		"BEGIN:" + w.component,
+		"UID:" + escapeText(item.UID),
+		"DTSTAMP:" + time.Now().UTC().Format(dateTimeLayout),
+		"SUMMARY:" + escapeText(item.Summary),
+	}
+	if item.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeText(item.Description))
+	}
+	if !item.Created.IsZero() {
+		lines = append(lines, "CREATED:"+item.Created.UTC().Format(dateTimeLayout))
+	}
+
+	due, end := ":"+item.Due.UTC().Format(dateTimeLayout), ""
+	if item.AllDay {
+		due = ";VALUE=DATE:" + item.Due.Format(dateLayout)
+		// end date of all-day event is exclusive
+		end = ";VALUE=DATE:" + item.Due.AddDate(0, 0, 1).Format(dateLayout)
+	}
+	if w.component == ComponentEvent {
+		if end == "" {
+			end = due
+		}
+		lines = append(lines, "DTSTART"+due, "DTEND"+end)
+		if item.Status == StatusCancelled {
+			lines = append(lines, "STATUS:CANCELLED")
+		}
+	} else {
+		lines = append(lines, "DUE"+due)
+		if item.Status != "" {
+			lines = append(lines, "STATUS:"+item.Status)
+		}
+	}
+
+	lines = append(lines, "END:"+w.component)
+
+	return w.writeLines(lines...)
+}
+
+// Close writes the end of the calendar, the header is written as well if there were no items
+func (w *Writer) Close() error {
+	err := w.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	return w.writeLines("END:VCALENDAR")
+}
+
+func (w *Writer) writeLines(lines ...string) error {
+	for _, line := range lines {
+		_, err := io.WriteString(w.w, foldLine(line))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// foldLine splits content line into lines no longer than 75 octets without breaking utf-8 characters
+func foldLine(line string) string {
+	var b strings.Builder
+
+	length := 0
+	for _, r := range line {
+		size := len(string(r))
+		if length+size > maxLineLength {
+			b.WriteString("\r\n ")
+			length = 1
+		}
+		b.WriteRune(r)
+		length += size
+	}
+	b.WriteString("\r\n")
+
+	return b.String()
+}
+
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+func unescapeText(s string) string {
+	return strings.NewReplacer(
+		`\\`, `\`,
+		`\;`, ";",
+		`\,`, ",",
+		`\n`, "\n",
+		`\N`, "\n",
+	).Replace(s)
+}
+
+// Parse reads all components of the selected type from the calendar.
+// Malformed property fails only its item, the error is kept in Item.Err
+func Parse(r io.Reader, component string) ([]Item, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		items      []Item
+		current    *Item
+		inCalendar bool
+		found      bool
+	)
+
+	for _, line := range lines {
+		name, params, value, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			inCalendar = true
+			found = true
+		case name == "END" && strings.EqualFold(value, "VCALENDAR"):
+			inCalendar = false
+		case !inCalendar:
+			continue
+		case name == "BEGIN" && strings.EqualFold(value, component):
+			current = &Item{}
+		case name == "END" && strings.EqualFold(value, component):
+			if current != nil {
+				items = append(items, *current)
+				current = nil
+			}
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = unescapeText(value)
+		case name == "SUMMARY":
+			current.Summary = unescapeText(value)
+		case name == "DESCRIPTION":
+			current.Description = unescapeText(value)
+		case name == "STATUS":
+			current.Status = strings.ToUpper(value)
+		case name == "DUE", name == "DTSTART" && current.Due.IsZero():
+			due, err := parseTime(params, value)
+			if err != nil {
+				current.setErr(name, err)
+				continue
+			}
+			current.Due = due
+			current.AllDay = isDate(params, value)
+		case name == "CREATED":
+			created, err := parseTime(params, value)
+			if err != nil {
+				current.setErr(name, err)
+				continue
+			}
+			current.Created = created
+		}
+	}
+
+	if !found {
+		return nil, ErrNoCalendar
+	}
+
+	return items, nil
+}
+
+func (i *Item) setErr(property string, err error) {
+	if i.Err == nil {
+		i.Err = fmt.Errorf("%s: %w", property, err)
+	}
+}
+
+func unfoldLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// parseLine splits content line "NAME;PARAM=VALUE:value" into its parts
+func parseLine(line string) (string, map[string]string, string, bool) {
+	i := strings.Index(line, ":")
+	if i < 0 {
+		return "", nil, "", false
+	}
+
+	head, value := line[:i], line[i+1:]
+	parts := strings.Split(head, ";")
+
+	params := make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		key, val, ok := strings.Cut(param, "=")
+		if ok {
+			params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+		}
+	}
+
+	return strings.ToUpper(parts[0]), params, value, true
+}
+
+// isDate reports whether value is DATE without time
+func isDate(params map[string]string, value string) bool {
+	return params["VALUE"] == "DATE" || len(value) == len(dateLayout)
+}
+
+func parseTime(params map[string]string, value string) (time.Time, error) {
+	loc := time.UTC
+	if tzid, ok := params["TZID"]; ok {
+		l, err := time.LoadLocation(tzid)
+		if err == nil {
+			loc = l
+		}
+	}
+
+	if isDate(params, value) {
+		t, err := time.ParseInLocation(dateLayout, value, loc)
+		if err != nil {
+			return t, fmt.Errorf("ical: invalid date %q: %w", value, err)
+		}
+		return t, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeLayout, value)
+		if err != nil {
+			return t, fmt.Errorf("ical: invalid date-time %q: %w", value, err)
+		}
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return t, fmt.Errorf("ical: invalid date-time %q: %w", value, err)
+	}
+
+	return t, nil
+}
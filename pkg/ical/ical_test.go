@@ -0,0 +1,175 @@
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriterAndParse(t *testing.T) {
+	due := time.Date(2030, 1, 2, 10, 30, 0, 0, time.UTC)
+
+	items := []Item{
+		{
+			UID:         "1@todo",
+			Summary:     "Pay rent; electricity, water",
+			Description: strings.Repeat("Очень длинное описание задачи ", 5) + "\nsecond line",
+			Status:      StatusCompleted,
+			Due:         due,
+			Created:     due.Add(-time.Hour),
+		},
+		{
+			UID:     "2@todo",
+			Summary: "Call back",
+			Status:  StatusNeedsAction,
+			Due:     due.AddDate(0, 0, 1),
+		},
+		{
+			UID:     "3@todo",
+			Summary: "Birthday",
+			Due:     time.Date(2030, 1, 5, 0, 0, 0, 0, time.UTC),
+			AllDay:  true,
+		},
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, "-//todo//EN", ComponentTodo)
+	for _, item := range items {
+		require.NoError(t, w.Write(item))
+	}
+	require.NoError(t, w.Close())
+
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		require.LessOrEqual(t, len(line), maxLineLength+1)
+	}
+
+	parsed, err := Parse(&buf, ComponentTodo)
+	require.NoError(t, err)
+	require.Equal(t, items, parsed)
+}
+
+func TestWriterEmptyCalendar(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, "-//todo//EN", ComponentEvent)
+	require.NoError(t, w.Close())
+
+	require.Equal(t, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//todo//EN\r\nCALSCALE:GREGORIAN\r\nEND:VCALENDAR\r\n", buf.String())
+}
+
+func TestWriterAllDayEvent(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, "-//todo//EN", ComponentEvent)
+	require.NoError(t, w.Write(Item{
+		UID:     "1@todo",
+		Summary: "Birthday",
+		Due:     time.Date(2030, 1, 5, 0, 0, 0, 0, time.UTC),
+		AllDay:  true,
+	}))
+	require.NoError(t, w.Close())
+
+	require.Contains(t, buf.String(), "DTSTART;VALUE=DATE:20300105\r\nDTEND;VALUE=DATE:20300106\r\n")
+}
+
+func TestParse(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		input         string
+		expectedItems []Item
+		expectedError error
+	}{
+		{
+			name: "date value and tzid",
+			input: "BEGIN:VCALENDAR\n" +
+				"BEGIN:VTODO\n" +
+				"UID:a\n" +
+				"SUMMARY:All day\n" +
+				"DUE;VALUE=DATE:20300102\n" +
+				"END:VTODO\n" +
+				"BEGIN:VEVENT\n" +
+				"UID:skipped\n" +
+				"END:VEVENT\n" +
+				"BEGIN:VTODO\n" +
+				"UID:b\n" +
+				"SUMMARY:Folded\n" +
+				"  summary\n" +
+				"DUE;TZID=Europe/Moscow:20300102T090000\n" +
+				"STATUS:in-process\n" +
+				"END:VTODO\n" +
+				"END:VCALENDAR\n",
+			expectedItems: []Item{
+				{
+					UID:     "a",
+					Summary: "All day",
+					Due:     time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC),
+					AllDay:  true,
+				},
+				{
+					UID:     "b",
+					Summary: "Folded summary",
+					Status:  StatusInProcess,
+					Due:     time.Date(2030, 1, 2, 9, 0, 0, 0, moscow),
+				},
+			},
+		},
+		{
+			name: "malformed item",
+			input: "BEGIN:VCALENDAR\n" +
+				"BEGIN:VTODO\n" +
+				"UID:a\n" +
+				"DUE:tomorrow\n" +
+				"END:VTODO\n" +
+				"BEGIN:VTODO\n" +
+				"UID:b\n" +
+				"DUE;VALUE=DATE:20300102\n" +
+				"END:VTODO\n" +
+				"END:VCALENDAR\n",
+			expectedItems: []Item{
+				{
+					UID: "a",
+					Err: errors.New(`DUE: ical: invalid date "tomorrow": parsing time "tomorrow" as "20060102": cannot parse "tomorrow" as "2006"`),
+				},
+				{
+					UID:    "b",
+					Due:    time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC),
+					AllDay: true,
+				},
+			},
+		},
+		{
+			name:          "no calendar",
+			input:         "BEGIN:VTODO\nEND:VTODO\n",
+			expectedError: ErrNoCalendar,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			items, err := Parse(strings.NewReader(tc.input), ComponentTodo)
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, len(tc.expectedItems), len(items))
+			for i := range items {
+				require.Equal(t, tc.expectedItems[i].UID, items[i].UID)
+				require.Equal(t, tc.expectedItems[i].Summary, items[i].Summary)
+				require.Equal(t, tc.expectedItems[i].Status, items[i].Status)
+				require.True(t, tc.expectedItems[i].Due.Equal(items[i].Due))
+				require.Equal(t, tc.expectedItems[i].AllDay, items[i].AllDay)
+				if tc.expectedItems[i].Err != nil {
+					require.EqualError(t, items[i].Err, tc.expectedItems[i].Err.Error())
+				} else {
+					require.NoError(t, items[i].Err)
+				}
+			}
+		})
+	}
+}
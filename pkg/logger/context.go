@@ -0,0 +1,37 @@
+package logger
+
+import "context"
+
+type ctxKey struct{}
+
+// WithFields returns context carrying key-value pairs added to lines logged with the context,
+// like "request_id", "3f2a". Value of already added key is replaced
+func WithFields(ctx context.Context, keysAndValues ...any) context.Context {
+	current := Fields(ctx)
+	fields := make([]any, len(current), len(current)+len(keysAndValues))
+	copy(fields, current)
+
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, value := keysAndValues[i], keysAndValues[i+1]
+
+		replaced := false
+		for j := 0; j+1 < len(fields); j += 2 {
+			if fields[j] == key {
+				fields[j+1] = value
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			fields = append(fields, key, value)
+		}
+	}
+
+	return context.WithValue(ctx, ctxKey{}, fields)
+}
+
+// Fields returns key-value pairs of the context in order of addition
+func Fields(ctx context.Context) []any {
+	fields, _ := ctx.Value(ctxKey{}).([]any)
+	return fields
+}
@@ -0,0 +1,19 @@
+package logger
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	ctx := context.Background()
+	require.Empty(t, Fields(ctx))
+
+	ctx = WithFields(ctx, "request_id", "3f2a", "route", "/api/v1/tasks")
+	userCtx := WithFields(ctx, "user", "ivan")
+	userCtx = WithFields(userCtx, "user", "petr")
+
+	require.Equal(t, []any{"request_id", "3f2a", "route", "/api/v1/tasks"}, Fields(ctx))
+	require.Equal(t, []any{"request_id", "3f2a", "route", "/api/v1/tasks", "user", "petr"}, Fields(userCtx))
+}
@@ -0,0 +1,65 @@
+package logger
+
+import "time"
+
+// badKey is the key of the value without a key, like slog does
+const badKey = "!BADKEY"
+
+// Field is the typed key-value pair of the line, backends convert it to their own fields
+type Field struct {
+	Key   string
+	Value any
+}
+
+func String(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Any(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Error is the field of err with "error" key
+func Error(err error) Field {
+	return Field{Key: "error", Value: err}
+}
+
+// ToFields normalizes args of the Logger methods. Args are either fields or key-value pairs
+// like "user", "ivan"; value without a key is kept under !BADKEY key, so the line is never lost
+func ToFields(args ...any) []Field {
+	fields := make([]Field, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i].(type) {
+		case Field:
+			fields = append(fields, arg)
+		case string:
+			if i+1 == len(args) {
+				fields = append(fields, Field{Key: badKey, Value: arg})
+				continue
+			}
+			fields = append(fields, Field{Key: arg, Value: args[i+1]})
+			i++
+		default:
+			fields = append(fields, Field{Key: badKey, Value: arg})
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestToFields(t *testing.T) {
+	err := errors.New("boom")
+
+	testCases := []struct {
+		name     string
+		args     []any
+		expected []Field
+	}{
+		{
+			name:     "typed fields",
+			args:     []any{String("user", "ivan"), Int("task id", 1), Error(err)},
+			expected: []Field{{Key: "user", Value: "ivan"}, {Key: "task id", Value: 1}, {Key: "error", Value: err}},
+		},
+		{
+			name:     "key-value pairs",
+			args:     []any{"user", "ivan", "task id", 1},
+			expected: []Field{{Key: "user", Value: "ivan"}, {Key: "task id", Value: 1}},
+		},
+		{
+			name:     "mixed",
+			args:     []any{"user", "ivan", Error(err)},
+			expected: []Field{{Key: "user", Value: "ivan"}, {Key: "error", Value: err}},
+		},
+		{
+			name:     "key without value",
+			args:     []any{Int("task id", 1), "user"},
+			expected: []Field{{Key: "task id", Value: 1}, {Key: badKey, Value: "user"}},
+		},
+		{
+			name:     "value without key",
+			args:     []any{42, "user", "ivan"},
+			expected: []Field{{Key: badKey, Value: 42}, {Key: "user", Value: "ivan"}},
+		},
+		{
+			name:     "no args",
+			expected: []Field{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, ToFields(tc.args...))
+		})
+	}
+}
+
+func TestContextFields(t *testing.T) {
+	ctx := WithFields(context.Background(), "request_id", "3f2a")
+
+	require.Equal(t,
+		[]Field{{Key: "request_id", Value: "3f2a"}, {Key: "user", Value: "ivan"}},
+		ContextFields(ctx, String("user", "ivan")),
+	)
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, lvl := range []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel, FatalLevel} {
+		parsed, err := ParseLevel(lvl.String())
+		require.NoError(t, err)
+		require.Equal(t, lvl, parsed)
+	}
+
+	lvl, err := ParseLevel("WARNING")
+	require.NoError(t, err)
+	require.Equal(t, WarnLevel, lvl)
+
+	_, err = ParseLevel("verbose")
+	require.Error(t, err)
+}
@@ -1,9 +1,36 @@
+// Package logger is the logging API of the app. Backends are in subpackages:
+// zap, slog, nop and recorder for tests.
+//
+// Args of the methods are Field values like logger.String("user", "ivan")
+// or key-value pairs like "user", "ivan", every backend handles both the same way
 package logger
 
 //go:generate mockgen -source=logger.go -destination=mock/mock.go logger
 
+import "context"
+
 type Logger interface {
+	Debug(msg string, args ...any)
 	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
 	Error(msg string, args ...any)
 	Fatal(msg string, args ...any)
+	// DebugContext, InfoContext, WarnContext and ErrorContext add fields of the context like request id to the line
+	DebugContext(ctx context.Context, msg string, args ...any)
+	InfoContext(ctx context.Context, msg string, args ...any)
+	WarnContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+	// With returns logger adding args to every line
+	With(args ...any) Logger
+}
+
+// ContextFields returns fields of the context followed by args
+func ContextFields(ctx context.Context, args ...any) []Field {
+	return append(ToFields(Fields(ctx)...), ToFields(args...)...)
 }
+
+const (
+	BackendZap  = "zap"
+	BackendSlog = "slog"
+	BackendNop  = "nop"
+)
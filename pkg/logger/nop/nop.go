@@ -0,0 +1,39 @@
+// Package noplogger discards all lines, it is used when logs are not needed like in benchmarks
+package noplogger
+
+import (
+	"context"
+	"github.com/romandnk/todo/pkg/logger"
+	"os"
+)
+
+type Logger struct{}
+
+func NewLogger() *Logger {
+	return &Logger{}
+}
+
+func (l *Logger) Debug(string, ...any) {}
+
+func (l *Logger) Info(string, ...any) {}
+
+func (l *Logger) Warn(string, ...any) {}
+
+func (l *Logger) Error(string, ...any) {}
+
+// Fatal exits as other loggers do, so the app does not continue after fatal error
+func (l *Logger) Fatal(string, ...any) {
+	os.Exit(1)
+}
+
+func (l *Logger) DebugContext(context.Context, string, ...any) {}
+
+func (l *Logger) InfoContext(context.Context, string, ...any) {}
+
+func (l *Logger) WarnContext(context.Context, string, ...any) {}
+
+func (l *Logger) ErrorContext(context.Context, string, ...any) {}
+
+func (l *Logger) With(...any) logger.Logger {
+	return l
+}
@@ -0,0 +1,117 @@
+// Package recorderlogger keeps logged lines in memory, so tests can assert what was logged
+// without mocking every call
+package recorderlogger
+
+import (
+	"context"
+	"github.com/romandnk/todo/pkg/logger"
+	"sync"
+)
+
+// Entry is the logged line
+type Entry struct {
+	Level   logger.Level
+	Message string
+	Fields  []logger.Field
+}
+
+// Field returns value of the field with the key
+func (e Entry) Field(key string) (any, bool) {
+	for _, f := range e.Fields {
+		if f.Key == key {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+type entries struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// Logger records lines of all levels. Loggers created by With share entries with the parent.
+// Fatal is recorded and does not exit
+type Logger struct {
+	entries *entries
+	fields  []logger.Field
+}
+
+func NewLogger() *Logger {
+	return &Logger{entries: &entries{}}
+}
+
+// Entries returns recorded lines in order of logging
+func (l *Logger) Entries() []Entry {
+	l.entries.mu.Lock()
+	defer l.entries.mu.Unlock()
+
+	return append([]Entry(nil), l.entries.entries...)
+}
+
+// Reset removes recorded lines
+func (l *Logger) Reset() {
+	l.entries.mu.Lock()
+	defer l.entries.mu.Unlock()
+
+	l.entries.entries = nil
+}
+
+func (l *Logger) Debug(msg string, args ...any) {
+	l.record(logger.DebugLevel, msg, logger.ToFields(args...))
+}
+
+func (l *Logger) Info(msg string, args ...any) {
+	l.record(logger.InfoLevel, msg, logger.ToFields(args...))
+}
+
+func (l *Logger) Warn(msg string, args ...any) {
+	l.record(logger.WarnLevel, msg, logger.ToFields(args...))
+}
+
+func (l *Logger) Error(msg string, args ...any) {
+	l.record(logger.ErrorLevel, msg, logger.ToFields(args...))
+}
+
+func (l *Logger) Fatal(msg string, args ...any) {
+	l.record(logger.FatalLevel, msg, logger.ToFields(args...))
+}
+
+func (l *Logger) DebugContext(ctx context.Context, msg string, args ...any) {
+	l.record(logger.DebugLevel, msg, logger.ContextFields(ctx, args...))
+}
+
+func (l *Logger) InfoContext(ctx context.Context, msg string, args ...any) {
+	l.record(logger.InfoLevel, msg, logger.ContextFields(ctx, args...))
+}
+
+func (l *Logger) WarnContext(ctx context.Context, msg string, args ...any) {
+	l.record(logger.WarnLevel, msg, logger.ContextFields(ctx, args...))
+}
+
+func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...any) {
+	l.record(logger.ErrorLevel, msg, logger.ContextFields(ctx, args...))
+}
+
+func (l *Logger) With(args ...any) logger.Logger {
+	fields := make([]logger.Field, 0, len(l.fields)+len(args))
+	fields = append(fields, l.fields...)
+	fields = append(fields, logger.ToFields(args...)...)
+
+	return &Logger{entries: l.entries, fields: fields}
+}
+
+func (l *Logger) record(lvl logger.Level, msg string, fields []logger.Field) {
+	entry := Entry{
+		Level:   lvl,
+		Message: msg,
+		Fields:  make([]logger.Field, 0, len(l.fields)+len(fields)),
+	}
+	entry.Fields = append(entry.Fields, l.fields...)
+	entry.Fields = append(entry.Fields, fields...)
+
+	l.entries.mu.Lock()
+	defer l.entries.mu.Unlock()
+
+	l.entries.entries = append(l.entries.entries, entry)
+}
@@ -0,0 +1,39 @@
+package recorderlogger
+
+import (
+	"context"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	l := NewLogger()
+	ctx := logger.WithFields(context.Background(), "request_id", "3f2a")
+
+	l.Debug("debug line")
+	l.With("user", "ivan").InfoContext(ctx, "task created", logger.Int("task id", 1))
+	l.Fatal("fatal line")
+
+	entries := l.Entries()
+	require.Equal(t, []Entry{
+		{Level: logger.DebugLevel, Message: "debug line", Fields: []logger.Field{}},
+		{
+			Level:   logger.InfoLevel,
+			Message: "task created",
+			Fields: []logger.Field{
+				logger.String("user", "ivan"),
+				logger.String("request_id", "3f2a"),
+				logger.Int("task id", 1),
+			},
+		},
+		{Level: logger.FatalLevel, Message: "fatal line", Fields: []logger.Field{}},
+	}, entries)
+
+	id, ok := entries[1].Field("task id")
+	require.True(t, ok)
+	require.Equal(t, 1, id)
+
+	l.Reset()
+	require.Empty(t, l.Entries())
+}
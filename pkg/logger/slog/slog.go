@@ -0,0 +1,144 @@
+package sloglogger
+
+import (
+	"context"
+	"fmt"
+	"github.com/romandnk/todo/config"
+	"github.com/romandnk/todo/pkg/logger"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// levelFatal is logged by Fatal, slog has no fatal level
+const levelFatal = slog.LevelError + 4
+
+type Logger struct {
+	logger *slog.Logger
+}
+
+func NewLogger(cfg config.SlogLogger) (*Logger, error) {
+	return newLogger(cfg, os.Stdout)
+}
+
+func newLogger(cfg config.SlogLogger, w io.Writer) (*Logger, error) {
+	lvl, err := logger.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{
+		Level: slogLevel(lvl),
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			switch {
+			case a.Key == slog.TimeKey && a.Value.Kind() == slog.KindTime:
+				a.Key = "ts"
+				a.Value = slog.StringValue(a.Value.Time().Format(time.DateTime))
+			case a.Key == slog.LevelKey:
+				a.Key = "lvl"
+				if l, ok := a.Value.Any().(slog.Level); ok && l == levelFatal {
+					a.Value = slog.StringValue(logger.FatalLevel.String())
+				} else {
+					a.Value = slog.StringValue(a.Value.String())
+				}
+			}
+			return a
+		},
+	}
+
+	var handler slog.Handler
+	switch cfg.Format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	case FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("unknown slog format '%s'", cfg.Format)
+	}
+
+	return &Logger{logger: slog.New(handler)}, nil
+}
+
+func (l *Logger) Debug(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelDebug, msg, logger.ToFields(args...))
+}
+
+func (l *Logger) Info(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelInfo, msg, logger.ToFields(args...))
+}
+
+func (l *Logger) Warn(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelWarn, msg, logger.ToFields(args...))
+}
+
+func (l *Logger) Error(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelError, msg, logger.ToFields(args...))
+}
+
+func (l *Logger) Fatal(msg string, args ...any) {
+	l.log(context.Background(), levelFatal, msg, logger.ToFields(args...))
+	os.Exit(1)
+}
+
+func (l *Logger) DebugContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelDebug, msg, logger.ContextFields(ctx, args...))
+}
+
+func (l *Logger) InfoContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelInfo, msg, logger.ContextFields(ctx, args...))
+}
+
+func (l *Logger) WarnContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelWarn, msg, logger.ContextFields(ctx, args...))
+}
+
+func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelError, msg, logger.ContextFields(ctx, args...))
+}
+
+func (l *Logger) With(args ...any) logger.Logger {
+	return &Logger{logger: slog.New(l.logger.Handler().WithAttrs(attrs(logger.ToFields(args...))))}
+}
+
+func (l *Logger) log(ctx context.Context, lvl slog.Level, msg string, fields []logger.Field) {
+	if !l.logger.Enabled(ctx, lvl) {
+		return
+	}
+	l.logger.LogAttrs(ctx, lvl, msg, attrs(fields)...)
+}
+
+// attrs converts fields to slog attributes, error values are logged by their message
+func attrs(fields []logger.Field) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(fields))
+
+	for _, f := range fields {
+		if err, ok := f.Value.(error); ok {
+			attrs = append(attrs, slog.String(f.Key, err.Error()))
+			continue
+		}
+		attrs = append(attrs, slog.Any(f.Key, f.Value))
+	}
+
+	return attrs
+}
+
+func slogLevel(lvl logger.Level) slog.Level {
+	switch lvl {
+	case logger.DebugLevel:
+		return slog.LevelDebug
+	case logger.WarnLevel:
+		return slog.LevelWarn
+	case logger.ErrorLevel:
+		return slog.LevelError
+	case logger.FatalLevel:
+		return levelFatal
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,37 @@
+package sloglogger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/romandnk/todo/config"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	l, err := newLogger(config.SlogLogger{Level: "info", Format: FormatJSON}, &buf)
+	require.NoError(t, err)
+
+	ctx := logger.WithFields(context.Background(), "request_id", "3f2a")
+
+	l.Debug("not logged")
+	l.With("user", "ivan").WarnContext(ctx, "task is overdue", logger.Int("task id", 1))
+	l.Error("error getting task", logger.Error(errors.New("boom")))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	require.Regexp(t, `^\{"ts":"[^"]+","lvl":"WARN","msg":"task is overdue","user":"ivan","request_id":"3f2a","task id":1\}$`, string(lines[0]))
+	require.Regexp(t, `^\{"ts":"[^"]+","lvl":"ERROR","msg":"error getting task","error":"boom"\}$`, string(lines[1]))
+}
+
+func TestNewLogger(t *testing.T) {
+	_, err := newLogger(config.SlogLogger{Level: "info", Format: "xml"}, &bytes.Buffer{})
+	require.Error(t, err)
+
+	_, err = newLogger(config.SlogLogger{Level: "verbose", Format: FormatText}, &bytes.Buffer{})
+	require.Error(t, err)
+}
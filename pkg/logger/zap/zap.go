@@ -1,7 +1,9 @@
 package zaplogger
 
 import (
+	"context"
 	"github.com/romandnk/todo/config"
+	"github.com/romandnk/todo/pkg/logger"
 	"time"
 
 	"go.uber.org/zap"
@@ -84,47 +86,57 @@ func prodCfg(cfg config.ZapLogger) *zap.Config {
 	}
 }
 
+func (l *Logger) Debug(msg string, args ...any) {
+	l.logger.Debug(msg, zapFields(logger.ToFields(args...))...)
+}
+
 func (l *Logger) Info(msg string, args ...any) {
-	zapFields := make([]zap.Field, 0, len(args))
-
-	for _, field := range args {
-		switch f := field.(type) {
-		case zap.Field:
-			zapFields = append(zapFields, f)
-		default:
-			return
-		}
-	}
+	l.logger.Info(msg, zapFields(logger.ToFields(args...))...)
+}
 
-	l.logger.Info(msg, zapFields...)
+func (l *Logger) Warn(msg string, args ...any) {
+	l.logger.Warn(msg, zapFields(logger.ToFields(args...))...)
 }
 
 func (l *Logger) Error(msg string, args ...any) {
-	zapFields := make([]zap.Field, 0, len(args))
-
-	for _, field := range args {
-		switch f := field.(type) {
-		case zap.Field:
-			zapFields = append(zapFields, f)
-		default:
-			return
-		}
-	}
-
-	l.logger.Error(msg, zapFields...)
+	l.logger.Error(msg, zapFields(logger.ToFields(args...))...)
 }
 
 func (l *Logger) Fatal(msg string, args ...any) {
-	zapFields := make([]zap.Field, 0, len(args))
-
-	for _, field := range args {
-		switch f := field.(type) {
-		case zap.Field:
-			zapFields = append(zapFields, f)
-		default:
-			return
+	l.logger.Fatal(msg, zapFields(logger.ToFields(args...))...)
+}
+
+func (l *Logger) DebugContext(ctx context.Context, msg string, args ...any) {
+	l.logger.Debug(msg, zapFields(logger.ContextFields(ctx, args...))...)
+}
+
+func (l *Logger) InfoContext(ctx context.Context, msg string, args ...any) {
+	l.logger.Info(msg, zapFields(logger.ContextFields(ctx, args...))...)
+}
+
+func (l *Logger) WarnContext(ctx context.Context, msg string, args ...any) {
+	l.logger.Warn(msg, zapFields(logger.ContextFields(ctx, args...))...)
+}
+
+func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...any) {
+	l.logger.Error(msg, zapFields(logger.ContextFields(ctx, args...))...)
+}
+
+func (l *Logger) With(args ...any) logger.Logger {
+	return &Logger{logger: l.logger.With(zapFields(logger.ToFields(args...))...)}
+}
+
+// zapFields converts fields to zap fields, error values are logged by their message
+func zapFields(fields []logger.Field) []zap.Field {
+	zapFields := make([]zap.Field, 0, len(fields))
+
+	for _, f := range fields {
+		if err, ok := f.Value.(error); ok {
+			zapFields = append(zapFields, zap.NamedError(f.Key, err))
+			continue
 		}
+		zapFields = append(zapFields, zap.Any(f.Key, f.Value))
 	}
 
-	l.logger.Fatal(msg, zapFields...)
+	return zapFields
 }
@@ -0,0 +1,34 @@
+package zaplogger
+
+import (
+	"context"
+	"errors"
+	"github.com/romandnk/todo/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := &Logger{logger: zap.New(core)}
+
+	ctx := logger.WithFields(context.Background(), "request_id", "3f2a")
+	err := errors.New("boom")
+
+	l.Debug("not logged")
+	l.With("user", "ivan").WarnContext(ctx, "task is overdue", logger.Int("task id", 1))
+	l.Error("error getting task", "task id", 1, logger.Error(err))
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+
+	require.Equal(t, zapcore.WarnLevel, entries[0].Level)
+	require.Equal(t, "task is overdue", entries[0].Message)
+	require.Equal(t, map[string]any{"user": "ivan", "request_id": "3f2a", "task id": int64(1)}, entries[0].ContextMap())
+
+	require.Equal(t, zapcore.ErrorLevel, entries[1].Level)
+	require.Equal(t, map[string]any{"task id": int64(1), "error": "boom"}, entries[1].ContextMap())
+}
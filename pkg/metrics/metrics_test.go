@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	postgres "github.com/romandnk/todo/pkg/storage"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// scrape returns metrics in Prometheus text format
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestMetrics_ObserveRequest(t *testing.T) {
+	m := New()
+
+	m.ObserveRequest(http.MethodGet, "/api/v1/tasks/:id", http.StatusOK, 20*time.Millisecond)
+	m.ObserveRequest(http.MethodGet, "/api/v1/tasks/:id", http.StatusOK, 30*time.Millisecond)
+	m.ObserveRequest(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+
+	body := scrape(t, m)
+	require.Contains(t, body, `todo_http_requests_total{method="GET",route="/api/v1/tasks/:id",status="200"} 2`)
+	require.Contains(t, body, `todo_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	require.Contains(t, body, `todo_http_request_duration_seconds_count{method="GET",route="/api/v1/tasks/:id",status="200"} 2`)
+	require.Contains(t, body, "go_goroutines")
+}
+
+func TestMetrics_Counter(t *testing.T) {
+	m := New()
+
+	created := m.Counter("tasks_created_total", "Number of created tasks.")
+	created.Inc()
+	created.Inc()
+
+	require.Contains(t, scrape(t, m), "todo_tasks_created_total 2")
+}
+
+func TestMetrics_RegisterPool(t *testing.T) {
+	m := New()
+
+	// pool without min conns does not connect until the first acquire
+	pool, err := pgxpool.New(context.Background(), "host=localhost port=5432 pool_max_conns=4")
+	require.NoError(t, err)
+	defer pool.Close()
+
+	require.NoError(t, m.RegisterPool(pool))
+
+	body := scrape(t, m)
+	require.Contains(t, body, "todo_db_pool_acquired_conns 0")
+	require.Contains(t, body, "todo_db_pool_idle_conns 0")
+	require.Contains(t, body, "todo_db_pool_total_conns 0")
+	require.Contains(t, body, "todo_db_pool_max_conns 4")
+}
+
+type taskRepo struct {
+	tracer *QueryTracer
+}
+
+func (r *taskRepo) CreateTask(ctx context.Context) {
+	ctx = postgres.WithMethod(ctx, "taskRepo.CreateTask")
+	ctx = r.tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "INSERT INTO tasks"})
+	r.tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+}
+
+func (r *taskRepo) ExecTaskBatch(ctx context.Context) {
+	ctx = postgres.WithMethod(ctx, "taskRepo.ExecTaskBatch")
+	ctx = r.tracer.TraceBatchStart(ctx, nil, pgx.TraceBatchStartData{})
+	r.tracer.TraceBatchEnd(ctx, nil, pgx.TraceBatchEndData{})
+}
+
+func TestQueryTracer(t *testing.T) {
+	m := New()
+	tracer := m.NewQueryTracer()
+	repo := &taskRepo{tracer: tracer}
+
+	ctx := context.Background()
+	repo.CreateTask(ctx)
+	repo.CreateTask(ctx)
+	repo.ExecTaskBatch(ctx)
+	// query without method in the context
+	tracer.TraceQueryEnd(tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{}), nil, pgx.TraceQueryEndData{})
+	// query without start is skipped
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+	body := scrape(t, m)
+	require.Contains(t, body, `todo_repository_query_duration_seconds_count{method="taskRepo.CreateTask"} 2`)
+	require.Contains(t, body, `todo_repository_query_duration_seconds_count{method="taskRepo.ExecTaskBatch"} 1`)
+	require.Contains(t, body, `todo_repository_query_duration_seconds_count{method="other"} 1`)
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	postgres "github.com/romandnk/todo/pkg/storage"
+	"time"
+)
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	method string
+	at     time.Time
+}
+
+// QueryTracer records durations of queries and batches by repository method
+type QueryTracer struct {
+	metrics *Metrics
+}
+
+// NewQueryTracer creates tracer of queries labeled by the method of their context
+func (m *Metrics) NewQueryTracer() *QueryTracer {
+	return &QueryTracer{metrics: m}
+}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	return t.start(ctx)
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryEndData) {
+	t.end(ctx)
+}
+
+func (t *QueryTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceBatchStartData) context.Context {
+	return t.start(ctx)
+}
+
+func (t *QueryTracer) TraceBatchQuery(context.Context, *pgx.Conn, pgx.TraceBatchQueryData) {}
+
+func (t *QueryTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, _ pgx.TraceBatchEndData) {
+	t.end(ctx)
+}
+
+func (t *QueryTracer) start(ctx context.Context) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{
+		method: postgres.Method(ctx),
+		at:     time.Now(),
+	})
+}
+
+func (t *QueryTracer) end(ctx context.Context) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	t.metrics.ObserveQuery(start.method, time.Since(start.at))
+}
@@ -0,0 +1,448 @@
+// Package naturaldate parses due dates written in English or Russian natural language
+// like "tomorrow 9am", "next friday", "in 3 days" or "завтра в 9"
+package naturaldate
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrUnknownDate = errors.New("naturaldate: cannot parse date")
+
+// maxAmount bounds relative offset, larger amounts overflow durations and dates
+const maxAmount = 10000
+
+// clockRe matches "9", "9am", "9:30", "21.30" and "9:30pm"
+var clockRe = regexp.MustCompile(`^(\d{1,2})(?:[:.](\d{2}))?(am|pm)?$`)
+
+// ordinalRe matches day of month "5", "5th" and "5-го"
+var ordinalRe = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th|-го|-е)?$`)
+
+// parser holds the day and the time of day found in the input
+type parser struct {
+	ref    time.Time
+	tokens []string
+
+	day      time.Time
+	hasDay   bool
+	hour     int
+	minute   int
+	hasTime  bool
+	exact    time.Time
+	hasExact bool
+	conflict bool
+}
+
+// matcher consumes tokens of one expression and returns their number, 0 means no match
+type matcher func(p *parser, tokens []string) int
+
+var matchers = []matcher{
+	matchRelative,
+	matchDay,
+	matchWeekday,
+	matchPeriod,
+	matchDate,
+	matchNamedTime,
+	matchClock,
+}
+
+// Parse parses date relative to ref, the result is in ref location.
+// Input without time of the day returns midnight of the day and allDay true.
+// Time without day means today or tomorrow if the time has already passed
+func Parse(input string, ref time.Time) (t time.Time, allDay bool, err error) {
+	p := &parser{
+		ref:    ref,
+		tokens: tokenize(input),
+	}
+
+	for i := 0; i < len(p.tokens); {
+		if _, ok := fillers[p.tokens[i]]; ok {
+			i++
+			continue
+		}
+
+		n := 0
+		for _, match := range matchers {
+			n = match(p, p.tokens[i:])
+			if n > 0 {
+				break
+			}
+		}
+		if n == 0 || p.conflict {
+			return time.Time{}, false, ErrUnknownDate
+		}
+		i += n
+	}
+
+	switch {
+	case p.hasExact:
+		return p.exact, false, nil
+	case p.hasTime:
+		day := p.day
+		if !p.hasDay {
+			day = startOfDay(ref)
+		}
+		t = time.Date(day.Year(), day.Month(), day.Day(), p.hour, p.minute, 0, 0, ref.Location())
+		if !p.hasDay && t.Before(ref) {
+			t = time.Date(day.Year(), day.Month(), day.Day()+1, p.hour, p.minute, 0, 0, ref.Location())
+		}
+		return t, false, nil
+	case p.hasDay:
+		return p.day, true, nil
+	}
+
+	return time.Time{}, false, ErrUnknownDate
+}
+
+// tokenize lowercases input and splits it by spaces and commas
+func tokenize(input string) []string {
+	input = strings.ToLower(input)
+	input = strings.ReplaceAll(input, "ё", "е")
+	return strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
+
+func (p *parser) setDay(day time.Time) {
+	if p.hasDay || p.hasExact {
+		p.conflict = true
+	}
+	p.day, p.hasDay = day, true
+}
+
+func (p *parser) setTime(hour, minute int) {
+	if p.hasTime || p.hasExact {
+		p.conflict = true
+	}
+	p.hour, p.minute, p.hasTime = hour, minute, true
+}
+
+func (p *parser) setExact(t time.Time) {
+	if p.hasDay || p.hasTime || p.hasExact {
+		p.conflict = true
+	}
+	p.exact, p.hasExact = t, true
+}
+
+func (p *parser) today() time.Time {
+	return startOfDay(p.ref)
+}
+
+// matchRelative matches "in 3 days", "in a week", "через 2 часа" and "через неделю"
+func matchRelative(p *parser, tokens []string) int {
+	if len(tokens) < 2 || (tokens[0] != "in" && tokens[0] != "через") {
+		return 0
+	}
+
+	n, i := 1, 1
+	if amount, ok := parseAmount(tokens[1]); ok {
+		n, i = amount, 2
+	} else if tokens[0] == "in" {
+		return 0
+	}
+	if i >= len(tokens) {
+		return 0
+	}
+
+	u, ok := units[tokens[i]]
+	if !ok {
+		return 0
+	}
+
+	switch u {
+	case unitMinute:
+		p.setExact(p.ref.Truncate(time.Minute).Add(time.Duration(n) * time.Minute))
+	case unitHour:
+		p.setExact(p.ref.Truncate(time.Minute).Add(time.Duration(n) * time.Hour))
+	case unitDay:
+		p.setDay(p.today().AddDate(0, 0, n))
+	case unitWeek:
+		p.setDay(p.today().AddDate(0, 0, 7*n))
+	case unitMonth:
+		p.setDay(addMonths(p.today(), n))
+	case unitYear:
+		p.setDay(addMonths(p.today(), 12*n))
+	}
+
+	return i + 1
+}
+
+// matchDay matches "today", "tomorrow", "day after tomorrow" and their Russian words
+func matchDay(p *parser, tokens []string) int {
+	if len(tokens) >= 3 && tokens[0] == "day" && tokens[1] == "after" && tokens[2] == "tomorrow" {
+		p.setDay(p.today().AddDate(0, 0, 2))
+		return 3
+	}
+	if tokens[0] == "tonight" {
+		p.setDay(p.today())
+		p.setTime(20, 0)
+		return 1
+	}
+
+	offset, ok := days[tokens[0]]
+	if !ok {
+		return 0
+	}
+	p.setDay(p.today().AddDate(0, 0, offset))
+	return 1
+}
+
+// matchWeekday matches "friday", "this friday", "next friday", "в пятницу" and "в следующую пятницу".
+// Weekday without prefix and with next prefix is the first such day after today,
+// this prefix includes today
+func matchWeekday(p *parser, tokens []string) int {
+	i := 0
+	if tokens[0] == "в" || tokens[0] == "во" {
+		i++
+	}
+
+	includeToday := false
+	if i < len(tokens) {
+		if _, ok := nextWords[tokens[i]]; ok {
+			i++
+		} else if _, ok = thisWords[tokens[i]]; ok {
+			includeToday = true
+			i++
+		}
+	}
+	if i >= len(tokens) {
+		return 0
+	}
+
+	weekday, ok := weekdays[tokens[i]]
+	if !ok {
+		return 0
+	}
+
+	diff := (int(weekday) - int(p.ref.Weekday()) + 7) % 7
+	if diff == 0 && !includeToday {
+		diff = 7
+	}
+	p.setDay(p.today().AddDate(0, 0, diff))
+
+	return i + 1
+}
+
+// period is a phrase of the calendar period
+type period int
+
+const (
+	periodNextWeek period = iota + 1
+	periodNextMonth
+	periodWeekend
+)
+
+var periods = []struct {
+	words  []string
+	period period
+}{
+	{words: []string{"next", "week"}, period: periodNextWeek},
+	{words: []string{"на", "следующей", "неделе"}, period: periodNextWeek},
+	{words: []string{"next", "month"}, period: periodNextMonth},
+	{words: []string{"в", "следующем", "месяце"}, period: periodNextMonth},
+	{words: []string{"weekend"}, period: periodWeekend},
+	{words: []string{"в", "выходные"}, period: periodWeekend},
+}
+
+// matchPeriod matches "next week" as its monday, "next month" as its first day,
+// "weekend" as the nearest saturday and their Russian phrases
+func matchPeriod(p *parser, tokens []string) int {
+	today := p.today()
+
+	for _, phrase := range periods {
+		if !hasPrefix(tokens, phrase.words...) {
+			continue
+		}
+
+		switch phrase.period {
+		case periodNextWeek:
+			daysToMonday := (int(time.Monday) - int(today.Weekday()) + 7) % 7
+			if daysToMonday == 0 {
+				daysToMonday = 7
+			}
+			p.setDay(today.AddDate(0, 0, daysToMonday))
+		case periodNextMonth:
+			p.setDay(time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()))
+		case periodWeekend:
+			p.setDay(today.AddDate(0, 0, (int(time.Saturday)-int(today.Weekday())+7)%7))
+		}
+
+		return len(phrase.words)
+	}
+
+	return 0
+}
+
+// matchDate matches "5 january", "january 5th", "5 января 2031" and "jan 5 2031".
+// Date without year which has already passed is the date of the next year
+func matchDate(p *parser, tokens []string) int {
+	if len(tokens) < 2 {
+		return 0
+	}
+
+	day, month, ok := 0, time.Month(0), false
+	if month, ok = months[tokens[0]]; ok {
+		day, ok = parseDayOfMonth(tokens[1])
+	} else if day, ok = parseDayOfMonth(tokens[0]); ok {
+		month, ok = months[tokens[1]]
+	}
+	if !ok {
+		return 0
+	}
+
+	n := 2
+	year, hasYear := 0, false
+	if len(tokens) > 2 && len(tokens[2]) == 4 {
+		if y, err := strconv.Atoi(tokens[2]); err == nil {
+			year, hasYear = y, true
+			n = 3
+			if len(tokens) > 3 && (tokens[3] == "г" || tokens[3] == "г." || tokens[3] == "года") {
+				n = 4
+			}
+		}
+	}
+
+	today := p.today()
+	if !hasYear {
+		year = today.Year()
+	}
+	date := time.Date(year, month, day, 0, 0, 0, 0, today.Location())
+	if date.Day() != day {
+		return 0
+	}
+	if !hasYear && date.Before(today) {
+		date = time.Date(year+1, month, day, 0, 0, 0, 0, today.Location())
+	}
+	p.setDay(date)
+
+	return n
+}
+
+// matchNamedTime matches "noon", "at midnight", "в полдень", "morning", "in the evening" and "утром"
+func matchNamedTime(p *parser, tokens []string) int {
+	i := 0
+	if _, ok := timePrefixes[tokens[0]]; ok {
+		i++
+	} else if hasPrefix(tokens, "in", "the") {
+		i += 2
+	}
+	if i >= len(tokens) {
+		return 0
+	}
+
+	hour, ok := namedTimes[tokens[i]]
+	if !ok {
+		return 0
+	}
+	p.setTime(hour, 0)
+
+	return i + 1
+}
+
+// matchClock matches "9am", "9 pm", "21:00", "at 9", "в 9:30" and "в 7 вечера".
+// Plain hour without minutes and meridiem is accepted only after time prefix
+func matchClock(p *parser, tokens []string) int {
+	i := 0
+	_, prefixed := timePrefixes[tokens[0]]
+	if prefixed {
+		i++
+	}
+	if i >= len(tokens) {
+		return 0
+	}
+
+	m := clockRe.FindStringSubmatch(tokens[i])
+	if m == nil {
+		return 0
+	}
+	i++
+
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+
+	meridiem := m[3]
+	if meridiem == "" && i < len(tokens) {
+		if _, ok := meridiems[tokens[i]]; ok {
+			meridiem = tokens[i]
+			i++
+		}
+	}
+
+	if meridiem == "" && m[2] == "" && !prefixed {
+		return 0
+	}
+
+	if meridiem != "" {
+		if hour < 1 || hour > 12 {
+			return 0
+		}
+		pm := meridiems[meridiem]
+		switch {
+		case pm && hour != 12:
+			hour += 12
+		case !pm && hour == 12:
+			hour = 0
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return 0
+	}
+
+	p.setTime(hour, minute)
+
+	return i
+}
+
+// parseAmount parses amount of relative offset written with digits or words
+func parseAmount(token string) (int, bool) {
+	if n, ok := numbers[token]; ok {
+		return n, true
+	}
+	n, err := strconv.Atoi(token)
+	if err != nil || n <= 0 || n > maxAmount {
+		return 0, false
+	}
+	return n, true
+}
+
+func parseDayOfMonth(token string) (int, bool) {
+	m := ordinalRe.FindStringSubmatch(token)
+	if m == nil {
+		return 0, false
+	}
+	day, _ := strconv.Atoi(m[1])
+	return day, day >= 1 && day <= 31
+}
+
+func hasPrefix(tokens []string, words ...string) bool {
+	if len(tokens) < len(words) {
+		return false
+	}
+	for i, word := range words {
+		if tokens[i] != word {
+			return false
+		}
+	}
+	return true
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// addMonths adds months keeping the day within the resulting month, January 31 plus a month is February 28
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,266 @@
+package naturaldate
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	// Wednesday 2030-01-02 10:30 in Moscow
+	ref := time.Date(2030, 1, 2, 10, 30, 15, 0, moscow)
+
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2030, month, d, 0, 0, 0, 0, moscow)
+	}
+	at := func(month time.Month, d, hour, minute int) time.Time {
+		return time.Date(2030, month, d, hour, minute, 0, 0, moscow)
+	}
+
+	testCases := []struct {
+		input          string
+		expectedDate   time.Time
+		expectedAllDay bool
+	}{
+		// days
+		{input: "today", expectedDate: day(1, 2), expectedAllDay: true},
+		{input: "Today", expectedDate: day(1, 2), expectedAllDay: true},
+		{input: "tomorrow", expectedDate: day(1, 3), expectedAllDay: true},
+		{input: "  TOMORROW  ", expectedDate: day(1, 3), expectedAllDay: true},
+		{input: "day after tomorrow", expectedDate: day(1, 4), expectedAllDay: true},
+		{input: "yesterday", expectedDate: day(1, 1), expectedAllDay: true},
+		{input: "сегодня", expectedDate: day(1, 2), expectedAllDay: true},
+		{input: "завтра", expectedDate: day(1, 3), expectedAllDay: true},
+		{input: "Завтра", expectedDate: day(1, 3), expectedAllDay: true},
+		{input: "послезавтра", expectedDate: day(1, 4), expectedAllDay: true},
+		{input: "вчера", expectedDate: day(1, 1), expectedAllDay: true},
+
+		// weekdays
+		{input: "friday", expectedDate: day(1, 4), expectedAllDay: true},
+		{input: "fri", expectedDate: day(1, 4), expectedAllDay: true},
+		{input: "on friday", expectedDate: day(1, 4), expectedAllDay: true},
+		{input: "next friday", expectedDate: day(1, 4), expectedAllDay: true},
+		{input: "this friday", expectedDate: day(1, 4), expectedAllDay: true},
+		{input: "monday", expectedDate: day(1, 7), expectedAllDay: true},
+		{input: "tuesday", expectedDate: day(1, 8), expectedAllDay: true},
+		{input: "wednesday", expectedDate: day(1, 9), expectedAllDay: true},
+		{input: "next wednesday", expectedDate: day(1, 9), expectedAllDay: true},
+		{input: "this wednesday", expectedDate: day(1, 2), expectedAllDay: true},
+		{input: "thurs", expectedDate: day(1, 3), expectedAllDay: true},
+		{input: "sunday", expectedDate: day(1, 6), expectedAllDay: true},
+		{input: "пятница", expectedDate: day(1, 4), expectedAllDay: true},
+		{input: "в пятницу", expectedDate: day(1, 4), expectedAllDay: true},
+		{input: "в следующую пятницу", expectedDate: day(1, 4), expectedAllDay: true},
+		{input: "во вторник", expectedDate: day(1, 8), expectedAllDay: true},
+		{input: "в среду", expectedDate: day(1, 9), expectedAllDay: true},
+		{input: "в эту среду", expectedDate: day(1, 2), expectedAllDay: true},
+		{input: "в понедельник", expectedDate: day(1, 7), expectedAllDay: true},
+		{input: "в воскресенье", expectedDate: day(1, 6), expectedAllDay: true},
+		{input: "пт", expectedDate: day(1, 4), expectedAllDay: true},
+
+		// periods
+		{input: "next week", expectedDate: day(1, 7), expectedAllDay: true},
+		{input: "на следующей неделе", expectedDate: day(1, 7), expectedAllDay: true},
+		{input: "next month", expectedDate: day(2, 1), expectedAllDay: true},
+		{input: "в следующем месяце", expectedDate: day(2, 1), expectedAllDay: true},
+		{input: "weekend", expectedDate: day(1, 5), expectedAllDay: true},
+		{input: "в выходные", expectedDate: day(1, 5), expectedAllDay: true},
+
+		// relative offsets
+		{input: "in 3 days", expectedDate: day(1, 5), expectedAllDay: true},
+		{input: "in 1 day", expectedDate: day(1, 3), expectedAllDay: true},
+		{input: "in a day", expectedDate: day(1, 3), expectedAllDay: true},
+		{input: "in two weeks", expectedDate: day(1, 16), expectedAllDay: true},
+		{input: "in a week", expectedDate: day(1, 9), expectedAllDay: true},
+		{input: "in 1 month", expectedDate: day(2, 2), expectedAllDay: true},
+		{input: "in a year", expectedDate: time.Date(2031, 1, 2, 0, 0, 0, 0, moscow), expectedAllDay: true},
+		{input: "in 10000 days", expectedDate: time.Date(2057, 5, 20, 0, 0, 0, 0, moscow), expectedAllDay: true},
+		{input: "in 30 minutes", expectedDate: at(1, 2, 11, 0)},
+		{input: "in 2 hours", expectedDate: at(1, 2, 12, 30)},
+		{input: "in an hour", expectedDate: at(1, 2, 11, 30)},
+		{input: "in 15 mins", expectedDate: at(1, 2, 10, 45)},
+		{input: "через 3 дня", expectedDate: day(1, 5), expectedAllDay: true},
+		{input: "через 5 дней", expectedDate: day(1, 7), expectedAllDay: true},
+		{input: "через день", expectedDate: day(1, 3), expectedAllDay: true},
+		{input: "через неделю", expectedDate: day(1, 9), expectedAllDay: true},
+		{input: "через две недели", expectedDate: day(1, 16), expectedAllDay: true},
+		{input: "через месяц", expectedDate: day(2, 2), expectedAllDay: true},
+		{input: "через год", expectedDate: time.Date(2031, 1, 2, 0, 0, 0, 0, moscow), expectedAllDay: true},
+		{input: "через час", expectedDate: at(1, 2, 11, 30)},
+		{input: "через 2 часа", expectedDate: at(1, 2, 12, 30)},
+		{input: "через 10 минут", expectedDate: at(1, 2, 10, 40)},
+		{input: "через пять минут", expectedDate: at(1, 2, 10, 35)},
+
+		// absolute dates
+		{input: "5 january", expectedDate: day(1, 5), expectedAllDay: true},
+		{input: "january 5th", expectedDate: day(1, 5), expectedAllDay: true},
+		{input: "jan 5, 2031", expectedDate: time.Date(2031, 1, 5, 0, 0, 0, 0, moscow), expectedAllDay: true},
+		{input: "on march 1st", expectedDate: day(3, 1), expectedAllDay: true},
+		{input: "may 22", expectedDate: day(5, 22), expectedAllDay: true},
+		{input: "1 january", expectedDate: time.Date(2031, 1, 1, 0, 0, 0, 0, moscow), expectedAllDay: true},
+		{input: "2 january", expectedDate: day(1, 2), expectedAllDay: true},
+		{input: "5 января", expectedDate: day(1, 5), expectedAllDay: true},
+		{input: "8 марта", expectedDate: day(3, 8), expectedAllDay: true},
+		{input: "5-го февраля", expectedDate: day(2, 5), expectedAllDay: true},
+		{input: "5 января 2031", expectedDate: time.Date(2031, 1, 5, 0, 0, 0, 0, moscow), expectedAllDay: true},
+		{input: "5 января 2031 г.", expectedDate: time.Date(2031, 1, 5, 0, 0, 0, 0, moscow), expectedAllDay: true},
+		{input: "5 января 2031 года", expectedDate: time.Date(2031, 1, 5, 0, 0, 0, 0, moscow), expectedAllDay: true},
+		{input: "29 февраля 2032", expectedDate: time.Date(2032, 2, 29, 0, 0, 0, 0, moscow), expectedAllDay: true},
+
+		// time only, passed time is tomorrow
+		{input: "9pm", expectedDate: at(1, 2, 21, 0)},
+		{input: "9am", expectedDate: at(1, 3, 9, 0)},
+		{input: "11 am", expectedDate: at(1, 2, 11, 0)},
+		{input: "12pm", expectedDate: at(1, 2, 12, 0)},
+		{input: "12am", expectedDate: at(1, 3, 0, 0)},
+		{input: "10:45", expectedDate: at(1, 2, 10, 45)},
+		{input: "10:30", expectedDate: at(1, 3, 10, 30)},
+		{input: "21.30", expectedDate: at(1, 2, 21, 30)},
+		{input: "at 5", expectedDate: at(1, 3, 5, 0)},
+		{input: "at 5:15pm", expectedDate: at(1, 2, 17, 15)},
+		{input: "5 p.m.", expectedDate: at(1, 2, 17, 0)},
+		{input: "noon", expectedDate: at(1, 2, 12, 0)},
+		{input: "at midnight", expectedDate: at(1, 3, 0, 0)},
+		{input: "evening", expectedDate: at(1, 2, 18, 0)},
+		{input: "in the evening", expectedDate: at(1, 2, 18, 0)},
+		{input: "tonight", expectedDate: at(1, 2, 20, 0)},
+		{input: "в 18:00", expectedDate: at(1, 2, 18, 0)},
+		{input: "в 9", expectedDate: at(1, 3, 9, 0)},
+		{input: "к 15", expectedDate: at(1, 2, 15, 0)},
+		{input: "в 7 вечера", expectedDate: at(1, 2, 19, 0)},
+		{input: "в 3 дня", expectedDate: at(1, 2, 15, 0)},
+		{input: "в 2 ночи", expectedDate: at(1, 3, 2, 0)},
+		{input: "в полдень", expectedDate: at(1, 2, 12, 0)},
+		{input: "вечером", expectedDate: at(1, 2, 18, 0)},
+
+		// day and time
+		{input: "tomorrow 9am", expectedDate: at(1, 3, 9, 0)},
+		{input: "tomorrow at 9:30", expectedDate: at(1, 3, 9, 30)},
+		{input: "9am tomorrow", expectedDate: at(1, 3, 9, 0)},
+		{input: "today 8am", expectedDate: at(1, 2, 8, 0)},
+		{input: "tomorrow morning", expectedDate: at(1, 3, 9, 0)},
+		{input: "tomorrow noon", expectedDate: at(1, 3, 12, 0)},
+		{input: "next friday 5pm", expectedDate: at(1, 4, 17, 0)},
+		{input: "friday at 17:00", expectedDate: at(1, 4, 17, 0)},
+		{input: "monday, 10am", expectedDate: at(1, 7, 10, 0)},
+		{input: "in 3 days at 8pm", expectedDate: at(1, 5, 20, 0)},
+		{input: "jan 5 at 9am", expectedDate: at(1, 5, 9, 0)},
+		{input: "due friday by 6pm", expectedDate: at(1, 4, 18, 0)},
+		{input: "завтра в 9", expectedDate: at(1, 3, 9, 0)},
+		{input: "завтра в 9:30", expectedDate: at(1, 3, 9, 30)},
+		{input: "завтра утром", expectedDate: at(1, 3, 9, 0)},
+		{input: "завтра вечером", expectedDate: at(1, 3, 18, 0)},
+		{input: "в 18:00 завтра", expectedDate: at(1, 3, 18, 0)},
+		{input: "в пятницу в 10 утра", expectedDate: at(1, 4, 10, 0)},
+		{input: "через 3 дня в 21:00", expectedDate: at(1, 5, 21, 0)},
+		{input: "5 января в 12:00", expectedDate: at(1, 5, 12, 0)},
+		{input: "послезавтра днём", expectedDate: at(1, 4, 14, 0)},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			date, allDay, err := Parse(tc.input, ref)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDate, date)
+			require.Equal(t, tc.expectedAllDay, allDay)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	ref := time.Date(2030, 1, 2, 10, 30, 0, 0, time.UTC)
+
+	inputs := []string{
+		"",
+		"   ",
+		"someday",
+		"9",
+		"tomorrow tomorrow",
+		"today tomorrow",
+		"9am 10am",
+		"in 2 hours tomorrow",
+		"tomorrow in 30 minutes",
+		"in days",
+		"in 0 days",
+		"in -1 days",
+		"in 10001 days",
+		"in 9223372036854775807 minutes",
+		"через 100000 лет",
+		"in 3 fortnights",
+		"через",
+		"next",
+		"в",
+		"at",
+		"13pm",
+		"0am",
+		"25:00",
+		"10:60",
+		"31 february",
+		"32 january",
+		"february",
+		"tomorrow 9am please",
+		"02.01.2030",
+		"завтра или послезавтра",
+	}
+
+	for _, input := range inputs {
+		input := input
+		t.Run(input, func(t *testing.T) {
+			_, _, err := Parse(input, ref)
+			require.ErrorIs(t, err, ErrUnknownDate)
+		})
+	}
+}
+
+func TestParseDaylightSaving(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// clocks are moved forward on 2030-03-31 in Berlin
+	ref := time.Date(2030, 3, 30, 12, 0, 0, 0, berlin)
+
+	date, allDay, err := Parse("tomorrow 9am", ref)
+	require.NoError(t, err)
+	require.False(t, allDay)
+	require.Equal(t, time.Date(2030, 3, 31, 7, 0, 0, 0, time.UTC), date.UTC())
+
+	date, allDay, err = Parse("in 2 days", ref)
+	require.NoError(t, err)
+	require.True(t, allDay)
+	require.Equal(t, time.Date(2030, 4, 1, 0, 0, 0, 0, berlin), date)
+}
+
+func TestAddMonths(t *testing.T) {
+	testCases := []struct {
+		date     time.Time
+		months   int
+		expected time.Time
+	}{
+		{
+			date:     time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC),
+			months:   1,
+			expected: time.Date(2030, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			date:     time.Date(2032, 1, 31, 0, 0, 0, 0, time.UTC),
+			months:   1,
+			expected: time.Date(2032, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			date:     time.Date(2030, 12, 15, 0, 0, 0, 0, time.UTC),
+			months:   2,
+			expected: time.Date(2031, 2, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			date:     time.Date(2032, 2, 29, 0, 0, 0, 0, time.UTC),
+			months:   12,
+			expected: time.Date(2033, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, addMonths(tc.date, tc.months))
+	}
+}
@@ -0,0 +1,130 @@
+package naturaldate
+
+import "time"
+
+// unit of the relative "in 3 days" or "через 3 дня" offset
+type unit int
+
+const (
+	unitMinute unit = iota + 1
+	unitHour
+	unitDay
+	unitWeek
+	unitMonth
+	unitYear
+)
+
+var units = map[string]unit{
+	"min": unitMinute, "mins": unitMinute, "minute": unitMinute, "minutes": unitMinute,
+	"минуту": unitMinute, "минуты": unitMinute, "минут": unitMinute, "мин": unitMinute,
+
+	"h": unitHour, "hr": unitHour, "hrs": unitHour, "hour": unitHour, "hours": unitHour,
+	"час": unitHour, "часа": unitHour, "часов": unitHour,
+
+	"day": unitDay, "days": unitDay,
+	"день": unitDay, "дня": unitDay, "дней": unitDay, "сутки": unitDay, "суток": unitDay,
+
+	"wk": unitWeek, "wks": unitWeek, "week": unitWeek, "weeks": unitWeek,
+	"неделю": unitWeek, "недели": unitWeek, "недель": unitWeek,
+
+	"month": unitMonth, "months": unitMonth,
+	"месяц": unitMonth, "месяца": unitMonth, "месяцев": unitMonth,
+
+	"year": unitYear, "years": unitYear,
+	"год": unitYear, "года": unitYear, "лет": unitYear,
+}
+
+// numbers written as words, "a" and "an" are English articles of "in a week"
+var numbers = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10, "twelve": 12,
+	"один": 1, "одну": 1, "одна": 1, "два": 2, "две": 2, "три": 3, "четыре": 4, "пять": 5,
+	"шесть": 6, "семь": 7, "восемь": 8, "девять": 9, "десять": 10, "двенадцать": 12,
+}
+
+// days relative to today
+var days = map[string]int{
+	"today": 0, "сегодня": 0,
+	"tomorrow": 1, "завтра": 1, "послезавтра": 2,
+	"yesterday": -1, "вчера": -1,
+}
+
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday,
+
+	"понедельник": time.Monday, "пн": time.Monday,
+	"вторник": time.Tuesday, "вт": time.Tuesday,
+	"среда": time.Wednesday, "среду": time.Wednesday, "ср": time.Wednesday,
+	"четверг": time.Thursday, "чт": time.Thursday,
+	"пятница": time.Friday, "пятницу": time.Friday, "пт": time.Friday,
+	"суббота": time.Saturday, "субботу": time.Saturday, "сб": time.Saturday,
+	"воскресенье": time.Sunday, "вс": time.Sunday,
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April, "may": time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+
+	"январь": time.January, "января": time.January,
+	"февраль": time.February, "февраля": time.February,
+	"март": time.March, "марта": time.March,
+	"апрель": time.April, "апреля": time.April,
+	"май": time.May, "мая": time.May,
+	"июнь": time.June, "июня": time.June,
+	"июль": time.July, "июля": time.July,
+	"август": time.August, "августа": time.August,
+	"сентябрь": time.September, "сентября": time.September,
+	"октябрь": time.October, "октября": time.October,
+	"ноябрь": time.November, "ноября": time.November,
+	"декабрь": time.December, "декабря": time.December,
+}
+
+// times of named moments and parts of the day
+var namedTimes = map[string]int{
+	"noon": 12, "полдень": 12,
+	"midnight": 0, "полночь": 0,
+	"morning": 9, "утром": 9,
+	"afternoon": 14, "днем": 14,
+	"evening": 18, "вечером": 18,
+}
+
+// meridiems after the hour, true means the hour is after noon
+var meridiems = map[string]bool{
+	"am": false, "a.m.": false, "утра": false, "ночи": false,
+	"pm": true, "p.m.": true, "дня": true, "вечера": true,
+}
+
+// prefixes of the weekday selecting the first weekday after today or the weekday of the current week
+var (
+	nextWords = map[string]struct{}{
+		"next": {}, "следующий": {}, "следующую": {}, "следующая": {}, "следующее": {},
+	}
+	thisWords = map[string]struct{}{
+		"this": {}, "этот": {}, "эту": {}, "это": {}, "эта": {},
+	}
+)
+
+// prefixes of the time, a plain hour is accepted only after them
+var timePrefixes = map[string]struct{}{
+	"at": {}, "в": {}, "во": {}, "к": {},
+}
+
+// fillers are skipped anywhere in the input
+var fillers = map[string]struct{}{
+	"on": {}, "by": {}, "due": {},
+}
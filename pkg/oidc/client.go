@@ -0,0 +1,208 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/romandnk/todo/config"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	// errorBodyLimit is the max number of bytes of the error response added to the error
+	errorBodyLimit = 512
+)
+
+// discovery is the part of provider metadata used by the client
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Client talks to the identity provider found by discovery document of the issuer.
+// Discovery is loaded on first use, so the app starts while the provider is unavailable
+type Client struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	audience     string
+	scopes       []string
+	client       *http.Client
+	now          func() time.Time
+
+	mu        sync.Mutex
+	discovery *discovery
+	keys      *keySet
+}
+
+func NewClient(cfg config.OIDC) (*Client, error) {
+	issuer, err := url.Parse(cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing oidc issuer: %w", err)
+	}
+	if issuer.Scheme == "" || issuer.Host == "" {
+		return nil, fmt.Errorf("oidc issuer must be absolute url, got '%s'", cfg.Issuer)
+	}
+	if cfg.ClientID == "" {
+		return nil, fmt.Errorf("oidc client id cannot be empty")
+	}
+
+	audience := cfg.Audience
+	if audience == "" {
+		audience = cfg.ClientID
+	}
+
+	c := &Client{
+		issuer:       strings.TrimSuffix(cfg.Issuer, "/"),
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		redirectURL:  cfg.RedirectURL,
+		audience:     audience,
+		scopes:       cfg.Scopes,
+		client:       http.DefaultClient,
+		now:          time.Now,
+	}
+	c.keys = newKeySet(c.client, cfg.JWKSCacheTTL, c.now)
+
+	return c, nil
+}
+
+func (c *Client) AuthCodeURL(ctx context.Context, state string) (string, error) {
+	d, err := c.loadDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("error parsing oidc authorization endpoint: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", c.clientID)
+	q.Set("redirect_uri", c.redirectURL)
+	q.Set("scope", strings.Join(c.scopes, " "))
+	q.Set("state", state)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Exchange uses client_secret_basic authentication of the token endpoint
+func (c *Client) Exchange(ctx context.Context, code string) (Token, error) {
+	var token Token
+
+	d, err := c.loadDiscovery(ctx)
+	if err != nil {
+		return token, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.redirectURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return token, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.clientID), url.QueryEscape(c.clientSecret))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return token, fmt.Errorf("error requesting oidc token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusBadRequest:
+		return token, fmt.Errorf("%w: %s", ErrInvalidGrant, readErrorBody(resp.Body))
+	case resp.StatusCode != http.StatusOK:
+		return token, fmt.Errorf("unexpected oidc token status %d: %s", resp.StatusCode, readErrorBody(resp.Body))
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return token, fmt.Errorf("error decoding oidc token: %w", err)
+	}
+	if token.IDToken == "" {
+		return token, fmt.Errorf("oidc token response has no id token")
+	}
+
+	// id token is issued for the client regardless of the audience of bearer tokens
+	token.Claims, err = c.verify(ctx, d, token.IDToken, c.clientID)
+	if err != nil {
+		return token, err
+	}
+
+	return token, nil
+}
+
+func (c *Client) Verify(ctx context.Context, rawToken string) (Claims, error) {
+	d, err := c.loadDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.verify(ctx, d, rawToken, c.audience)
+}
+
+// loadDiscovery returns cached discovery document, failed loading is retried on the next call
+func (c *Client) loadDiscovery(ctx context.Context) (*discovery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.discovery != nil {
+		return c.discovery, nil
+	}
+
+	var d discovery
+	if err := getJSON(ctx, c.client, c.issuer+discoveryPath, &d); err != nil {
+		return nil, fmt.Errorf("error loading oidc discovery: %w", err)
+	}
+	if strings.TrimSuffix(d.Issuer, "/") != c.issuer {
+		return nil, fmt.Errorf("oidc discovery issuer '%s' does not match '%s'", d.Issuer, c.issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc discovery must have authorization, token and jwks endpoints")
+	}
+
+	c.discovery = &d
+	return c.discovery, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, readErrorBody(resp.Body))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func readErrorBody(r io.Reader) string {
+	body, _ := io.ReadAll(io.LimitReader(r, errorBodyLimit))
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,188 @@
+package oidc
+
+import (
+	"context"
+	"github.com/romandnk/todo/config"
+	"github.com/romandnk/todo/pkg/oidc/oidctest"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T) (*Client, *oidctest.Server) {
+	idp, err := oidctest.NewServer("todo", "secret")
+	require.NoError(t, err)
+	t.Cleanup(idp.Close)
+
+	client, err := NewClient(config.OIDC{
+		Issuer:       idp.Issuer(),
+		ClientID:     "todo",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost:8080/api/v1/auth/oidc/callback",
+		Scopes:       []string{"openid", "profile"},
+		JWKSCacheTTL: time.Hour,
+	})
+	require.NoError(t, err)
+
+	return client, idp
+}
+
+func TestClient_Verify(t *testing.T) {
+	client, idp := newTestClient(t)
+
+	testCases := []struct {
+		name          string
+		claims        func(claims map[string]any)
+		expectedError error
+	}{
+		{
+			name:   "OK",
+			claims: func(claims map[string]any) {},
+		},
+		{
+			name: "audience array",
+			claims: func(claims map[string]any) {
+				claims["aud"] = []string{"api", "todo"}
+			},
+		},
+		{
+			name: "another issuer",
+			claims: func(claims map[string]any) {
+				claims["iss"] = "https://evil.example.com"
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "another audience",
+			claims: func(claims map[string]any) {
+				claims["aud"] = "another"
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "expired",
+			claims: func(claims map[string]any) {
+				claims["exp"] = time.Now().Add(-2 * time.Minute).Unix()
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "without expiration",
+			claims: func(claims map[string]any) {
+				delete(claims, "exp")
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "not valid yet",
+			claims: func(claims map[string]any) {
+				claims["nbf"] = time.Now().Add(time.Hour).Unix()
+			},
+			expectedError: ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			claims := idp.Claims("petr")
+			tc.claims(claims)
+
+			verified, err := client.Verify(context.Background(), idp.Sign(claims))
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, "petr", verified.String("preferred_username"))
+			}
+		})
+	}
+}
+
+func TestClient_VerifyMalformed(t *testing.T) {
+	client, idp := newTestClient(t)
+
+	token := idp.Sign(idp.Claims("petr"))
+
+	for _, raw := range []string{"", "abc", "a.b.c", token[:len(token)-4] + "AAAA"} {
+		_, err := client.Verify(context.Background(), raw)
+		require.ErrorIs(t, err, ErrInvalidToken)
+	}
+}
+
+func TestClient_KeysCache(t *testing.T) {
+	client, idp := newTestClient(t)
+
+	now := time.Now()
+	client.now = func() time.Time { return now }
+	client.keys.now = client.now
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Verify(context.Background(), idp.Sign(idp.Claims("petr")))
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, idp.JWKSRequests())
+
+	// token signed with rotated key is rejected until reload is allowed
+	require.NoError(t, idp.RotateKey())
+	_, err := client.Verify(context.Background(), idp.Sign(idp.Claims("petr")))
+	require.ErrorIs(t, err, ErrInvalidToken)
+	require.Equal(t, 1, idp.JWKSRequests())
+
+	now = now.Add(minRefreshInterval)
+	_, err = client.Verify(context.Background(), idp.Sign(idp.Claims("petr")))
+	require.NoError(t, err)
+	require.Equal(t, 2, idp.JWKSRequests())
+
+	// keys are reloaded after ttl
+	now = now.Add(time.Hour)
+	_, err = client.Verify(context.Background(), idp.Sign(idp.Claims("petr")))
+	require.NoError(t, err)
+	require.Equal(t, 3, idp.JWKSRequests())
+}
+
+func TestClient_AuthorizationCodeFlow(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	authURL, err := client.AuthCodeURL(context.Background(), "state")
+	require.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, "todo", u.Query().Get("client_id"))
+	require.Equal(t, "openid profile", u.Query().Get("scope"))
+
+	// the mock provider signs in the user from login hint and redirects back at once
+	q := u.Query()
+	q.Set("login_hint", "petr")
+	u.RawQuery = q.Encode()
+
+	httpClient := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := httpClient.Get(u.String())
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, "state", callback.Query().Get("state"))
+
+	token, err := client.Exchange(context.Background(), callback.Query().Get("code"))
+	require.NoError(t, err)
+	require.Equal(t, "petr", token.Claims.String("preferred_username"))
+	require.NotEmpty(t, token.IDToken)
+
+	// code is one-time
+	_, err = client.Exchange(context.Background(), callback.Query().Get("code"))
+	require.ErrorIs(t, err, ErrInvalidGrant)
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient(config.OIDC{Issuer: "accounts.example.com", ClientID: "todo"})
+	require.Error(t, err)
+
+	_, err = NewClient(config.OIDC{Issuer: "https://accounts.example.com"})
+	require.Error(t, err)
+}
@@ -0,0 +1,223 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// clockSkew is the allowed difference between clocks of the app and the identity provider
+	clockSkew = time.Minute
+	// minRefreshInterval limits reloading of keys caused by tokens with unknown key id
+	minRefreshInterval = 10 * time.Second
+)
+
+// algorithms are supported signature algorithms with their hashes
+var algorithms = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// registeredClaims are claims checked by verification
+type registeredClaims struct {
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+}
+
+// verify checks the token signed with RSA key of the provider and returns its claims
+func (c *Client) verify(ctx context.Context, d *discovery, rawToken, aud string) (Claims, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: token must have 3 parts", ErrInvalidToken)
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: error decoding header: %s", ErrInvalidToken, err)
+	}
+	hash, ok := algorithms[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported algorithm '%s'", ErrInvalidToken, header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: error decoding signature: %s", ErrInvalidToken, err)
+	}
+
+	key, err := c.keys.key(ctx, d.JWKSURI, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	h := hash.New()
+	h.Write([]byte(parts[0] + "." + parts[1]))
+	if err = rsa.VerifyPKCS1v15(key, hash, h.Sum(nil), signature); err != nil {
+		return nil, fmt.Errorf("%w: signature does not match", ErrInvalidToken)
+	}
+
+	var registered registeredClaims
+	if err = decodeSegment(parts[1], &registered); err != nil {
+		return nil, fmt.Errorf("%w: error decoding claims: %s", ErrInvalidToken, err)
+	}
+	var claims Claims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: error decoding claims: %s", ErrInvalidToken, err)
+	}
+
+	if err = c.validate(registered, aud); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (c *Client) validate(claims registeredClaims, aud string) error {
+	if strings.TrimSuffix(claims.Issuer, "/") != c.issuer {
+		return fmt.Errorf("%w: unexpected issuer '%s'", ErrInvalidToken, claims.Issuer)
+	}
+	if !claims.Audience.contains(aud) {
+		return fmt.Errorf("%w: audience does not contain '%s'", ErrInvalidToken, aud)
+	}
+
+	now := c.now()
+	if claims.ExpiresAt == nil {
+		return fmt.Errorf("%w: token has no expiration time", ErrInvalidToken)
+	}
+	if now.After(numericDate(*claims.ExpiresAt).Add(clockSkew)) {
+		return fmt.Errorf("%w: token is expired", ErrInvalidToken)
+	}
+	if claims.NotBefore != nil && now.Add(clockSkew).Before(numericDate(*claims.NotBefore)) {
+		return fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+
+	return nil
+}
+
+func numericDate(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// keySet caches RSA keys of the provider JWKS for ttl,
+// token with unknown key id reloads keys, so rotated keys are picked up before ttl
+type keySet struct {
+	client *http.Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu       sync.Mutex
+	keys     map[string]*rsa.PublicKey
+	loadedAt time.Time
+}
+
+func newKeySet(client *http.Client, ttl time.Duration, now func() time.Time) *keySet {
+	return &keySet{
+		client: client,
+		ttl:    ttl,
+		now:    now,
+	}
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func (s *keySet) key(ctx context.Context, jwksURI, kid string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	age := s.now().Sub(s.loadedAt)
+	if key, ok := s.keys[kid]; ok && age < s.ttl {
+		return key, nil
+	}
+
+	if s.keys == nil || age >= minRefreshInterval {
+		if err := s.load(ctx, jwksURI); err != nil {
+			return nil, err
+		}
+	}
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key id '%s'", ErrInvalidToken, kid)
+	}
+	return key, nil
+}
+
+// load replaces keys with RSA signing keys of JWKS, keys of other types are skipped
+func (s *keySet) load(ctx context.Context, jwksURI string) error {
+	var set jwks
+	if err := getJSON(ctx, s.client, jwksURI, &set); err != nil {
+		return fmt.Errorf("error loading oidc jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		key, err := rsaKey(k)
+		if err != nil {
+			return fmt.Errorf("error parsing oidc jwk '%s': %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+
+	s.keys = keys
+	s.loadedAt = s.now()
+	return nil
+}
+
+func rsaKey(k jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid rsa key")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
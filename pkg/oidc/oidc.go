@@ -0,0 +1,73 @@
+// Package oidc describes OpenID Connect identity provider used for login with authorization code flow
+// and verification of bearer tokens, mock provider for tests is in oidctest package
+package oidc
+
+//go:generate mockgen -source=oidc.go -destination=mock/mock.go oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+var (
+	// ErrInvalidToken means the token is malformed, expired, has wrong signature, issuer or audience
+	ErrInvalidToken = errors.New("oidc: token is invalid")
+	// ErrInvalidGrant means the identity provider rejected authorization code
+	ErrInvalidGrant = errors.New("oidc: authorization code is invalid")
+)
+
+// Provider is the identity provider of OpenID Connect
+type Provider interface {
+	// AuthCodeURL returns the provider login page url which redirects back with authorization code and state
+	AuthCodeURL(ctx context.Context, state string) (string, error)
+	// Exchange exchanges authorization code for tokens, id token is verified
+	Exchange(ctx context.Context, code string) (Token, error)
+	// Verify checks signature, issuer, audience and lifetime of the bearer token and returns its claims
+	Verify(ctx context.Context, rawToken string) (Claims, error)
+}
+
+// Token is the response of token endpoint
+type Token struct {
+	IDToken     string `json:"id_token"`
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	// Claims are claims of verified id token
+	Claims Claims `json:"-"`
+}
+
+// Claims are claims of JSON Web Token
+type Claims map[string]any
+
+// String returns string claim, empty for missing claim or claim of another type
+func (c Claims) String(name string) string {
+	value, _ := c[name].(string)
+	return value
+}
+
+// audience is aud claim which is either string or array of strings
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+func (a audience) contains(aud string) bool {
+	for _, value := range a {
+		if value == aud {
+			return true
+		}
+	}
+	return false
+}
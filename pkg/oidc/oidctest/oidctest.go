@@ -0,0 +1,258 @@
+// Package oidctest provides OpenID Connect identity provider for tests and local runs.
+// It signs in any user without a password: the login page redirects back at once
+// with the user from login_hint parameter
+package oidctest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultUsername is the user signed in without login_hint
+	DefaultUsername = "ivan"
+	tokenTTL        = time.Hour
+	keySize         = 2048
+)
+
+// IdP is the http handler of discovery, jwks, authorization and token endpoints
+type IdP struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+
+	mu           sync.Mutex
+	key          *rsa.PrivateKey
+	kid          string
+	codes        map[string]string
+	jwksRequests int
+}
+
+func New(issuer, clientID, clientSecret string) (*IdP, error) {
+	i := &IdP{
+		issuer:       issuer,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		codes:        make(map[string]string),
+	}
+	if err := i.RotateKey(); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// Server is IdP listening on local random port
+type Server struct {
+	*IdP
+	server *httptest.Server
+}
+
+// NewServer starts IdP, the issuer is the server url
+func NewServer(clientID, clientSecret string) (*Server, error) {
+	server := httptest.NewUnstartedServer(nil)
+	server.Start()
+
+	idp, err := New(server.URL, clientID, clientSecret)
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+	server.Config.Handler = idp
+
+	return &Server{IdP: idp, server: server}, nil
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+func (i *IdP) Issuer() string {
+	return i.issuer
+}
+
+// RotateKey replaces the signing key, tokens signed with the old key are no longer verified
+func (i *IdP) RotateKey() error {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return fmt.Errorf("error generating rsa key: %w", err)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.key = key
+	i.kid = randomString(8)
+	return nil
+}
+
+// JWKSRequests returns the number of requests of jwks endpoint
+func (i *IdP) JWKSRequests() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.jwksRequests
+}
+
+// Claims returns claims of valid token of the user issued for the client
+func (i *IdP) Claims(username string) map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss":                i.issuer,
+		"sub":                "sub-" + username,
+		"aud":                i.clientID,
+		"iat":                now.Unix(),
+		"exp":                now.Add(tokenTTL).Unix(),
+		"preferred_username": username,
+		"email":              username + "@example.com",
+	}
+}
+
+// Sign returns RS256 token with the claims signed with the current key
+func (i *IdP) Sign(claims map[string]any) string {
+	i.mu.Lock()
+	key, kid := i.key, i.kid
+	i.mu.Unlock()
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	sum := sha256.Sum256([]byte(signed))
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (i *IdP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/.well-known/openid-configuration":
+		writeJSON(w, http.StatusOK, map[string]string{
+			"issuer":                 i.issuer,
+			"authorization_endpoint": i.issuer + "/authorize",
+			"token_endpoint":         i.issuer + "/token",
+			"jwks_uri":               i.issuer + "/jwks",
+		})
+	case "/jwks":
+		i.jwks(w)
+	case "/authorize":
+		i.authorize(w, r)
+	case "/token":
+		i.token(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (i *IdP) jwks(w http.ResponseWriter) {
+	i.mu.Lock()
+	i.jwksRequests++
+	key, kid := i.key.PublicKey, i.kid
+	i.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": kid,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+}
+
+// authorize signs in the user from login_hint and redirects back with the code
+func (i *IdP) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("response_type") != "code" || q.Get("client_id") != i.clientID {
+		http.Error(w, "unsupported response type or unknown client", http.StatusBadRequest)
+		return
+	}
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || redirect.Scheme == "" {
+		http.Error(w, "invalid redirect uri", http.StatusBadRequest)
+		return
+	}
+
+	username := q.Get("login_hint")
+	if username == "" {
+		username = DefaultUsername
+	}
+
+	code := i.Code(username)
+
+	params := redirect.Query()
+	params.Set("code", code)
+	params.Set("state", q.Get("state"))
+	redirect.RawQuery = params.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// Code returns one-time authorization code of the user
+func (i *IdP) Code(username string) string {
+	code := randomString(16)
+
+	i.mu.Lock()
+	i.codes[code] = username
+	i.mu.Unlock()
+
+	return code
+}
+
+func (i *IdP) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+	clientID, _ = url.QueryUnescape(clientID)
+	clientSecret, _ = url.QueryUnescape(clientSecret)
+	if clientID != i.clientID || clientSecret != i.clientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostFormValue("code")
+
+	i.mu.Lock()
+	username, ok := i.codes[code]
+	delete(i.codes, code)
+	i.mu.Unlock()
+
+	if r.PostFormValue("grant_type") != "authorization_code" || !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	token := i.Sign(i.Claims(username))
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": token,
+		"id_token":     token,
+		"token_type":   "Bearer",
+		"expires_in":   int(tokenTTL.Seconds()),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomString(size int) string {
+	b := make([]byte, size)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
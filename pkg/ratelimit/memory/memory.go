@@ -0,0 +1,43 @@
+package memoryratelimit
+
+import (
+	"context"
+	"github.com/romandnk/todo/pkg/ratelimit"
+	"sync"
+	"time"
+)
+
+// Store keeps buckets in memory of the process, so every app instance has its own quotas
+type Store struct {
+	mu      sync.Mutex
+	buckets map[string]time.Time
+}
+
+func NewStore() *Store {
+	return &Store{buckets: make(map[string]time.Time)}
+}
+
+func (s *Store) Take(_ context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fullAt, allowed := ratelimit.Take(s.buckets[key], now, limit)
+	if allowed {
+		s.buckets[key] = fullAt
+	}
+
+	return ratelimit.NewResult(fullAt, now, limit, allowed), nil
+}
+
+func (s *Store) Cleanup(_ context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, fullAt := range s.buckets {
+		if !fullAt.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+
+	return nil
+}
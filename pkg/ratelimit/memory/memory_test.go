@@ -0,0 +1,32 @@
+package memoryratelimit
+
+import (
+	"context"
+	"github.com/romandnk/todo/pkg/ratelimit"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore()
+	limit := ratelimit.Limit{Rate: 1, Burst: 2}
+	now := time.Now()
+
+	for _, expected := range []bool{true, true, false} {
+		result, err := store.Take(context.Background(), "ip:10.0.0.1", limit, now)
+		require.NoError(t, err)
+		require.Equal(t, expected, result.Allowed)
+	}
+
+	// buckets of clients are independent
+	result, err := store.Take(context.Background(), "ip:10.0.0.2", limit, now)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	require.NoError(t, store.Cleanup(context.Background(), now.Add(time.Second)))
+	require.Len(t, store.buckets, 1)
+
+	require.NoError(t, store.Cleanup(context.Background(), now.Add(2*time.Second)))
+	require.Empty(t, store.buckets)
+}
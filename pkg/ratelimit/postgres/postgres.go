@@ -0,0 +1,75 @@
+package postgresratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/pkg/ratelimit"
+	postgres "github.com/romandnk/todo/pkg/storage"
+	"time"
+)
+
+const bucketsTable = "rate_limit_buckets"
+
+// Store keeps buckets in postgres table, so quotas are shared by all app instances
+type Store struct {
+	db postgres.PgxPool
+}
+
+func NewStore(db postgres.PgxPool) *Store {
+	return &Store{db: db}
+}
+
+// Take updates the bucket with single statement, so concurrent requests of the client never take the same token
+func (s *Store) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	ctx = postgres.WithMethod(ctx, "Store.Take")
+
+	// the bucket is new or full, so the token is taken from the full bucket
+	next, _ := ratelimit.Take(now, now, limit)
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s AS b
+		(key, full_at)
+		VALUES ($1, $3::timestamptz)
+		ON CONFLICT (key) DO UPDATE
+		SET full_at=GREATEST(b.full_at, $2::timestamptz) + ($3::timestamptz - $2::timestamptz)
+		WHERE GREATEST(b.full_at, $2::timestamptz) + ($3::timestamptz - $2::timestamptz) <= $4::timestamptz
+		RETURNING full_at
+	`, bucketsTable)
+
+	var fullAt time.Time
+	err := s.db.QueryRow(ctx, query, key, now, next, now.Add(limit.Window())).Scan(&fullAt)
+	if err == nil {
+		return ratelimit.NewResult(fullAt, now, limit, true), nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return ratelimit.Result{}, err
+	}
+
+	// the bucket is empty, its time is read only to tell when to retry
+	query = fmt.Sprintf(`
+		SELECT full_at
+		FROM %[1]s
+		WHERE key=$1
+	`, bucketsTable)
+
+	err = s.db.QueryRow(ctx, query, key).Scan(&fullAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return ratelimit.Result{}, err
+	}
+
+	return ratelimit.NewResult(fullAt, now, limit, false), nil
+}
+
+func (s *Store) Cleanup(ctx context.Context, now time.Time) error {
+	ctx = postgres.WithMethod(ctx, "Store.Cleanup")
+
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE full_at<=$1
+	`, bucketsTable)
+
+	_, err := s.db.Exec(ctx, query, now)
+	return err
+}
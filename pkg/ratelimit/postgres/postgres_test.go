@@ -0,0 +1,79 @@
+package postgresratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/romandnk/todo/pkg/ratelimit"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestStore_Take(t *testing.T) {
+	takeQuery := fmt.Sprintf(`
+		INSERT INTO %[1]s AS b
+		(key, full_at)
+		VALUES ($1, $3::timestamptz)
+		ON CONFLICT (key) DO UPDATE
+		SET full_at=GREATEST(b.full_at, $2::timestamptz) + ($3::timestamptz - $2::timestamptz)
+		WHERE GREATEST(b.full_at, $2::timestamptz) + ($3::timestamptz - $2::timestamptz) <= $4::timestamptz
+		RETURNING full_at
+	`, bucketsTable)
+	selectQuery := fmt.Sprintf(`
+		SELECT full_at
+		FROM %[1]s
+		WHERE key=$1
+	`, bucketsTable)
+
+	limit := ratelimit.Limit{Rate: 1, Burst: 5}
+	now := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		mockFunc func(mock pgxmock.PgxPoolIface)
+		expected ratelimit.Result
+	}{
+		{
+			name: "allowed",
+			mockFunc: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(regexp.QuoteMeta(takeQuery)).
+					WithArgs("tasks:user:ivan", now, now.Add(time.Second), now.Add(5*time.Second)).
+					WillReturnRows(pgxmock.NewRows([]string{"full_at"}).AddRow(now.Add(2 * time.Second)))
+			},
+			expected: ratelimit.Result{Allowed: true, Limit: 5, Remaining: 3, Window: 5 * time.Second, ResetAfter: 2 * time.Second},
+		},
+		{
+			name: "empty bucket",
+			mockFunc: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(regexp.QuoteMeta(takeQuery)).
+					WithArgs("tasks:user:ivan", now, now.Add(time.Second), now.Add(5*time.Second)).
+					WillReturnError(pgx.ErrNoRows)
+				mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WithArgs("tasks:user:ivan").
+					WillReturnRows(pgxmock.NewRows([]string{"full_at"}).AddRow(now.Add(5 * time.Second)))
+			},
+			expected: ratelimit.Result{Limit: 5, Window: 5 * time.Second, RetryAfter: time.Second, ResetAfter: 5 * time.Second},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			tc.mockFunc(mock)
+
+			store := NewStore(mock)
+
+			result, err := store.Take(context.Background(), "tasks:user:ivan", limit, now)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+
+			require.NoError(t, mock.ExpectationsWereMet(), "there was unexpected result")
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Package ratelimit limits requests with token buckets kept by Store,
+// implementations are in memory and postgres packages.
+//
+// Bucket is kept as the time when it becomes full again (generic cell rate algorithm),
+// so a shared store updates it with a single timestamp and bucket of idle client can be dropped
+package ratelimit
+
+//go:generate mockgen -source=ratelimit.go -destination=mock/mock.go ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+const (
+	StoreMemory   = "memory"
+	StorePostgres = "postgres"
+)
+
+// Limit allows Burst requests at once, tokens are refilled with Rate tokens per second
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited reports whether the limit does not restrict requests
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+// interval is the time of refilling one token
+func (l Limit) interval() time.Duration {
+	return time.Duration(float64(time.Second) / l.Rate)
+}
+
+// Window is the time of refilling the whole bucket
+func (l Limit) Window() time.Duration {
+	return l.interval() * time.Duration(l.Burst)
+}
+
+// Result describes the bucket after taking a token
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Window is the time of refilling the whole bucket
+	Window time.Duration
+	// RetryAfter is the time until the next token for not allowed request
+	RetryAfter time.Duration
+	// ResetAfter is the time until the bucket is full
+	ResetAfter time.Duration
+}
+
+// Store keeps buckets by keys like "tasks:user:ivan"
+type Store interface {
+	// Take takes a token from the bucket of the key, missing bucket is full
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+	// Cleanup removes buckets which are full at now
+	Cleanup(ctx context.Context, now time.Time) error
+}
+
+// Take returns the time when the bucket is full after taking a token at now and whether the token was taken.
+// Zero fullAt is full bucket
+func Take(fullAt, now time.Time, limit Limit) (time.Time, bool) {
+	if fullAt.Before(now) {
+		fullAt = now
+	}
+
+	next := fullAt.Add(limit.interval())
+	if next.Sub(now) > limit.Window() {
+		return fullAt, false
+	}
+	return next, true
+}
+
+// NewResult describes the bucket which is full at fullAt
+func NewResult(fullAt, now time.Time, limit Limit, allowed bool) Result {
+	resetAfter := fullAt.Sub(now)
+	if resetAfter < 0 {
+		resetAfter = 0
+	}
+
+	result := Result{
+		Allowed:    allowed,
+		Limit:      limit.Burst,
+		Window:     limit.Window(),
+		ResetAfter: resetAfter,
+	}
+
+	interval := limit.interval()
+	if allowed {
+		result.Remaining = int(math.Floor(float64(limit.Window()-resetAfter) / float64(interval)))
+	} else {
+		result.RetryAfter = resetAfter + interval - limit.Window()
+		if result.RetryAfter < 0 {
+			result.RetryAfter = 0
+		}
+	}
+
+	return result
+}
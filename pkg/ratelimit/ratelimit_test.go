@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestTake(t *testing.T) {
+	limit := Limit{Rate: 2, Burst: 3}
+	now := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	var fullAt time.Time
+	for i := 0; i < 3; i++ {
+		var allowed bool
+		fullAt, allowed = Take(fullAt, now, limit)
+		require.True(t, allowed)
+
+		result := NewResult(fullAt, now, limit, allowed)
+		require.Equal(t, 2-i, result.Remaining)
+		require.Equal(t, 3, result.Limit)
+	}
+	require.Equal(t, now.Add(1500*time.Millisecond), fullAt)
+
+	// the bucket is empty
+	fullAt, allowed := Take(fullAt, now, limit)
+	require.False(t, allowed)
+	result := NewResult(fullAt, now, limit, allowed)
+	require.Equal(t, 0, result.Remaining)
+	require.Equal(t, 500*time.Millisecond, result.RetryAfter)
+	require.Equal(t, 1500*time.Millisecond, result.ResetAfter)
+
+	// one token is refilled
+	now = now.Add(500 * time.Millisecond)
+	fullAt, allowed = Take(fullAt, now, limit)
+	require.True(t, allowed)
+	require.Equal(t, 0, NewResult(fullAt, now, limit, allowed).Remaining)
+
+	// idle bucket is full again
+	now = now.Add(time.Hour)
+	fullAt, allowed = Take(fullAt, now, limit)
+	require.True(t, allowed)
+	require.Equal(t, 2, NewResult(fullAt, now, limit, allowed).Remaining)
+}
+
+func TestLimit(t *testing.T) {
+	require.True(t, Limit{}.Unlimited())
+	require.True(t, Limit{Rate: 1}.Unlimited())
+	require.False(t, Limit{Rate: 1, Burst: 1}.Unlimited())
+	require.Equal(t, 5*time.Second, Limit{Rate: 2, Burst: 10}.Window())
+}
@@ -0,0 +1,23 @@
+package postgres
+
+import "context"
+
+// OtherMethod is the method of queries made with context without the method
+const OtherMethod = "other"
+
+type methodKey struct{}
+
+// WithMethod names the repository method like TaskRepo.CreateTask making queries with the context,
+// query tracers measure and trace queries by it
+func WithMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, methodKey{}, method)
+}
+
+// Method returns the repository method of the context or OtherMethod
+func Method(ctx context.Context) string {
+	method, ok := ctx.Value(methodKey{}).(string)
+	if !ok {
+		return OtherMethod
+	}
+	return method
+}
@@ -0,0 +1,16 @@
+package postgres
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMethod(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, OtherMethod, Method(ctx))
+
+	ctx = WithMethod(ctx, "TaskRepo.CreateTask")
+	require.Equal(t, "TaskRepo.CreateTask", Method(ctx))
+	require.Equal(t, "TaskRepo.ExecTaskBatch", Method(WithMethod(ctx, "TaskRepo.ExecTaskBatch")))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/jackc/pgx/v5"
 	"github.com/romandnk/todo/config"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -23,7 +24,8 @@ type PgxPool interface {
 	Ping(ctx context.Context) error
 }
 
-func NewStorage(ctx context.Context, cfg config.Postgres) (*pgxpool.Pool, error) {
+// NewStorage connects pool to postgres, tracers trace queries of all connections
+func NewStorage(ctx context.Context, cfg config.Postgres, tracers ...pgx.QueryTracer) (*pgxpool.Pool, error) {
 	connString := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host,
 		cfg.Port,
@@ -40,16 +42,39 @@ func NewStorage(ctx context.Context, cfg config.Postgres) (*pgxpool.Pool, error)
 
 	pgxConf.MaxConns = cfg.MaxConns
 	pgxConf.MinConns = cfg.MinConns
+	if len(tracers) > 0 {
+		pgxConf.ConnConfig.Tracer = queryTracers(tracers)
+	}
 
 	db, err := pgxpool.NewWithConfig(ctx, pgxConf)
 	if err != nil {
 		return nil, fmt.Errorf("error creating new pgx pool: %w", err)
 	}
 
-	err = db.Ping(ctx)
+	err = ping(ctx, db, cfg.ConnectTimeout, cfg.ConnectRetryInterval)
 	if err != nil {
+		db.Close()
 		return nil, fmt.Errorf("error connecting pgx pool: %w", err)
 	}
 
 	return db, nil
 }
+
+// ping retries ping of the starting db until timeout, the last error is returned
+func ping(ctx context.Context, db PgxPool, timeout, retryInterval time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		err := db.Ping(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(retryInterval):
+		}
+	}
+}
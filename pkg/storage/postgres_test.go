@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPing(t *testing.T) {
+	errRefused := errors.New("connection refused")
+
+	t.Run("db is started after retries", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectPing().WillReturnError(errRefused)
+		mock.ExpectPing().WillReturnError(errRefused)
+		mock.ExpectPing()
+
+		require.NoError(t, ping(context.Background(), mock, time.Second, time.Millisecond))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectPing().WillReturnError(errRefused)
+
+		err = ping(context.Background(), mock, 10*time.Millisecond, time.Second)
+		require.ErrorIs(t, err, errRefused)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
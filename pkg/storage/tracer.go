@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+)
+
+// queryTracers calls tracers one by one, connection config keeps a single tracer.
+// Batches are traced by tracers implementing pgx.BatchTracer
+type queryTracers []pgx.QueryTracer
+
+func (t queryTracers) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	for _, tracer := range t {
+		ctx = tracer.TraceQueryStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (t queryTracers) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	for _, tracer := range t {
+		tracer.TraceQueryEnd(ctx, conn, data)
+	}
+}
+
+func (t queryTracers) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	for _, tracer := range t {
+		if batchTracer, ok := tracer.(pgx.BatchTracer); ok {
+			ctx = batchTracer.TraceBatchStart(ctx, conn, data)
+		}
+	}
+	return ctx
+}
+
+func (t queryTracers) TraceBatchQuery(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchQueryData) {
+	for _, tracer := range t {
+		if batchTracer, ok := tracer.(pgx.BatchTracer); ok {
+			batchTracer.TraceBatchQuery(ctx, conn, data)
+		}
+	}
+}
+
+func (t queryTracers) TraceBatchEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchEndData) {
+	for _, tracer := range t {
+		if batchTracer, ok := tracer.(pgx.BatchTracer); ok {
+			batchTracer.TraceBatchEnd(ctx, conn, data)
+		}
+	}
+}
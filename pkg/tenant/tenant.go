@@ -0,0 +1,60 @@
+package tenant
+
+import "context"
+
+// DefaultWorkspaceID is the workspace of requests without workspace, it holds data created before workspaces
+const DefaultWorkspaceID = 1
+
+type ctxKey struct{}
+
+type scopesKey struct{}
+
+type apiKeyIDKey struct{}
+
+type membership struct {
+	workspaceID int
+	role        string
+}
+
+// WithWorkspace returns context carrying the workspace of the request and the role of the request author in it.
+// Repositories scope their queries by this workspace
+func WithWorkspace(ctx context.Context, workspaceID int, role string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, membership{workspaceID: workspaceID, role: role})
+}
+
+// WorkspaceID returns the workspace of the request, DefaultWorkspaceID if it is not set
+func WorkspaceID(ctx context.Context) int {
+	if m, ok := ctx.Value(ctxKey{}).(membership); ok && m.workspaceID != 0 {
+		return m.workspaceID
+	}
+	return DefaultWorkspaceID
+}
+
+// Role returns the role of the request author in the workspace, empty if it is not set
+func Role(ctx context.Context) string {
+	m, _ := ctx.Value(ctxKey{}).(membership)
+	return m.role
+}
+
+// WithScopes returns context of the request authenticated by API key,
+// scopes of the key are checked instead of the role permissions
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// Scopes returns scopes of the request API key, ok is false if the request has no API key
+func Scopes(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(scopesKey{}).([]string)
+	return scopes, ok
+}
+
+// WithAPIKeyID returns context of the request authenticated by the API key with the id
+func WithAPIKeyID(ctx context.Context, id int) context.Context {
+	return context.WithValue(ctx, apiKeyIDKey{}, id)
+}
+
+// APIKeyID returns id of the request API key, zero if the request has no API key
+func APIKeyID(ctx context.Context) int {
+	id, _ := ctx.Value(apiKeyIDKey{}).(int)
+	return id
+}
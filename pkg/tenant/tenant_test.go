@@ -0,0 +1,31 @@
+package tenant
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	require.Equal(t, DefaultWorkspaceID, WorkspaceID(context.Background()))
+	require.Equal(t, "", Role(context.Background()))
+
+	ctx := WithWorkspace(context.Background(), 7, "viewer")
+	require.Equal(t, 7, WorkspaceID(ctx))
+	require.Equal(t, "viewer", Role(ctx))
+}
+
+func TestScopes(t *testing.T) {
+	_, ok := Scopes(context.Background())
+	require.False(t, ok)
+
+	ctx := WithScopes(context.Background(), []string{"tasks:read"})
+	scopes, ok := Scopes(ctx)
+	require.True(t, ok)
+	require.Equal(t, []string{"tasks:read"}, scopes)
+}
+
+func TestAPIKeyID(t *testing.T) {
+	require.Equal(t, 0, APIKeyID(context.Background()))
+	require.Equal(t, 3, APIKeyID(WithAPIKeyID(context.Background(), 3)))
+}
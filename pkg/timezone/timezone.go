@@ -0,0 +1,54 @@
+package timezone
+
+import (
+	"context"
+	"errors"
+	"time"
+	// app image has no system zoneinfo, so the time zone database is embedded into the binary
+	_ "time/tzdata"
+)
+
+var ErrUnknownTimezone = errors.New("timezone: unknown IANA time zone")
+
+type ctxKey struct{}
+
+// WithLocation returns context carrying the location of the request
+func WithLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, ctxKey{}, loc)
+}
+
+// FromContext returns the location of the request, UTC if it is not set
+func FromContext(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(ctxKey{}).(*time.Location); ok && loc != nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// Load returns location by IANA time zone name, empty name means UTC
+func Load(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	// time.LoadLocation reads files for names with path elements
+	for _, r := range name {
+		if r == '.' || r == '\\' {
+			return nil, ErrUnknownTimezone
+		}
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrUnknownTimezone
+	}
+	return loc, nil
+}
+
+// StartOfDay returns midnight of t calendar day in t location
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// CalendarDate returns t calendar day as midnight in UTC, all-day dates are stored this way
+func CalendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,53 @@
+package timezone
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	loc, err := Load("Europe/Moscow")
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Moscow", loc.String())
+
+	loc, err = Load("")
+	require.NoError(t, err)
+	require.Equal(t, time.UTC, loc)
+
+	for _, name := range []string{"Mars/Olympus", "../../etc/passwd", "Europe\\Moscow"} {
+		_, err = Load(name)
+		require.ErrorIs(t, err, ErrUnknownTimezone)
+	}
+}
+
+func TestLoadEmbedded(t *testing.T) {
+	// like in the app image without system zoneinfo
+	t.Setenv("ZONEINFO", "")
+
+	loc, err := Load("America/New_York")
+	require.NoError(t, err)
+
+	_, offset := time.Date(2030, 1, 1, 12, 0, 0, 0, loc).Zone()
+	require.Equal(t, -5*60*60, offset)
+}
+
+func TestContext(t *testing.T) {
+	require.Equal(t, time.UTC, FromContext(context.Background()))
+
+	loc, err := Load("Asia/Tokyo")
+	require.NoError(t, err)
+	require.Equal(t, loc, FromContext(WithLocation(context.Background(), loc)))
+}
+
+func TestDays(t *testing.T) {
+	loc, err := Load("Europe/Moscow")
+	require.NoError(t, err)
+
+	// 2030-01-01 22:30 UTC is already 2030-01-02 in Moscow
+	moment := time.Date(2030, 1, 1, 22, 30, 0, 0, time.UTC).In(loc)
+
+	require.True(t, time.Date(2030, 1, 1, 21, 0, 0, 0, time.UTC).Equal(StartOfDay(moment)))
+	require.Equal(t, time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC), CalendarDate(moment))
+}
@@ -0,0 +1,187 @@
+package todotxt
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+var ErrEmptyLine = errors.New("todotxt: line is empty")
+
+// Task is a single todo.txt line
+type Task struct {
+	Done bool
+	// Priority is 1 for (A) to 26 for (Z), 0 means no priority
+	Priority int
+	Created  time.Time
+	// Text is the task text without projects, contexts, due date and priority tags
+	Text     string
+	Projects []string
+	Contexts []string
+	Due      time.Time
+	// DueDateOnly is true when due date has no time part
+	DueDateOnly bool
+}
+
+// Parse parses todo.txt line
+func Parse(line string) (Task, error) {
+	var task Task
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return task, ErrEmptyLine
+	}
+
+	if fields[0] == "x" {
+		task.Done = true
+		fields = fields[1:]
+		// completion date goes before creation date
+		if len(fields) > 0 && isDate(fields[0]) {
+			fields = fields[1:]
+		}
+	}
+
+	if len(fields) > 0 && !task.Done {
+		if priority, ok := parsePriority(fields[0]); ok {
+			task.Priority = priority
+			fields = fields[1:]
+		}
+	}
+
+	if len(fields) > 0 && isDate(fields[0]) {
+		task.Created, _ = time.Parse(dateLayout, fields[0])
+		fields = fields[1:]
+	}
+
+	text := make([]string, 0, len(fields))
+	for _, field := range fields {
+		switch {
+		case len(field) > 1 && field[0] == '+':
+			task.Projects = append(task.Projects, field[1:])
+		case len(field) > 1 && field[0] == '@':
+			task.Contexts = append(task.Contexts, field[1:])
+		case strings.HasPrefix(field, "due:") && parseDue(&task, field[len("due:"):]):
+		case strings.HasPrefix(field, "pri:") && len(field) == len("pri:")+1 && task.Priority == 0:
+			priority, ok := parsePriority("(" + field[len("pri:"):] + ")")
+			if !ok {
+				text = append(text, field)
+				continue
+			}
+			task.Priority = priority
+		default:
+			text = append(text, field)
+		}
+	}
+
+	task.Text = strings.Join(text, " ")
+
+	return task, nil
+}
+
+// String formats task as todo.txt line. Priority of done tasks is kept in pri tag
+func (t Task) String() string {
+	parts := make([]string, 0, 4+len(t.Projects)+len(t.Contexts))
+
+	if t.Done {
+		parts = append(parts, "x")
+	} else if t.Priority > 0 {
+		parts = append(parts, "("+priorityLetter(t.Priority)+")")
+	}
+
+	// creation date of done task without completion date would be read as completion date
+	if !t.Created.IsZero() && !t.Done {
+		parts = append(parts, t.Created.Format(dateLayout))
+	}
+
+	if t.Text != "" {
+		parts = append(parts, t.Text)
+	}
+	for _, project := range t.Projects {
+		parts = append(parts, "+"+project)
+	}
+	for _, context := range t.Contexts {
+		parts = append(parts, "@"+context)
+	}
+
+	if !t.Due.IsZero() {
+		if t.DueDateOnly {
+			parts = append(parts, "due:"+t.Due.Format(dateLayout))
+		} else {
+			parts = append(parts, "due:"+t.Due.Format(time.RFC3339))
+		}
+	}
+
+	if t.Done && t.Priority > 0 {
+		parts = append(parts, "pri:"+priorityLetter(t.Priority))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Line is parsed todo.txt line with its number in the file
+type Line struct {
+	Number int
+	Task   Task
+	Err    error
+}
+
+// ParseAll parses every non-empty line of todo.txt file
+func ParseAll(r io.Reader) ([]Line, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []Line
+	for number := 1; scanner.Scan(); number++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		task, err := Parse(scanner.Text())
+		lines = append(lines, Line{
+			Number: number,
+			Task:   task,
+			Err:    err,
+		})
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+func parsePriority(s string) (int, bool) {
+	if len(s) != 3 || s[0] != '(' || s[2] != ')' || s[1] < 'A' || s[1] > 'Z' {
+		return 0, false
+	}
+	return int(s[1]-'A') + 1, true
+}
+
+func priorityLetter(priority int) string {
+	return string(rune('A' + priority - 1))
+}
+
+func parseDue(task *Task, value string) bool {
+	if due, err := time.Parse(dateLayout, value); err == nil {
+		task.Due = due
+		task.DueDateOnly = true
+		return true
+	}
+	if due, err := time.Parse(time.RFC3339, value); err == nil {
+		task.Due = due
+		task.DueDateOnly = false
+		return true
+	}
+	return false
+}
+
+func isDate(s string) bool {
+	_, err := time.Parse(dateLayout, s)
+	return err == nil
+}
@@ -0,0 +1,126 @@
+package todotxt
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expectedTask  Task
+		expectedError error
+	}{
+		{
+			name:  "full task",
+			input: "(A) 2030-01-01 Call mom +family @phone due:2030-01-05",
+			expectedTask: Task{
+				Priority:    1,
+				Created:     time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+				Text:        "Call mom",
+				Projects:    []string{"family"},
+				Contexts:    []string{"phone"},
+				Due:         time.Date(2030, 1, 5, 0, 0, 0, 0, time.UTC),
+				DueDateOnly: true,
+			},
+		},
+		{
+			name:  "done task with completion and creation dates",
+			input: "x 2030-01-03 2030-01-01 Pay rent +finance pri:B",
+			expectedTask: Task{
+				Done:     true,
+				Priority: 2,
+				Created:  time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+				Text:     "Pay rent",
+				Projects: []string{"finance"},
+			},
+		},
+		{
+			name:  "projects and contexts in the middle of text",
+			input: "Buy +home milk at @store today",
+			expectedTask: Task{
+				Text:     "Buy milk at today",
+				Projects: []string{"home"},
+				Contexts: []string{"store"},
+			},
+		},
+		{
+			name:  "due with time",
+			input: "Deploy due:2030-01-05T10:30:00+03:00",
+			expectedTask: Task{
+				Text: "Deploy",
+				Due:  time.Date(2030, 1, 5, 10, 30, 0, 0, time.FixedZone("", 3*60*60)),
+			},
+		},
+		{
+			name:  "lowercase priority and invalid due are text",
+			input: "(a) task due:tomorrow",
+			expectedTask: Task{
+				Text: "(a) task due:tomorrow",
+			},
+		},
+		{
+			name:  "priority is not at the start",
+			input: "task (A)",
+			expectedTask: Task{
+				Text: "task (A)",
+			},
+		},
+		{
+			name:          "empty line",
+			input:         "   ",
+			expectedError: ErrEmptyLine,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			task, err := Parse(tc.input)
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expectedTask.Done, task.Done)
+			require.Equal(t, tc.expectedTask.Priority, task.Priority)
+			require.True(t, tc.expectedTask.Created.Equal(task.Created))
+			require.Equal(t, tc.expectedTask.Text, task.Text)
+			require.Equal(t, tc.expectedTask.Projects, task.Projects)
+			require.Equal(t, tc.expectedTask.Contexts, task.Contexts)
+			require.True(t, tc.expectedTask.Due.Equal(task.Due))
+			require.Equal(t, tc.expectedTask.DueDateOnly, task.DueDateOnly)
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	lines := []string{
+		"(A) 2030-01-01 Call mom +family @phone due:2030-01-05",
+		"x Pay rent +finance pri:B",
+		"(Z) Deploy +backend +infra @work @laptop due:2030-01-05T10:30:00Z",
+		"Read a book",
+		"x Done without priority @home",
+	}
+
+	for _, line := range lines {
+		task, err := Parse(line)
+		require.NoError(t, err)
+		require.Equal(t, line, task.String())
+
+		again, err := Parse(task.String())
+		require.NoError(t, err)
+		require.Equal(t, task, again)
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	input := "(A) First\n\n   \nx Second\n"
+
+	lines, err := ParseAll(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	require.Equal(t, 1, lines[0].Number)
+	require.Equal(t, "First", lines[0].Task.Text)
+	require.Equal(t, 4, lines[1].Number)
+	require.True(t, lines[1].Task.Done)
+}
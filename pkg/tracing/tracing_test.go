@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/romandnk/todo/config"
+	postgres "github.com/romandnk/todo/pkg/storage"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(context.Background(), config.Tracing{Exporter: ExporterNone})
+	require.NoError(t, err)
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	_, err = NewProvider(context.Background(), config.Tracing{Exporter: "jaeger"})
+	require.EqualError(t, err, "unknown tracing exporter 'jaeger'")
+}
+
+type statusRepo struct {
+	tracer *QueryTracer
+}
+
+func (r *statusRepo) GetAllStatuses(ctx context.Context, err error) {
+	ctx = postgres.WithMethod(ctx, "statusRepo.GetAllStatuses")
+	ctx = r.tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "\n\t\tselect id, name from statuses"})
+	r.tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: err})
+}
+
+func (r *statusRepo) ExecBatch(ctx context.Context) {
+	ctx = postgres.WithMethod(ctx, "statusRepo.ExecBatch")
+	ctx = r.tracer.TraceBatchStart(ctx, nil, pgx.TraceBatchStartData{})
+	r.tracer.TraceBatchQuery(ctx, nil, pgx.TraceBatchQueryData{SQL: "UPDATE tasks SET deleted = true"})
+	r.tracer.TraceBatchQuery(ctx, nil, pgx.TraceBatchQueryData{SQL: "UPDATE tasks SET status_id = 1"})
+	r.tracer.TraceBatchEnd(ctx, nil, pgx.TraceBatchEndData{})
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	repo := &statusRepo{tracer: NewQueryTracer(provider)}
+
+	ctx := context.Background()
+	repo.GetAllStatuses(ctx, nil)
+	repo.GetAllStatuses(ctx, pgx.ErrNoRows)
+	repo.GetAllStatuses(ctx, errors.New("connection reset"))
+	repo.ExecBatch(ctx)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+
+	for _, span := range spans[:3] {
+		require.Equal(t, "statusRepo.GetAllStatuses", span.Name())
+		require.Contains(t, span.Attributes(), attribute.String("db.system", "postgresql"))
+		require.Contains(t, span.Attributes(), attribute.String("db.statement", "\n\t\tselect id, name from statuses"))
+		require.Contains(t, span.Attributes(), attribute.String("db.operation", "SELECT"))
+	}
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Equal(t, codes.Unset, spans[1].Status().Code)
+	require.Equal(t, codes.Error, spans[2].Status().Code)
+	require.Equal(t, "connection reset", spans[2].Status().Description)
+
+	require.Equal(t, "statusRepo.ExecBatch", spans[3].Name())
+	require.Len(t, spans[3].Events(), 2)
+	require.Contains(t, spans[3].Events()[1].Attributes, attribute.String("db.statement", "UPDATE tasks SET status_id = 1"))
+}
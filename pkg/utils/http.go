@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"net"
 	"net/http"
 	"strconv"
 	"time"
@@ -17,7 +18,7 @@ type RequestInfo struct {
 }
 
 func RequestInformation(r *http.Request, duration time.Duration) RequestInfo {
-	clientIP := r.RemoteAddr
+	clientIP := ClientIP(r)
 	date := time.Now().Format("02/Jan/2006:15:04:05 -0700")
 	method := r.Method
 	pth := r.URL.Path
@@ -37,3 +38,12 @@ func RequestInformation(r *http.Request, duration time.Duration) RequestInfo {
 		UserAgent:   userAgent,
 	}
 }
+
+// ClientIP returns ip address of the connection without port, proxy headers are not trusted
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -37,6 +37,10 @@ type TaskToUpdate struct {
 	Description sql.NullString
 	StatusID    sql.NullInt16
 	Date        sql.NullTime
+	AllDay      sql.NullBool
+	Priority    sql.NullInt16
+	Tags        []string
+	Projects    []string
 }
 
 func CheckEmptyTaskFields(task entity.Task) TaskToUpdate {
@@ -57,5 +61,17 @@ func CheckEmptyTaskFields(task entity.Task) TaskToUpdate {
 			Time:  task.Date,
 			Valid: !task.Date.IsZero(),
 		},
+		// all-day flag is changed together with the date only
+		AllDay: sql.NullBool{
+			Bool:  task.AllDay,
+			Valid: !task.Date.IsZero(),
+		},
+		Priority: sql.NullInt16{
+			Int16: int16(task.Priority),
+			Valid: task.Priority != 0 || task.ClearPriority,
+		},
+		// nil slices are saved as NULL and keep current values
+		Tags:     task.Tags,
+		Projects: task.Projects,
 	}
 }